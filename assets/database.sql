@@ -9,59 +9,399 @@ CREATE TABLE users (
     email                   VARCHAR(100),
     password                VARCHAR(100),
     role                    VARCHAR(100),
-    CreatedAt               TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    UpdatedAt               TIMESTAMP
+    CreatedAt               TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UpdatedAt               TIMESTAMPTZ
 );
 
 CREATE TABLE facilities (
     Id                      UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
     RoomDescription         TEXT,
-    Fwifi                   VARCHAR(100),
-    FsoundSystem            VARCHAR(100),
-    Fprojector              VARCHAR(100),
-    FscreenProjector        VARCHAR(100),
-    Fchairs                 VARCHAR(100),
-    Ftables                 VARCHAR(100),
-    FsoundProof             VARCHAR(100),
-    FsmonkingArea           VARCHAR(100),
-    Ftelevison              VARCHAR(100),
-    FAc                     VARCHAR(100),
-    Fbathroom               VARCHAR(100),
-    FcoffeMaker             VARCHAR(100),
-    CreatedAt               TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    UpdatedAt               TIMESTAMP
+    CreatedAt               TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UpdatedAt               TIMESTAMPTZ
+);
+
+CREATE TABLE sites (
+    id                      UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name                    VARCHAR(100) NOT NULL,
+    address                 TEXT NOT NULL DEFAULT '',
+    timezone                VARCHAR(100) NOT NULL DEFAULT '', -- nama IANA, misalnya Asia/Jakarta
+    CreatedAt               TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UpdatedAt               TIMESTAMPTZ
+);
+
+CREATE TABLE buildings (
+    id                      UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    siteId                  UUID NOT NULL,
+    name                    VARCHAR(100) NOT NULL,
+    address                 TEXT NOT NULL DEFAULT '',
+    timezone                VARCHAR(100) NOT NULL DEFAULT '', -- kosong berarti mengikuti timezone site
+    CreatedAt               TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UpdatedAt               TIMESTAMPTZ,
+    CONSTRAINT FK_buildings_siteId FOREIGN KEY(siteId) REFERENCES sites(id)
+);
+
+CREATE TABLE floors (
+    id                      UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    buildingId              UUID NOT NULL,
+    name                    VARCHAR(100) NOT NULL,
+    level                   INT NOT NULL DEFAULT 0,
+    mapWidth                INT NOT NULL DEFAULT 1000, -- bidang koordinat denah
+    mapHeight               INT NOT NULL DEFAULT 1000,
+    CreatedAt               TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UpdatedAt               TIMESTAMPTZ,
+    CONSTRAINT FK_floors_buildingId FOREIGN KEY(buildingId) REFERENCES buildings(id)
 );
 
 CREATE TABLE rooms (
     id                      UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name                    VARCHAR(100),
+    code                    VARCHAR(50) UNIQUE,
     RoomType                VARCHAR(100),
     Capacity                int,
     Facilities              UUID,
+    floorId                 UUID,
     Status                  VARCHAR(100),
-    CreatedAt               TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    UpdatedAt               TIMESTAMP,
-    CONSTRAINT FK_facility FOREIGN KEY(Facilities) REFERENCES facilities(id)
+    CreatedAt               TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UpdatedAt               TIMESTAMPTZ,
+    archivedAt              TIMESTAMPTZ, -- NULL berarti aktif
+    CONSTRAINT FK_facility FOREIGN KEY(Facilities) REFERENCES facilities(id),
+    CONSTRAINT FK_rooms_floorId FOREIGN KEY(floorId) REFERENCES floors(id)
+);
+
+CREATE TABLE amenities (
+    id                      UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    code                    VARCHAR(100) UNIQUE NOT NULL,
+    name                    VARCHAR(100) NOT NULL,
+    icon                    VARCHAR(100),
+    type                    VARCHAR(20) NOT NULL DEFAULT 'boolean', -- boolean atau quantity
+    CreatedAt               TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UpdatedAt               TIMESTAMPTZ,
+    CONSTRAINT CK_amenity_type CHECK (type IN ('boolean', 'quantity'))
+);
+
+CREATE TABLE room_amenities (
+    roomId                  UUID,
+    amenityId               UUID,
+    quantity                INT NOT NULL DEFAULT 1,
+    PRIMARY KEY (roomId, amenityId),
+    CONSTRAINT FK_room_amenities_roomId FOREIGN KEY(roomId) REFERENCES rooms(id) ON DELETE CASCADE,
+    CONSTRAINT FK_room_amenities_amenityId FOREIGN KEY(amenityId) REFERENCES amenities(id)
+);
+
+CREATE TABLE room_images (
+    id                      UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    roomId                  UUID NOT NULL,
+    kind                    VARCHAR(20) NOT NULL, -- photo atau floor_plan
+    url                     TEXT NOT NULL,
+    thumbnailUrl            TEXT NOT NULL,
+    contentType             VARCHAR(100) NOT NULL,
+    size                    BIGINT NOT NULL,
+    storageKey              TEXT NOT NULL,
+    thumbnailKey            TEXT NOT NULL,
+    CreatedAt               TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT FK_room_images_roomId FOREIGN KEY(roomId) REFERENCES rooms(id) ON DELETE CASCADE,
+    CONSTRAINT CK_room_image_kind CHECK (kind IN ('photo', 'floor_plan'))
+);
+
+CREATE TABLE room_map_shapes (
+    roomId                  UUID PRIMARY KEY,
+    type                    VARCHAR(20) NOT NULL, -- point atau polygon
+    points                  JSONB NOT NULL,       -- [{"x": 10, "y": 20}, ...] dalam koordinat floor
+    UpdatedAt               TIMESTAMPTZ,
+    CONSTRAINT FK_room_map_shapes_roomId FOREIGN KEY(roomId) REFERENCES rooms(id) ON DELETE CASCADE,
+    CONSTRAINT CK_room_map_shape_type CHECK (type IN ('point', 'polygon'))
+);
+
+-- ruangan gabungan (Hall A+B) dan bagian-bagiannya (Hall A, Hall B), booking salah satunya memblokir yang lain
+CREATE TABLE room_combinations (
+    combinedRoomId          UUID NOT NULL,
+    partRoomId              UUID NOT NULL,
+    PRIMARY KEY (combinedRoomId, partRoomId),
+    CONSTRAINT FK_room_combinations_combined FOREIGN KEY(combinedRoomId) REFERENCES rooms(id) ON DELETE CASCADE,
+    CONSTRAINT FK_room_combinations_part FOREIGN KEY(partRoomId) REFERENCES rooms(id) ON DELETE CASCADE,
+    CONSTRAINT CK_room_combinations_self CHECK (combinedRoomId <> partRoomId)
+);
+
+-- rule akses booking per ruangan, deny selalu menang dan adanya rule allow membatasi ruangan ke user yang cocok
+CREATE TABLE room_access_rules (
+    roomId                  UUID NOT NULL,
+    effect                  VARCHAR(10) NOT NULL,  -- allow atau deny
+    field                   VARCHAR(20) NOT NULL,  -- divisi, jabatan atau role
+    value                   VARCHAR(100) NOT NULL,
+    PRIMARY KEY (roomId, effect, field, value),
+    CONSTRAINT FK_room_access_rules_roomId FOREIGN KEY(roomId) REFERENCES rooms(id) ON DELETE CASCADE,
+    CONSTRAINT CK_room_access_rules_effect CHECK (effect IN ('allow', 'deny')),
+    CONSTRAINT CK_room_access_rules_field CHECK (field IN ('divisi', 'jabatan', 'role'))
+);
+
+CREATE TABLE room_layouts (
+    id                      UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    roomId                  UUID NOT NULL,
+    name                    VARCHAR(100) NOT NULL, -- theatre, classroom, boardroom, dll
+    capacity                INT NOT NULL,
+    setupMinutes            INT NOT NULL DEFAULT 0,
+    teardownMinutes         INT NOT NULL DEFAULT 0,
+    CreatedAt               TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UpdatedAt               TIMESTAMPTZ,
+    CONSTRAINT FK_room_layouts_roomId FOREIGN KEY(roomId) REFERENCES rooms(id) ON DELETE CASCADE,
+    CONSTRAINT UQ_room_layouts_name UNIQUE (roomId, name),
+    CONSTRAINT CK_room_layouts_capacity CHECK (capacity > 0),
+    CONSTRAINT CK_room_layouts_buffer CHECK (setupMinutes >= 0 AND teardownMinutes >= 0)
+);
+
+CREATE TABLE resource_types (
+    code                    VARCHAR(50) PRIMARY KEY, -- desk, parking, phone_booth; ruangan tetap memakai tabel rooms
+    name                    VARCHAR(100) NOT NULL,
+    bookingMode             VARCHAR(20) NOT NULL DEFAULT 'slot', -- slot: jam mulai-selesai, daily: satu hari penuh di zona resource
+    maxDurationMinutes      INT NOT NULL DEFAULT 0, -- khusus slot, 0 berarti tidak dibatasi
+    maxAdvanceDays          INT NOT NULL DEFAULT 0, -- paling jauh berapa hari ke depan bisa dibooking, 0 berarti tidak dibatasi
+    maxPerUserPerDay        INT NOT NULL DEFAULT 0, -- booking aktif per user per hari untuk tipe ini, 0 berarti tidak dibatasi
+    attributes              TEXT[] NOT NULL DEFAULT '{}', -- nama atribut yang boleh diisi di resource tipe ini
+    CONSTRAINT CK_resource_types_bookingMode CHECK (bookingMode IN ('slot', 'daily'))
+);
+
+CREATE TABLE neighborhoods (
+    id                      UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    floorId                 UUID NOT NULL,
+    name                    VARCHAR(100) NOT NULL,
+    division                VARCHAR(100) NOT NULL DEFAULT '', -- kosong berarti terbuka untuk semua divisi
+    CreatedAt               TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UpdatedAt               TIMESTAMPTZ,
+    CONSTRAINT FK_neighborhoods_floorId FOREIGN KEY(floorId) REFERENCES floors(id),
+    CONSTRAINT UQ_neighborhoods_floor_name UNIQUE (floorId, name)
+);
+
+CREATE TABLE resources (
+    id                      UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    resourceType            VARCHAR(50) NOT NULL,
+    code                    VARCHAR(50) NOT NULL UNIQUE,
+    name                    VARCHAR(100) NOT NULL,
+    floorId                 UUID,
+    neighborhoodId          UUID,
+    attributes              JSONB NOT NULL DEFAULT '{}', -- nilai atribut sesuai resource_types.attributes
+    archivedAt              TIMESTAMPTZ,
+    CreatedAt               TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UpdatedAt               TIMESTAMPTZ,
+    CONSTRAINT FK_resources_resourceType FOREIGN KEY(resourceType) REFERENCES resource_types(code),
+    CONSTRAINT FK_resources_floorId FOREIGN KEY(floorId) REFERENCES floors(id),
+    CONSTRAINT FK_resources_neighborhoodId FOREIGN KEY(neighborhoodId) REFERENCES neighborhoods(id)
 );
 
 CREATE TABLE booking (
     id                      UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
     userId                  UUID,
-    CreatedAt               TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    UpdatedAt               TIMESTAMP,
+    CreatedAt               TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UpdatedAt               TIMESTAMPTZ,
     CONSTRAINT FK_userId FOREIGN KEY(userId) REFERENCES users(id)
 );
 
+CREATE TABLE booking_participants (
+    bookingId               UUID,
+    userId                  UUID,
+    role                    VARCHAR(20) NOT NULL, -- attendee atau delegate
+    CreatedAt               TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (bookingId, userId),
+    CONSTRAINT FK_booking_participants_bookingId FOREIGN KEY(bookingId) REFERENCES booking(id) ON DELETE CASCADE,
+    CONSTRAINT FK_booking_participants_userId FOREIGN KEY(userId) REFERENCES users(id),
+    CONSTRAINT CK_booking_participants_role CHECK (role IN ('attendee', 'delegate'))
+);
+
 CREATE TABLE booking_details(
     id                      UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
     bookingId               UUID,
-    roomId                  UUID,
-    bookingDate             TIMESTAMP,
-    bookingDateEnd          TIMESTAMP,
+    roomId                  UUID, -- hanya diisi untuk booking ruangan, sama dengan resourceId
+    resourceType            VARCHAR(50) NOT NULL DEFAULT 'room', -- room atau resource_types.code
+    resourceId              UUID NOT NULL, -- rooms.id atau resources.id sesuai resourceType
+    bookingDate             TIMESTAMPTZ,
+    bookingDateEnd          TIMESTAMPTZ,
     status                  VARCHAR(100),
     description             TEXT,
-    CreatedAt               TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    UpdatedAt               TIMESTAMP,
+    layoutId                UUID,
+    attendees               INT NOT NULL DEFAULT 0,
+    blockedFrom             TIMESTAMPTZ, -- bookingDate dikurangi setup layout
+    blockedUntil            TIMESTAMPTZ, -- bookingDateEnd ditambah teardown layout
+    checkedInAt             TIMESTAMPTZ, -- NULL berarti pemesan belum check-in, booking accept yang selesai tanpa check-in dihitung no-show
+    conferenceId            VARCHAR(100), -- id meeting di provider video conference, diisi saat booking accept
+    conferenceUrl           TEXT,
+    inviteSequence          INT NOT NULL DEFAULT 0, -- SEQUENCE untuk invite iCalendar berikutnya, naik setiap invite dikirim
+    CreatedAt               TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UpdatedAt               TIMESTAMPTZ,
     CONSTRAINT FK_bookingId FOREIGN KEY(bookingId) REFERENCES booking(id),
     CONSTRAINT FK_roomId FOREIGN KEY(roomId) REFERENCES rooms(id),
+    CONSTRAINT FK_booking_details_layoutId FOREIGN KEY(layoutId) REFERENCES room_layouts(id) ON DELETE SET NULL,
+    CONSTRAINT CK_booking_details_resource CHECK ((resourceType = 'room' AND roomId = resourceId) OR (resourceType <> 'room' AND roomId IS NULL))
+);
+
+CREATE TABLE equipment (
+    id                      UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    siteId                  UUID NOT NULL,
+    code                    VARCHAR(100) NOT NULL,
+    name                    VARCHAR(100) NOT NULL,
+    quantity                INT NOT NULL,
+    CreatedAt               TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UpdatedAt               TIMESTAMPTZ,
+    CONSTRAINT FK_equipment_siteId FOREIGN KEY(siteId) REFERENCES sites(id),
+    CONSTRAINT UQ_equipment_code UNIQUE (siteId, code),
+    CONSTRAINT CK_equipment_quantity CHECK (quantity >= 0)
+);
+
+-- equipment yang dipesan per booking detail, tanpa cascade ke equipment supaya riwayat booking tidak hilang
+CREATE TABLE booking_detail_equipment (
+    bookingDetailId         UUID NOT NULL,
+    equipmentId             UUID NOT NULL,
+    quantity                INT NOT NULL,
+    PRIMARY KEY (bookingDetailId, equipmentId),
+    CONSTRAINT FK_booking_detail_equipment_detail FOREIGN KEY(bookingDetailId) REFERENCES booking_details(id) ON DELETE CASCADE,
+    CONSTRAINT FK_booking_detail_equipment_equipment FOREIGN KEY(equipmentId) REFERENCES equipment(id),
+    CONSTRAINT CK_booking_detail_equipment_quantity CHECK (quantity > 0)
+);
+
+CREATE TABLE room_feedback (
+    id                      UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    bookingDetailId         UUID NOT NULL,
+    roomId                  UUID NOT NULL,
+    userId                  UUID NOT NULL,
+    rating                  INT NOT NULL,
+    comment                 TEXT,
+    CreatedAt               TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT FK_room_feedback_bookingDetailId FOREIGN KEY(bookingDetailId) REFERENCES booking_details(id),
+    CONSTRAINT FK_room_feedback_roomId FOREIGN KEY(roomId) REFERENCES rooms(id),
+    CONSTRAINT FK_room_feedback_userId FOREIGN KEY(userId) REFERENCES users(id),
+    CONSTRAINT CK_room_feedback_rating CHECK (rating BETWEEN 1 AND 5),
+    CONSTRAINT UQ_room_feedback_bookingDetailId_userId UNIQUE (bookingDetailId, userId) -- satu feedback per user untuk setiap booking detail
+);
+
+CREATE TABLE maintenance_tickets (
+    id                      UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    roomId                  UUID NOT NULL,
+    bookingDetailId         UUID, -- NULL jika tiket tidak berasal dari feedback booking
+    reportedBy              UUID NOT NULL,
+    category                VARCHAR(50) NOT NULL,
+    severity                VARCHAR(20) NOT NULL,
+    description             TEXT,
+    status                  VARCHAR(20) NOT NULL DEFAULT 'open',
+    assignedTo              UUID,
+    resolution              TEXT,
+    CreatedAt               TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UpdatedAt               TIMESTAMPTZ,
+    resolvedAt              TIMESTAMPTZ,
+    CONSTRAINT FK_maintenance_tickets_roomId FOREIGN KEY(roomId) REFERENCES rooms(id),
+    CONSTRAINT FK_maintenance_tickets_bookingDetailId FOREIGN KEY(bookingDetailId) REFERENCES booking_details(id),
+    CONSTRAINT FK_maintenance_tickets_reportedBy FOREIGN KEY(reportedBy) REFERENCES users(id),
+    CONSTRAINT FK_maintenance_tickets_assignedTo FOREIGN KEY(assignedTo) REFERENCES users(id),
+    CONSTRAINT CK_maintenance_ticket_severity CHECK (severity IN ('low', 'medium', 'high', 'critical')),
+    CONSTRAINT CK_maintenance_ticket_status CHECK (status IN ('open', 'assigned', 'resolved'))
+);
+
+CREATE TABLE visitors (
+    id                      UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    bookingDetailId         UUID NOT NULL,
+    registeredBy            UUID NOT NULL,
+    name                    VARCHAR(100) NOT NULL,
+    company                 VARCHAR(100),
+    email                   VARCHAR(100) NOT NULL,
+    expectedArrival         TIMESTAMPTZ NOT NULL,
+    status                  VARCHAR(20) NOT NULL DEFAULT 'expected',
+    checkedInAt             TIMESTAMPTZ,
+    checkedOutAt            TIMESTAMPTZ,
+    CreatedAt               TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UpdatedAt               TIMESTAMPTZ,
+    CONSTRAINT FK_visitors_bookingDetailId FOREIGN KEY(bookingDetailId) REFERENCES booking_details(id) ON DELETE CASCADE,
+    CONSTRAINT FK_visitors_registeredBy FOREIGN KEY(registeredBy) REFERENCES users(id),
+    CONSTRAINT CK_visitors_status CHECK (status IN ('expected', 'checked_in', 'checked_out'))
+);
+
+CREATE TABLE service_orders (
+    id                      UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    bookingDetailId         UUID NOT NULL,
+    requestedBy             UUID NOT NULL,
+    serviceType             VARCHAR(30) NOT NULL,
+    quantity                INT NOT NULL DEFAULT 1, -- jumlah porsi atau orang untuk catering
+    deliverAt               TIMESTAMPTZ NOT NULL,
+    notes                   TEXT,
+    approvalStatus          VARCHAR(20) NOT NULL DEFAULT 'pending',
+    fulfillmentStatus       VARCHAR(20) NOT NULL DEFAULT 'pending',
+    handledBy               UUID, -- staf services yang terakhir memproses order
+    responseNote            TEXT,
+    CreatedAt               TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UpdatedAt               TIMESTAMPTZ,
+    CONSTRAINT FK_service_orders_bookingDetailId FOREIGN KEY(bookingDetailId) REFERENCES booking_details(id) ON DELETE CASCADE,
+    CONSTRAINT FK_service_orders_requestedBy FOREIGN KEY(requestedBy) REFERENCES users(id),
+    CONSTRAINT FK_service_orders_handledBy FOREIGN KEY(handledBy) REFERENCES users(id),
+    CONSTRAINT CK_service_orders_type CHECK (serviceType IN ('coffee_break', 'snack', 'lunch', 'dinner', 'it_setup', 'other')),
+    CONSTRAINT CK_service_orders_quantity CHECK (quantity > 0),
+    CONSTRAINT CK_service_orders_approval CHECK (approvalStatus IN ('pending', 'approved', 'rejected', 'cancelled')),
+    CONSTRAINT CK_service_orders_fulfillment CHECK (fulfillmentStatus IN ('pending', 'in_progress', 'fulfilled'))
+);
+
+CREATE INDEX idx_booking_userid ON booking(userId);
+CREATE INDEX idx_booking_participants_userid ON booking_participants(userId);
+CREATE INDEX idx_booking_details_bookingid ON booking_details(bookingId);
+-- dipakai juga oleh kalender yang mencari booking per ruangan dalam rentang tanggal
+CREATE INDEX idx_booking_details_room_date ON booking_details(roomId, bookingDate);
+CREATE INDEX idx_booking_details_resource_date ON booking_details(resourceId, bookingDate);
+CREATE INDEX idx_room_amenities_amenityid ON room_amenities(amenityId);
+CREATE INDEX idx_buildings_siteid ON buildings(siteId);
+CREATE INDEX idx_floors_buildingid ON floors(buildingId);
+CREATE INDEX idx_rooms_floorid ON rooms(floorId);
+CREATE INDEX idx_resources_floorid ON resources(floorId);
+CREATE INDEX idx_neighborhoods_floorid ON neighborhoods(floorId);
+CREATE INDEX idx_room_images_roomid ON room_images(roomId);
+CREATE INDEX idx_room_layouts_roomid ON room_layouts(roomId);
+CREATE INDEX idx_room_combinations_part ON room_combinations(partRoomId);
+CREATE INDEX idx_booking_detail_equipment_equipmentid ON booking_detail_equipment(equipmentId);
+-- dipakai pengecekan status ruangan, hanya tiket yang belum resolved
+CREATE INDEX idx_maintenance_tickets_open_room ON maintenance_tickets(roomId) WHERE status <> 'resolved';
+-- dipakai tampilan harian resepsionis dan penghapusan data visitor setelah masa retensi
+CREATE INDEX idx_visitors_expected_arrival ON visitors(expectedArrival);
+CREATE INDEX idx_visitors_bookingdetailid ON visitors(bookingDetailId);
+-- dipakai daftar harian vendor dan pembatalan order yang masih berjalan saat booking batal
+CREATE INDEX idx_service_orders_deliver_at ON service_orders(deliverAt);
+CREATE INDEX idx_service_orders_open ON service_orders(bookingDetailId) WHERE approvalStatus IN ('pending', 'approved');
+
+INSERT INTO amenities (code, name, icon, type, UpdatedAt) VALUES
+    ('wifi', 'Wifi', 'wifi', 'boolean', CURRENT_TIMESTAMP),
+    ('sound_system', 'Sound System', 'speaker', 'boolean', CURRENT_TIMESTAMP),
+    ('projector', 'Projector', 'projector', 'boolean', CURRENT_TIMESTAMP),
+    ('screen_projector', 'Screen Projector', 'screen', 'boolean', CURRENT_TIMESTAMP),
+    ('chairs', 'Chairs', 'chair', 'quantity', CURRENT_TIMESTAMP),
+    ('tables', 'Tables', 'table', 'quantity', CURRENT_TIMESTAMP),
+    ('sound_proof', 'Sound Proof', 'volume-off', 'boolean', CURRENT_TIMESTAMP),
+    ('smoking_area', 'Smoking Area', 'smoking', 'boolean', CURRENT_TIMESTAMP),
+    ('television', 'Television', 'tv', 'boolean', CURRENT_TIMESTAMP),
+    ('ac', 'AC', 'snowflake', 'boolean', CURRENT_TIMESTAMP),
+    ('bathroom', 'Bathroom', 'bath', 'boolean', CURRENT_TIMESTAMP),
+    ('coffee_maker', 'Coffee Maker', 'coffee', 'boolean', CURRENT_TIMESTAMP)
+ON CONFLICT (code) DO NOTHING;
+
+INSERT INTO resource_types (code, name, bookingMode, maxDurationMinutes, maxAdvanceDays, maxPerUserPerDay, attributes) VALUES
+    ('desk', 'Hot Desk', 'daily', 0, 14, 1, '{monitor,standing,docking_station}'),
+    ('parking', 'Parking Spot', 'daily', 0, 7, 1, '{ev_charger,covered}'),
+    ('phone_booth', 'Phone Booth', 'slot', 60, 7, 0, '{}')
+ON CONFLICT (code) DO NOTHING;
 
-);
\ No newline at end of file
+-- view read-only untuk kolom facility lama selama masa migrasi, nilainya diturunkan dari room_amenities
+CREATE VIEW v_room_facilities AS
+SELECT
+    r.id                                                                                   AS roomId,
+    f.Id                                                                                   AS id,
+    f.RoomDescription                                                                      AS roomDescription,
+    CASE WHEN bool_or(a.code = 'wifi') THEN 'ada' ELSE 'tidak' END                         AS fwifi,
+    CASE WHEN bool_or(a.code = 'sound_system') THEN 'ada' ELSE 'tidak' END                 AS fsoundSystem,
+    CASE WHEN bool_or(a.code = 'projector') THEN 'ada' ELSE 'tidak' END                    AS fprojector,
+    CASE WHEN bool_or(a.code = 'screen_projector') THEN 'ada' ELSE 'tidak' END             AS fscreenProjector,
+    COALESCE(MAX(CASE WHEN a.code = 'chairs' THEN ra.quantity END)::TEXT, 'tidak')         AS fchairs,
+    COALESCE(MAX(CASE WHEN a.code = 'tables' THEN ra.quantity END)::TEXT, 'tidak')         AS ftables,
+    CASE WHEN bool_or(a.code = 'sound_proof') THEN 'ada' ELSE 'tidak' END                  AS fsoundProof,
+    CASE WHEN bool_or(a.code = 'smoking_area') THEN 'ada' ELSE 'tidak' END                 AS fsmonkingArea,
+    CASE WHEN bool_or(a.code = 'television') THEN 'ada' ELSE 'tidak' END                   AS ftelevison,
+    CASE WHEN bool_or(a.code = 'ac') THEN 'ada' ELSE 'tidak' END                           AS fac,
+    CASE WHEN bool_or(a.code = 'bathroom') THEN 'ada' ELSE 'tidak' END                     AS fbathroom,
+    CASE WHEN bool_or(a.code = 'coffee_maker') THEN 'ada' ELSE 'tidak' END                 AS fcoffeMaker,
+    f.CreatedAt                                                                            AS createdAt,
+    f.UpdatedAt                                                                            AS updatedAt
+FROM rooms r
+JOIN facilities f ON f.Id = r.Facilities
+LEFT JOIN room_amenities ra ON ra.roomId = r.id AND ra.quantity > 0
+LEFT JOIN amenities a ON a.id = ra.amenityId
+GROUP BY r.id, f.Id;
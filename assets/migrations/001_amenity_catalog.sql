@@ -0,0 +1,94 @@
+-- Memindahkan kolom facility lama (fwifi, fprojector, ...) ke katalog amenities.
+-- Kolom lama di tabel facilities tidak lagi ditulis aplikasi dan bisa di-drop
+-- setelah semua client membaca field amenities.
+BEGIN;
+
+CREATE TABLE amenities (
+    id                      UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    code                    VARCHAR(100) UNIQUE NOT NULL,
+    name                    VARCHAR(100) NOT NULL,
+    icon                    VARCHAR(100),
+    type                    VARCHAR(20) NOT NULL DEFAULT 'boolean', -- boolean atau quantity
+    CreatedAt               TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UpdatedAt               TIMESTAMP,
+    CONSTRAINT CK_amenity_type CHECK (type IN ('boolean', 'quantity'))
+);
+
+CREATE TABLE room_amenities (
+    roomId                  UUID,
+    amenityId               UUID,
+    quantity                INT NOT NULL DEFAULT 1,
+    PRIMARY KEY (roomId, amenityId),
+    CONSTRAINT FK_room_amenities_roomId FOREIGN KEY(roomId) REFERENCES rooms(id) ON DELETE CASCADE,
+    CONSTRAINT FK_room_amenities_amenityId FOREIGN KEY(amenityId) REFERENCES amenities(id)
+);
+
+CREATE INDEX idx_room_amenities_amenityid ON room_amenities(amenityId);
+
+INSERT INTO amenities (code, name, icon, type, UpdatedAt) VALUES
+    ('wifi', 'Wifi', 'wifi', 'boolean', CURRENT_TIMESTAMP),
+    ('sound_system', 'Sound System', 'speaker', 'boolean', CURRENT_TIMESTAMP),
+    ('projector', 'Projector', 'projector', 'boolean', CURRENT_TIMESTAMP),
+    ('screen_projector', 'Screen Projector', 'screen', 'boolean', CURRENT_TIMESTAMP),
+    ('chairs', 'Chairs', 'chair', 'quantity', CURRENT_TIMESTAMP),
+    ('tables', 'Tables', 'table', 'quantity', CURRENT_TIMESTAMP),
+    ('sound_proof', 'Sound Proof', 'volume-off', 'boolean', CURRENT_TIMESTAMP),
+    ('smoking_area', 'Smoking Area', 'smoking', 'boolean', CURRENT_TIMESTAMP),
+    ('television', 'Television', 'tv', 'boolean', CURRENT_TIMESTAMP),
+    ('ac', 'AC', 'snowflake', 'boolean', CURRENT_TIMESTAMP),
+    ('bathroom', 'Bathroom', 'bath', 'boolean', CURRENT_TIMESTAMP),
+    ('coffee_maker', 'Coffee Maker', 'coffee', 'boolean', CURRENT_TIMESTAMP)
+ON CONFLICT (code) DO NOTHING;
+
+-- nilai seperti 'tidak', '-', 'no', '0' atau kosong dianggap tidak ada,
+-- kursi dan meja yang berisi angka disimpan sebagai quantity
+INSERT INTO room_amenities (roomId, amenityId, quantity)
+SELECT r.id, a.id,
+    CASE WHEN a.type = 'quantity' AND TRIM(legacy.value) ~ '^[0-9]+$' THEN TRIM(legacy.value)::INT ELSE 1 END
+FROM rooms r
+JOIN facilities f ON f.Id = r.Facilities
+CROSS JOIN LATERAL (VALUES
+    ('wifi', f.Fwifi),
+    ('sound_system', f.FsoundSystem),
+    ('projector', f.Fprojector),
+    ('screen_projector', f.FscreenProjector),
+    ('chairs', f.Fchairs),
+    ('tables', f.Ftables),
+    ('sound_proof', f.FsoundProof),
+    ('smoking_area', f.FsmonkingArea),
+    ('television', f.Ftelevison),
+    ('ac', f.FAc),
+    ('bathroom', f.Fbathroom),
+    ('coffee_maker', f.FcoffeMaker)
+) AS legacy(code, value)
+JOIN amenities a ON a.code = legacy.code
+WHERE LOWER(TRIM(COALESCE(legacy.value, ''))) NOT IN ('', 'tidak', 'tidak ada', '-', 'no', '0')
+ON CONFLICT DO NOTHING;
+
+-- view read-only untuk kolom facility lama selama masa migrasi, nilainya diturunkan dari room_amenities
+CREATE VIEW v_room_facilities AS
+SELECT
+    r.id                                                                                   AS roomId,
+    f.Id                                                                                   AS id,
+    f.RoomDescription                                                                      AS roomDescription,
+    CASE WHEN bool_or(a.code = 'wifi') THEN 'ada' ELSE 'tidak' END                         AS fwifi,
+    CASE WHEN bool_or(a.code = 'sound_system') THEN 'ada' ELSE 'tidak' END                 AS fsoundSystem,
+    CASE WHEN bool_or(a.code = 'projector') THEN 'ada' ELSE 'tidak' END                    AS fprojector,
+    CASE WHEN bool_or(a.code = 'screen_projector') THEN 'ada' ELSE 'tidak' END             AS fscreenProjector,
+    COALESCE(MAX(CASE WHEN a.code = 'chairs' THEN ra.quantity END)::TEXT, 'tidak')         AS fchairs,
+    COALESCE(MAX(CASE WHEN a.code = 'tables' THEN ra.quantity END)::TEXT, 'tidak')         AS ftables,
+    CASE WHEN bool_or(a.code = 'sound_proof') THEN 'ada' ELSE 'tidak' END                  AS fsoundProof,
+    CASE WHEN bool_or(a.code = 'smoking_area') THEN 'ada' ELSE 'tidak' END                 AS fsmonkingArea,
+    CASE WHEN bool_or(a.code = 'television') THEN 'ada' ELSE 'tidak' END                   AS ftelevison,
+    CASE WHEN bool_or(a.code = 'ac') THEN 'ada' ELSE 'tidak' END                           AS fac,
+    CASE WHEN bool_or(a.code = 'bathroom') THEN 'ada' ELSE 'tidak' END                     AS fbathroom,
+    CASE WHEN bool_or(a.code = 'coffee_maker') THEN 'ada' ELSE 'tidak' END                 AS fcoffeMaker,
+    f.CreatedAt                                                                            AS createdAt,
+    f.UpdatedAt                                                                            AS updatedAt
+FROM rooms r
+JOIN facilities f ON f.Id = r.Facilities
+LEFT JOIN room_amenities ra ON ra.roomId = r.id AND ra.quantity > 0
+LEFT JOIN amenities a ON a.id = ra.amenityId
+GROUP BY r.id, f.Id;
+
+COMMIT;
@@ -0,0 +1,48 @@
+-- Menambahkan hierarki lokasi site > building > floor dan kolom name/code/floorId di rooms.
+-- Room lama belum punya floor (floorId NULL) sampai admin menempatkannya lewat PUT /rooms.
+BEGIN;
+
+CREATE TABLE sites (
+    id                      UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name                    VARCHAR(100) NOT NULL,
+    address                 TEXT NOT NULL DEFAULT '',
+    timezone                VARCHAR(100) NOT NULL DEFAULT '', -- nama IANA, misalnya Asia/Jakarta
+    CreatedAt               TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UpdatedAt               TIMESTAMP
+);
+
+CREATE TABLE buildings (
+    id                      UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    siteId                  UUID NOT NULL,
+    name                    VARCHAR(100) NOT NULL,
+    address                 TEXT NOT NULL DEFAULT '',
+    timezone                VARCHAR(100) NOT NULL DEFAULT '', -- kosong berarti mengikuti timezone site
+    CreatedAt               TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UpdatedAt               TIMESTAMP,
+    CONSTRAINT FK_buildings_siteId FOREIGN KEY(siteId) REFERENCES sites(id)
+);
+
+CREATE TABLE floors (
+    id                      UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    buildingId              UUID NOT NULL,
+    name                    VARCHAR(100) NOT NULL,
+    level                   INT NOT NULL DEFAULT 0,
+    CreatedAt               TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UpdatedAt               TIMESTAMP,
+    CONSTRAINT FK_floors_buildingId FOREIGN KEY(buildingId) REFERENCES buildings(id)
+);
+
+ALTER TABLE rooms
+    ADD COLUMN name         VARCHAR(100),
+    ADD COLUMN code         VARCHAR(50) UNIQUE,
+    ADD COLUMN floorId      UUID,
+    ADD CONSTRAINT FK_rooms_floorId FOREIGN KEY(floorId) REFERENCES floors(id);
+
+CREATE INDEX idx_buildings_siteid ON buildings(siteId);
+CREATE INDEX idx_floors_buildingid ON floors(buildingId);
+CREATE INDEX idx_rooms_floorid ON rooms(floorId);
+
+-- sebelumnya room hanya dikenali lewat roomType
+UPDATE rooms SET name = RoomType WHERE name IS NULL;
+
+COMMIT;
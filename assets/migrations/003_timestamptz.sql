@@ -0,0 +1,72 @@
+-- Mengubah semua kolom waktu dari TIMESTAMP ke TIMESTAMPTZ.
+-- Data lama ditulis dengan time.Now() server yang berjalan di WIB, jadi nilainya
+-- dibaca sebagai jam Asia/Jakarta. Sesuaikan jika server lama memakai zona lain.
+BEGIN;
+
+-- view bergantung pada kolom facilities.createdAt/updatedAt, dibuat ulang di bawah
+DROP VIEW v_room_facilities;
+
+ALTER TABLE users
+    ALTER COLUMN CreatedAt TYPE TIMESTAMPTZ USING CreatedAt AT TIME ZONE 'Asia/Jakarta',
+    ALTER COLUMN UpdatedAt TYPE TIMESTAMPTZ USING UpdatedAt AT TIME ZONE 'Asia/Jakarta';
+
+ALTER TABLE facilities
+    ALTER COLUMN CreatedAt TYPE TIMESTAMPTZ USING CreatedAt AT TIME ZONE 'Asia/Jakarta',
+    ALTER COLUMN UpdatedAt TYPE TIMESTAMPTZ USING UpdatedAt AT TIME ZONE 'Asia/Jakarta';
+
+ALTER TABLE sites
+    ALTER COLUMN CreatedAt TYPE TIMESTAMPTZ USING CreatedAt AT TIME ZONE 'Asia/Jakarta',
+    ALTER COLUMN UpdatedAt TYPE TIMESTAMPTZ USING UpdatedAt AT TIME ZONE 'Asia/Jakarta';
+
+ALTER TABLE buildings
+    ALTER COLUMN CreatedAt TYPE TIMESTAMPTZ USING CreatedAt AT TIME ZONE 'Asia/Jakarta',
+    ALTER COLUMN UpdatedAt TYPE TIMESTAMPTZ USING UpdatedAt AT TIME ZONE 'Asia/Jakarta';
+
+ALTER TABLE floors
+    ALTER COLUMN CreatedAt TYPE TIMESTAMPTZ USING CreatedAt AT TIME ZONE 'Asia/Jakarta',
+    ALTER COLUMN UpdatedAt TYPE TIMESTAMPTZ USING UpdatedAt AT TIME ZONE 'Asia/Jakarta';
+
+ALTER TABLE rooms
+    ALTER COLUMN CreatedAt TYPE TIMESTAMPTZ USING CreatedAt AT TIME ZONE 'Asia/Jakarta',
+    ALTER COLUMN UpdatedAt TYPE TIMESTAMPTZ USING UpdatedAt AT TIME ZONE 'Asia/Jakarta';
+
+ALTER TABLE amenities
+    ALTER COLUMN CreatedAt TYPE TIMESTAMPTZ USING CreatedAt AT TIME ZONE 'Asia/Jakarta',
+    ALTER COLUMN UpdatedAt TYPE TIMESTAMPTZ USING UpdatedAt AT TIME ZONE 'Asia/Jakarta';
+
+ALTER TABLE booking
+    ALTER COLUMN CreatedAt TYPE TIMESTAMPTZ USING CreatedAt AT TIME ZONE 'Asia/Jakarta',
+    ALTER COLUMN UpdatedAt TYPE TIMESTAMPTZ USING UpdatedAt AT TIME ZONE 'Asia/Jakarta';
+
+ALTER TABLE booking_details
+    ALTER COLUMN bookingDate TYPE TIMESTAMPTZ USING bookingDate AT TIME ZONE 'Asia/Jakarta',
+    ALTER COLUMN bookingDateEnd TYPE TIMESTAMPTZ USING bookingDateEnd AT TIME ZONE 'Asia/Jakarta',
+    ALTER COLUMN CreatedAt TYPE TIMESTAMPTZ USING CreatedAt AT TIME ZONE 'Asia/Jakarta',
+    ALTER COLUMN UpdatedAt TYPE TIMESTAMPTZ USING UpdatedAt AT TIME ZONE 'Asia/Jakarta';
+
+CREATE VIEW v_room_facilities AS
+SELECT
+    r.id                                                                                   AS roomId,
+    f.Id                                                                                   AS id,
+    f.RoomDescription                                                                      AS roomDescription,
+    CASE WHEN bool_or(a.code = 'wifi') THEN 'ada' ELSE 'tidak' END                         AS fwifi,
+    CASE WHEN bool_or(a.code = 'sound_system') THEN 'ada' ELSE 'tidak' END                 AS fsoundSystem,
+    CASE WHEN bool_or(a.code = 'projector') THEN 'ada' ELSE 'tidak' END                    AS fprojector,
+    CASE WHEN bool_or(a.code = 'screen_projector') THEN 'ada' ELSE 'tidak' END             AS fscreenProjector,
+    COALESCE(MAX(CASE WHEN a.code = 'chairs' THEN ra.quantity END)::TEXT, 'tidak')         AS fchairs,
+    COALESCE(MAX(CASE WHEN a.code = 'tables' THEN ra.quantity END)::TEXT, 'tidak')         AS ftables,
+    CASE WHEN bool_or(a.code = 'sound_proof') THEN 'ada' ELSE 'tidak' END                  AS fsoundProof,
+    CASE WHEN bool_or(a.code = 'smoking_area') THEN 'ada' ELSE 'tidak' END                 AS fsmonkingArea,
+    CASE WHEN bool_or(a.code = 'television') THEN 'ada' ELSE 'tidak' END                   AS ftelevison,
+    CASE WHEN bool_or(a.code = 'ac') THEN 'ada' ELSE 'tidak' END                           AS fac,
+    CASE WHEN bool_or(a.code = 'bathroom') THEN 'ada' ELSE 'tidak' END                     AS fbathroom,
+    CASE WHEN bool_or(a.code = 'coffee_maker') THEN 'ada' ELSE 'tidak' END                 AS fcoffeMaker,
+    f.CreatedAt                                                                            AS createdAt,
+    f.UpdatedAt                                                                            AS updatedAt
+FROM rooms r
+JOIN facilities f ON f.Id = r.Facilities
+LEFT JOIN room_amenities ra ON ra.roomId = r.id AND ra.quantity > 0
+LEFT JOIN amenities a ON a.id = ra.amenityId
+GROUP BY r.id, f.Id;
+
+COMMIT;
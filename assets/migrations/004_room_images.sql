@@ -0,0 +1,21 @@
+-- Foto dan denah ruangan. File disimpan lewat FileStorage, tabel ini hanya menyimpan URL dan key-nya.
+BEGIN;
+
+CREATE TABLE room_images (
+    id                      UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    roomId                  UUID NOT NULL,
+    kind                    VARCHAR(20) NOT NULL, -- photo atau floor_plan
+    url                     TEXT NOT NULL,
+    thumbnailUrl            TEXT NOT NULL,
+    contentType             VARCHAR(100) NOT NULL,
+    size                    BIGINT NOT NULL,
+    storageKey              TEXT NOT NULL,
+    thumbnailKey            TEXT NOT NULL,
+    CreatedAt               TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT FK_room_images_roomId FOREIGN KEY(roomId) REFERENCES rooms(id) ON DELETE CASCADE,
+    CONSTRAINT CK_room_image_kind CHECK (kind IN ('photo', 'floor_plan'))
+);
+
+CREATE INDEX idx_room_images_roomid ON room_images(roomId);
+
+COMMIT;
@@ -0,0 +1,17 @@
+-- Denah interaktif: ukuran bidang koordinat per floor dan posisi ruangan di denah.
+BEGIN;
+
+ALTER TABLE floors
+    ADD COLUMN mapWidth     INT NOT NULL DEFAULT 1000,
+    ADD COLUMN mapHeight    INT NOT NULL DEFAULT 1000;
+
+CREATE TABLE room_map_shapes (
+    roomId                  UUID PRIMARY KEY,
+    type                    VARCHAR(20) NOT NULL, -- point atau polygon
+    points                  JSONB NOT NULL,       -- [{"x": 10, "y": 20}, ...] dalam koordinat floor
+    UpdatedAt               TIMESTAMPTZ,
+    CONSTRAINT FK_room_map_shapes_roomId FOREIGN KEY(roomId) REFERENCES rooms(id) ON DELETE CASCADE,
+    CONSTRAINT CK_room_map_shape_type CHECK (type IN ('point', 'polygon'))
+);
+
+COMMIT;
@@ -0,0 +1,7 @@
+-- Ruangan tidak lagi dihapus permanen: diarsipkan agar riwayat booking dan report tetap utuh.
+-- Booking yang dibatalkan karena ruangan diarsipkan memakai status 'cancel'.
+BEGIN;
+
+ALTER TABLE rooms ADD COLUMN archivedAt TIMESTAMPTZ;
+
+COMMIT;
@@ -0,0 +1,30 @@
+-- Layout ruangan: kapasitas dan waktu setup/teardown per susunan kursi.
+-- Booking lama tidak punya layout, rentang terpakainya sama dengan jam booking.
+BEGIN;
+
+CREATE TABLE room_layouts (
+    id                      UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    roomId                  UUID NOT NULL,
+    name                    VARCHAR(100) NOT NULL,
+    capacity                INT NOT NULL,
+    setupMinutes            INT NOT NULL DEFAULT 0,
+    teardownMinutes         INT NOT NULL DEFAULT 0,
+    CreatedAt               TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UpdatedAt               TIMESTAMPTZ,
+    CONSTRAINT FK_room_layouts_roomId FOREIGN KEY(roomId) REFERENCES rooms(id) ON DELETE CASCADE,
+    CONSTRAINT UQ_room_layouts_name UNIQUE (roomId, name),
+    CONSTRAINT CK_room_layouts_capacity CHECK (capacity > 0),
+    CONSTRAINT CK_room_layouts_buffer CHECK (setupMinutes >= 0 AND teardownMinutes >= 0)
+);
+CREATE INDEX idx_room_layouts_roomid ON room_layouts(roomId);
+
+ALTER TABLE booking_details
+    ADD COLUMN layoutId     UUID,
+    ADD COLUMN attendees    INT NOT NULL DEFAULT 0,
+    ADD COLUMN blockedFrom  TIMESTAMPTZ,
+    ADD COLUMN blockedUntil TIMESTAMPTZ,
+    ADD CONSTRAINT FK_booking_details_layoutId FOREIGN KEY(layoutId) REFERENCES room_layouts(id) ON DELETE SET NULL;
+
+UPDATE booking_details SET blockedFrom = bookingDate, blockedUntil = bookingDateEnd;
+
+COMMIT;
@@ -0,0 +1,14 @@
+-- Ruangan gabungan: booking Hall A+B memblokir Hall A dan Hall B, booking salah satu bagian memblokir Hall A+B.
+BEGIN;
+
+CREATE TABLE room_combinations (
+    combinedRoomId          UUID NOT NULL,
+    partRoomId              UUID NOT NULL,
+    PRIMARY KEY (combinedRoomId, partRoomId),
+    CONSTRAINT FK_room_combinations_combined FOREIGN KEY(combinedRoomId) REFERENCES rooms(id) ON DELETE CASCADE,
+    CONSTRAINT FK_room_combinations_part FOREIGN KEY(partRoomId) REFERENCES rooms(id) ON DELETE CASCADE,
+    CONSTRAINT CK_room_combinations_self CHECK (combinedRoomId <> partRoomId)
+);
+CREATE INDEX idx_room_combinations_part ON room_combinations(partRoomId);
+
+COMMIT;
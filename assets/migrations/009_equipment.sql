@@ -0,0 +1,29 @@
+-- Inventaris equipment per site (projector portable, speakerphone, dll) yang bisa dipesan bersama ruangan.
+BEGIN;
+
+CREATE TABLE equipment (
+    id                      UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    siteId                  UUID NOT NULL,
+    code                    VARCHAR(100) NOT NULL,
+    name                    VARCHAR(100) NOT NULL,
+    quantity                INT NOT NULL,
+    CreatedAt               TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UpdatedAt               TIMESTAMPTZ,
+    CONSTRAINT FK_equipment_siteId FOREIGN KEY(siteId) REFERENCES sites(id),
+    CONSTRAINT UQ_equipment_code UNIQUE (siteId, code),
+    CONSTRAINT CK_equipment_quantity CHECK (quantity >= 0)
+);
+
+-- equipment yang dipesan per booking detail, tanpa cascade ke equipment supaya riwayat booking tidak hilang
+CREATE TABLE booking_detail_equipment (
+    bookingDetailId         UUID NOT NULL,
+    equipmentId             UUID NOT NULL,
+    quantity                INT NOT NULL,
+    PRIMARY KEY (bookingDetailId, equipmentId),
+    CONSTRAINT FK_booking_detail_equipment_detail FOREIGN KEY(bookingDetailId) REFERENCES booking_details(id) ON DELETE CASCADE,
+    CONSTRAINT FK_booking_detail_equipment_equipment FOREIGN KEY(equipmentId) REFERENCES equipment(id),
+    CONSTRAINT CK_booking_detail_equipment_quantity CHECK (quantity > 0)
+);
+CREATE INDEX idx_booking_detail_equipment_equipmentid ON booking_detail_equipment(equipmentId);
+
+COMMIT;
@@ -0,0 +1,16 @@
+-- Ruangan tertentu (ruang rapat direksi, ruang interview HR) hanya boleh dibooking divisi, jabatan atau role tertentu.
+BEGIN;
+
+-- rule akses booking per ruangan, deny selalu menang dan adanya rule allow membatasi ruangan ke user yang cocok
+CREATE TABLE room_access_rules (
+    roomId                  UUID NOT NULL,
+    effect                  VARCHAR(10) NOT NULL,  -- allow atau deny
+    field                   VARCHAR(20) NOT NULL,  -- divisi, jabatan atau role
+    value                   VARCHAR(100) NOT NULL,
+    PRIMARY KEY (roomId, effect, field, value),
+    CONSTRAINT FK_room_access_rules_roomId FOREIGN KEY(roomId) REFERENCES rooms(id) ON DELETE CASCADE,
+    CONSTRAINT CK_room_access_rules_effect CHECK (effect IN ('allow', 'deny')),
+    CONSTRAINT CK_room_access_rules_field CHECK (field IN ('divisi', 'jabatan', 'role'))
+);
+
+COMMIT;
@@ -0,0 +1,7 @@
+-- Kalender booking mencari booking per ruangan dalam rentang tanggal, index roomId saja diganti index gabungan.
+BEGIN;
+
+DROP INDEX IF EXISTS idx_booking_details_roomid;
+CREATE INDEX idx_booking_details_room_date ON booking_details(roomId, bookingDate);
+
+COMMIT;
@@ -0,0 +1,6 @@
+-- Check-in pemesan dipakai analytics untuk menghitung no-show.
+BEGIN;
+
+ALTER TABLE booking_details ADD COLUMN checkedInAt TIMESTAMPTZ;
+
+COMMIT;
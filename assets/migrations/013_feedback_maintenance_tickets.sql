@@ -0,0 +1,42 @@
+-- Feedback ruangan setelah booking selesai dan maintenance ticket dari laporan masalahnya.
+BEGIN;
+
+CREATE TABLE room_feedback (
+    id                      UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    bookingDetailId         UUID NOT NULL UNIQUE,
+    roomId                  UUID NOT NULL,
+    userId                  UUID NOT NULL,
+    rating                  INT NOT NULL,
+    comment                 TEXT,
+    CreatedAt               TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT FK_room_feedback_bookingDetailId FOREIGN KEY(bookingDetailId) REFERENCES booking_details(id),
+    CONSTRAINT FK_room_feedback_roomId FOREIGN KEY(roomId) REFERENCES rooms(id),
+    CONSTRAINT FK_room_feedback_userId FOREIGN KEY(userId) REFERENCES users(id),
+    CONSTRAINT CK_room_feedback_rating CHECK (rating BETWEEN 1 AND 5)
+);
+
+CREATE TABLE maintenance_tickets (
+    id                      UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    roomId                  UUID NOT NULL,
+    bookingDetailId         UUID,
+    reportedBy              UUID NOT NULL,
+    category                VARCHAR(50) NOT NULL,
+    severity                VARCHAR(20) NOT NULL,
+    description             TEXT,
+    status                  VARCHAR(20) NOT NULL DEFAULT 'open',
+    assignedTo              UUID,
+    resolution              TEXT,
+    CreatedAt               TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UpdatedAt               TIMESTAMPTZ,
+    resolvedAt              TIMESTAMPTZ,
+    CONSTRAINT FK_maintenance_tickets_roomId FOREIGN KEY(roomId) REFERENCES rooms(id),
+    CONSTRAINT FK_maintenance_tickets_bookingDetailId FOREIGN KEY(bookingDetailId) REFERENCES booking_details(id),
+    CONSTRAINT FK_maintenance_tickets_reportedBy FOREIGN KEY(reportedBy) REFERENCES users(id),
+    CONSTRAINT FK_maintenance_tickets_assignedTo FOREIGN KEY(assignedTo) REFERENCES users(id),
+    CONSTRAINT CK_maintenance_ticket_severity CHECK (severity IN ('low', 'medium', 'high', 'critical')),
+    CONSTRAINT CK_maintenance_ticket_status CHECK (status IN ('open', 'assigned', 'resolved'))
+);
+
+CREATE INDEX idx_maintenance_tickets_open_room ON maintenance_tickets(roomId) WHERE status <> 'resolved';
+
+COMMIT;
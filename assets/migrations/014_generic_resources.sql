@@ -0,0 +1,61 @@
+-- Desk, parking spot dan phone booth dibooking lewat booking dan booking_details yang sama dengan ruangan.
+-- resourceType + resourceId menjadi referensi yang dibooking, roomId tetap diisi untuk booking ruangan
+-- supaya query dan endpoint ruangan yang lama tetap jalan.
+BEGIN;
+
+CREATE TABLE resource_types (
+    code                    VARCHAR(50) PRIMARY KEY,
+    name                    VARCHAR(100) NOT NULL,
+    bookingMode             VARCHAR(20) NOT NULL DEFAULT 'slot',
+    maxDurationMinutes      INT NOT NULL DEFAULT 0,
+    maxAdvanceDays          INT NOT NULL DEFAULT 0,
+    maxPerUserPerDay        INT NOT NULL DEFAULT 0,
+    attributes              TEXT[] NOT NULL DEFAULT '{}',
+    CONSTRAINT CK_resource_types_bookingMode CHECK (bookingMode IN ('slot', 'daily'))
+);
+
+INSERT INTO resource_types (code, name, bookingMode, maxDurationMinutes, maxAdvanceDays, maxPerUserPerDay, attributes) VALUES
+    ('desk', 'Hot Desk', 'daily', 0, 14, 1, '{monitor,standing,docking_station}'),
+    ('parking', 'Parking Spot', 'daily', 0, 7, 1, '{ev_charger,covered}'),
+    ('phone_booth', 'Phone Booth', 'slot', 60, 7, 0, '{}');
+
+CREATE TABLE neighborhoods (
+    id                      UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    floorId                 UUID NOT NULL,
+    name                    VARCHAR(100) NOT NULL,
+    division                VARCHAR(100) NOT NULL DEFAULT '',
+    CreatedAt               TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UpdatedAt               TIMESTAMPTZ,
+    CONSTRAINT FK_neighborhoods_floorId FOREIGN KEY(floorId) REFERENCES floors(id),
+    CONSTRAINT UQ_neighborhoods_floor_name UNIQUE (floorId, name)
+);
+
+CREATE TABLE resources (
+    id                      UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    resourceType            VARCHAR(50) NOT NULL,
+    code                    VARCHAR(50) NOT NULL UNIQUE,
+    name                    VARCHAR(100) NOT NULL,
+    floorId                 UUID,
+    neighborhoodId          UUID,
+    attributes              JSONB NOT NULL DEFAULT '{}',
+    archivedAt              TIMESTAMPTZ,
+    CreatedAt               TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UpdatedAt               TIMESTAMPTZ,
+    CONSTRAINT FK_resources_resourceType FOREIGN KEY(resourceType) REFERENCES resource_types(code),
+    CONSTRAINT FK_resources_floorId FOREIGN KEY(floorId) REFERENCES floors(id),
+    CONSTRAINT FK_resources_neighborhoodId FOREIGN KEY(neighborhoodId) REFERENCES neighborhoods(id)
+);
+
+CREATE INDEX idx_resources_floorid ON resources(floorId);
+CREATE INDEX idx_neighborhoods_floorid ON neighborhoods(floorId);
+
+ALTER TABLE booking_details ADD COLUMN resourceType VARCHAR(50) NOT NULL DEFAULT 'room';
+ALTER TABLE booking_details ADD COLUMN resourceId UUID;
+UPDATE booking_details SET resourceId = roomId;
+ALTER TABLE booking_details ALTER COLUMN resourceId SET NOT NULL;
+ALTER TABLE booking_details ADD CONSTRAINT CK_booking_details_resource
+    CHECK ((resourceType = 'room' AND roomId = resourceId) OR (resourceType <> 'room' AND roomId IS NULL));
+
+CREATE INDEX idx_booking_details_resource_date ON booking_details(resourceId, bookingDate);
+
+COMMIT;
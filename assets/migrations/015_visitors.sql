@@ -0,0 +1,25 @@
+-- Tamu eksternal yang didaftarkan ke booking ruangan untuk check-in di resepsionis.
+BEGIN;
+
+CREATE TABLE visitors (
+    id                      UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    bookingDetailId         UUID NOT NULL,
+    registeredBy            UUID NOT NULL,
+    name                    VARCHAR(100) NOT NULL,
+    company                 VARCHAR(100),
+    email                   VARCHAR(100) NOT NULL,
+    expectedArrival         TIMESTAMPTZ NOT NULL,
+    status                  VARCHAR(20) NOT NULL DEFAULT 'expected',
+    checkedInAt             TIMESTAMPTZ,
+    checkedOutAt            TIMESTAMPTZ,
+    CreatedAt               TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UpdatedAt               TIMESTAMPTZ,
+    CONSTRAINT FK_visitors_bookingDetailId FOREIGN KEY(bookingDetailId) REFERENCES booking_details(id) ON DELETE CASCADE,
+    CONSTRAINT FK_visitors_registeredBy FOREIGN KEY(registeredBy) REFERENCES users(id),
+    CONSTRAINT CK_visitors_status CHECK (status IN ('expected', 'checked_in', 'checked_out'))
+);
+
+CREATE INDEX idx_visitors_expected_arrival ON visitors(expectedArrival);
+CREATE INDEX idx_visitors_bookingdetailid ON visitors(bookingDetailId);
+
+COMMIT;
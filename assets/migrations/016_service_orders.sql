@@ -0,0 +1,30 @@
+-- Order layanan (catering, IT setup) yang dilampirkan ke booking ruangan dan diproses role services.
+BEGIN;
+
+CREATE TABLE service_orders (
+    id                      UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    bookingDetailId         UUID NOT NULL,
+    requestedBy             UUID NOT NULL,
+    serviceType             VARCHAR(30) NOT NULL,
+    quantity                INT NOT NULL DEFAULT 1, -- jumlah porsi atau orang untuk catering
+    deliverAt               TIMESTAMPTZ NOT NULL,
+    notes                   TEXT,
+    approvalStatus          VARCHAR(20) NOT NULL DEFAULT 'pending',
+    fulfillmentStatus       VARCHAR(20) NOT NULL DEFAULT 'pending',
+    handledBy               UUID, -- staf services yang terakhir memproses order
+    responseNote            TEXT,
+    CreatedAt               TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UpdatedAt               TIMESTAMPTZ,
+    CONSTRAINT FK_service_orders_bookingDetailId FOREIGN KEY(bookingDetailId) REFERENCES booking_details(id) ON DELETE CASCADE,
+    CONSTRAINT FK_service_orders_requestedBy FOREIGN KEY(requestedBy) REFERENCES users(id),
+    CONSTRAINT FK_service_orders_handledBy FOREIGN KEY(handledBy) REFERENCES users(id),
+    CONSTRAINT CK_service_orders_type CHECK (serviceType IN ('coffee_break', 'snack', 'lunch', 'dinner', 'it_setup', 'other')),
+    CONSTRAINT CK_service_orders_quantity CHECK (quantity > 0),
+    CONSTRAINT CK_service_orders_approval CHECK (approvalStatus IN ('pending', 'approved', 'rejected', 'cancelled')),
+    CONSTRAINT CK_service_orders_fulfillment CHECK (fulfillmentStatus IN ('pending', 'in_progress', 'fulfilled'))
+);
+
+CREATE INDEX idx_service_orders_deliver_at ON service_orders(deliverAt);
+CREATE INDEX idx_service_orders_open ON service_orders(bookingDetailId) WHERE approvalStatus IN ('pending', 'approved');
+
+COMMIT;
@@ -0,0 +1,7 @@
+-- Link video conference untuk meeting hybrid, dibuat saat booking accept.
+BEGIN;
+
+ALTER TABLE booking_details ADD COLUMN conferenceId VARCHAR(100);
+ALTER TABLE booking_details ADD COLUMN conferenceUrl TEXT;
+
+COMMIT;
@@ -0,0 +1,6 @@
+-- SEQUENCE invite iCalendar per booking detail supaya kalender penerima mengganti event lama.
+BEGIN;
+
+ALTER TABLE booking_details ADD COLUMN inviteSequence INT NOT NULL DEFAULT 0;
+
+COMMIT;
@@ -0,0 +1,17 @@
+-- User lain yang diikutkan ke booking sebagai attendee atau delegate, booking ikut tampil di daftar booking mereka.
+BEGIN;
+
+CREATE TABLE booking_participants (
+    bookingId               UUID,
+    userId                  UUID,
+    role                    VARCHAR(20) NOT NULL,
+    CreatedAt               TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (bookingId, userId),
+    CONSTRAINT FK_booking_participants_bookingId FOREIGN KEY(bookingId) REFERENCES booking(id) ON DELETE CASCADE,
+    CONSTRAINT FK_booking_participants_userId FOREIGN KEY(userId) REFERENCES users(id),
+    CONSTRAINT CK_booking_participants_role CHECK (role IN ('attendee', 'delegate'))
+);
+
+CREATE INDEX idx_booking_participants_userid ON booking_participants(userId);
+
+COMMIT;
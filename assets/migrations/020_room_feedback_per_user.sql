@@ -0,0 +1,7 @@
+-- Attendee dan delegate ikut memberi feedback, jadi feedback unik per booking detail dan user.
+BEGIN;
+
+ALTER TABLE room_feedback DROP CONSTRAINT room_feedback_bookingdetailid_key;
+ALTER TABLE room_feedback ADD CONSTRAINT UQ_room_feedback_bookingDetailId_userId UNIQUE (bookingDetailId, userId);
+
+COMMIT;
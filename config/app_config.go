@@ -28,16 +28,106 @@ const (
 	BookingGet            = "/:id"
 	BookingGetAll         = "/"
 	BookingGetAllByStatus = "/status/:status"
+	BookingGetMine        = "/me"
+	BookingSearch         = "/search"
+	BookingCalendar       = "/calendar"     //query startDate, endDate, roomIds, lokasi, tz
+	BookingCheckIn        = "/:id/check-in" //id booking detail
 	Approval              = "/approval"
 
+	//analytics
+	AnalyticsGroup       = "/analytics"
+	AnalyticsUtilization = "/utilization" //query startDate, endDate, period, groupBy, roomType, lokasi
+
 	//room
-	RoomGroup         = "/rooms"
-	RoomPost          = "/create"
-	RoomGetByroomType = "/" //query
-	RoomGetAll        = "/get"
-	RoomGetById       = "/:id"
-	RoomGetByStatus   = "/status"
-	RoomDelete        = "/:id"
-	RoomUpdate        = "/:id"
-	RoomUpdateStatus  = "/status/:id"
+	RoomGroup        = "/rooms"
+	RoomPost         = "/create"
+	RoomImport       = "/import"          //multipart field file (csv/xlsx), query dryRun
+	RoomList         = "/"                //query roomType, status, minCapacity, maxCapacity, amenities, lokasi, sortBy, order, page, size
+	RoomRecommend    = "/recommendations" //query attendees, amenities, start, end (RFC3339), lokasi
+	RoomGetById      = "/:id"
+	RoomDelete       = "/:id"
+	RoomUpdate       = "/:id"
+	RoomUpdateStatus = "/status/:id"
+	RoomRestore      = "/:id/restore"
+	RoomParts        = "/:id/parts"
+	RoomAccess       = "/:id/access"
+
+	//room images
+	RoomPhotoUpload     = "/:id/photos"
+	RoomFloorPlanUpload = "/:id/floor-plans"
+	RoomImageDelete     = "/:id/images/:imageId"
+
+	//room layouts
+	RoomLayoutPost   = "/:id/layouts"
+	RoomLayoutUpdate = "/:id/layouts/:layoutId"
+	RoomLayoutDelete = "/:id/layouts/:layoutId"
+
+	//floor map, FloorMapGet di bawah FloorGroup dan RoomShape di bawah RoomGroup
+	FloorMapGet = "/:id/map"
+	RoomShape   = "/:id/shape"
+
+	//amenity
+	AmenityGroup   = "/amenities"
+	AmenityPost    = "/"
+	AmenityGetAll  = "/"
+	AmenityGetById = "/:id"
+	AmenityUpdate  = "/:id"
+	AmenityDelete  = "/:id"
+
+	//equipment
+	EquipmentGroup   = "/equipment"
+	EquipmentPost    = "/"
+	EquipmentGetAll  = "/" //query siteId
+	EquipmentGetById = "/:id"
+	EquipmentUpdate  = "/:id"
+	EquipmentDelete  = "/:id"
+
+	//feedback dan maintenance ticket, BookingFeedback di bawah BookingGroup
+	BookingFeedback = "/:id/feedback" //id booking detail
+	TicketGroup     = "/tickets"
+	TicketGetAll    = "/" //query status, severity, roomId, page, size
+	TicketAssign    = "/:id/assign"
+	TicketResolve   = "/:id/resolve"
+
+	//resource selain ruangan (desk, parking, phone booth)
+	ResourceGroup         = "/resources"
+	ResourceTypes         = "/types"
+	NeighborhoodPost      = "/neighborhoods"
+	NeighborhoodGetAll    = "/neighborhoods" //query floorId
+	ResourcePost          = "/"
+	ResourceList          = "/" //query type, neighborhoodId, lokasi, availableOn (YYYY-MM-DD), page, size
+	ResourceGetById       = "/:id"
+	ResourceUpdate        = "/:id"
+	ResourceDelete        = "/:id"
+	ResourceBook          = "/:id/bookings"
+	ResourceMyBookings    = "/bookings/me"
+	ResourceCancelBooking = "/bookings/:bookingId/cancel"
+
+	//visitor, BookingVisitorPost dan BookingVisitorGet di bawah BookingGroup
+	BookingVisitorPost = "/:id/visitors" //id booking detail
+	BookingVisitorGet  = "/:id/visitors" //id booking detail
+	VisitorGroup       = "/visitors"
+	VisitorGetAll      = "/" //query date (YYYY-MM-DD), lokasi
+	VisitorCheckIn     = "/:id/check-in"
+	VisitorCheckOut    = "/:id/check-out"
+
+	//order layanan (catering, IT setup), BookingServicePost dan BookingServiceGet di bawah BookingGroup
+	BookingServicePost = "/:id/services" //id booking detail
+	BookingServiceGet  = "/:id/services" //id booking detail
+	ServiceGroup       = "/services"
+	ServiceGetAll      = "/"      //query approvalStatus, fulfillmentStatus, page, size
+	ServiceDaily       = "/daily" //query date (YYYY-MM-DD), lokasi
+	ServiceApproval    = "/:id/approval"
+	ServiceFulfillment = "/:id/fulfillment"
+	ServiceCancel      = "/:id/cancel"
+
+	//location, dipakai bersama oleh sites, buildings dan floors
+	SiteGroup       = "/sites"
+	BuildingGroup   = "/buildings"
+	FloorGroup      = "/floors"
+	LocationPost    = "/"
+	LocationGetAll  = "/"
+	LocationGetById = "/:id"
+	LocationUpdate  = "/:id"
+	LocationDelete  = "/:id"
 )
@@ -14,12 +14,13 @@ type ApiConfig struct {
 }
 
 type DbConfig struct {
-	Host     string
-	Port     string
-	Name     string
-	User     string
-	Password string
-	Driver   string
+	Host         string
+	Port         string
+	Name         string
+	User         string
+	Password     string
+	Driver       string
+	QueryTimeout time.Duration
 }
 
 type TokenConfig struct {
@@ -38,12 +39,33 @@ type EmailConfig struct {
 	Password  string
 }
 
+// StorageConfig mengatur penyimpanan file upload (foto ruangan, denah)
+type StorageConfig struct {
+	Dir           string // folder penyimpanan untuk storage lokal
+	PublicURL     string // prefix URL publik file, misalnya /uploads
+	MaxUploadSize int64  // ukuran maksimal satu file dalam byte
+}
+
+// ConferenceConfig mengatur provider link video conference untuk booking
+type ConferenceConfig struct {
+	Provider string // saat ini hanya local
+	BaseURL  string // prefix link meeting untuk provider local
+}
+
+// VisitorConfig mengatur data tamu eksternal
+type VisitorConfig struct {
+	Retention time.Duration // data visitor dihapus setelah kunjungannya lebih lama dari ini
+}
+
 type Config struct {
 	ApiConfig
 	EmailConfig
 	DbConfig
 	TokenConfig
 	LogFileConfig
+	StorageConfig
+	VisitorConfig
+	ConferenceConfig
 }
 
 func (c *Config) readConfig() error {
@@ -71,10 +93,61 @@ func (c *Config) readConfig() error {
 		Driver:   os.Getenv("DB_DRIVER"),
 	}
 
+	// batas waktu query per request dalam detik, default 10 detik
+	c.DbConfig.QueryTimeout = 10 * time.Second
+	if queryTimeout := os.Getenv("DB_QUERY_TIMEOUT"); queryTimeout != "" {
+		seconds, err := strconv.Atoi(queryTimeout)
+		if err != nil || seconds <= 0 {
+			return errors.New("DB_QUERY_TIMEOUT must be a positive number of seconds")
+		}
+		c.DbConfig.QueryTimeout = time.Duration(seconds) * time.Second
+	}
+
 	c.LogFileConfig = LogFileConfig{
 		FilePath: os.Getenv("LOG_FILE"),
 	}
 
+	c.StorageConfig = StorageConfig{
+		Dir:           os.Getenv("STORAGE_DIR"),
+		PublicURL:     os.Getenv("STORAGE_PUBLIC_URL"),
+		MaxUploadSize: 5 << 20,
+	}
+	if c.StorageConfig.Dir == "" {
+		c.StorageConfig.Dir = "uploads"
+	}
+	if c.StorageConfig.PublicURL == "" {
+		c.StorageConfig.PublicURL = "/uploads"
+	}
+	// batas ukuran upload dalam MB, default 5 MB
+	if maxUpload := os.Getenv("UPLOAD_MAX_SIZE_MB"); maxUpload != "" {
+		megabytes, err := strconv.Atoi(maxUpload)
+		if err != nil || megabytes <= 0 {
+			return errors.New("UPLOAD_MAX_SIZE_MB must be a positive number of megabytes")
+		}
+		c.StorageConfig.MaxUploadSize = int64(megabytes) << 20
+	}
+
+	c.ConferenceConfig = ConferenceConfig{
+		Provider: os.Getenv("CONFERENCE_PROVIDER"),
+		BaseURL:  os.Getenv("CONFERENCE_BASE_URL"),
+	}
+	if c.ConferenceConfig.Provider == "" {
+		c.ConferenceConfig.Provider = "local"
+	}
+	if c.ConferenceConfig.BaseURL == "" {
+		c.ConferenceConfig.BaseURL = "https://meet.local"
+	}
+
+	// masa retensi data visitor dalam hari, default 30 hari
+	c.VisitorConfig.Retention = 30 * 24 * time.Hour
+	if retention := os.Getenv("VISITOR_RETENTION_DAYS"); retention != "" {
+		days, err := strconv.Atoi(retention)
+		if err != nil || days <= 0 {
+			return errors.New("VISITOR_RETENTION_DAYS must be a positive number of days")
+		}
+		c.VisitorConfig.Retention = time.Duration(days) * 24 * time.Hour
+	}
+
 	tokenLifeTime, err := strconv.Atoi(os.Getenv("TOKEN_LIFE_TIME"))
 	if err != nil {
 		return err
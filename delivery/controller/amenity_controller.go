@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"final-project-booking-room/config"
+	"final-project-booking-room/delivery/middleware"
+	"final-project-booking-room/model"
+	"final-project-booking-room/usecase"
+	"final-project-booking-room/utils/common"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AmenityController struct {
+	uc             usecase.AmenityUseCase
+	rg             *gin.RouterGroup
+	authMiddleware middleware.AuthMiddleware
+}
+
+func (a *AmenityController) createHandler(ctx *gin.Context) {
+	var payload model.Amenity
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	amenity, err := a.uc.RegisterNewAmenity(ctx.Request.Context(), payload)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	common.SendCreateResponse(ctx, "ok", amenity)
+}
+
+func (a *AmenityController) getAllHandler(ctx *gin.Context) {
+	amenities, err := a.uc.ViewAllAmenities(ctx.Request.Context())
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "Ok", amenities)
+}
+
+func (a *AmenityController) getHandler(ctx *gin.Context) {
+	amenity, err := a.uc.FindById(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "Ok", amenity)
+}
+
+func (a *AmenityController) updateHandler(ctx *gin.Context) {
+	var payload model.Amenity
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	amenity, err := a.uc.UpdateById(ctx.Request.Context(), ctx.Param("id"), payload)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "Ok", amenity)
+}
+
+func (a *AmenityController) deleteHandler(ctx *gin.Context) {
+	if err := a.uc.DeleteById(ctx.Request.Context(), ctx.Param("id")); err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "ok", nil)
+}
+
+func (a *AmenityController) Route() {
+	ag := a.rg.Group(config.AmenityGroup)
+
+	ag.POST(config.AmenityPost, a.authMiddleware.RequireToken("admin"), a.createHandler)                    //ADMIN
+	ag.GET(config.AmenityGetAll, a.authMiddleware.RequireToken("admin", "GA", "employee"), a.getAllHandler) //ALL
+	ag.GET(config.AmenityGetById, a.authMiddleware.RequireToken("admin", "GA", "employee"), a.getHandler)   //ALL
+	ag.PUT(config.AmenityUpdate, a.authMiddleware.RequireToken("admin"), a.updateHandler)                   //ADMIN
+	ag.DELETE(config.AmenityDelete, a.authMiddleware.RequireToken("admin"), a.deleteHandler)                //ADMIN
+}
+
+func NewAmenityController(uc usecase.AmenityUseCase, rg *gin.RouterGroup, authMiddleware middleware.AuthMiddleware) *AmenityController {
+	return &AmenityController{uc: uc, rg: rg, authMiddleware: authMiddleware}
+}
@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"final-project-booking-room/config"
+	"final-project-booking-room/delivery/middleware"
+	"final-project-booking-room/model/dto"
+	"final-project-booking-room/usecase"
+	"final-project-booking-room/utils/common"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AnalyticsController struct {
+	uc             usecase.AnalyticsUseCase
+	rg             *gin.RouterGroup
+	authMiddleware middleware.AuthMiddleware
+}
+
+func (a *AnalyticsController) utilizationHandler(ctx *gin.Context) {
+	filter := dto.UtilizationFilter{
+		Period:   ctx.Query("period"),
+		GroupBy:  ctx.Query("groupBy"),
+		RoomType: ctx.Query("roomType"),
+		Location: locationFilterFromQuery(ctx),
+	}
+
+	// tanggal laporan tidak terikat zona, setiap booking dibaca di timezone ruangannya
+	var err error
+	if startDate := ctx.Query("startDate"); startDate != "" {
+		filter.StartDate, err = time.Parse("2006-01-02", startDate)
+		if err != nil {
+			common.SendErrorResponse(ctx, http.StatusBadRequest, "startDate must use format YYYY-MM-DD")
+			return
+		}
+	}
+	if endDate := ctx.Query("endDate"); endDate != "" {
+		filter.EndDate, err = time.Parse("2006-01-02", endDate)
+		if err != nil {
+			common.SendErrorResponse(ctx, http.StatusBadRequest, "endDate must use format YYYY-MM-DD")
+			return
+		}
+	}
+
+	rspPayload, err := a.uc.RoomUtilization(ctx.Request.Context(), filter)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	common.SendSingleResponse(ctx, "Ok", rspPayload)
+}
+
+func (a *AnalyticsController) Route() {
+	ag := a.rg.Group(config.AnalyticsGroup)
+	ag.GET(config.AnalyticsUtilization, a.authMiddleware.RequireToken("admin", "GA"), a.utilizationHandler) //ADMIN GA
+}
+
+func NewAnalyticsController(uc usecase.AnalyticsUseCase, rg *gin.RouterGroup, authMiddleware middleware.AuthMiddleware) *AnalyticsController {
+	return &AnalyticsController{uc: uc, rg: rg, authMiddleware: authMiddleware}
+}
@@ -26,7 +26,7 @@ func (a *AuthController) registerHandler(ctx *gin.Context) {
 		return
 	}
 
-	newRsp, err := a.uc.Register(payload)
+	newRsp, err := a.uc.Register(ctx.Request.Context(), payload)
 	if err != nil {
 		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
 		return
@@ -42,7 +42,7 @@ func (a *AuthController) loginHandler(ctx *gin.Context) {
 		return
 	}
 
-	rspPayload, err := a.uc.Login(payload)
+	rspPayload, err := a.uc.Login(ctx.Request.Context(), payload)
 	if err != nil {
 		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
 		return
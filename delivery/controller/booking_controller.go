@@ -4,10 +4,15 @@ import (
 	"encoding/json"
 	"final-project-booking-room/config"
 	"final-project-booking-room/delivery/middleware"
+	"final-project-booking-room/model"
 	"final-project-booking-room/model/dto"
 	"final-project-booking-room/usecase"
 	"final-project-booking-room/utils/common"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -24,14 +29,19 @@ func (b *BookingController) createHandler(ctx *gin.Context) {
 		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
+	loc, err := viewerLocation(ctx)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
 	userId := ctx.MustGet(config.UserSesion).(string)
-	rspPayload, err := b.uc.RegisterNewBooking(payload, userId)
+	rspPayload, err := b.uc.RegisterNewBooking(ctx.Request.Context(), payload, userId)
 	if err != nil {
 		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	common.SendCreateResponse(ctx, "Ok", rspPayload)
+	common.SendCreateResponse(ctx, "Ok", rspPayload.In(loc))
 }
 
 func (b *BookingController) UpdateStatusHandler(ctx *gin.Context) {
@@ -40,14 +50,19 @@ func (b *BookingController) UpdateStatusHandler(ctx *gin.Context) {
 		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
+	loc, err := viewerLocation(ctx)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
 
-	rspPayload, err := b.uc.UpdateStatusBookAndRoom(payload.BookingDetailId, payload.Approval)
+	rspPayload, err := b.uc.UpdateStatusBookAndRoom(ctx.Request.Context(), payload.BookingDetailId, payload.Approval)
 	if err != nil {
 		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	common.SendCreateResponse(ctx, "Ok", rspPayload)
+	common.SendCreateResponse(ctx, "Ok", rspPayload.In(loc))
 }
 
 func (b *BookingController) getHandler(ctx *gin.Context) {
@@ -56,16 +71,21 @@ func (b *BookingController) getHandler(ctx *gin.Context) {
 		common.SendErrorResponse(ctx, http.StatusBadRequest, "Booking ID can't be empty")
 		return
 	}
+	loc, err := viewerLocation(ctx)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
 
 	userId := ctx.MustGet(config.UserSesion).(string)
 	roleUser := ctx.MustGet(config.RoleSesion).(string)
-	rspPayload, err := b.uc.FindById(id, userId, roleUser)
+	rspPayload, err := b.uc.FindById(ctx.Request.Context(), id, userId, roleUser)
 	if err != nil {
 		common.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
 		return
 	}
 
-	common.SendSingleResponse(ctx, "Ok", rspPayload)
+	common.SendSingleResponse(ctx, "Ok", rspPayload.In(loc))
 }
 
 func (b *BookingController) getByStatusHandler(ctx *gin.Context) {
@@ -74,7 +94,89 @@ func (b *BookingController) getByStatusHandler(ctx *gin.Context) {
 		common.SendErrorResponse(ctx, http.StatusBadRequest, "Status can't be empty")
 		return
 	}
-	rspPayload, err := b.uc.ViewAllBookingByStatus(status)
+	loc, err := viewerLocation(ctx)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	rspPayload, err := b.uc.ViewAllBookingByStatus(ctx.Request.Context(), status)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	common.SendSingleResponse(ctx, "Ok", localizeBookings(rspPayload, loc))
+}
+
+func (b *BookingController) getMineHandler(ctx *gin.Context) {
+	filter, err := bookingFilterFromQuery(ctx)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId := ctx.MustGet(config.UserSesion).(string)
+	bookings, paging, err := b.uc.ViewMyBookings(ctx.Request.Context(), userId, filter)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	sendBookingPage(ctx, localizeBookings(bookings, filter.Timezone), paging)
+}
+
+func (b *BookingController) searchHandler(ctx *gin.Context) {
+	filter, err := bookingFilterFromQuery(ctx)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	filter.RequesterId = ctx.Query("requesterId")
+	filter.Divisi = ctx.Query("divisi")
+
+	bookings, paging, err := b.uc.SearchBooking(ctx.Request.Context(), filter)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	sendBookingPage(ctx, localizeBookings(bookings, filter.Timezone), paging)
+}
+
+func (b *BookingController) calendarHandler(ctx *gin.Context) {
+	loc, err := viewerLocation(ctx)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	filter := dto.CalendarFilter{Location: locationFilterFromQuery(ctx), Timezone: loc}
+	// tanggal kalender tidak terikat zona, jam dan zonanya ditentukan per ruangan di usecase
+	if startDate := ctx.Query("startDate"); startDate != "" {
+		filter.StartDate, err = time.Parse("2006-01-02", startDate)
+		if err != nil {
+			common.SendErrorResponse(ctx, http.StatusBadRequest, "startDate must use format YYYY-MM-DD")
+			return
+		}
+	}
+	if endDate := ctx.Query("endDate"); endDate != "" {
+		filter.EndDate, err = time.Parse("2006-01-02", endDate)
+		if err != nil {
+			common.SendErrorResponse(ctx, http.StatusBadRequest, "endDate must use format YYYY-MM-DD")
+			return
+		}
+	}
+	if roomIds := ctx.Query("roomIds"); roomIds != "" {
+		for _, id := range strings.Split(roomIds, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				filter.RoomIds = append(filter.RoomIds, id)
+			}
+		}
+	}
+
+	userId := ctx.MustGet(config.UserSesion).(string)
+	roleUser := ctx.MustGet(config.RoleSesion).(string)
+	rspPayload, err := b.uc.ViewCalendar(ctx.Request.Context(), filter, userId, roleUser)
 	if err != nil {
 		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
@@ -83,14 +185,116 @@ func (b *BookingController) getByStatusHandler(ctx *gin.Context) {
 	common.SendSingleResponse(ctx, "Ok", rspPayload)
 }
 
+func (b *BookingController) checkInHandler(ctx *gin.Context) {
+	userId := ctx.MustGet(config.UserSesion).(string)
+	if err := b.uc.CheckIn(ctx.Request.Context(), ctx.Param("id"), userId); err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	common.SendSingleResponse(ctx, "Checked in", nil)
+}
+
+func sendBookingPage(ctx *gin.Context, bookings []model.Booking, paging dto.Paging) {
+	rspPayload := make([]any, 0, len(bookings))
+	for _, booking := range bookings {
+		rspPayload = append(rspPayload, booking)
+	}
+
+	common.SendPagedResponse(ctx, "Ok", rspPayload, paging)
+}
+
+// viewerLocation membaca zona waktu viewer dari query tz atau header X-Timezone.
+// Hasilnya nil jika keduanya kosong, artinya waktu ditampilkan dalam timezone ruangan.
+func viewerLocation(ctx *gin.Context) (*time.Location, error) {
+	name := ctx.Query("tz")
+	if name == "" {
+		name = ctx.GetHeader("X-Timezone")
+	}
+	if name == "" {
+		return nil, nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %s", name)
+	}
+	return loc, nil
+}
+
+func localizeBookings(bookings []model.Booking, loc *time.Location) []model.Booking {
+	localized := make([]model.Booking, len(bookings))
+	for i, booking := range bookings {
+		localized[i] = booking.In(loc)
+	}
+	return localized
+}
+
+// bookingFilterFromQuery membaca filter, sorting dan paging dari query param
+func bookingFilterFromQuery(ctx *gin.Context) (dto.BookingFilter, error) {
+	loc, err := viewerLocation(ctx)
+	if err != nil {
+		return dto.BookingFilter{}, err
+	}
+
+	// startDate dan endDate dibaca sebagai tanggal di zona viewer
+	dateLoc := loc
+	if dateLoc == nil {
+		dateLoc = time.UTC
+	}
+
+	filter := dto.BookingFilter{
+		Status:   ctx.Query("status"),
+		RoomId:   ctx.Query("roomId"),
+		Keyword:  ctx.Query("q"),
+		Location: locationFilterFromQuery(ctx),
+		Timezone: loc,
+		SortBy:   ctx.Query("sortBy"),
+		Order:    ctx.Query("order"),
+	}
+
+	if startDate := ctx.Query("startDate"); startDate != "" {
+		filter.StartDate, err = time.ParseInLocation("2006-01-02", startDate, dateLoc)
+		if err != nil {
+			return dto.BookingFilter{}, fmt.Errorf("startDate must use format YYYY-MM-DD")
+		}
+	}
+	if endDate := ctx.Query("endDate"); endDate != "" {
+		filter.EndDate, err = time.ParseInLocation("2006-01-02", endDate, dateLoc)
+		if err != nil {
+			return dto.BookingFilter{}, fmt.Errorf("endDate must use format YYYY-MM-DD")
+		}
+	}
+	if page := ctx.Query("page"); page != "" {
+		filter.Page, err = strconv.Atoi(page)
+		if err != nil {
+			return dto.BookingFilter{}, fmt.Errorf("page must be a number")
+		}
+	}
+	if size := ctx.Query("size"); size != "" {
+		filter.Size, err = strconv.Atoi(size)
+		if err != nil {
+			return dto.BookingFilter{}, fmt.Errorf("size must be a number")
+		}
+	}
+
+	return filter, nil
+}
+
 func (b *BookingController) getAllHandler(ctx *gin.Context) {
-	rspPayload, err := b.uc.ViewAllBooking()
+	loc, err := viewerLocation(ctx)
 	if err != nil {
 		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	common.SendSingleResponse(ctx, "Ok", rspPayload)
+	rspPayload, err := b.uc.ViewAllBooking(ctx.Request.Context())
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	common.SendSingleResponse(ctx, "Ok", localizeBookings(rspPayload, loc))
 }
 
 func (b *BookingController) sendReportHandler(ctx *gin.Context) {
@@ -111,7 +315,7 @@ func (b *BookingController) sendReportHandler(ctx *gin.Context) {
 		common.SendErrorResponse(ctx, http.StatusInternalServerError, "Error marshaling JSON")
 		return
 	}
-	rspPayload, err := b.uc.SendReport(string(requestJSON))
+	rspPayload, err := b.uc.SendReport(ctx.Request.Context(), string(requestJSON))
 	if err != nil {
 		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
@@ -121,13 +325,19 @@ func (b *BookingController) sendReportHandler(ctx *gin.Context) {
 }
 
 func (b *BookingController) getReportHandler(ctx *gin.Context) {
-	rspPayload, err := b.uc.DownloadReport()
+	filter, err := bookingFilterFromQuery(ctx)
 	if err != nil {
 		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	common.SendSingleResponse(ctx, "Ok", rspPayload)
+	rspPayload, err := b.uc.DownloadReport(ctx.Request.Context(), filter)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	common.SendSingleResponse(ctx, "Ok", localizeBookings(rspPayload, filter.Timezone))
 }
 
 func (b *BookingController) Route() {
@@ -135,6 +345,10 @@ func (b *BookingController) Route() {
 	bc.POST(config.BookingPost, b.authMiddleware.RequireToken("admin", "employee", "GA"), b.createHandler)
 	bc.PUT(config.Approval, b.authMiddleware.RequireToken("GA"), b.UpdateStatusHandler)
 	bc.GET(config.BookingGetAll, b.authMiddleware.RequireToken("admin", "GA"), b.getAllHandler)
+	bc.GET(config.BookingSearch, b.authMiddleware.RequireToken("admin", "GA"), b.searchHandler)
+	bc.GET(config.BookingGetMine, b.authMiddleware.RequireToken("admin", "employee", "GA"), b.getMineHandler)
+	bc.GET(config.BookingCalendar, b.authMiddleware.RequireToken("admin", "employee", "GA"), b.calendarHandler)
+	bc.PUT(config.BookingCheckIn, b.authMiddleware.RequireToken("admin", "employee", "GA"), b.checkInHandler)
 	bc.GET(config.BookingGet, b.authMiddleware.RequireToken("admin", "employee", "GA"), b.getHandler)
 	bc.GET(config.BookingGetAllByStatus, b.authMiddleware.RequireToken("admin", "GA"), b.getByStatusHandler)
 	bc.GET(config.DownloadReport, b.authMiddleware.RequireToken("admin", "GA"), b.getReportHandler)
@@ -13,9 +13,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -137,11 +139,12 @@ func JSONToMap(jsonStr string) gin.H {
 func (suite *BookingControllerTestSuite) TestGetByStatusHandler_Success() {
 	gin.SetMode(gin.TestMode)
 	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/api/v1/booking/status/someStatus", nil)
 
 	status := "someStatus"
 	ctx.Params = append(ctx.Params, gin.Param{Key: "status", Value: status})
 
-	suite.bum.On("ViewAllBookingByStatus", status).Return(mockBookingSlice, nil)
+	suite.bum.On("ViewAllBookingByStatus", mock.Anything, status).Return(mockBookingSlice, nil)
 
 	bookingController := NewBookingController(suite.bum, suite.rg, suite.amm)
 
@@ -158,8 +161,9 @@ func (suite *BookingControllerTestSuite) TestGetByStatusHandler_Success() {
 func (suite *BookingControllerTestSuite) TestGetAllHandler_Success() {
 	gin.SetMode(gin.TestMode)
 	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/api/v1/booking", nil)
 
-	suite.bum.On("ViewAllBooking").Return(mockBookingSlice, nil)
+	suite.bum.On("ViewAllBooking", mock.Anything).Return(mockBookingSlice, nil)
 
 	bookingController := NewBookingController(suite.bum, suite.rg, suite.amm)
 
@@ -173,6 +177,7 @@ func (suite *BookingControllerTestSuite) TestGetAllHandler_Success() {
 func (suite *BookingControllerTestSuite) TestGetHandler_Success() {
 	gin.SetMode(gin.TestMode)
 	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/api/v1/booking/someBookingID", nil)
 
 	id := "someBookingID"
 	ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: id})
@@ -180,7 +185,7 @@ func (suite *BookingControllerTestSuite) TestGetHandler_Success() {
 	ctx.Set(config.UserSesion, "someUserID")
 	ctx.Set(config.RoleSesion, "someRole")
 
-	suite.bum.On("FindById", id, "someUserID", "someRole").Return(mockBooking, nil)
+	suite.bum.On("FindById", mock.Anything, id, "someUserID", "someRole").Return(mockBooking, nil)
 
 	bookingController := NewBookingController(suite.bum, suite.rg, suite.amm)
 
@@ -193,7 +198,7 @@ func (suite *BookingControllerTestSuite) TestGetHandler_Success() {
 }
 
 func (suite *BookingControllerTestSuite) TestUpdateStatusHandler_Success() {
-	suite.bum.On("UpdateStatusBookAndRoom", userId, approval).Return(mockBooking, nil)
+	suite.bum.On("UpdateStatusBookAndRoom", mock.Anything, userId, approval).Return(mockBooking, nil)
 
 	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
 	bookingController := NewBookingController(suite.bum, suite.rg, suite.amm)
@@ -207,7 +212,7 @@ func (suite *BookingControllerTestSuite) TestUpdateStatusHandler_Success() {
 }
 
 func (suite *BookingControllerTestSuite) TestCreateHandler_Success() {
-	suite.bum.On("RegisterNewBooking", mockPayload, userId).Return(mockBooking, nil)
+	suite.bum.On("RegisterNewBooking", mock.Anything, mockPayload, userId).Return(mockBooking, nil)
 	bookingController := NewBookingController(suite.bum, suite.rg, suite.amm)
 	bookingController.Route()
 	record := httptest.NewRecorder()
@@ -224,3 +229,111 @@ func (suite *BookingControllerTestSuite) TestCreateHandler_Success() {
 	bookingController.createHandler(ctx)
 	assert.Equal(suite.T(), http.StatusCreated, record.Code)
 }
+
+func (suite *BookingControllerTestSuite) TestGetMineHandler_Success() {
+	gin.SetMode(gin.TestMode)
+	record := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(record)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/api/v1/booking/me?status=pending&startDate=2023-11-01&page=2", nil)
+	ctx.Set(config.UserSesion, "someUserID")
+
+	startDate, _ := time.Parse("2006-01-02", "2023-11-01")
+	filter := dto.BookingFilter{Status: "pending", StartDate: startDate, Page: 2}
+	suite.bum.On("ViewMyBookings", mock.Anything, "someUserID", filter).Return(mockBookingSlice, dto.Paging{Page: 2, Size: 10, TotalRows: 11, TotalPages: 2}, nil)
+
+	bookingController := NewBookingController(suite.bum, suite.rg, suite.amm)
+	bookingController.getMineHandler(ctx)
+
+	assert.Equal(suite.T(), http.StatusOK, record.Code)
+	suite.bum.AssertExpectations(suite.T())
+}
+
+func (suite *BookingControllerTestSuite) TestCalendarHandler_Success() {
+	gin.SetMode(gin.TestMode)
+	record := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(record)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/api/v1/booking/calendar?startDate=2023-11-20&endDate=2023-11-26&roomIds=r1,r2", nil)
+	ctx.Set(config.UserSesion, "someUserID")
+	ctx.Set(config.RoleSesion, "GA")
+
+	startDate, _ := time.Parse("2006-01-02", "2023-11-20")
+	filter := dto.CalendarFilter{RoomIds: []string{"r1", "r2"}, StartDate: startDate, EndDate: startDate.AddDate(0, 0, 6)}
+	suite.bum.On("ViewCalendar", mock.Anything, filter, "someUserID", "GA").Return([]dto.CalendarRoom{}, nil)
+
+	bookingController := NewBookingController(suite.bum, suite.rg, suite.amm)
+	bookingController.calendarHandler(ctx)
+
+	assert.Equal(suite.T(), http.StatusOK, record.Code)
+	suite.bum.AssertExpectations(suite.T())
+}
+
+func (suite *BookingControllerTestSuite) TestGetMineHandler_InvalidDate() {
+	gin.SetMode(gin.TestMode)
+	record := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(record)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/api/v1/booking/me?startDate=01-11-2023", nil)
+	ctx.Set(config.UserSesion, "someUserID")
+
+	bookingController := NewBookingController(suite.bum, suite.rg, suite.amm)
+	bookingController.getMineHandler(ctx)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, record.Code)
+}
+
+func (suite *BookingControllerTestSuite) TestSearchHandler_Success() {
+	gin.SetMode(gin.TestMode)
+	record := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(record)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/api/v1/booking/search?divisi=HR&requesterId=1&q=rapat&sortBy=createdAt&order=asc", nil)
+
+	filter := dto.BookingFilter{RequesterId: "1", Divisi: "HR", Keyword: "rapat", SortBy: "createdAt", Order: "asc"}
+	suite.bum.On("SearchBooking", mock.Anything, filter).Return(mockBookingSlice, dto.Paging{Page: 1, Size: 10, TotalRows: 1, TotalPages: 1}, nil)
+
+	bookingController := NewBookingController(suite.bum, suite.rg, suite.amm)
+	bookingController.searchHandler(ctx)
+
+	assert.Equal(suite.T(), http.StatusOK, record.Code)
+	suite.bum.AssertExpectations(suite.T())
+}
+
+func (suite *BookingControllerTestSuite) TestGetMineHandler_ViewerTimezone() {
+	gin.SetMode(gin.TestMode)
+	record := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(record)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/api/v1/booking/me?startDate=2023-11-01&tz=Asia/Makassar", nil)
+	ctx.Set(config.UserSesion, "someUserID")
+
+	wita, _ := time.LoadLocation("Asia/Makassar")
+	booking := model.Booking{Id: "1", BookingDetails: []model.BookingDetail{{
+		Id:          "1",
+		BookingDate: time.Date(2023, 11, 1, 2, 0, 0, 0, time.UTC),
+		Rooms:       model.Room{Id: "1", Location: model.RoomLocation{Timezone: "Asia/Jakarta"}},
+	}}}
+	suite.bum.On("ViewMyBookings", mock.Anything, "someUserID", mock.MatchedBy(func(filter dto.BookingFilter) bool {
+		// tanggal filter dibaca sebagai tengah malam WITA
+		return filter.Timezone.String() == "Asia/Makassar" && filter.StartDate.Equal(time.Date(2023, 11, 1, 0, 0, 0, 0, wita))
+	})).Return([]model.Booking{booking}, dto.Paging{Page: 1, Size: 10, TotalRows: 1, TotalPages: 1}, nil)
+
+	bookingController := NewBookingController(suite.bum, suite.rg, suite.amm)
+	bookingController.getMineHandler(ctx)
+
+	assert.Equal(suite.T(), http.StatusOK, record.Code)
+	assert.Contains(suite.T(), record.Body.String(), `"bookingDate":"2023-11-01T10:00:00+08:00"`)
+	assert.Contains(suite.T(), record.Body.String(), `"timezone":"Asia/Makassar"`)
+	suite.bum.AssertExpectations(suite.T())
+}
+
+func (suite *BookingControllerTestSuite) TestGetMineHandler_InvalidTimezone() {
+	gin.SetMode(gin.TestMode)
+	record := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(record)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/api/v1/booking/me", nil)
+	ctx.Request.Header.Set("X-Timezone", "WITA")
+	ctx.Set(config.UserSesion, "someUserID")
+
+	bookingController := NewBookingController(suite.bum, suite.rg, suite.amm)
+	bookingController.getMineHandler(ctx)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, record.Code)
+	suite.bum.AssertNotCalled(suite.T(), "ViewMyBookings", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"final-project-booking-room/config"
+	"final-project-booking-room/delivery/middleware"
+	"final-project-booking-room/model"
+	"final-project-booking-room/usecase"
+	"final-project-booking-room/utils/common"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EquipmentController struct {
+	uc             usecase.EquipmentUseCase
+	rg             *gin.RouterGroup
+	authMiddleware middleware.AuthMiddleware
+}
+
+func (e *EquipmentController) createHandler(ctx *gin.Context) {
+	var payload model.Equipment
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	equipment, err := e.uc.RegisterNewEquipment(ctx.Request.Context(), payload)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	common.SendCreateResponse(ctx, "ok", equipment)
+}
+
+func (e *EquipmentController) getAllHandler(ctx *gin.Context) {
+	equipments, err := e.uc.ViewAllEquipment(ctx.Request.Context(), ctx.Query("siteId"))
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "Ok", equipments)
+}
+
+func (e *EquipmentController) getHandler(ctx *gin.Context) {
+	equipment, err := e.uc.FindById(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "Ok", equipment)
+}
+
+func (e *EquipmentController) updateHandler(ctx *gin.Context) {
+	var payload model.Equipment
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	equipment, err := e.uc.UpdateById(ctx.Request.Context(), ctx.Param("id"), payload)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "Ok", equipment)
+}
+
+func (e *EquipmentController) deleteHandler(ctx *gin.Context) {
+	if err := e.uc.DeleteById(ctx.Request.Context(), ctx.Param("id")); err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "ok", nil)
+}
+
+func (e *EquipmentController) Route() {
+	eg := e.rg.Group(config.EquipmentGroup)
+
+	eg.POST(config.EquipmentPost, e.authMiddleware.RequireToken("admin", "GA"), e.createHandler)              //ADMIN GA
+	eg.GET(config.EquipmentGetAll, e.authMiddleware.RequireToken("admin", "GA", "employee"), e.getAllHandler) //ALL
+	eg.GET(config.EquipmentGetById, e.authMiddleware.RequireToken("admin", "GA", "employee"), e.getHandler)   //ALL
+	eg.PUT(config.EquipmentUpdate, e.authMiddleware.RequireToken("admin", "GA"), e.updateHandler)             //ADMIN GA
+	eg.DELETE(config.EquipmentDelete, e.authMiddleware.RequireToken("admin"), e.deleteHandler)                //ADMIN
+}
+
+func NewEquipmentController(uc usecase.EquipmentUseCase, rg *gin.RouterGroup, authMiddleware middleware.AuthMiddleware) *EquipmentController {
+	return &EquipmentController{uc: uc, rg: rg, authMiddleware: authMiddleware}
+}
@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"final-project-booking-room/config"
+	"final-project-booking-room/delivery/middleware"
+	"final-project-booking-room/model"
+	"final-project-booking-room/usecase"
+	"final-project-booking-room/utils/common"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FloorMapController struct {
+	uc             usecase.FloorMapUseCase
+	rg             *gin.RouterGroup
+	authMiddleware middleware.AuthMiddleware
+}
+
+// getMapHandler mengembalikan denah floor dalam JSON, atau SVG jika ?format=svg
+func (f *FloorMapController) getMapHandler(ctx *gin.Context) {
+	switch ctx.DefaultQuery("format", "json") {
+	case "svg":
+		svg, err := f.uc.GetFloorMapSVG(ctx.Request.Context(), ctx.Param("id"))
+		if err != nil {
+			common.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+		// ketersediaan berubah terus, layar lobby harus selalu mengambil ulang
+		ctx.Header("Cache-Control", "no-store")
+		ctx.Data(http.StatusOK, "image/svg+xml; charset=utf-8", svg)
+	case "json":
+		floorMap, err := f.uc.GetFloorMap(ctx.Request.Context(), ctx.Param("id"))
+		if err != nil {
+			common.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+		ctx.Header("Cache-Control", "no-store")
+		common.SendSingleResponse(ctx, "Ok", floorMap)
+	default:
+		common.SendErrorResponse(ctx, http.StatusBadRequest, `format must be "json" or "svg"`)
+	}
+}
+
+func (f *FloorMapController) setShapeHandler(ctx *gin.Context) {
+	var payload model.RoomShape
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	shape, err := f.uc.SetRoomShape(ctx.Request.Context(), ctx.Param("id"), payload)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "Ok", shape)
+}
+
+func (f *FloorMapController) deleteShapeHandler(ctx *gin.Context) {
+	if err := f.uc.DeleteRoomShape(ctx.Request.Context(), ctx.Param("id")); err != nil {
+		common.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "ok", nil)
+}
+
+func (f *FloorMapController) Route() {
+	fg := f.rg.Group(config.FloorGroup)
+	fg.GET(config.FloorMapGet, f.authMiddleware.RequireToken("admin", "GA", "employee"), f.getMapHandler)
+
+	rg := f.rg.Group(config.RoomGroup)
+	rg.PUT(config.RoomShape, f.authMiddleware.RequireToken("admin"), f.setShapeHandler)
+	rg.DELETE(config.RoomShape, f.authMiddleware.RequireToken("admin"), f.deleteShapeHandler)
+}
+
+func NewFloorMapController(uc usecase.FloorMapUseCase, rg *gin.RouterGroup, authMiddleware middleware.AuthMiddleware) *FloorMapController {
+	return &FloorMapController{uc: uc, rg: rg, authMiddleware: authMiddleware}
+}
@@ -0,0 +1,222 @@
+package controller
+
+import (
+	"final-project-booking-room/config"
+	"final-project-booking-room/delivery/middleware"
+	"final-project-booking-room/model"
+	"final-project-booking-room/usecase"
+	"final-project-booking-room/utils/common"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LocationController struct {
+	uc             usecase.LocationUseCase
+	rg             *gin.RouterGroup
+	authMiddleware middleware.AuthMiddleware
+}
+
+// site
+
+func (l *LocationController) createSiteHandler(ctx *gin.Context) {
+	var payload model.Site
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	site, err := l.uc.RegisterNewSite(ctx.Request.Context(), payload)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	common.SendCreateResponse(ctx, "ok", site)
+}
+
+func (l *LocationController) getAllSitesHandler(ctx *gin.Context) {
+	sites, err := l.uc.ViewAllSites(ctx.Request.Context())
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "Ok", sites)
+}
+
+func (l *LocationController) getSiteHandler(ctx *gin.Context) {
+	site, err := l.uc.FindSiteById(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "Ok", site)
+}
+
+func (l *LocationController) updateSiteHandler(ctx *gin.Context) {
+	var payload model.Site
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	site, err := l.uc.UpdateSiteById(ctx.Request.Context(), ctx.Param("id"), payload)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "Ok", site)
+}
+
+func (l *LocationController) deleteSiteHandler(ctx *gin.Context) {
+	if err := l.uc.DeleteSiteById(ctx.Request.Context(), ctx.Param("id")); err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "ok", nil)
+}
+
+// building
+
+func (l *LocationController) createBuildingHandler(ctx *gin.Context) {
+	var payload model.Building
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	building, err := l.uc.RegisterNewBuilding(ctx.Request.Context(), payload)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	common.SendCreateResponse(ctx, "ok", building)
+}
+
+func (l *LocationController) getAllBuildingsHandler(ctx *gin.Context) {
+	buildings, err := l.uc.ViewAllBuildings(ctx.Request.Context(), ctx.Query("siteId"))
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "Ok", buildings)
+}
+
+func (l *LocationController) getBuildingHandler(ctx *gin.Context) {
+	building, err := l.uc.FindBuildingById(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "Ok", building)
+}
+
+func (l *LocationController) updateBuildingHandler(ctx *gin.Context) {
+	var payload model.Building
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	building, err := l.uc.UpdateBuildingById(ctx.Request.Context(), ctx.Param("id"), payload)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "Ok", building)
+}
+
+func (l *LocationController) deleteBuildingHandler(ctx *gin.Context) {
+	if err := l.uc.DeleteBuildingById(ctx.Request.Context(), ctx.Param("id")); err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "ok", nil)
+}
+
+// floor
+
+func (l *LocationController) createFloorHandler(ctx *gin.Context) {
+	var payload model.Floor
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	floor, err := l.uc.RegisterNewFloor(ctx.Request.Context(), payload)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	common.SendCreateResponse(ctx, "ok", floor)
+}
+
+func (l *LocationController) getAllFloorsHandler(ctx *gin.Context) {
+	floors, err := l.uc.ViewAllFloors(ctx.Request.Context(), ctx.Query("buildingId"))
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "Ok", floors)
+}
+
+func (l *LocationController) getFloorHandler(ctx *gin.Context) {
+	floor, err := l.uc.FindFloorById(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "Ok", floor)
+}
+
+func (l *LocationController) updateFloorHandler(ctx *gin.Context) {
+	var payload model.Floor
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	floor, err := l.uc.UpdateFloorById(ctx.Request.Context(), ctx.Param("id"), payload)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "Ok", floor)
+}
+
+func (l *LocationController) deleteFloorHandler(ctx *gin.Context) {
+	if err := l.uc.DeleteFloorById(ctx.Request.Context(), ctx.Param("id")); err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "ok", nil)
+}
+
+func (l *LocationController) Route() {
+	readers := l.authMiddleware.RequireToken("admin", "GA", "employee")
+	admin := l.authMiddleware.RequireToken("admin")
+
+	sg := l.rg.Group(config.SiteGroup)
+	sg.POST(config.LocationPost, admin, l.createSiteHandler)
+	sg.GET(config.LocationGetAll, readers, l.getAllSitesHandler)
+	sg.GET(config.LocationGetById, readers, l.getSiteHandler)
+	sg.PUT(config.LocationUpdate, admin, l.updateSiteHandler)
+	sg.DELETE(config.LocationDelete, admin, l.deleteSiteHandler)
+
+	bg := l.rg.Group(config.BuildingGroup)
+	bg.POST(config.LocationPost, admin, l.createBuildingHandler)
+	bg.GET(config.LocationGetAll, readers, l.getAllBuildingsHandler) // ?siteId=
+	bg.GET(config.LocationGetById, readers, l.getBuildingHandler)
+	bg.PUT(config.LocationUpdate, admin, l.updateBuildingHandler)
+	bg.DELETE(config.LocationDelete, admin, l.deleteBuildingHandler)
+
+	fg := l.rg.Group(config.FloorGroup)
+	fg.POST(config.LocationPost, admin, l.createFloorHandler)
+	fg.GET(config.LocationGetAll, readers, l.getAllFloorsHandler) // ?buildingId=
+	fg.GET(config.LocationGetById, readers, l.getFloorHandler)
+	fg.PUT(config.LocationUpdate, admin, l.updateFloorHandler)
+	fg.DELETE(config.LocationDelete, admin, l.deleteFloorHandler)
+}
+
+func NewLocationController(uc usecase.LocationUseCase, rg *gin.RouterGroup, authMiddleware middleware.AuthMiddleware) *LocationController {
+	return &LocationController{uc: uc, rg: rg, authMiddleware: authMiddleware}
+}
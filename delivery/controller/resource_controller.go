@@ -0,0 +1,190 @@
+package controller
+
+import (
+	"final-project-booking-room/config"
+	"final-project-booking-room/delivery/middleware"
+	"final-project-booking-room/model"
+	"final-project-booking-room/model/dto"
+	"final-project-booking-room/usecase"
+	"final-project-booking-room/utils/common"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ResourceController struct {
+	uc             usecase.ResourceUseCase
+	rg             *gin.RouterGroup
+	authMiddleware middleware.AuthMiddleware
+}
+
+func (r *ResourceController) typesHandler(ctx *gin.Context) {
+	resourceTypes, err := r.uc.ViewResourceTypes(ctx.Request.Context())
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "Ok", resourceTypes)
+}
+
+func (r *ResourceController) createNeighborhoodHandler(ctx *gin.Context) {
+	var payload model.Neighborhood
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	neighborhood, err := r.uc.RegisterNeighborhood(ctx.Request.Context(), payload)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	common.SendCreateResponse(ctx, "Ok", neighborhood)
+}
+
+func (r *ResourceController) neighborhoodsHandler(ctx *gin.Context) {
+	neighborhoods, err := r.uc.ViewNeighborhoods(ctx.Request.Context(), ctx.Query("floorId"))
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "Ok", neighborhoods)
+}
+
+func (r *ResourceController) createHandler(ctx *gin.Context) {
+	var payload model.Resource
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resource, err := r.uc.RegisterNewResource(ctx.Request.Context(), payload)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	common.SendCreateResponse(ctx, "Ok", resource)
+}
+
+func (r *ResourceController) listHandler(ctx *gin.Context) {
+	filter := dto.ResourceFilter{
+		ResourceType:   ctx.Query("type"),
+		NeighborhoodId: ctx.Query("neighborhoodId"),
+		Location:       locationFilterFromQuery(ctx),
+		AvailableOn:    ctx.Query("availableOn"),
+	}
+	var err error
+	if page := ctx.Query("page"); page != "" {
+		if filter.Page, err = strconv.Atoi(page); err != nil {
+			common.SendErrorResponse(ctx, http.StatusBadRequest, "page must be a number")
+			return
+		}
+	}
+	if size := ctx.Query("size"); size != "" {
+		if filter.Size, err = strconv.Atoi(size); err != nil {
+			common.SendErrorResponse(ctx, http.StatusBadRequest, "size must be a number")
+			return
+		}
+	}
+
+	resources, paging, err := r.uc.ListResources(ctx.Request.Context(), filter)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rspPayload := make([]any, 0, len(resources))
+	for _, resource := range resources {
+		rspPayload = append(rspPayload, resource)
+	}
+	common.SendPagedResponse(ctx, "Ok", rspPayload, paging)
+}
+
+func (r *ResourceController) getHandler(ctx *gin.Context) {
+	resource, err := r.uc.FindById(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "Ok", resource)
+}
+
+func (r *ResourceController) updateHandler(ctx *gin.Context) {
+	var payload model.Resource
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resource, err := r.uc.UpdateById(ctx.Request.Context(), ctx.Param("id"), payload)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "Ok", resource)
+}
+
+func (r *ResourceController) deleteHandler(ctx *gin.Context) {
+	cancelled, err := r.uc.ArchiveById(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "Resource archived", gin.H{"cancelledBookings": cancelled})
+}
+
+func (r *ResourceController) bookHandler(ctx *gin.Context) {
+	var payload dto.ResourceBookingRequest
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId := ctx.MustGet(config.UserSesion).(string)
+	booking, err := r.uc.BookResource(ctx.Request.Context(), ctx.Param("id"), userId, payload)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	common.SendCreateResponse(ctx, "Ok", booking)
+}
+
+func (r *ResourceController) myBookingsHandler(ctx *gin.Context) {
+	userId := ctx.MustGet(config.UserSesion).(string)
+	bookings, err := r.uc.ViewMyBookings(ctx.Request.Context(), userId)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "Ok", bookings)
+}
+
+func (r *ResourceController) cancelBookingHandler(ctx *gin.Context) {
+	userId := ctx.MustGet(config.UserSesion).(string)
+	if err := r.uc.CancelBooking(ctx.Request.Context(), ctx.Param("bookingId"), userId); err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "Booking cancelled", nil)
+}
+
+func (r *ResourceController) Route() {
+	rg := r.rg.Group(config.ResourceGroup)
+
+	rg.GET(config.ResourceTypes, r.authMiddleware.RequireToken("admin", "GA", "employee"), r.typesHandler)                 //ALL
+	rg.POST(config.NeighborhoodPost, r.authMiddleware.RequireToken("admin"), r.createNeighborhoodHandler)                  //ADMIN
+	rg.GET(config.NeighborhoodGetAll, r.authMiddleware.RequireToken("admin", "GA", "employee"), r.neighborhoodsHandler)    //ALL
+	rg.POST(config.ResourcePost, r.authMiddleware.RequireToken("admin"), r.createHandler)                                  //ADMIN
+	rg.GET(config.ResourceList, r.authMiddleware.RequireToken("admin", "GA", "employee"), r.listHandler)                   //ALL
+	rg.GET(config.ResourceMyBookings, r.authMiddleware.RequireToken("admin", "GA", "employee"), r.myBookingsHandler)       //ALL
+	rg.PUT(config.ResourceCancelBooking, r.authMiddleware.RequireToken("admin", "GA", "employee"), r.cancelBookingHandler) //ALL
+	rg.GET(config.ResourceGetById, r.authMiddleware.RequireToken("admin", "GA", "employee"), r.getHandler)                 //ALL
+	rg.PUT(config.ResourceUpdate, r.authMiddleware.RequireToken("admin"), r.updateHandler)                                 //ADMIN
+	rg.DELETE(config.ResourceDelete, r.authMiddleware.RequireToken("admin"), r.deleteHandler)                              //ADMIN
+	rg.POST(config.ResourceBook, r.authMiddleware.RequireToken("admin", "GA", "employee"), r.bookHandler)                  //ALL
+}
+
+func NewResourceController(uc usecase.ResourceUseCase, rg *gin.RouterGroup, authMiddleware middleware.AuthMiddleware) *ResourceController {
+	return &ResourceController{uc: uc, rg: rg, authMiddleware: authMiddleware}
+}
@@ -1,12 +1,18 @@
 package controller
 
 import (
+	"errors"
 	"final-project-booking-room/config"
 	"final-project-booking-room/delivery/middleware"
 	"final-project-booking-room/model"
+	"final-project-booking-room/model/dto"
 	"final-project-booking-room/usecase"
 	"final-project-booking-room/utils/common"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -25,7 +31,11 @@ func (r *RoomController) createHandler(ctx *gin.Context) {
 		return
 	}
 
-	createRoom, err := r.uc.RegisterNewRoom(payload)
+	createRoom, err := r.uc.RegisterNewRoom(ctx.Request.Context(), payload)
+	if errors.Is(err, usecase.ErrAmenityNotFound) {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
 		return
@@ -33,29 +43,158 @@ func (r *RoomController) createHandler(ctx *gin.Context) {
 	common.SendCreateResponse(ctx, "ok", createRoom)
 }
 
-func (r *RoomController) getAllRoomByStatus(ctx *gin.Context) {
-	status := ctx.Query("status")
-	if status == "" {
-		common.SendErrorResponse(ctx, http.StatusBadRequest, "status cant be empty")
+// importHandler membaca field "file" (csv atau xlsx) dari form multipart, query dryRun=true hanya memvalidasi tanpa menyimpan
+func (r *RoomController) importHandler(ctx *gin.Context) {
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, "file is required")
 		return
 	}
-	rspPayload, err := r.uc.GetAllRoomByStatus(status)
+
+	dryRun := false
+	if value := ctx.Query("dryRun"); value != "" {
+		dryRun, err = strconv.ParseBool(value)
+		if err != nil {
+			common.SendErrorResponse(ctx, http.StatusBadRequest, "dryRun must be true or false")
+			return
+		}
+	}
+
+	file, err := fileHeader.Open()
 	if err != nil {
 		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
+	defer file.Close()
 
-	common.SendSingleResponse(ctx, "Ok", rspPayload)
+	result, err := r.uc.ImportRooms(ctx.Request.Context(), fileHeader.Filename, file, dryRun)
+	if errors.Is(err, usecase.ErrRoomImportInvalid) {
+		common.SendErrorDataResponse(ctx, http.StatusUnprocessableEntity, err.Error(), result)
+		return
+	}
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	common.SendSingleResponse(ctx, "Ok", result)
 }
 
-func (r *RoomController) getAllRoom(ctx *gin.Context) {
-	rspPayload, err := r.uc.ViewAllRooms()
+func (r *RoomController) listHandler(ctx *gin.Context) {
+	filter, err := roomFilterFromQuery(ctx)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	common.SendSingleResponse(ctx, "Ok", rspPayload)
+	userId := ctx.MustGet(config.UserSesion).(string)
+	role := ctx.MustGet(config.RoleSesion).(string)
+	rooms, paging, err := r.uc.ListRooms(ctx.Request.Context(), filter, userId, role)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rspPayload := make([]any, 0, len(rooms))
+	for _, room := range rooms {
+		rspPayload = append(rspPayload, room)
+	}
+	common.SendPagedResponse(ctx, "Ok", rspPayload, paging)
+}
+
+// recommendHandler membaca kebutuhan booking dari query, start dan end memakai format RFC3339
+func (r *RoomController) recommendHandler(ctx *gin.Context) {
+	filter := dto.RecommendationFilter{Location: locationFilterFromQuery(ctx)}
+	var err error
+	if attendees := ctx.Query("attendees"); attendees != "" {
+		filter.Attendees, err = strconv.Atoi(attendees)
+		if err != nil {
+			common.SendErrorResponse(ctx, http.StatusBadRequest, "attendees must be a number")
+			return
+		}
+	}
+	if amenities := ctx.Query("amenities"); amenities != "" {
+		filter.Amenities = strings.Split(amenities, ",")
+	}
+	if start := ctx.Query("start"); start != "" {
+		filter.Start, err = time.Parse(time.RFC3339, start)
+		if err != nil {
+			common.SendErrorResponse(ctx, http.StatusBadRequest, "start must use RFC3339 format")
+			return
+		}
+	}
+	if end := ctx.Query("end"); end != "" {
+		filter.End, err = time.Parse(time.RFC3339, end)
+		if err != nil {
+			common.SendErrorResponse(ctx, http.StatusBadRequest, "end must use RFC3339 format")
+			return
+		}
+	}
+
+	userId := ctx.MustGet(config.UserSesion).(string)
+	role := ctx.MustGet(config.RoleSesion).(string)
+	result, err := r.uc.RecommendRooms(ctx.Request.Context(), filter, userId, role)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	common.SendSingleResponse(ctx, "Ok", result)
+}
+
+// roomFilterFromQuery membaca filter list ruangan dari query param, amenities berupa kode dipisah koma
+func roomFilterFromQuery(ctx *gin.Context) (dto.RoomFilter, error) {
+	filter := dto.RoomFilter{
+		RoomType: ctx.Query("roomType"),
+		Status:   ctx.Query("status"),
+		Location: locationFilterFromQuery(ctx),
+		SortBy:   ctx.Query("sortBy"),
+		Order:    ctx.Query("order"),
+	}
+	if amenities := ctx.Query("amenities"); amenities != "" {
+		for _, code := range strings.Split(amenities, ",") {
+			if code = strings.TrimSpace(code); code != "" {
+				filter.Amenities = append(filter.Amenities, code)
+			}
+		}
+	}
+
+	var err error
+	if minCapacity := ctx.Query("minCapacity"); minCapacity != "" {
+		filter.MinCapacity, err = strconv.Atoi(minCapacity)
+		if err != nil {
+			return dto.RoomFilter{}, fmt.Errorf("minCapacity must be a number")
+		}
+	}
+	if maxCapacity := ctx.Query("maxCapacity"); maxCapacity != "" {
+		filter.MaxCapacity, err = strconv.Atoi(maxCapacity)
+		if err != nil {
+			return dto.RoomFilter{}, fmt.Errorf("maxCapacity must be a number")
+		}
+	}
+	if page := ctx.Query("page"); page != "" {
+		filter.Page, err = strconv.Atoi(page)
+		if err != nil {
+			return dto.RoomFilter{}, fmt.Errorf("page must be a number")
+		}
+	}
+	if size := ctx.Query("size"); size != "" {
+		filter.Size, err = strconv.Atoi(size)
+		if err != nil {
+			return dto.RoomFilter{}, fmt.Errorf("size must be a number")
+		}
+	}
+
+	return filter, nil
+}
+
+// locationFilterFromQuery membaca filter lokasi siteId, buildingId dan floorId dari query param
+func locationFilterFromQuery(ctx *gin.Context) dto.LocationFilter {
+	return dto.LocationFilter{
+		SiteId:     ctx.Query("siteId"),
+		BuildingId: ctx.Query("buildingId"),
+		FloorId:    ctx.Query("floorId"),
+	}
 }
 
 func (r *RoomController) getHandler(ctx *gin.Context) {
@@ -65,7 +204,7 @@ func (r *RoomController) getHandler(ctx *gin.Context) {
 		return
 	}
 
-	rspPayload, err := r.uc.FindById(id)
+	rspPayload, err := r.uc.FindById(ctx.Request.Context(), id)
 	if err != nil {
 		common.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
 		return
@@ -73,41 +212,74 @@ func (r *RoomController) getHandler(ctx *gin.Context) {
 	common.SendSingleResponse(ctx, "ok", rspPayload)
 }
 
-func (r *RoomController) getByRoomtypeHandler(ctx *gin.Context) {
-	roomType := ctx.Query("roomtype")
+func (r *RoomController) deleteHandler(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, "id can't be empty")
+		return
+	}
 
-	if roomType == "" {
-		getAll, err := r.uc.ViewAllRooms()
-		if err != nil {
-			common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
-			return
-		}
-		common.SendSingleResponse(ctx, "Ok", getAll)
+	// ruangan tidak dihapus permanen, hanya diarsipkan agar riwayat booking tetap utuh
+	option := dto.ArchiveOption{Cascade: ctx.Query("cascade"), ReassignTo: ctx.Query("reassignTo")}
+	rspPayload, err := r.uc.ArchiveById(ctx.Request.Context(), id, option)
+	if errors.Is(err, usecase.ErrRoomHasFutureBookings) {
+		common.SendErrorResponse(ctx, http.StatusConflict, err.Error())
+		return
 	}
-	rspPayload, err := r.uc.FindByRoomType(roomType)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	common.SendSingleResponse(ctx, "ok", rspPayload)
+}
+
+func (r *RoomController) setPartsHandler(ctx *gin.Context) {
+	var payload dto.RoomPartsRequest
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rspPayload, err := r.uc.SetRoomParts(ctx.Request.Context(), ctx.Param("id"), payload.PartIds)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	common.SendSingleResponse(ctx, "Ok", rspPayload)
+}
+
+func (r *RoomController) setAccessHandler(ctx *gin.Context) {
+	var payload dto.RoomAccessRequest
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
 
+	rspPayload, err := r.uc.SetRoomAccess(ctx.Request.Context(), ctx.Param("id"), payload.Rules)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	common.SendSingleResponse(ctx, "Ok", rspPayload)
 }
 
-func (r *RoomController) deleteHandler(ctx *gin.Context) {
+func (r *RoomController) restoreHandler(ctx *gin.Context) {
 	id := ctx.Param("id")
 	if id == "" {
 		common.SendErrorResponse(ctx, http.StatusBadRequest, "id can't be empty")
 		return
 	}
 
-	_, err := r.uc.DeleteById(id)
+	rspPayload, err := r.uc.RestoreById(ctx.Request.Context(), id)
 	if err != nil {
 		common.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
 		return
 	}
 
-	common.SendSingleResponse(ctx, "ok", nil)
+	common.SendSingleResponse(ctx, "Ok", rspPayload)
 }
 
 func (r *RoomController) changeStatusHandler(ctx *gin.Context) {
@@ -117,7 +289,7 @@ func (r *RoomController) changeStatusHandler(ctx *gin.Context) {
 		return
 	}
 
-	err := r.uc.ChangeRoomStatus(id)
+	err := r.uc.ChangeRoomStatus(ctx.Request.Context(), id)
 	if err != nil {
 		common.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
 		return
@@ -141,7 +313,7 @@ func (r *RoomController) updateHandler(ctx *gin.Context) {
 
 	roomUpdate.Id = id
 
-	roomUpdate, err = r.uc.UpdateById(roomUpdate.Id, roomUpdate)
+	roomUpdate, err = r.uc.UpdateById(ctx.Request.Context(), roomUpdate.Id, roomUpdate)
 	if err != nil {
 		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
@@ -155,10 +327,11 @@ func (r *RoomController) Route() {
 
 	br.POST(config.RoomPost, r.authMiddleware.RequireToken("admin"), r.createHandler) //ADMIN
 
-	br.GET(config.RoomGetByroomType, r.authMiddleware.RequireToken("admin", "GA"), r.getByRoomtypeHandler)
-	//ADMIN GA
+	br.POST(config.RoomImport, r.authMiddleware.RequireToken("admin"), r.importHandler) //ADMIN
 
-	br.GET(config.RoomGetAll, r.authMiddleware.RequireToken("admin", "GA"), r.getAllRoom) //admin GA
+	br.GET(config.RoomList, r.authMiddleware.RequireToken("admin", "GA", "employee"), r.listHandler) //ADMIN GA USER
+
+	br.GET(config.RoomRecommend, r.authMiddleware.RequireToken("admin", "GA", "employee"), r.recommendHandler) //ADMIN GA USER
 
 	br.GET(config.RoomGetById, r.authMiddleware.RequireToken("admin", "GA"), r.getHandler) //ADMIN GA
 
@@ -166,11 +339,15 @@ func (r *RoomController) Route() {
 
 	br.PUT(config.RoomUpdate, r.authMiddleware.RequireToken("admin", "GA"), r.updateHandler) //ADMIN GA
 
+	br.PUT(config.RoomRestore, r.authMiddleware.RequireToken("admin"), r.restoreHandler) //ADMIN
+
+	br.PUT(config.RoomParts, r.authMiddleware.RequireToken("admin"), r.setPartsHandler) //ADMIN
+
+	br.PUT(config.RoomAccess, r.authMiddleware.RequireToken("admin"), r.setAccessHandler) //ADMIN
+
 	br.PUT(config.RoomUpdateStatus, r.authMiddleware.RequireToken("GA"), r.changeStatusHandler)
 	//GA
 
-	br.GET(config.RoomGetByStatus, r.authMiddleware.RequireToken("employee"), r.getAllRoomByStatus)
-
 	//ChangeRoomStatus(id string) //USER
 }
 
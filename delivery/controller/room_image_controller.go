@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"final-project-booking-room/config"
+	"final-project-booking-room/delivery/middleware"
+	"final-project-booking-room/model"
+	"final-project-booking-room/model/dto"
+	"final-project-booking-room/usecase"
+	"final-project-booking-room/utils/common"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RoomImageController struct {
+	uc             usecase.RoomImageUseCase
+	rg             *gin.RouterGroup
+	authMiddleware middleware.AuthMiddleware
+}
+
+// uploadHandler membaca field "file" dari form multipart dan menyimpannya sebagai gambar dengan kind tertentu
+func (r *RoomImageController) uploadHandler(kind string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		fileHeader, err := ctx.FormFile("file")
+		if err != nil {
+			common.SendErrorResponse(ctx, http.StatusBadRequest, "file is required")
+			return
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+		defer file.Close()
+
+		image, err := r.uc.UploadImage(ctx.Request.Context(), ctx.Param("id"), dto.ImageUpload{
+			Kind:     kind,
+			Filename: fileHeader.Filename,
+			Size:     fileHeader.Size,
+			File:     file,
+		})
+		if err != nil {
+			common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+		common.SendCreateResponse(ctx, "ok", image)
+	}
+}
+
+func (r *RoomImageController) deleteHandler(ctx *gin.Context) {
+	if err := r.uc.DeleteImage(ctx.Request.Context(), ctx.Param("id"), ctx.Param("imageId")); err != nil {
+		common.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "ok", nil)
+}
+
+func (r *RoomImageController) Route() {
+	ri := r.rg.Group(config.RoomGroup)
+	ri.POST(config.RoomPhotoUpload, r.authMiddleware.RequireToken("admin", "GA"), r.uploadHandler(model.RoomImagePhoto))
+	ri.POST(config.RoomFloorPlanUpload, r.authMiddleware.RequireToken("admin", "GA"), r.uploadHandler(model.RoomImageFloorPlan))
+	ri.DELETE(config.RoomImageDelete, r.authMiddleware.RequireToken("admin", "GA"), r.deleteHandler)
+}
+
+func NewRoomImageController(uc usecase.RoomImageUseCase, rg *gin.RouterGroup, authMiddleware middleware.AuthMiddleware) *RoomImageController {
+	return &RoomImageController{uc: uc, rg: rg, authMiddleware: authMiddleware}
+}
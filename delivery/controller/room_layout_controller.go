@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"final-project-booking-room/config"
+	"final-project-booking-room/delivery/middleware"
+	"final-project-booking-room/model"
+	"final-project-booking-room/usecase"
+	"final-project-booking-room/utils/common"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RoomLayoutController struct {
+	uc             usecase.RoomLayoutUseCase
+	rg             *gin.RouterGroup
+	authMiddleware middleware.AuthMiddleware
+}
+
+func (r *RoomLayoutController) createHandler(ctx *gin.Context) {
+	var payload model.RoomLayout
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	layout, err := r.uc.AddLayout(ctx.Request.Context(), ctx.Param("id"), payload)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	common.SendCreateResponse(ctx, "ok", layout)
+}
+
+func (r *RoomLayoutController) updateHandler(ctx *gin.Context) {
+	var payload model.RoomLayout
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	layout, err := r.uc.UpdateLayout(ctx.Request.Context(), ctx.Param("id"), ctx.Param("layoutId"), payload)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "ok", layout)
+}
+
+func (r *RoomLayoutController) deleteHandler(ctx *gin.Context) {
+	if err := r.uc.DeleteLayout(ctx.Request.Context(), ctx.Param("id"), ctx.Param("layoutId")); err != nil {
+		common.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "ok", nil)
+}
+
+func (r *RoomLayoutController) Route() {
+	rl := r.rg.Group(config.RoomGroup)
+	rl.POST(config.RoomLayoutPost, r.authMiddleware.RequireToken("admin"), r.createHandler)
+	rl.PUT(config.RoomLayoutUpdate, r.authMiddleware.RequireToken("admin"), r.updateHandler)
+	rl.DELETE(config.RoomLayoutDelete, r.authMiddleware.RequireToken("admin"), r.deleteHandler)
+}
+
+func NewRoomLayoutController(uc usecase.RoomLayoutUseCase, rg *gin.RouterGroup, authMiddleware middleware.AuthMiddleware) *RoomLayoutController {
+	return &RoomLayoutController{uc: uc, rg: rg, authMiddleware: authMiddleware}
+}
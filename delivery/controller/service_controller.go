@@ -0,0 +1,146 @@
+package controller
+
+import (
+	"final-project-booking-room/config"
+	"final-project-booking-room/delivery/middleware"
+	"final-project-booking-room/model/dto"
+	"final-project-booking-room/usecase"
+	"final-project-booking-room/utils/common"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ServiceController struct {
+	uc             usecase.ServiceUseCase
+	rg             *gin.RouterGroup
+	authMiddleware middleware.AuthMiddleware
+}
+
+func (s *ServiceController) requestHandler(ctx *gin.Context) {
+	var payload dto.ServiceOrderRequest
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId := ctx.MustGet(config.UserSesion).(string)
+	order, err := s.uc.RequestService(ctx.Request.Context(), ctx.Param("id"), userId, payload)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	common.SendCreateResponse(ctx, "Ok", order)
+}
+
+func (s *ServiceController) bookingOrdersHandler(ctx *gin.Context) {
+	userId := ctx.MustGet(config.UserSesion).(string)
+	roleUser := ctx.MustGet(config.RoleSesion).(string)
+	orders, err := s.uc.ViewBookingOrders(ctx.Request.Context(), ctx.Param("id"), userId, roleUser)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "Ok", orders)
+}
+
+func (s *ServiceController) listHandler(ctx *gin.Context) {
+	filter := dto.ServiceOrderFilter{
+		ApprovalStatus:    ctx.Query("approvalStatus"),
+		FulfillmentStatus: ctx.Query("fulfillmentStatus"),
+	}
+	var err error
+	if page := ctx.Query("page"); page != "" {
+		if filter.Page, err = strconv.Atoi(page); err != nil {
+			common.SendErrorResponse(ctx, http.StatusBadRequest, "page must be a number")
+			return
+		}
+	}
+	if size := ctx.Query("size"); size != "" {
+		if filter.Size, err = strconv.Atoi(size); err != nil {
+			common.SendErrorResponse(ctx, http.StatusBadRequest, "size must be a number")
+			return
+		}
+	}
+
+	orders, paging, err := s.uc.ListOrders(ctx.Request.Context(), filter)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rspPayload := make([]any, 0, len(orders))
+	for _, order := range orders {
+		rspPayload = append(rspPayload, order)
+	}
+	common.SendPagedResponse(ctx, "Ok", rspPayload, paging)
+}
+
+func (s *ServiceController) dailyHandler(ctx *gin.Context) {
+	filter := dto.ServiceDailyFilter{Date: ctx.Query("date"), Location: locationFilterFromQuery(ctx)}
+	list, err := s.uc.ViewDailyList(ctx.Request.Context(), filter)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "Ok", list)
+}
+
+func (s *ServiceController) approvalHandler(ctx *gin.Context) {
+	var payload dto.ServiceApprovalRequest
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId := ctx.MustGet(config.UserSesion).(string)
+	order, err := s.uc.RespondOrder(ctx.Request.Context(), ctx.Param("id"), userId, payload)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "Ok", order)
+}
+
+func (s *ServiceController) fulfillmentHandler(ctx *gin.Context) {
+	var payload dto.ServiceFulfillmentRequest
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId := ctx.MustGet(config.UserSesion).(string)
+	order, err := s.uc.UpdateFulfillment(ctx.Request.Context(), ctx.Param("id"), userId, payload)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "Ok", order)
+}
+
+func (s *ServiceController) cancelHandler(ctx *gin.Context) {
+	userId := ctx.MustGet(config.UserSesion).(string)
+	if err := s.uc.CancelOrder(ctx.Request.Context(), ctx.Param("id"), userId); err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "Service order cancelled", nil)
+}
+
+func (s *ServiceController) Route() {
+	bg := s.rg.Group(config.BookingGroup)
+	bg.POST(config.BookingServicePost, s.authMiddleware.RequireToken("admin", "employee", "GA"), s.requestHandler)                 //ADMIN GA USER
+	bg.GET(config.BookingServiceGet, s.authMiddleware.RequireToken("admin", "employee", "GA", "services"), s.bookingOrdersHandler) //ALL
+
+	sg := s.rg.Group(config.ServiceGroup)
+	sg.GET(config.ServiceGetAll, s.authMiddleware.RequireToken("admin", "services"), s.listHandler)         //ADMIN SERVICES
+	sg.GET(config.ServiceDaily, s.authMiddleware.RequireToken("admin", "services"), s.dailyHandler)         //ADMIN SERVICES
+	sg.PUT(config.ServiceApproval, s.authMiddleware.RequireToken("services"), s.approvalHandler)            //SERVICES
+	sg.PUT(config.ServiceFulfillment, s.authMiddleware.RequireToken("services"), s.fulfillmentHandler)      //SERVICES
+	sg.PUT(config.ServiceCancel, s.authMiddleware.RequireToken("admin", "employee", "GA"), s.cancelHandler) //ADMIN GA USER
+}
+
+func NewServiceController(uc usecase.ServiceUseCase, rg *gin.RouterGroup, authMiddleware middleware.AuthMiddleware) *ServiceController {
+	return &ServiceController{uc: uc, rg: rg, authMiddleware: authMiddleware}
+}
@@ -0,0 +1,112 @@
+package controller
+
+import (
+	"final-project-booking-room/config"
+	"final-project-booking-room/delivery/middleware"
+	"final-project-booking-room/model/dto"
+	"final-project-booking-room/usecase"
+	"final-project-booking-room/utils/common"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TicketController struct {
+	uc             usecase.TicketUseCase
+	rg             *gin.RouterGroup
+	authMiddleware middleware.AuthMiddleware
+}
+
+func (t *TicketController) feedbackHandler(ctx *gin.Context) {
+	var payload dto.FeedbackRequest
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId := ctx.MustGet(config.UserSesion).(string)
+	feedback, err := t.uc.SubmitFeedback(ctx.Request.Context(), ctx.Param("id"), userId, payload)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	common.SendCreateResponse(ctx, "Ok", feedback)
+}
+
+func (t *TicketController) listHandler(ctx *gin.Context) {
+	filter := dto.TicketFilter{
+		Status:   ctx.Query("status"),
+		Severity: ctx.Query("severity"),
+		RoomId:   ctx.Query("roomId"),
+	}
+	var err error
+	if page := ctx.Query("page"); page != "" {
+		if filter.Page, err = strconv.Atoi(page); err != nil {
+			common.SendErrorResponse(ctx, http.StatusBadRequest, "page must be a number")
+			return
+		}
+	}
+	if size := ctx.Query("size"); size != "" {
+		if filter.Size, err = strconv.Atoi(size); err != nil {
+			common.SendErrorResponse(ctx, http.StatusBadRequest, "size must be a number")
+			return
+		}
+	}
+
+	tickets, paging, err := t.uc.ListTickets(ctx.Request.Context(), filter)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rspPayload := make([]any, 0, len(tickets))
+	for _, ticket := range tickets {
+		rspPayload = append(rspPayload, ticket)
+	}
+	common.SendPagedResponse(ctx, "Ok", rspPayload, paging)
+}
+
+func (t *TicketController) assignHandler(ctx *gin.Context) {
+	var payload dto.TicketAssignRequest
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ticket, err := t.uc.AssignTicket(ctx.Request.Context(), ctx.Param("id"), payload.AssigneeId)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "Ok", ticket)
+}
+
+func (t *TicketController) resolveHandler(ctx *gin.Context) {
+	var payload dto.TicketResolveRequest
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ticket, err := t.uc.ResolveTicket(ctx.Request.Context(), ctx.Param("id"), payload.Resolution)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "Ok", ticket)
+}
+
+func (t *TicketController) Route() {
+	bg := t.rg.Group(config.BookingGroup)
+	bg.POST(config.BookingFeedback, t.authMiddleware.RequireToken("admin", "employee", "GA"), t.feedbackHandler) //ADMIN GA USER
+
+	tg := t.rg.Group(config.TicketGroup)
+	tg.GET(config.TicketGetAll, t.authMiddleware.RequireToken("admin", "GA"), t.listHandler) //ADMIN GA
+	tg.PUT(config.TicketAssign, t.authMiddleware.RequireToken("GA"), t.assignHandler)        //GA
+	tg.PUT(config.TicketResolve, t.authMiddleware.RequireToken("GA"), t.resolveHandler)      //GA
+}
+
+func NewTicketController(uc usecase.TicketUseCase, rg *gin.RouterGroup, authMiddleware middleware.AuthMiddleware) *TicketController {
+	return &TicketController{uc: uc, rg: rg, authMiddleware: authMiddleware}
+}
@@ -19,7 +19,7 @@ type UserController struct {
 
 func (u *UserController) getAllHandler(ctx *gin.Context) {
 
-	rspPayload, err := u.uc.ViewAllUser()
+	rspPayload, err := u.uc.ViewAllUser(ctx.Request.Context())
 	if err != nil {
 		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
 		return
@@ -35,7 +35,7 @@ func (u *UserController) createHandler(ctx *gin.Context) {
 		return
 	}
 
-	rspPayload, err := u.uc.RegisterNewUser(payload)
+	rspPayload, err := u.uc.RegisterNewUser(ctx.Request.Context(), payload)
 	if err != nil {
 		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
@@ -57,7 +57,7 @@ func (u *UserController) UpdateUserHandler(ctx *gin.Context) {
 		return
 	}
 	userId := ctx.MustGet(config.UserSesion).(string)
-	rspPayload, err := u.uc.UpdateUserById(userId, payload)
+	rspPayload, err := u.uc.UpdateUserById(ctx.Request.Context(), userId, payload)
 	if err != nil {
 		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
@@ -73,7 +73,7 @@ func (u *UserController) getByIdHandler(ctx *gin.Context) {
 		return
 	}
 
-	rspPayload, err := u.uc.FindById(id)
+	rspPayload, err := u.uc.FindById(ctx.Request.Context(), id)
 	if err != nil {
 		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
@@ -89,7 +89,7 @@ func (u *UserController) DeleteByIdHandler(ctx *gin.Context) {
 		return
 	}
 
-	_, err := u.uc.DeleteUser(id)
+	_, err := u.uc.DeleteUser(ctx.Request.Context(), id)
 	if err != nil {
 		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
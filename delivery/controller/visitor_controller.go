@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"final-project-booking-room/config"
+	"final-project-booking-room/delivery/middleware"
+	"final-project-booking-room/model/dto"
+	"final-project-booking-room/usecase"
+	"final-project-booking-room/utils/common"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type VisitorController struct {
+	uc             usecase.VisitorUseCase
+	rg             *gin.RouterGroup
+	authMiddleware middleware.AuthMiddleware
+}
+
+func (v *VisitorController) registerHandler(ctx *gin.Context) {
+	var payload dto.VisitorRequest
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId := ctx.MustGet(config.UserSesion).(string)
+	roleUser := ctx.MustGet(config.RoleSesion).(string)
+	visitors, err := v.uc.RegisterVisitors(ctx.Request.Context(), ctx.Param("id"), userId, roleUser, payload)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	common.SendCreateResponse(ctx, "Ok", visitors)
+}
+
+func (v *VisitorController) bookingVisitorsHandler(ctx *gin.Context) {
+	userId := ctx.MustGet(config.UserSesion).(string)
+	roleUser := ctx.MustGet(config.RoleSesion).(string)
+	visitors, err := v.uc.ViewBookingVisitors(ctx.Request.Context(), ctx.Param("id"), userId, roleUser)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusNotFound, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "Ok", visitors)
+}
+
+func (v *VisitorController) dayHandler(ctx *gin.Context) {
+	filter := dto.VisitorFilter{Date: ctx.Query("date"), Location: locationFilterFromQuery(ctx)}
+	visitors, err := v.uc.ViewDay(ctx.Request.Context(), filter)
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "Ok", visitors)
+}
+
+func (v *VisitorController) checkInHandler(ctx *gin.Context) {
+	visitor, err := v.uc.CheckIn(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "Visitor checked in", visitor)
+}
+
+func (v *VisitorController) checkOutHandler(ctx *gin.Context) {
+	visitor, err := v.uc.CheckOut(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	common.SendSingleResponse(ctx, "Visitor checked out", visitor)
+}
+
+func (v *VisitorController) Route() {
+	bg := v.rg.Group(config.BookingGroup)
+	bg.POST(config.BookingVisitorPost, v.authMiddleware.RequireToken("admin", "employee", "GA"), v.registerHandler)      //ADMIN GA USER
+	bg.GET(config.BookingVisitorGet, v.authMiddleware.RequireToken("admin", "employee", "GA"), v.bookingVisitorsHandler) //ADMIN GA USER
+
+	// resepsionis memakai akun GA
+	vg := v.rg.Group(config.VisitorGroup)
+	vg.GET(config.VisitorGetAll, v.authMiddleware.RequireToken("admin", "GA"), v.dayHandler)        //ADMIN GA
+	vg.PUT(config.VisitorCheckIn, v.authMiddleware.RequireToken("admin", "GA"), v.checkInHandler)   //ADMIN GA
+	vg.PUT(config.VisitorCheckOut, v.authMiddleware.RequireToken("admin", "GA"), v.checkOutHandler) //ADMIN GA
+}
+
+func NewVisitorController(uc usecase.VisitorUseCase, rg *gin.RouterGroup, authMiddleware middleware.AuthMiddleware) *VisitorController {
+	return &VisitorController{uc: uc, rg: rg, authMiddleware: authMiddleware}
+}
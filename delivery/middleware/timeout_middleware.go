@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TimeoutMiddleware interface {
+	RequestTimeout() gin.HandlerFunc
+}
+
+type timeoutMiddleware struct {
+	timeout time.Duration
+}
+
+// RequestTimeout memberi batas waktu pada context request, sehingga query yang
+// terlalu lama atau request yang diputus client ikut dibatalkan
+func (t *timeoutMiddleware) RequestTimeout() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		timeoutCtx, cancel := context.WithTimeout(ctx.Request.Context(), t.timeout)
+		defer cancel()
+
+		ctx.Request = ctx.Request.WithContext(timeoutCtx)
+		ctx.Next()
+	}
+}
+
+func NewTimeoutMiddleware(timeout time.Duration) TimeoutMiddleware {
+	return &timeoutMiddleware{timeout: timeout}
+}
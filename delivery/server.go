@@ -4,6 +4,7 @@ import (
 
 	// "final-project/delivery/middleware"
 
+	"context"
 	"final-project-booking-room/config"
 	"final-project-booking-room/delivery/controller"
 	"final-project-booking-room/delivery/middleware"
@@ -12,6 +13,8 @@ import (
 	"final-project-booking-room/utils/common"
 	"fmt"
 	"log"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -23,21 +26,57 @@ type Server struct {
 	host       string
 	logService common.MyLogger
 
-	jwtService common.JwtToken
+	jwtService   common.JwtToken
+	queryTimeout time.Duration
+	storageCfg   config.StorageConfig
+	visitorCfg   config.VisitorConfig
 }
 
 func (s *Server) setupControllers() {
 	s.engine.Use(middleware.NewLogMiddleware(s.logService).LogRequest())
+	s.engine.Use(middleware.NewTimeoutMiddleware(s.queryTimeout).RequestTimeout())
 	authMiddlerware := middleware.NewAuthMiddleware(s.jwtService)
 	rg := s.engine.Group("/api/v1")
 	controller.NewUserController(s.uc.UserUseCase(), rg, authMiddlerware).Route()
 	controller.NewBookingController(s.uc.BookingUsecase(), rg, authMiddlerware).Route()
 	controller.NewAuthController(s.auth, rg, s.jwtService).Route()
 	controller.NewRoomController(s.uc.RoomUsecase(), rg, authMiddlerware).Route()
+	controller.NewAmenityController(s.uc.AmenityUsecase(), rg, authMiddlerware).Route()
+	controller.NewEquipmentController(s.uc.EquipmentUsecase(), rg, authMiddlerware).Route()
+	controller.NewLocationController(s.uc.LocationUsecase(), rg, authMiddlerware).Route()
+	controller.NewRoomImageController(s.uc.RoomImageUsecase(), rg, authMiddlerware).Route()
+	controller.NewRoomLayoutController(s.uc.RoomLayoutUsecase(), rg, authMiddlerware).Route()
+	controller.NewFloorMapController(s.uc.FloorMapUsecase(), rg, authMiddlerware).Route()
+	controller.NewAnalyticsController(s.uc.AnalyticsUsecase(), rg, authMiddlerware).Route()
+	controller.NewTicketController(s.uc.TicketUsecase(), rg, authMiddlerware).Route()
+	controller.NewResourceController(s.uc.ResourceUsecase(), rg, authMiddlerware).Route()
+	controller.NewVisitorController(s.uc.VisitorUsecase(), rg, authMiddlerware).Route()
+	controller.NewServiceController(s.uc.ServiceUsecase(), rg, authMiddlerware).Route()
+
+	// file upload disajikan langsung oleh server selama storage lokal dipakai (PublicURL berupa path)
+	if strings.HasPrefix(s.storageCfg.PublicURL, "/") {
+		s.engine.Static(s.storageCfg.PublicURL, s.storageCfg.Dir)
+	}
+}
+
+// purgeVisitors menghapus data visitor yang melewati masa retensi, dijalankan saat server start lalu setiap hari
+func (s *Server) purgeVisitors() {
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+	for {
+		purged, err := s.uc.VisitorUsecase().PurgeExpired(context.Background(), s.visitorCfg.Retention)
+		if err != nil {
+			log.Println("failed to purge visitors:", err)
+		} else if purged > 0 {
+			log.Printf("%d visitor record(s) purged", purged)
+		}
+		<-ticker.C
+	}
 }
 
 func (s *Server) Run() {
 	s.setupControllers()
+	go s.purgeVisitors()
 	if err := s.engine.Run(s.host); err != nil {
 		log.Fatal("server can't run")
 	}
@@ -54,8 +93,13 @@ func NewServer() *Server {
 		log.Fatal(err)
 	}
 
+	conference, err := common.NewConferencingProvider(cfg.ConferenceConfig)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	repo := manager.NewRepoManager(infra)
-	uc := manager.NewUseCaseManager(repo, common.NewEmailService(cfg))
+	uc := manager.NewUseCaseManager(repo, common.NewEmailService(cfg), common.NewLocalStorage(cfg.StorageConfig), conference, cfg.MaxUploadSize)
 	engine := gin.Default()
 	host := fmt.Sprintf(":%s", cfg.ApiPort)
 	logService := common.NewMyLogger(cfg.LogFileConfig)
@@ -67,6 +111,9 @@ func NewServer() *Server {
 		logService: logService,
 		auth: usecase.NewAuthUseCase(uc.UserUseCase(),
 			jwtService),
-		jwtService: jwtService,
+		jwtService:   jwtService,
+		queryTimeout: cfg.QueryTimeout,
+		storageCfg:   cfg.StorageConfig,
+		visitorCfg:   cfg.VisitorConfig,
 	}
 }
@@ -6,12 +6,72 @@ type RepoManager interface {
 	UserRepo() repository.UserRepository
 	RoomRepo() repository.RoomRepository
 	BookingRepo() repository.BookingRepository
+	AmenityRepo() repository.AmenityRepository
+	EquipmentRepo() repository.EquipmentRepository
+	LocationRepo() repository.LocationRepository
+	RoomImageRepo() repository.RoomImageRepository
+	RoomLayoutRepo() repository.RoomLayoutRepository
+	FloorMapRepo() repository.FloorMapRepository
+	TicketRepo() repository.TicketRepository
+	ResourceRepo() repository.ResourceRepository
+	VisitorRepo() repository.VisitorRepository
+	ServiceRepo() repository.ServiceRepository
 }
 
 type repoManager struct {
 	infra InfraManager
 }
 
+// ServiceRepo implements RepoManager.
+func (r *repoManager) ServiceRepo() repository.ServiceRepository {
+	return repository.NewServiceRepository(r.infra.Conn())
+}
+
+// VisitorRepo implements RepoManager.
+func (r *repoManager) VisitorRepo() repository.VisitorRepository {
+	return repository.NewVisitorRepository(r.infra.Conn())
+}
+
+// ResourceRepo implements RepoManager.
+func (r *repoManager) ResourceRepo() repository.ResourceRepository {
+	return repository.NewResourceRepository(r.infra.Conn())
+}
+
+// TicketRepo implements RepoManager.
+func (r *repoManager) TicketRepo() repository.TicketRepository {
+	return repository.NewTicketRepository(r.infra.Conn())
+}
+
+// EquipmentRepo implements RepoManager.
+func (r *repoManager) EquipmentRepo() repository.EquipmentRepository {
+	return repository.NewEquipmentRepository(r.infra.Conn())
+}
+
+// AmenityRepo implements RepoManager.
+func (r *repoManager) AmenityRepo() repository.AmenityRepository {
+	return repository.NewAmenityRepository(r.infra.Conn())
+}
+
+// LocationRepo implements RepoManager.
+func (r *repoManager) LocationRepo() repository.LocationRepository {
+	return repository.NewLocationRepository(r.infra.Conn())
+}
+
+// RoomLayoutRepo implements RepoManager.
+func (r *repoManager) RoomLayoutRepo() repository.RoomLayoutRepository {
+	return repository.NewRoomLayoutRepository(r.infra.Conn())
+}
+
+// RoomImageRepo implements RepoManager.
+func (r *repoManager) RoomImageRepo() repository.RoomImageRepository {
+	return repository.NewRoomImageRepository(r.infra.Conn())
+}
+
+// FloorMapRepo implements RepoManager.
+func (r *repoManager) FloorMapRepo() repository.FloorMapRepository {
+	return repository.NewFloorMapRepository(r.infra.Conn())
+}
+
 // BookingRepo implements RepoManager.
 func (r *repoManager) BookingRepo() repository.BookingRepository {
 	return repository.NewBookingRepository(r.infra.Conn())
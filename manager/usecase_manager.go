@@ -9,26 +9,95 @@ type UseCaseManager interface {
 	UserUseCase() usecase.UserUseCase
 	RoomUsecase() usecase.RoomUseCase
 	BookingUsecase() usecase.BookingUseCase
+	AmenityUsecase() usecase.AmenityUseCase
+	EquipmentUsecase() usecase.EquipmentUseCase
+	LocationUsecase() usecase.LocationUseCase
+	RoomImageUsecase() usecase.RoomImageUseCase
+	RoomLayoutUsecase() usecase.RoomLayoutUseCase
+	FloorMapUsecase() usecase.FloorMapUseCase
+	AnalyticsUsecase() usecase.AnalyticsUseCase
+	TicketUsecase() usecase.TicketUseCase
+	ResourceUsecase() usecase.ResourceUseCase
+	VisitorUsecase() usecase.VisitorUseCase
+	ServiceUsecase() usecase.ServiceUseCase
 }
 
 type useCaseManager struct {
-	repo  RepoManager
-	email common.EmailService
+	repo          RepoManager
+	email         common.EmailService
+	storage       common.FileStorage
+	conference    common.ConferencingProvider
+	maxUploadSize int64
+}
+
+// ServiceUsecase implements UseCaseManager.
+func (u *useCaseManager) ServiceUsecase() usecase.ServiceUseCase {
+	return usecase.NewServiceUseCase(u.repo.ServiceRepo())
+}
+
+// VisitorUsecase implements UseCaseManager.
+func (u *useCaseManager) VisitorUsecase() usecase.VisitorUseCase {
+	return usecase.NewVisitorUseCase(u.repo.VisitorRepo(), u.email)
+}
+
+// ResourceUsecase implements UseCaseManager.
+func (u *useCaseManager) ResourceUsecase() usecase.ResourceUseCase {
+	return usecase.NewResourceUseCase(u.repo.ResourceRepo(), u.UserUseCase())
+}
+
+// TicketUsecase implements UseCaseManager.
+func (u *useCaseManager) TicketUsecase() usecase.TicketUseCase {
+	return usecase.NewTicketUseCase(u.repo.TicketRepo(), u.UserUseCase())
+}
+
+// AnalyticsUsecase implements UseCaseManager.
+func (u *useCaseManager) AnalyticsUsecase() usecase.AnalyticsUseCase {
+	return usecase.NewAnalyticsUseCase(u.repo.BookingRepo())
+}
+
+// EquipmentUsecase implements UseCaseManager.
+func (u *useCaseManager) EquipmentUsecase() usecase.EquipmentUseCase {
+	return usecase.NewEquipmentUseCase(u.repo.EquipmentRepo())
+}
+
+// AmenityUsecase implements UseCaseManager.
+func (u *useCaseManager) AmenityUsecase() usecase.AmenityUseCase {
+	return usecase.NewAmenityUseCase(u.repo.AmenityRepo())
+}
+
+// LocationUsecase implements UseCaseManager.
+func (u *useCaseManager) LocationUsecase() usecase.LocationUseCase {
+	return usecase.NewLocationUseCase(u.repo.LocationRepo())
+}
+
+// RoomLayoutUsecase implements UseCaseManager.
+func (u *useCaseManager) RoomLayoutUsecase() usecase.RoomLayoutUseCase {
+	return usecase.NewRoomLayoutUseCase(u.repo.RoomLayoutRepo(), u.RoomUsecase())
+}
+
+// RoomImageUsecase implements UseCaseManager.
+func (u *useCaseManager) RoomImageUsecase() usecase.RoomImageUseCase {
+	return usecase.NewRoomImageUseCase(u.repo.RoomImageRepo(), u.RoomUsecase(), u.storage, u.maxUploadSize)
+}
+
+// FloorMapUsecase implements UseCaseManager.
+func (u *useCaseManager) FloorMapUsecase() usecase.FloorMapUseCase {
+	return usecase.NewFloorMapUseCase(u.repo.FloorMapRepo(), u.RoomUsecase(), u.LocationUsecase())
 }
 
 // BookingUsecase implements UseCaseManager.
 func (u *useCaseManager) BookingUsecase() usecase.BookingUseCase {
-	return usecase.NewBookingUseCase(u.repo.BookingRepo(), u.UserUseCase(), u.RoomUsecase(), u.email)
+	return usecase.NewBookingUseCase(u.repo.BookingRepo(), u.UserUseCase(), u.RoomUsecase(), u.email, u.conference)
 }
 
 // RoomUsecase implements UseCaseManager.
 func (u *useCaseManager) RoomUsecase() usecase.RoomUseCase {
-	return usecase.NewRoomUseCase(u.repo.RoomRepo())
+	return usecase.NewRoomUseCase(u.repo.RoomRepo(), u.UserUseCase(), u.AmenityUsecase(), u.email, u.conference)
 }
 func (u *useCaseManager) UserUseCase() usecase.UserUseCase {
 	return usecase.NewUserUseCase(u.repo.UserRepo(), u.email)
 }
 
-func NewUseCaseManager(repo RepoManager, email common.EmailService) UseCaseManager {
-	return &useCaseManager{repo: repo, email: email}
+func NewUseCaseManager(repo RepoManager, email common.EmailService, storage common.FileStorage, conference common.ConferencingProvider, maxUploadSize int64) UseCaseManager {
+	return &useCaseManager{repo: repo, email: email, storage: storage, conference: conference, maxUploadSize: maxUploadSize}
 }
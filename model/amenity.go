@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// Amenity adalah satu jenis fasilitas di katalog, misalnya wifi, proyektor atau kursi
+type Amenity struct {
+	Id        string    `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Icon      string    `json:"icon"`
+	Type      string    `json:"type"` // boolean (ada/tidak) atau quantity (ada jumlahnya)
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func (a Amenity) IsValidType() bool {
+	return a.Type == "boolean" || a.Type == "quantity"
+}
+
+// RoomAmenity adalah amenity yang terpasang di sebuah ruangan
+type RoomAmenity struct {
+	Id        string `json:"id"`
+	Code      string `json:"code"`
+	Name      string `json:"name"`
+	Icon      string `json:"icon"`
+	Type      string `json:"type"`
+	Available bool   `json:"available"`
+	Quantity  int    `json:"quantity"`
+}
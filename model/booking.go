@@ -8,18 +8,69 @@ type Booking struct {
 	Id             string          `json:"bookingId"`
 	Users          User            `json:"employe"`
 	BookingDetails []BookingDetail `json:"bookingDetails"`
+	Participants   []Participant   `json:"participants,omitempty"` // hanya diisi saat booking dibuat
 	CreatedAt      time.Time       `json:"createdAt"`
 	UpdatedAt      time.Time       `json:"updatedAt"`
 }
 
+// role user lain yang diikutkan ke booking, booking ikut tampil di daftar booking mereka
+const (
+	ParticipantAttendee = "attendee"
+	ParticipantDelegate = "delegate" // mewakili pemesan untuk mengurus booking
+)
+
+type Participant struct {
+	UserId string `json:"userId"`
+	Role   string `json:"role"`
+}
+
 type BookingDetail struct {
-	Id             string    `json:"id"`
-	BookingId      string    `json:"bookingId"`
-	Rooms          Room      `json:"rooms"`
-	Description    string    `json:"description"`
-	Status         string    `json:"status"`
-	BookingDate    time.Time `json:"bookingDate"`
-	BookingDateEnd time.Time `json:"bookingDateEnd"`
-	CreatedAt      time.Time `json:"createdAt"`
-	UpdatedAt      time.Time `json:"updatedAt"`
+	Id             string                 `json:"id"`
+	BookingId      string                 `json:"bookingId"`
+	Rooms          Room                   `json:"rooms"`
+	Description    string                 `json:"description"`
+	Status         string                 `json:"status"`
+	Layout         *RoomLayout            `json:"layout,omitempty"` // request cukup mengirim layout.id
+	Attendees      int                    `json:"attendees"`
+	Equipment      []EquipmentReservation `json:"equipment"`
+	BookingDate    time.Time              `json:"bookingDate"`
+	BookingDateEnd time.Time              `json:"bookingDateEnd"`
+	BlockedFrom    time.Time              `json:"blockedFrom"`   // BookingDate dikurangi waktu setup layout
+	BlockedUntil   time.Time              `json:"blockedUntil"`  // BookingDateEnd ditambah waktu teardown layout
+	Timezone       string                 `json:"timezone"`      // zona yang dipakai untuk menampilkan waktu di atas
+	ConferenceURL  string                 `json:"conferenceUrl"` // link video conference, diisi saat booking accept
+	CreatedAt      time.Time              `json:"createdAt"`
+	UpdatedAt      time.Time              `json:"updatedAt"`
+}
+
+// In mengembalikan salinan booking dengan semua waktu ditampilkan di zona loc.
+// Jika loc nil, waktu tiap detail memakai timezone ruangannya dan sisanya UTC.
+func (b Booking) In(loc *time.Location) Booking {
+	base := loc
+	if base == nil {
+		base = time.UTC
+	}
+	b.CreatedAt = b.CreatedAt.In(base)
+	b.UpdatedAt = b.UpdatedAt.In(base)
+
+	details := make([]BookingDetail, len(b.BookingDetails))
+	for i, detail := range b.BookingDetails {
+		details[i] = detail.In(loc)
+	}
+	b.BookingDetails = details
+	return b
+}
+
+func (d BookingDetail) In(loc *time.Location) BookingDetail {
+	if loc == nil {
+		loc = d.Rooms.Location.Zone()
+	}
+	d.BookingDate = d.BookingDate.In(loc)
+	d.BookingDateEnd = d.BookingDateEnd.In(loc)
+	d.BlockedFrom = d.BlockedFrom.In(loc)
+	d.BlockedUntil = d.BlockedUntil.In(loc)
+	d.CreatedAt = d.CreatedAt.In(loc)
+	d.UpdatedAt = d.UpdatedAt.In(loc)
+	d.Timezone = loc.String()
+	return d
 }
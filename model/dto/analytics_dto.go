@@ -0,0 +1,62 @@
+package dto
+
+import "time"
+
+// UtilizationFilter menentukan ruangan, rentang tanggal dan pengelompokan laporan utilisasi
+type UtilizationFilter struct {
+	StartDate time.Time // tanggal pertama, jamnya diabaikan
+	EndDate   time.Time // tanggal terakhir (inklusif)
+	Period    string    // day, week atau month
+	GroupBy   string    // room, roomType, building atau division
+	RoomType  string
+	Location  LocationFilter
+	From      time.Time // rentang waktu absolut yang diambil repository, diisi oleh usecase
+	Until     time.Time
+}
+
+// UtilizationRoom adalah data ruangan beserta booking di rentang filter, hasil repository
+type UtilizationRoom struct {
+	RoomId       string
+	RoomName     string
+	RoomType     string
+	BuildingId   string
+	BuildingName string
+	Timezone     string
+	Capacity     int
+	Bookings     []UtilizationBooking
+}
+
+type UtilizationBooking struct {
+	Division  string // divisi pemesan
+	Status    string
+	Attendees int
+	Start     time.Time
+	End       time.Time
+	CheckedIn bool
+}
+
+type UtilizationReport struct {
+	StartDate string           `json:"startDate"`
+	EndDate   string           `json:"endDate"`
+	Period    string           `json:"period"`
+	GroupBy   string           `json:"groupBy"`
+	OpenHour  int              `json:"openHour"`  // jam buka lokal, jam tersedia dihitung dari jam buka sampai jam tutup di hari kerja
+	CloseHour int              `json:"closeHour"` // jam tutup lokal
+	Rows      []UtilizationRow `json:"rows"`
+}
+
+// UtilizationRow adalah angka utilisasi satu kelompok di satu periode, persentase bernilai 0-100
+type UtilizationRow struct {
+	Period           string  `json:"period"`    // YYYY-MM-DD untuk day dan week (senin), YYYY-MM untuk month
+	GroupKey         string  `json:"groupKey"`  // room id, room type, building id atau divisi
+	GroupName        string  `json:"groupName"` // nama ruangan, room type, nama building atau divisi
+	Bookings         int     `json:"bookings"`
+	BookedHours      float64 `json:"bookedHours"`
+	AvailableHours   float64 `json:"availableHours"`
+	Utilization      float64 `json:"utilization"`
+	AverageAttendees float64 `json:"averageAttendees"`
+	AverageCapacity  float64 `json:"averageCapacity"`
+	PeakHours        []int   `json:"peakHours"` // jam lokal (0-23) dengan booking terbanyak
+	NoShowRate       float64 `json:"noShowRate"`
+	CancellationRate float64 `json:"cancellationRate"`
+}
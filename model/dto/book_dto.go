@@ -1,9 +1,30 @@
 package dto
 
-import "final-project-booking-room/model"
+import (
+	"final-project-booking-room/model"
+	"time"
+)
 
 type BookingRequestDto struct {
 	Id              string                `json:"id"`
 	BoookingDetails []model.BookingDetail `json:"bookingDetails" binding:"required"`
+	Participants    []model.Participant   `json:"participants"`
 	Description     string                `json:"description"`
 }
+
+type BookingFilter struct {
+	Status      string
+	RoomId      string
+	RequesterId string
+	UserId      string // booking milik user ini atau yang mengikutkannya sebagai attendee/delegate
+	Divisi      string
+	Keyword     string
+	Location    LocationFilter
+	Timezone    *time.Location // zona viewer, nil berarti pakai timezone ruangan
+	StartDate   time.Time
+	EndDate     time.Time
+	SortBy      string
+	Order       string
+	Page        int
+	Size        int
+}
@@ -0,0 +1,48 @@
+package dto
+
+import "time"
+
+// CalendarFilter menentukan ruangan dan rentang tanggal untuk tampilan kalender
+type CalendarFilter struct {
+	RoomIds   []string // kosong berarti semua ruangan sesuai filter lokasi
+	Location  LocationFilter
+	StartDate time.Time      // tanggal pertama, jamnya diabaikan
+	EndDate   time.Time      // tanggal terakhir (inklusif)
+	Timezone  *time.Location // zona viewer, nil berarti setiap ruangan memakai timezone-nya sendiri
+	From      time.Time      // rentang waktu absolut yang diambil repository, diisi oleh usecase
+	Until     time.Time
+}
+
+type CalendarRoom struct {
+	RoomId   string            `json:"roomId"`
+	RoomName string            `json:"roomName"`
+	Timezone string            `json:"timezone"`
+	Days     []CalendarDay     `json:"days"`
+	Bookings []CalendarBooking `json:"-"` // hasil repository, dikelompokkan ke Days oleh usecase
+}
+
+type CalendarDay struct {
+	Date     string            `json:"date"` // YYYY-MM-DD di timezone ruangan atau viewer
+	Bookings []CalendarBooking `json:"bookings"`
+}
+
+// CalendarBooking adalah satu interval terpakai. Untuk booking milik orang lain,
+// employee hanya melihat waktu dan statusnya saja.
+type CalendarBooking struct {
+	BookingId       string         `json:"bookingId,omitempty"`
+	BookingDetailId string         `json:"bookingDetailId,omitempty"`
+	Owner           *CalendarOwner `json:"owner,omitempty"`
+	Status          string         `json:"status"`
+	Description     string         `json:"description,omitempty"`
+	Mine            bool           `json:"mine"`
+	Start           time.Time      `json:"start"`
+	End             time.Time      `json:"end"`
+	BlockedFrom     time.Time      `json:"blockedFrom"`
+	BlockedUntil    time.Time      `json:"blockedUntil"`
+}
+
+type CalendarOwner struct {
+	Id     string `json:"id"`
+	Name   string `json:"name"`
+	Divisi string `json:"divisi"`
+}
@@ -0,0 +1,8 @@
+package dto
+
+type Paging struct {
+	Page       int `json:"page"`
+	Size       int `json:"size"`
+	TotalRows  int `json:"totalRows"`
+	TotalPages int `json:"totalPages"`
+}
@@ -0,0 +1,67 @@
+package dto
+
+import (
+	"final-project-booking-room/model"
+	"time"
+)
+
+// RecommendationFilter adalah kebutuhan booking yang dicarikan ruangannya
+type RecommendationFilter struct {
+	Attendees int
+	Amenities []string // kode amenity yang dibutuhkan
+	Start     time.Time
+	End       time.Time
+	Location  LocationFilter
+	Viewer    *model.User // jika diisi, ruangan yang dibatasi rule akses untuk user ini tidak ikut
+	From      time.Time   // rentang booking yang diambil repository, diisi oleh usecase
+	Until     time.Time
+}
+
+// RoomCandidate adalah ruangan yang muat untuk jumlah peserta beserta interval terpakainya, hasil repository
+type RoomCandidate struct {
+	RoomId       string
+	RoomName     string
+	RoomType     string
+	Capacity     int
+	BuildingId   string
+	BuildingName string
+	Amenities    []string // amenity yang diminta dan dimiliki ruangan
+	Busy         []BusyInterval
+}
+
+// BusyInterval adalah rentang terpakai (termasuk setup dan teardown) dari booking pending atau accept
+// di ruangan itu sendiri, bagiannya atau ruangan gabungannya
+type BusyInterval struct {
+	From  time.Time
+	Until time.Time
+}
+
+type RoomRecommendation struct {
+	RoomId           string   `json:"roomId"`
+	RoomName         string   `json:"roomName"`
+	RoomType         string   `json:"roomType"`
+	Capacity         int      `json:"capacity"`
+	BuildingId       string   `json:"buildingId"`
+	BuildingName     string   `json:"buildingName"`
+	Score            float64  `json:"score"`         // 0-100, makin besar makin cocok
+	CapacityFit      float64  `json:"capacityFit"`   // peserta dibagi kapasitas, 0-100
+	AmenityMatch     float64  `json:"amenityMatch"`  // amenity yang diminta dan tersedia, 0-100
+	UsualBuilding    bool     `json:"usualBuilding"` // gedung yang paling sering dibooking user
+	MissingAmenities []string `json:"missingAmenities"`
+}
+
+// AlternativeSlot adalah waktu lain di sekitar waktu yang diminta beserta ruangan terbaiknya
+type AlternativeSlot struct {
+	Start time.Time          `json:"start"`
+	End   time.Time          `json:"end"`
+	Room  RoomRecommendation `json:"room"`
+}
+
+// RecommendationResult berisi ruangan yang kosong di waktu yang diminta, urut dari skor tertinggi.
+// Alternatives hanya terisi jika tidak ada ruangan yang kosong.
+type RecommendationResult struct {
+	Start        time.Time            `json:"start"`
+	End          time.Time            `json:"end"`
+	Rooms        []RoomRecommendation `json:"rooms"`
+	Alternatives []AlternativeSlot    `json:"alternatives"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+import "time"
+
+// ResourceFilter adalah filter dan paging untuk list resource, field kosong diabaikan
+type ResourceFilter struct {
+	ResourceType   string
+	NeighborhoodId string
+	Location       LocationFilter
+	AvailableOn    string // YYYY-MM-DD, hanya resource yang tidak dibooking di tanggal lokal ini
+	Page           int
+	Size           int
+}
+
+// ResourceBookingRequest memakai Date untuk tipe daily, Start dan End untuk tipe slot
+type ResourceBookingRequest struct {
+	Date        string    `json:"date"`
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end"`
+	Description string    `json:"description"`
+}
+
+// ResourceBookingLimit adalah batas booking per user per hari yang dicek repository di dalam transaksi booking
+type ResourceBookingLimit struct {
+	DayStart   time.Time
+	DayEnd     time.Time
+	MaxPerUser int // 0 berarti tidak dibatasi
+}
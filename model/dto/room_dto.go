@@ -0,0 +1,97 @@
+package dto
+
+import (
+	"final-project-booking-room/model"
+	"io"
+	"time"
+)
+
+// LocationFilter membatasi hasil ke site, building atau floor tertentu, field kosong diabaikan
+type LocationFilter struct {
+	SiteId     string
+	BuildingId string
+	FloorId    string
+}
+
+// RoomFilter adalah filter, urutan dan paging untuk list ruangan, field kosong diabaikan
+type RoomFilter struct {
+	RoomType    string
+	Status      string // available, booked atau maintenance, dihitung bersama ruangan gabungan
+	MinCapacity int
+	MaxCapacity int
+	Amenities   []string // kode amenity, ruangan harus punya semuanya
+	Location    LocationFilter
+	Viewer      *model.User // jika diisi, ruangan yang dibatasi rule akses untuk user ini tidak ikut
+	SortBy      string
+	Order       string
+	Page        int
+	Size        int
+}
+
+// ImageUpload adalah file gambar dari request multipart
+type ImageUpload struct {
+	Kind     string
+	Filename string
+	Size     int64
+	File     io.Reader
+}
+
+// ArchiveOption menentukan nasib booking yang akan datang saat ruangan diarsipkan
+type ArchiveOption struct {
+	Cascade    string // kosong, "cancel" atau "reassign"
+	ReassignTo string // room id tujuan jika Cascade = reassign
+}
+
+// AffectedBooking adalah booking yang akan datang di ruangan yang diarsipkan
+type AffectedBooking struct {
+	BookingDetailId string    `json:"bookingDetailId"`
+	BookingId       string    `json:"bookingId"`
+	UserId          string    `json:"-"`
+	UserName        string    `json:"userName"`
+	UserEmail       string    `json:"userEmail"`
+	Participants    []string  `json:"-"` // email attendee dan delegate yang ikut menerima pemberitahuan
+	Status          string    `json:"status"`
+	BookingDate     time.Time `json:"bookingDate"`
+	BookingDateEnd  time.Time `json:"bookingDateEnd"`
+	Notified        bool      `json:"notified"`
+	Description     string    `json:"-"`
+	ConferenceId    string    `json:"-"` // meeting video conference yang ikut diubah atau dihapus
+	ConferenceURL   string    `json:"-"`
+	InviteSequence  int       `json:"-"` // SEQUENCE invite iCalendar untuk perubahan ini
+}
+
+type ArchiveResult struct {
+	Room             model.Room        `json:"room"`
+	Cascade          string            `json:"cascade"`
+	AffectedBookings []AffectedBooking `json:"affectedBookings"`
+}
+
+type RoomPartsRequest struct {
+	PartIds []string `json:"partIds"`
+}
+
+type RoomAccessRequest struct {
+	Rules []model.RoomAccessRule `json:"rules"`
+}
+
+// RoomImportRow adalah satu baris file import ruangan yang sudah lolos validasi, Row adalah nomor baris di file
+type RoomImportRow struct {
+	Row  int
+	Room model.Room
+}
+
+// RoomImportError adalah kesalahan validasi pada satu baris file import
+type RoomImportError struct {
+	Row     int    `json:"row"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// RoomImportResult adalah ringkasan import ruangan, pada dry run tidak ada perubahan yang disimpan
+type RoomImportResult struct {
+	DryRun    bool              `json:"dryRun"`
+	TotalRows int               `json:"totalRows"`
+	Created   int               `json:"created"`
+	Updated   int               `json:"updated"`
+	Errors    []RoomImportError `json:"errors"`
+}
@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"final-project-booking-room/model"
+	"time"
+)
+
+type ServiceOrderRequest struct {
+	ServiceType string    `json:"serviceType"`
+	Quantity    int       `json:"quantity"`  // kosong berarti 1
+	DeliverAt   time.Time `json:"deliverAt"` // kosong berarti waktu mulai booking
+	Notes       string    `json:"notes"`
+}
+
+type ServiceApprovalRequest struct {
+	Approval string `json:"approval"` // approve atau reject
+	Note     string `json:"note"`
+}
+
+type ServiceFulfillmentRequest struct {
+	Status string `json:"status"` // in_progress atau fulfilled
+	Note   string `json:"note"`
+}
+
+// ServiceTarget adalah data booking detail yang dicek sebelum order layanan dibuat
+type ServiceTarget struct {
+	UserId         string
+	Status         string
+	BookingDate    time.Time
+	BookingDateEnd time.Time
+}
+
+// ServiceOrderFilter adalah filter dan paging list order layanan, field kosong diabaikan
+type ServiceOrderFilter struct {
+	ApprovalStatus    string
+	FulfillmentStatus string
+	Page              int
+	Size              int
+}
+
+// ServiceDailyFilter adalah filter daftar harian vendor, tanggal dibaca di zona masing-masing ruangan
+type ServiceDailyFilter struct {
+	Date     string // YYYY-MM-DD
+	Location LocationFilter
+}
+
+// ServiceDailyList adalah order approved yang dikirim pada satu tanggal beserta total per jenis layanan
+type ServiceDailyList struct {
+	Date   string               `json:"date"`
+	Totals map[string]int       `json:"totals"` // jumlah quantity per serviceType
+	Orders []model.ServiceOrder `json:"orders"`
+}
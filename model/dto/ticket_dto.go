@@ -0,0 +1,42 @@
+package dto
+
+import "time"
+
+type FeedbackRequest struct {
+	Rating  int            `json:"rating"`
+	Comment string         `json:"comment"`
+	Issues  []IssueRequest `json:"issues"` // setiap issue membuka satu maintenance ticket
+}
+
+type IssueRequest struct {
+	Category    string `json:"category"`
+	Severity    string `json:"severity"`
+	Description string `json:"description"`
+}
+
+// FeedbackTarget adalah data booking detail yang dicek sebelum feedback seorang user disimpan
+type FeedbackTarget struct {
+	RoomId         string
+	UserId         string // pemesan
+	Participant    bool   // user yang memberi feedback diikutkan sebagai attendee atau delegate
+	Status         string
+	BookingDateEnd time.Time
+	HasFeedback    bool // user yang memberi feedback sudah pernah mengisi feedback booking detail ini
+}
+
+// TicketFilter adalah filter dan paging list maintenance ticket, field kosong diabaikan
+type TicketFilter struct {
+	Status   string
+	Severity string
+	RoomId   string
+	Page     int
+	Size     int
+}
+
+type TicketAssignRequest struct {
+	AssigneeId string `json:"assigneeId"`
+}
+
+type TicketResolveRequest struct {
+	Resolution string `json:"resolution"`
+}
@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"final-project-booking-room/model"
+	"time"
+)
+
+type VisitorRequest struct {
+	Visitors []VisitorInput `json:"visitors"`
+}
+
+type VisitorInput struct {
+	Name            string    `json:"name"`
+	Company         string    `json:"company"`
+	Email           string    `json:"email"`
+	ExpectedArrival time.Time `json:"expectedArrival"` // kosong berarti waktu mulai booking
+}
+
+// VisitorTarget adalah data booking detail yang dicek sebelum visitor didaftarkan
+type VisitorTarget struct {
+	UserId         string
+	HostName       string
+	Status         string
+	BookingDate    time.Time
+	BookingDateEnd time.Time
+	RoomId         string
+	RoomName       string
+	Location       model.RoomLocation
+}
+
+// VisitorFilter adalah filter tampilan harian resepsionis, tanggal dibaca di zona masing-masing ruangan
+type VisitorFilter struct {
+	Date     string // YYYY-MM-DD
+	Location LocationFilter
+}
@@ -0,0 +1,34 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Equipment adalah barang yang dipinjam bersama booking ruangan (proyektor, speaker, dll), stoknya dihitung per site
+type Equipment struct {
+	Id        string    `json:"id"`
+	SiteId    string    `json:"siteId"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Quantity  int       `json:"quantity"` // jumlah unit yang dimiliki site
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// EquipmentReservation adalah equipment yang dipesan di satu booking detail
+type EquipmentReservation struct {
+	EquipmentId string `json:"equipmentId"`
+	Name        string `json:"name"` // read-only
+	Quantity    int    `json:"quantity"`
+}
+
+// EquipmentSummary meringkas equipment yang dipesan untuk report, misalnya "Projector x1, Speaker x2"
+func (d BookingDetail) EquipmentSummary() string {
+	items := make([]string, len(d.Equipment))
+	for i, item := range d.Equipment {
+		items[i] = fmt.Sprintf("%s x%d", item.Name, item.Quantity)
+	}
+	return strings.Join(items, ", ")
+}
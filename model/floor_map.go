@@ -0,0 +1,55 @@
+package model
+
+import "time"
+
+const (
+	ShapePoint   = "point"
+	ShapePolygon = "polygon"
+)
+
+// MapPoint adalah koordinat di bidang denah floor, (0,0) di pojok kiri atas
+type MapPoint struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// RoomShape adalah posisi ruangan di denah, berupa satu titik atau polygon
+type RoomShape struct {
+	Type   string     `json:"type"` // point atau polygon
+	Points []MapPoint `json:"points"`
+}
+
+func (s RoomShape) IsEmpty() bool {
+	return s.Type == ""
+}
+
+// Center mengembalikan titik tengah shape (rata-rata titik polygon), dipakai untuk posisi label
+func (s RoomShape) Center() MapPoint {
+	var center MapPoint
+	if len(s.Points) == 0 {
+		return center
+	}
+	for _, p := range s.Points {
+		center.X += p.X
+		center.Y += p.Y
+	}
+	center.X /= float64(len(s.Points))
+	center.Y /= float64(len(s.Points))
+	return center
+}
+
+// FloorMapRoom adalah satu ruangan di denah beserta ketersediaannya saat ini
+type FloorMapRoom struct {
+	RoomId       string    `json:"roomId"`
+	Name         string    `json:"name"`
+	Code         string    `json:"code"`
+	Capacity     int       `json:"capacity"`
+	Availability string    `json:"availability"` // available, pending atau booked
+	Shape        RoomShape `json:"shape"`        // kosong jika ruangan belum ditempatkan di denah
+}
+
+type FloorMap struct {
+	Floor       Floor          `json:"floor"`
+	Rooms       []FloorMapRoom `json:"rooms"`
+	GeneratedAt time.Time      `json:"generatedAt"`
+}
@@ -0,0 +1,55 @@
+package model
+
+import "time"
+
+// Site adalah satu kantor/lokasi, misalnya "Jakarta HQ"
+type Site struct {
+	Id        string    `json:"id"`
+	Name      string    `json:"name"`
+	Address   string    `json:"address"`
+	Timezone  string    `json:"timezone"` // nama IANA, contoh Asia/Jakarta
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type Building struct {
+	Id        string    `json:"id"`
+	SiteId    string    `json:"siteId"`
+	Name      string    `json:"name"`
+	Address   string    `json:"address"`
+	Timezone  string    `json:"timezone"` // kosong berarti ikut timezone site
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type Floor struct {
+	Id         string    `json:"id"`
+	BuildingId string    `json:"buildingId"`
+	Name       string    `json:"name"`
+	Level      int       `json:"level"`
+	MapWidth   int       `json:"mapWidth"`  // lebar bidang koordinat denah, default 1000
+	MapHeight  int       `json:"mapHeight"` // tinggi bidang koordinat denah, default 1000
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// RoomLocation adalah ringkasan lokasi ruangan yang ikut di response room
+type RoomLocation struct {
+	SiteId       string `json:"siteId"`
+	SiteName     string `json:"siteName"`
+	BuildingId   string `json:"buildingId"`
+	BuildingName string `json:"buildingName"`
+	FloorId      string `json:"floorId"`
+	FloorName    string `json:"floorName"`
+	FloorLevel   int    `json:"floorLevel"`
+	Timezone     string `json:"timezone"`
+}
+
+// Zone mengembalikan timezone lokal ruangan, UTC jika ruangan belum punya lokasi
+func (l RoomLocation) Zone() *time.Location {
+	loc, err := time.LoadLocation(l.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
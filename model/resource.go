@@ -0,0 +1,64 @@
+package model
+
+import (
+	"slices"
+	"time"
+)
+
+// ResourceType adalah jenis resource selain ruangan (desk, parking, phone booth) beserta aturan bookingnya
+type ResourceType struct {
+	Code               string   `json:"code"`
+	Name               string   `json:"name"`
+	BookingMode        string   `json:"bookingMode"`        // slot: jam mulai-selesai, daily: satu hari penuh di zona resource
+	MaxDurationMinutes int      `json:"maxDurationMinutes"` // khusus slot, 0 berarti tidak dibatasi
+	MaxAdvanceDays     int      `json:"maxAdvanceDays"`     // 0 berarti tidak dibatasi
+	MaxPerUserPerDay   int      `json:"maxPerUserPerDay"`   // 0 berarti tidak dibatasi
+	Attributes         []string `json:"attributes"`         // atribut yang boleh diisi di resource tipe ini
+}
+
+// AllowsAttribute bernilai true jika atribut name boleh diisi di resource tipe ini
+func (t ResourceType) AllowsAttribute(name string) bool {
+	return slices.Contains(t.Attributes, name)
+}
+
+// Neighborhood adalah area desk di satu floor, biasanya dipakai satu tim.
+// Jika Division diisi, desk di neighborhood ini hanya bisa dibooking user dari divisi tersebut.
+type Neighborhood struct {
+	Id        string    `json:"id"`
+	FloorId   string    `json:"floorId"`
+	Name      string    `json:"name"`
+	Division  string    `json:"division"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type Resource struct {
+	Id               string            `json:"id"`
+	ResourceType     string            `json:"resourceType"`
+	Code             string            `json:"code"`
+	Name             string            `json:"name"`
+	FloorId          string            `json:"floorId"`
+	NeighborhoodId   string            `json:"neighborhoodId"`
+	NeighborhoodName string            `json:"neighborhoodName"` // read-only
+	Division         string            `json:"division"`         // read-only, divisi pemilik neighborhood
+	Attributes       map[string]string `json:"attributes"`
+	Location         RoomLocation      `json:"location"` // read-only, diisi dari floor -> building -> site
+	ArchivedAt       *time.Time        `json:"archivedAt"`
+	CreatedAt        time.Time         `json:"createdAt"`
+	UpdatedAt        time.Time         `json:"updatedAt"`
+}
+
+// ResourceBooking adalah satu booking detail untuk resource selain ruangan, Id adalah id booking detail
+type ResourceBooking struct {
+	Id           string    `json:"id"`
+	BookingId    string    `json:"bookingId"`
+	ResourceId   string    `json:"resourceId"`
+	ResourceType string    `json:"resourceType"`
+	ResourceName string    `json:"resourceName"`
+	UserId       string    `json:"userId"`
+	Start        time.Time `json:"start"`
+	End          time.Time `json:"end"`
+	Status       string    `json:"status"`
+	Description  string    `json:"description"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
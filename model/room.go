@@ -3,13 +3,24 @@ package model
 import "time"
 
 type Room struct {
-	Id          string       `json:"id"`
-	RoomType    string       `json:"roomType"`
-	MaxCapacity int          `json:"maxcapacity"`
-	Facility    RoomFacility `json:"facility"`
-	Status      string       `json:"status"` //untuk status hanya ada dua yaitu Available atau Booked
-	CreatedAt   time.Time    `json:"createdAt"`
-	UpdatedAt   time.Time    `json:"updatedAt"`
+	Id          string           `json:"id"`
+	Name        string           `json:"name"`
+	Code        string           `json:"code"`
+	RoomType    string           `json:"roomType"`
+	MaxCapacity int              `json:"maxcapacity"`
+	Facility    RoomFacility     `json:"facility"` // read-only, diturunkan dari Amenities selama masa migrasi
+	Amenities   []RoomAmenity    `json:"amenities"`
+	FloorId     string           `json:"floorId"`
+	Location    RoomLocation     `json:"location"`    // read-only, diisi dari floor -> building -> site
+	Images      []RoomImage      `json:"images"`      // read-only, diisi lewat endpoint upload
+	Layouts     []RoomLayout     `json:"layouts"`     // read-only, diatur lewat endpoint layouts
+	PartIds     []string         `json:"partIds"`     // read-only, ruangan yang digabung menjadi ruangan ini (Hall A+B -> Hall A, Hall B)
+	CombinedIn  []string         `json:"combinedIn"`  // read-only, ruangan gabungan yang memuat ruangan ini
+	AccessRules []RoomAccessRule `json:"accessRules"` // read-only, diatur lewat endpoint access
+	ArchivedAt  *time.Time       `json:"archivedAt"`  // terisi jika ruangan diarsipkan, tidak muncul di pencarian dan tidak bisa dibooking
+	Status      string           `json:"status"`      //untuk status hanya ada dua yaitu Available atau Booked
+	CreatedAt   time.Time        `json:"createdAt"`
+	UpdatedAt   time.Time        `json:"updatedAt"`
 }
 
 type RoomFacility struct {
@@ -0,0 +1,51 @@
+package model
+
+import "strings"
+
+// RoomAccessRule membatasi siapa yang boleh booking ruangan berdasarkan divisi, jabatan atau role user
+type RoomAccessRule struct {
+	Effect string `json:"effect"` // allow atau deny
+	Field  string `json:"field"`  // divisi, jabatan atau role
+	Value  string `json:"value"`
+}
+
+func (a RoomAccessRule) IsValid() bool {
+	return (a.Effect == "allow" || a.Effect == "deny") &&
+		(a.Field == "divisi" || a.Field == "jabatan" || a.Field == "role") &&
+		strings.TrimSpace(a.Value) != ""
+}
+
+// Matches mengecek apakah user cocok dengan rule, perbandingan tidak membedakan huruf besar kecil
+func (a RoomAccessRule) Matches(user User) bool {
+	var value string
+	switch a.Field {
+	case "divisi":
+		value = user.Divisi
+	case "jabatan":
+		value = user.Jabatan
+	case "role":
+		value = user.Role
+	}
+	return strings.EqualFold(strings.TrimSpace(value), strings.TrimSpace(a.Value))
+}
+
+// AllowsUser mengecek apakah user boleh booking ruangan.
+// Rule deny selalu menang, dan jika ada rule allow user harus cocok dengan salah satunya.
+// Ruangan tanpa rule terbuka untuk semua user.
+func (r Room) AllowsUser(user User) bool {
+	var hasAllow, allowed bool
+	for _, rule := range r.AccessRules {
+		switch rule.Effect {
+		case "deny":
+			if rule.Matches(user) {
+				return false
+			}
+		case "allow":
+			hasAllow = true
+			if rule.Matches(user) {
+				allowed = true
+			}
+		}
+	}
+	return !hasAllow || allowed
+}
@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+const (
+	RoomImagePhoto     = "photo"
+	RoomImageFloorPlan = "floor_plan"
+)
+
+// RoomImage adalah foto atau denah ruangan yang sudah di-upload
+type RoomImage struct {
+	Id           string    `json:"id"`
+	RoomId       string    `json:"roomId"`
+	Kind         string    `json:"kind"` // photo atau floor_plan
+	Url          string    `json:"url"`
+	ThumbnailUrl string    `json:"thumbnailUrl"`
+	ContentType  string    `json:"contentType"`
+	Size         int64     `json:"size"`
+	StorageKey   string    `json:"-"`
+	ThumbnailKey string    `json:"-"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+func (r RoomImage) IsValidKind() bool {
+	return r.Kind == RoomImagePhoto || r.Kind == RoomImageFloorPlan
+}
@@ -0,0 +1,42 @@
+package model
+
+import "time"
+
+// RoomLayout adalah susunan kursi ruangan (theatre, classroom, dll) dengan kapasitas dan waktu persiapannya sendiri
+type RoomLayout struct {
+	Id              string    `json:"id"`
+	RoomId          string    `json:"roomId"`
+	Name            string    `json:"name"`
+	Capacity        int       `json:"capacity"`
+	SetupMinutes    int       `json:"setupMinutes"`    // ruangan sudah terpakai sebelum booking dimulai
+	TeardownMinutes int       `json:"teardownMinutes"` // ruangan masih terpakai setelah booking selesai
+	CreatedAt       time.Time `json:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+}
+
+// Buffer mengembalikan rentang waktu ruangan terpakai untuk booking start-end termasuk setup dan teardown.
+// Layout nil berarti tanpa buffer.
+func (l *RoomLayout) Buffer(start, end time.Time) (time.Time, time.Time) {
+	if l == nil {
+		return start, end
+	}
+	return start.Add(-time.Duration(l.SetupMinutes) * time.Minute), end.Add(time.Duration(l.TeardownMinutes) * time.Minute)
+}
+
+// Layout mencari layout ruangan berdasarkan id
+func (r Room) Layout(id string) (RoomLayout, bool) {
+	for _, layout := range r.Layouts {
+		if layout.Id == id {
+			return layout, true
+		}
+	}
+	return RoomLayout{}, false
+}
+
+// Capacity mengembalikan kapasitas ruangan untuk layout yang dipilih, MaxCapacity jika tanpa layout
+func (r Room) Capacity(layout *RoomLayout) int {
+	if layout == nil {
+		return r.MaxCapacity
+	}
+	return layout.Capacity
+}
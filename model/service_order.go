@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// ServiceOrder adalah order layanan seperti coffee break, makan siang atau IT setup yang dilampirkan ke booking ruangan.
+// Approval dan fulfillment order diproses role services, terpisah dari approval booking oleh GA.
+// Order yang masih berjalan otomatis cancelled jika bookingnya dibatalkan atau ditolak.
+type ServiceOrder struct {
+	Id                string       `json:"id"`
+	BookingDetailId   string       `json:"bookingDetailId"`
+	RoomId            string       `json:"roomId"`   // read-only
+	RoomName          string       `json:"roomName"` // read-only
+	Location          RoomLocation `json:"location"` // read-only, lokasi ruangan booking
+	RequestedBy       string       `json:"requestedBy"`
+	RequesterName     string       `json:"requesterName"` // read-only
+	ServiceType       string       `json:"serviceType"`   // coffee_break, snack, lunch, dinner, it_setup atau other
+	Quantity          int          `json:"quantity"`      // jumlah porsi atau orang
+	DeliverAt         time.Time    `json:"deliverAt"`
+	Notes             string       `json:"notes"`
+	ApprovalStatus    string       `json:"approvalStatus"`    // pending, approved, rejected atau cancelled
+	FulfillmentStatus string       `json:"fulfillmentStatus"` // pending, in_progress atau fulfilled
+	HandledBy         string       `json:"handledBy"`
+	ResponseNote      string       `json:"responseNote"`
+	CreatedAt         time.Time    `json:"createdAt"`
+	UpdatedAt         time.Time    `json:"updatedAt"`
+}
+
+func (s ServiceOrder) IsValidType() bool {
+	switch s.ServiceType {
+	case "coffee_break", "snack", "lunch", "dinner", "it_setup", "other":
+		return true
+	}
+	return false
+}
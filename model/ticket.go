@@ -0,0 +1,42 @@
+package model
+
+import "time"
+
+// RoomFeedback adalah penilaian pemesan terhadap ruangan setelah bookingnya selesai
+type RoomFeedback struct {
+	Id              string              `json:"id"`
+	BookingDetailId string              `json:"bookingDetailId"`
+	RoomId          string              `json:"roomId"`
+	UserId          string              `json:"userId"`
+	Rating          int                 `json:"rating"` // 1 sampai 5
+	Comment         string              `json:"comment"`
+	Tickets         []MaintenanceTicket `json:"tickets"` // tiket yang dibuka dari laporan masalah di feedback ini
+	CreatedAt       time.Time           `json:"createdAt"`
+}
+
+// MaintenanceTicket adalah laporan masalah ruangan, misalnya proyektor rusak atau ruangan kotor, yang ditangani GA.
+// Tiket critical yang belum resolved membuat ruangan berstatus maintenance dan tidak bisa dibooking.
+type MaintenanceTicket struct {
+	Id              string     `json:"id"`
+	RoomId          string     `json:"roomId"`
+	RoomName        string     `json:"roomName"`        // read-only
+	BookingDetailId string     `json:"bookingDetailId"` // kosong jika tiket tidak berasal dari feedback booking
+	ReportedBy      string     `json:"reportedBy"`
+	Category        string     `json:"category"`
+	Severity        string     `json:"severity"` // low, medium, high atau critical
+	Description     string     `json:"description"`
+	Status          string     `json:"status"`     // open, assigned atau resolved
+	AssignedTo      string     `json:"assignedTo"` // user id staf GA
+	Resolution      string     `json:"resolution"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
+	ResolvedAt      *time.Time `json:"resolvedAt"`
+}
+
+func (t MaintenanceTicket) IsValidSeverity() bool {
+	switch t.Severity {
+	case "low", "medium", "high", "critical":
+		return true
+	}
+	return false
+}
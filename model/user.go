@@ -15,7 +15,7 @@ type User struct {
 }
 
 func (u User) IsValidRole() bool {
-	return u.Role == "admin" || u.Role == "employee" || u.Role == "GA"
+	return u.Role == "admin" || u.Role == "employee" || u.Role == "GA" || u.Role == "services"
 }
 
 func (u User) IsEmpty() bool {
@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// Visitor adalah tamu eksternal yang didaftarkan pemesan ke booking ruangan dan check-in di resepsionis.
+// Data visitor dihapus setelah masa retensi lewat.
+type Visitor struct {
+	Id              string       `json:"id"`
+	BookingDetailId string       `json:"bookingDetailId"`
+	RoomId          string       `json:"roomId"`   // read-only
+	RoomName        string       `json:"roomName"` // read-only
+	Location        RoomLocation `json:"location"` // read-only, lokasi ruangan booking
+	RegisteredBy    string       `json:"registeredBy"`
+	HostName        string       `json:"hostName"` // read-only, nama pemesan
+	Name            string       `json:"name"`
+	Company         string       `json:"company"`
+	Email           string       `json:"email"`
+	ExpectedArrival time.Time    `json:"expectedArrival"`
+	Status          string       `json:"status"` // expected, checked_in atau checked_out
+	CheckedInAt     *time.Time   `json:"checkedInAt"`
+	CheckedOutAt    *time.Time   `json:"checkedOutAt"`
+	Notified        bool         `json:"notified"` // hanya diisi saat didaftarkan, true jika email instruksi terkirim
+	CreatedAt       time.Time    `json:"createdAt"`
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"final-project-booking-room/model"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type AmenityRepository interface {
+	Create(ctx context.Context, payload model.Amenity) (model.Amenity, error)
+	Get(ctx context.Context, id string) (model.Amenity, error)
+	GetAll(ctx context.Context) ([]model.Amenity, error)
+	Update(ctx context.Context, id string, payload model.Amenity) (model.Amenity, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type amenityRepository struct {
+	db *sql.DB
+}
+
+func (a *amenityRepository) Create(ctx context.Context, payload model.Amenity) (model.Amenity, error) {
+	var amenity model.Amenity
+	err := a.db.QueryRowContext(ctx, `INSERT INTO amenities (code, name, icon, type, updatedat) VALUES ($1, $2, $3, $4, $5) RETURNING id, code, name, icon, type, createdat, updatedat`,
+		payload.Code, payload.Name, payload.Icon, payload.Type, time.Now()).Scan(
+		&amenity.Id, &amenity.Code, &amenity.Name, &amenity.Icon, &amenity.Type, &amenity.CreatedAt, &amenity.UpdatedAt)
+	if err != nil {
+		return model.Amenity{}, err
+	}
+	return amenity, nil
+}
+
+func (a *amenityRepository) Get(ctx context.Context, id string) (model.Amenity, error) {
+	var amenity model.Amenity
+	err := a.db.QueryRowContext(ctx, `SELECT id, code, name, icon, type, createdat, updatedat FROM amenities WHERE id = $1`, id).Scan(
+		&amenity.Id, &amenity.Code, &amenity.Name, &amenity.Icon, &amenity.Type, &amenity.CreatedAt, &amenity.UpdatedAt)
+	if err != nil {
+		return model.Amenity{}, err
+	}
+	return amenity, nil
+}
+
+func (a *amenityRepository) GetAll(ctx context.Context) ([]model.Amenity, error) {
+	rows, err := a.db.QueryContext(ctx, `SELECT id, code, name, icon, type, createdat, updatedat FROM amenities ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	amenities := []model.Amenity{}
+	for rows.Next() {
+		var amenity model.Amenity
+		err := rows.Scan(&amenity.Id, &amenity.Code, &amenity.Name, &amenity.Icon, &amenity.Type, &amenity.CreatedAt, &amenity.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		amenities = append(amenities, amenity)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return amenities, nil
+}
+
+func (a *amenityRepository) Update(ctx context.Context, id string, payload model.Amenity) (model.Amenity, error) {
+	var amenity model.Amenity
+	err := a.db.QueryRowContext(ctx, `UPDATE amenities SET code = $1, name = $2, icon = $3, type = $4, updatedat = $5 WHERE id = $6 RETURNING id, code, name, icon, type, createdat, updatedat`,
+		payload.Code, payload.Name, payload.Icon, payload.Type, time.Now(), id).Scan(
+		&amenity.Id, &amenity.Code, &amenity.Name, &amenity.Icon, &amenity.Type, &amenity.CreatedAt, &amenity.UpdatedAt)
+	if err != nil {
+		return model.Amenity{}, err
+	}
+	return amenity, nil
+}
+
+// Delete hanya menghapus amenity yang sudah tidak dipakai ruangan manapun
+func (a *amenityRepository) Delete(ctx context.Context, id string) error {
+	result, err := a.db.ExecContext(ctx, `DELETE FROM amenities WHERE id = $1 AND NOT EXISTS (SELECT 1 FROM room_amenities WHERE amenityid = $1)`, id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.New("amenity is still used by rooms")
+	}
+	return nil
+}
+
+// queryer dipenuhi oleh *sql.DB maupun *sql.Tx
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// getRoomAmenities mengambil amenity untuk banyak ruangan dalam satu query, dikelompokkan per room id
+func getRoomAmenities(ctx context.Context, q queryer, roomIds []string) (map[string][]model.RoomAmenity, error) {
+	roomAmenities := make(map[string][]model.RoomAmenity)
+	if len(roomIds) == 0 {
+		return roomAmenities, nil
+	}
+
+	rows, err := q.QueryContext(ctx, `SELECT ra.roomid, a.id, a.code, a.name, a.icon, a.type, ra.quantity
+	FROM room_amenities ra JOIN amenities a ON a.id = ra.amenityid
+	WHERE ra.roomid = ANY($1) ORDER BY a.name`, pq.Array(roomIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var roomId string
+		var amenity model.RoomAmenity
+		err := rows.Scan(&roomId, &amenity.Id, &amenity.Code, &amenity.Name, &amenity.Icon, &amenity.Type, &amenity.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		amenity.Available = amenity.Quantity > 0
+		roomAmenities[roomId] = append(roomAmenities[roomId], amenity)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return roomAmenities, nil
+}
+
+func NewAmenityRepository(db *sql.DB) AmenityRepository {
+	return &amenityRepository{db: db}
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"final-project-booking-room/model"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type AmenityRepositoryTestSuite struct {
+	suite.Suite
+	mockDB  *sql.DB
+	mockSql sqlmock.Sqlmock
+	repo    AmenityRepository
+}
+
+func (suite *AmenityRepositoryTestSuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	assert.NoError(suite.T(), err)
+	suite.mockDB = db
+	suite.mockSql = mock
+	suite.repo = NewAmenityRepository(suite.mockDB)
+}
+
+func TestAmenityRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(AmenityRepositoryTestSuite))
+}
+
+var amenityColumns = []string{"id", "code", "name", "icon", "type", "createdat", "updatedat"}
+
+func (suite *AmenityRepositoryTestSuite) TestCreate_Success() {
+	mockAmenity := model.Amenity{Id: "1", Code: "whiteboard", Name: "Whiteboard", Icon: "board", Type: "boolean"}
+
+	suite.mockSql.ExpectQuery("INSERT INTO amenities").
+		WithArgs(mockAmenity.Code, mockAmenity.Name, mockAmenity.Icon, mockAmenity.Type, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows(amenityColumns).AddRow(mockAmenity.Id, mockAmenity.Code, mockAmenity.Name, mockAmenity.Icon, mockAmenity.Type, time.Time{}, time.Time{}))
+
+	actual, err := suite.repo.Create(context.Background(), mockAmenity)
+
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), mockAmenity, actual)
+}
+
+func (suite *AmenityRepositoryTestSuite) TestGetAll_Success() {
+	suite.mockSql.ExpectQuery("SELECT id, code, name, icon, type, createdat, updatedat FROM amenities ORDER BY name").
+		WillReturnRows(sqlmock.NewRows(amenityColumns).
+			AddRow("1", "ac", "AC", "ac", "boolean", time.Time{}, time.Time{}).
+			AddRow("2", "chairs", "Chairs", "chair", "quantity", time.Time{}, time.Time{}))
+
+	actual, err := suite.repo.GetAll(context.Background())
+
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), actual, 2)
+}
+
+func (suite *AmenityRepositoryTestSuite) TestDelete_StillUsed() {
+	suite.mockSql.ExpectExec(`DELETE FROM amenities WHERE id = \$1 AND NOT EXISTS`).
+		WithArgs("1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := suite.repo.Delete(context.Background(), "1")
+
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+	assert.EqualError(suite.T(), err, "amenity is still used by rooms")
+}
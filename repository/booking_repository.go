@@ -1,23 +1,44 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"final-project-booking-room/model"
+	"final-project-booking-room/model/dto"
 	"final-project-booking-room/utils/common"
+	"strings"
 
 	"fmt"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 type BookingRepository interface {
-	Create(payload model.Booking, userId string) (model.Booking, error)
-	Get(id string, userId string, roleUser string) (model.Booking, error)
-	GetAll() ([]model.Booking, error)
-	GetAllByStatus(status string) ([]model.Booking, error)
-	UpdateStatus(id string, approval string) (model.Booking, error)
-	GetBookStatus(id string) (string, error)
-	GetBookingDetailsByBookingID(bookingID string) ([]model.BookingDetail, error)
-	GetReport(requestJSON string) ([]model.Booking, error)
+	Create(ctx context.Context, payload model.Booking, userId string) (model.Booking, error)
+	Get(ctx context.Context, id string, userId string, roleUser string) (model.Booking, error)
+	GetAll(ctx context.Context) ([]model.Booking, error)
+	GetAllByStatus(ctx context.Context, status string) ([]model.Booking, error)
+	UpdateStatus(ctx context.Context, id string, approval string) (model.Booking, error)
+	GetBookStatus(ctx context.Context, id string) (string, error)
+	GetBookingDetailsByBookingID(ctx context.Context, bookingID string) ([]model.BookingDetail, error)
+	GetReport(ctx context.Context, requestJSON string) ([]model.Booking, error)
+	GetAllByUser(ctx context.Context, userId string, filter dto.BookingFilter) ([]model.Booking, dto.Paging, error)
+	Search(ctx context.Context, filter dto.BookingFilter) ([]model.Booking, dto.Paging, error)
+	GetCalendar(ctx context.Context, filter dto.CalendarFilter) ([]dto.CalendarRoom, error)
+	GetUtilization(ctx context.Context, filter dto.UtilizationFilter) ([]dto.UtilizationRoom, error)
+	CheckIn(ctx context.Context, id string, userId string, at time.Time) error
+	SetConference(ctx context.Context, id string, conferenceId string, conferenceURL string) error
+	NextInviteSequence(ctx context.Context, id string) (int, error)
+	GetParticipantEmails(ctx context.Context, bookingId string) ([]string, error)
+}
+
+// kolom sorting yang boleh dipakai dari query param sortBy
+var bookingSortColumns = map[string]string{
+	"bookingDate": "MIN(bd.bookingdate)",
+	"createdAt":   "b.createdat",
+	"updatedAt":   "b.updatedat",
 }
 
 type bookingRepository struct {
@@ -25,60 +46,96 @@ type bookingRepository struct {
 }
 
 // GetBookStatus implements BookingRepository.
-func (b *bookingRepository) GetBookStatus(id string) (string, error) {
+func (b *bookingRepository) GetBookStatus(ctx context.Context, id string) (string, error) {
 	var status string
-	err := b.db.QueryRow("SELECT status FROM booking_details WHERE id = $1", id).Scan(&status)
+	err := b.db.QueryRowContext(ctx, "SELECT status FROM booking_details WHERE id = $1", id).Scan(&status)
 	if err != nil {
 		return "", fmt.Errorf("ID booking details %s is not found", id)
 	}
 	return status, nil
 }
 
-func (b *bookingRepository) GetReport(requestJSON string) ([]model.Booking, error) {
+func (b *bookingRepository) GetReport(ctx context.Context, requestJSON string) ([]model.Booking, error) {
 	var result []model.Booking
-	_, err := b.db.Exec(common.DownloadReport, result)
+	_, err := b.db.ExecContext(ctx, common.DownloadReport, result)
 	if err != nil {
 		return result, err
 	}
 	return result, nil
 }
 
+// ErrBookingConflict dikembalikan UpdateStatus jika booking yang di-accept beririsan dengan booking accept lain
+// di ruangan yang sama, ruangan gabungan atau bagiannya
+var ErrBookingConflict = errors.New("room is already booked for the requested time")
+
 // UpdateStatus implements BookingRepository.
-func (b *bookingRepository) UpdateStatus(id string, approval string) (model.Booking, error) {
+func (b *bookingRepository) UpdateStatus(ctx context.Context, id string, approval string) (model.Booking, error) {
 	var booking model.Booking
 
 	// Memulai transaksi
-	tx, err := b.db.Begin()
+	tx, err := b.db.BeginTx(ctx, nil)
 	if err != nil {
 		return model.Booking{}, err
 	}
 
 	// Update status booking_details
 	var bookingId, roomId string
-	err = tx.QueryRow(`UPDATE booking_details SET status = $1
-	WHERE id = $2 RETURNING bookingid, roomid`, approval, id).Scan(&bookingId, &roomId)
+	err = tx.QueryRowContext(ctx, `UPDATE booking_details SET status = $1
+	WHERE id = $2 AND resourcetype = 'room' RETURNING bookingid, roomid`, approval, id).Scan(&bookingId, &roomId)
 	if err != nil {
 		tx.Rollback()
 		return model.Booking{}, err
 	}
 
+	// request pending tidak ikut cek bentrok saat dibuat, jadi rentang terpakai dicek lagi saat accept
+	if approval == "accept" {
+		var conflicts int
+		err = tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM booking_details bd JOIN booking_details me ON me.id = $1
+		WHERE bd.roomid IN (`+linkedRoomsOf("$2::uuid")+`) AND bd.id <> me.id
+		AND bd.status = 'accept' AND bd.blockedfrom < me.blockeduntil AND me.blockedfrom < bd.blockeduntil`, id, roomId).Scan(&conflicts)
+		if err != nil {
+			tx.Rollback()
+			return model.Booking{}, err
+		}
+		if conflicts > 0 {
+			tx.Rollback()
+			return model.Booking{}, fmt.Errorf("%w in room %s or a combined or partial room", ErrBookingConflict, roomId)
+		}
+	}
+
+	// approval juga mencakup equipment: stok harus cukup bersama booking lain yang sudah accept
+	if approval == "accept" {
+		if err := checkApprovedEquipment(ctx, tx, id); err != nil {
+			tx.Rollback()
+			return model.Booking{}, err
+		}
+	}
+
 	// Update status rooms based on approval
 	status := "available"
 	if approval == "accept" {
 		status = "booked"
 	}
-	_, err = tx.Query(`UPDATE rooms SET status = $1 WHERE id = $2`, status, roomId)
+	_, err = tx.ExecContext(ctx, `UPDATE rooms SET status = $1 WHERE id = $2`, status, roomId)
 	if err != nil {
 		tx.Rollback()
 		return model.Booking{}, err
 	}
 
+	// order layanan booking yang ditolak ikut dibatalkan
+	if approval == "decline" {
+		if err := cancelOpenServiceOrders(ctx, tx, []string{id}, time.Now().UTC()); err != nil {
+			tx.Rollback()
+			return model.Booking{}, err
+		}
+	}
+
 	// Commit transaksi
 	if err := tx.Commit(); err != nil {
 		return model.Booking{}, err
 	}
 
-	err = b.db.QueryRow(`
+	err = b.db.QueryRowContext(ctx, `
 		SELECT b.id, u.id, u.name, u.divisi, u.jabatan, u.email, u.role, u.createdat, u.updatedat, b.createdat, b.updatedat 
 		FROM booking b 
 		JOIN users u ON u.id = b.userid
@@ -101,7 +158,7 @@ func (b *bookingRepository) UpdateStatus(id string, approval string) (model.Book
 	}
 
 	// Menggunakan getBookingDetailsByBookingID untuk mendapatkan data booking details
-	bookingDetails, err := b.GetBookingDetailsByBookingID(bookingId)
+	bookingDetails, err := b.GetBookingDetailsByBookingID(ctx, bookingId)
 	if err != nil {
 		return model.Booking{}, err
 	}
@@ -112,12 +169,12 @@ func (b *bookingRepository) UpdateStatus(id string, approval string) (model.Book
 }
 
 // GetAllByStatus implements BookingRepository.
-func (b *bookingRepository) GetAllByStatus(status string) ([]model.Booking, error) {
+func (b *bookingRepository) GetAllByStatus(ctx context.Context, status string) ([]model.Booking, error) {
 	var bookings []model.Booking
 
-	rows, err := b.db.Query(`SELECT b.id, u.id, u.name, u.divisi, u.jabatan, u.email, u.role, u.createdat, u.updatedat, b.createdat, b.updatedat 
+	rows, err := b.db.QueryContext(ctx, `SELECT DISTINCT b.id, u.id, u.name, u.divisi, u.jabatan, u.email, u.role, u.createdat, u.updatedat, b.createdat, b.updatedat 
 	FROM 
-	booking b JOIN users u ON u.id = b.userid JOIN booking_details bd ON bd.bookingid = b.id WHERE status = $1`, status)
+	booking b JOIN users u ON u.id = b.userid JOIN booking_details bd ON bd.bookingid = b.id WHERE bd.status = $1 AND bd.resourcetype = 'room'`, status)
 
 	if err != nil {
 		return nil, fmt.Errorf("can't find data with status : %s", status)
@@ -144,15 +201,16 @@ func (b *bookingRepository) GetAllByStatus(status string) ([]model.Booking, erro
 			return nil, err
 		}
 
-		// Ambil data booking_details untuk setiap booking
-		bookingDetails, err := b.GetBookingDetailsByBookingID(booking.Id)
-		if err != nil {
-			return nil, err
-		}
-
-		booking.BookingDetails = bookingDetails
 		bookings = append(bookings, booking)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// booking_details untuk semua booking diambil dalam satu query, bukan satu query per booking
+	if err := b.attachBookingDetails(ctx, bookings, nil, nil); err != nil {
+		return nil, err
+	}
 
 	if len(bookings) == 0 {
 		return nil, fmt.Errorf("can't find data with status: %s", status)
@@ -161,12 +219,12 @@ func (b *bookingRepository) GetAllByStatus(status string) ([]model.Booking, erro
 }
 
 // GetAll implements BookingRepository.
-func (b *bookingRepository) GetAll() ([]model.Booking, error) {
+func (b *bookingRepository) GetAll(ctx context.Context) ([]model.Booking, error) {
 	var bookings []model.Booking
 
-	rows, err := b.db.Query(`SELECT b.id, u.id, u.name, u.divisi, u.jabatan, u.email, u.role, u.createdat, u.updatedat, b.createdat, b.updatedat 
+	rows, err := b.db.QueryContext(ctx, `SELECT b.id, u.id, u.name, u.divisi, u.jabatan, u.email, u.role, u.createdat, u.updatedat, b.createdat, b.updatedat 
 	FROM 
-	booking b JOIN users u ON u.id = b.userid`)
+	booking b JOIN users u ON u.id = b.userid WHERE `+roomBookingOnly)
 
 	if err != nil {
 		return nil, err
@@ -193,26 +251,28 @@ func (b *bookingRepository) GetAll() ([]model.Booking, error) {
 			return nil, err
 		}
 
-		// Ambil data booking_details untuk setiap booking
-		bookingDetails, err := b.GetBookingDetailsByBookingID(booking.Id)
-		if err != nil {
-			return nil, err
-		}
-
-		booking.BookingDetails = bookingDetails
 		bookings = append(bookings, booking)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// booking_details untuk semua booking diambil dalam satu query, bukan satu query per booking
+	if err := b.attachBookingDetails(ctx, bookings, nil, nil); err != nil {
+		return nil, err
+	}
 
 	return bookings, nil
 }
 
-func (b *bookingRepository) GetBookingDetailsByBookingID(bookingID string) ([]model.BookingDetail, error) {
+func (b *bookingRepository) GetBookingDetailsByBookingID(ctx context.Context, bookingID string) ([]model.BookingDetail, error) {
 	var bookingDetails []model.BookingDetail
 
-	rows, err := b.db.Query(`SELECT bd.id, bd.bookingdate, bd.bookingdateend, bd.status, bd.description, bd.createdat, bd.updatedat, r.id, r.roomtype, r.capacity, r.status, r.createdat, r.updatedat, f.id, f.roomdescription, f.fwifi, f.fsoundsystem, f.fprojector, f.fchairs, f.ftables, f.fsoundproof, f.fsmonkingarea, f.ftelevison, f.fac, f.fbathroom, f.fcoffemaker, f.createdat, f.updatedat
+	rows, err := b.db.QueryContext(ctx, `SELECT bd.id, bd.bookingdate, bd.bookingdateend, bd.status, bd.description, bd.createdat, bd.updatedat, r.id, r.roomtype, r.capacity, r.status, r.createdat, r.updatedat, f.id, f.roomdescription, f.fwifi, f.fsoundsystem, f.fprojector, f.fscreenprojector, f.fchairs, f.ftables, f.fsoundproof, f.fsmonkingarea, f.ftelevison, f.fac, f.fbathroom, f.fcoffemaker, f.createdat, f.updatedat, `+roomLocationColumns+`, `+bookingLayoutColumns+`
 	FROM 
 	booking_details bd JOIN rooms r ON r.id = bd.roomid
-	JOIN facilities f ON f.id = r.facilities 
+	JOIN v_room_facilities f ON f.roomid = r.id
+	`+roomLocationJoins+` `+bookingLayoutJoin+`
 	WHERE bd.bookingid = $1`, bookingID)
 
 	if err != nil {
@@ -223,7 +283,7 @@ func (b *bookingRepository) GetBookingDetailsByBookingID(bookingID string) ([]mo
 
 	for rows.Next() {
 		var bookingDetail model.BookingDetail
-		err := rows.Scan(
+		dest := []any{
 			&bookingDetail.Id,
 			&bookingDetail.BookingDate,
 			&bookingDetail.BookingDateEnd,
@@ -242,6 +302,7 @@ func (b *bookingRepository) GetBookingDetailsByBookingID(bookingID string) ([]mo
 			&bookingDetail.Rooms.Facility.Fwifi,
 			&bookingDetail.Rooms.Facility.FsoundSystem,
 			&bookingDetail.Rooms.Facility.Fprojector,
+			&bookingDetail.Rooms.Facility.FscreenProjector,
 			&bookingDetail.Rooms.Facility.Fchairs,
 			&bookingDetail.Rooms.Facility.Ftables,
 			&bookingDetail.Rooms.Facility.FsoundProof,
@@ -250,29 +311,561 @@ func (b *bookingRepository) GetBookingDetailsByBookingID(bookingID string) ([]mo
 			&bookingDetail.Rooms.Facility.FAc,
 			&bookingDetail.Rooms.Facility.Fbathroom,
 			&bookingDetail.Rooms.Facility.FcoffeMaker,
-			&bookingDetail.Rooms.Facility.UpdatedAt,
 			&bookingDetail.Rooms.Facility.CreatedAt,
-		)
+			&bookingDetail.Rooms.Facility.UpdatedAt,
+		}
+		var layout layoutRow
+		dest = append(dest, roomLocationDest(&bookingDetail.Rooms)...)
+		err := rows.Scan(append(dest, layout.dest(&bookingDetail)...)...)
 		if err != nil {
 			return nil, err
 		}
+		bookingDetail.Rooms.FloorId = bookingDetail.Rooms.Location.FloorId
+		bookingDetail.Layout = layout.toLayout(bookingDetail.Rooms.Id)
 
 		bookingDetails = append(bookingDetails, bookingDetail)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := b.attachRoomAmenities(ctx, bookingDetails); err != nil {
+		return nil, err
+	}
+	if err := b.attachEquipment(ctx, bookingDetails); err != nil {
+		return nil, err
+	}
 
 	return bookingDetails, nil
 }
 
+// GetAllByUser implements BookingRepository.
+func (b *bookingRepository) GetAllByUser(ctx context.Context, userId string, filter dto.BookingFilter) ([]model.Booking, dto.Paging, error) {
+	filter.UserId = userId
+	return b.Search(ctx, filter)
+}
+
+// Search implements BookingRepository.
+func (b *bookingRepository) Search(ctx context.Context, filter dto.BookingFilter) ([]model.Booking, dto.Paging, error) {
+	conditions, args := bookingConditions(filter, 1)
+	detailConditions, detailArgs := bookingDetailConditions(filter, len(args)+1)
+	where := whereClause(append(conditions, detailConditions...))
+	args = append(args, detailArgs...)
+
+	// COUNT DISTINCT dan GROUP BY supaya booking dengan beberapa detail yang cocok tidak terhitung dobel
+	var totalRows int
+	err := b.db.QueryRowContext(ctx, `SELECT COUNT(DISTINCT b.id) FROM booking b JOIN users u ON u.id = b.userid JOIN booking_details bd ON bd.bookingid = b.id `+where, args...).Scan(&totalRows)
+	if err != nil {
+		return nil, dto.Paging{}, err
+	}
+
+	sortColumn, ok := bookingSortColumns[filter.SortBy]
+	if !ok {
+		sortColumn = bookingSortColumns["bookingDate"]
+	}
+	order := "DESC"
+	if strings.EqualFold(filter.Order, "asc") {
+		order = "ASC"
+	}
+
+	limit := fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	rows, err := b.db.QueryContext(ctx, `SELECT b.id, u.id, u.name, u.divisi, u.jabatan, u.email, u.role, u.createdat, u.updatedat, b.createdat, b.updatedat
+	FROM booking b JOIN users u ON u.id = b.userid JOIN booking_details bd ON bd.bookingid = b.id `+where+`
+	GROUP BY b.id, u.id ORDER BY `+sortColumn+` `+order+limit, append(args, filter.Size, (filter.Page-1)*filter.Size)...)
+	if err != nil {
+		return nil, dto.Paging{}, err
+	}
+	defer rows.Close()
+
+	bookings := []model.Booking{}
+	for rows.Next() {
+		var booking model.Booking
+		err := rows.Scan(
+			&booking.Id,
+			&booking.Users.Id,
+			&booking.Users.Name,
+			&booking.Users.Divisi,
+			&booking.Users.Jabatan,
+			&booking.Users.Email,
+			&booking.Users.Role,
+			&booking.Users.CreatedAt,
+			&booking.Users.UpdatedAt,
+			&booking.CreatedAt,
+			&booking.UpdatedAt,
+		)
+		if err != nil {
+			return nil, dto.Paging{}, err
+		}
+		bookings = append(bookings, booking)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, dto.Paging{}, err
+	}
+
+	// booking details yang ditampilkan hanya yang lolos filter
+	detailConditions, detailArgs = bookingDetailConditions(filter, 2)
+	if err := b.attachBookingDetails(ctx, bookings, detailConditions, detailArgs); err != nil {
+		return nil, dto.Paging{}, err
+	}
+
+	paging := dto.Paging{
+		Page:       filter.Page,
+		Size:       filter.Size,
+		TotalRows:  totalRows,
+		TotalPages: (totalRows + filter.Size - 1) / filter.Size,
+	}
+	return bookings, paging, nil
+}
+
+// GetCalendar mengambil ruangan yang diminta beserta booking pending dan accept yang beririsan dengan filter.From-filter.Until.
+// Ruangan tanpa booking tetap dikembalikan supaya baris kalendernya tetap tampil.
+func (b *bookingRepository) GetCalendar(ctx context.Context, filter dto.CalendarFilter) ([]dto.CalendarRoom, error) {
+	var conditions []string
+	var args []any
+	conditions = append(conditions, "r.archivedat IS NULL")
+	if len(filter.RoomIds) > 0 {
+		conditions = append(conditions, "r.id = ANY($1)")
+		args = append(args, pq.Array(filter.RoomIds))
+	}
+	locConditions, locArgs := locationConditions(filter.Location, len(args)+1)
+	conditions = append(conditions, locConditions...)
+	args = append(args, locArgs...)
+
+	rows, err := b.db.QueryContext(ctx, `SELECT r.id, COALESCE(r.name, r.roomtype), COALESCE(NULLIF(bg.timezone, ''), s.timezone, '')
+	FROM rooms r `+roomLocationJoins+` `+whereClause(conditions)+` ORDER BY COALESCE(r.name, r.roomtype), r.id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rooms := []dto.CalendarRoom{}
+	roomIndex := make(map[string]int)
+	var roomIds []string
+	for rows.Next() {
+		var room dto.CalendarRoom
+		if err := rows.Scan(&room.RoomId, &room.RoomName, &room.Timezone); err != nil {
+			return nil, err
+		}
+		roomIndex[room.RoomId] = len(rooms)
+		rooms = append(rooms, room)
+		roomIds = append(roomIds, room.RoomId)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(rooms) == 0 {
+		return rooms, nil
+	}
+
+	// memakai index booking_details(roomid, bookingdate)
+	bookingRows, err := b.db.QueryContext(ctx, `SELECT bd.roomid, b.id, bd.id, u.id, u.name, u.divisi, bd.status, bd.description,
+	bd.bookingdate, bd.bookingdateend, COALESCE(bd.blockedfrom, bd.bookingdate), COALESCE(bd.blockeduntil, bd.bookingdateend)
+	FROM booking_details bd JOIN booking b ON b.id = bd.bookingid JOIN users u ON u.id = b.userid
+	WHERE bd.roomid = ANY($1) AND bd.status IN ('pending', 'accept') AND bd.bookingdate < $3 AND $2 < bd.bookingdateend
+	ORDER BY bd.bookingdate, bd.id`, pq.Array(roomIds), filter.From, filter.Until)
+	if err != nil {
+		return nil, err
+	}
+	defer bookingRows.Close()
+
+	for bookingRows.Next() {
+		var roomId string
+		booking := dto.CalendarBooking{Owner: &dto.CalendarOwner{}}
+		err := bookingRows.Scan(&roomId, &booking.BookingId, &booking.BookingDetailId, &booking.Owner.Id, &booking.Owner.Name, &booking.Owner.Divisi,
+			&booking.Status, &booking.Description, &booking.Start, &booking.End, &booking.BlockedFrom, &booking.BlockedUntil)
+		if err != nil {
+			return nil, err
+		}
+		i := roomIndex[roomId]
+		rooms[i].Bookings = append(rooms[i].Bookings, booking)
+	}
+	if err := bookingRows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rooms, nil
+}
+
+// GetUtilization mengambil ruangan yang belum diarsipkan sebelum filter.From beserta semua booking (status apa pun)
+// yang dimulai di rentang filter.From-filter.Until, bahan perhitungan analytics utilisasi.
+func (b *bookingRepository) GetUtilization(ctx context.Context, filter dto.UtilizationFilter) ([]dto.UtilizationRoom, error) {
+	conditions := []string{"(r.archivedat IS NULL OR r.archivedat > $1)"}
+	args := []any{filter.From}
+	if filter.RoomType != "" {
+		conditions = append(conditions, fmt.Sprintf("r.roomtype = $%d", len(args)+1))
+		args = append(args, filter.RoomType)
+	}
+	locConditions, locArgs := locationConditions(filter.Location, len(args)+1)
+	conditions = append(conditions, locConditions...)
+	args = append(args, locArgs...)
+
+	rows, err := b.db.QueryContext(ctx, `SELECT r.id, COALESCE(r.name, r.roomtype), r.roomtype, COALESCE(bg.id::text, ''), COALESCE(bg.name, ''),
+	COALESCE(NULLIF(bg.timezone, ''), s.timezone, ''), r.capacity
+	FROM rooms r `+roomLocationJoins+` `+whereClause(conditions)+` ORDER BY COALESCE(r.name, r.roomtype), r.id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rooms := []dto.UtilizationRoom{}
+	roomIndex := make(map[string]int)
+	var roomIds []string
+	for rows.Next() {
+		var room dto.UtilizationRoom
+		if err := rows.Scan(&room.RoomId, &room.RoomName, &room.RoomType, &room.BuildingId, &room.BuildingName, &room.Timezone, &room.Capacity); err != nil {
+			return nil, err
+		}
+		roomIndex[room.RoomId] = len(rooms)
+		rooms = append(rooms, room)
+		roomIds = append(roomIds, room.RoomId)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(rooms) == 0 {
+		return rooms, nil
+	}
+
+	// memakai index booking_details(roomid, bookingdate)
+	bookingRows, err := b.db.QueryContext(ctx, `SELECT bd.roomid, COALESCE(u.divisi, ''), bd.status, bd.attendees, bd.bookingdate, bd.bookingdateend, bd.checkedinat IS NOT NULL
+	FROM booking_details bd JOIN booking b ON b.id = bd.bookingid JOIN users u ON u.id = b.userid
+	WHERE bd.roomid = ANY($1) AND bd.bookingdate >= $2 AND bd.bookingdate < $3
+	ORDER BY bd.bookingdate, bd.id`, pq.Array(roomIds), filter.From, filter.Until)
+	if err != nil {
+		return nil, err
+	}
+	defer bookingRows.Close()
+
+	for bookingRows.Next() {
+		var roomId string
+		var booking dto.UtilizationBooking
+		err := bookingRows.Scan(&roomId, &booking.Division, &booking.Status, &booking.Attendees, &booking.Start, &booking.End, &booking.CheckedIn)
+		if err != nil {
+			return nil, err
+		}
+		i := roomIndex[roomId]
+		rooms[i].Bookings = append(rooms[i].Bookings, booking)
+	}
+	if err := bookingRows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rooms, nil
+}
+
+// ErrCheckInNotAllowed dikembalikan CheckIn jika booking bukan milik user, belum accept, sudah check-in atau tidak sedang berlangsung
+var ErrCheckInNotAllowed = errors.New("booking can't be checked in")
+
+// CheckIn menandai pemesan hadir. Check-in dibuka sejak awal setup layout sampai booking selesai.
+func (b *bookingRepository) CheckIn(ctx context.Context, id string, userId string, at time.Time) error {
+	result, err := b.db.ExecContext(ctx, `UPDATE booking_details bd SET checkedinat = $3, updatedat = $3 FROM booking b
+	WHERE b.id = bd.bookingid AND bd.id = $1 AND b.userid = $2 AND bd.status = 'accept' AND bd.checkedinat IS NULL
+	AND COALESCE(bd.blockedfrom, bd.bookingdate) <= $3 AND $3 < bd.bookingdateend`, id, userId, at)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrCheckInNotAllowed
+	}
+	return nil
+}
+
+// SetConference implements BookingRepository, menyimpan meeting video conference milik booking detail
+func (b *bookingRepository) SetConference(ctx context.Context, id string, conferenceId string, conferenceURL string) error {
+	_, err := b.db.ExecContext(ctx, `UPDATE booking_details SET conferenceid = $2, conferenceurl = $3 WHERE id = $1`, id, conferenceId, conferenceURL)
+	return err
+}
+
+// NextInviteSequence implements BookingRepository, mengembalikan SEQUENCE untuk invite yang akan dikirim lalu menaikkannya
+func (b *bookingRepository) NextInviteSequence(ctx context.Context, id string) (int, error) {
+	var sequence int
+	err := b.db.QueryRowContext(ctx, `UPDATE booking_details SET invitesequence = invitesequence + 1 WHERE id = $1 RETURNING invitesequence - 1`, id).Scan(&sequence)
+	return sequence, err
+}
+
+// GetParticipantEmails mengambil email attendee dan delegate booking untuk penerima konfirmasi dan invite
+func (b *bookingRepository) GetParticipantEmails(ctx context.Context, bookingId string) ([]string, error) {
+	rows, err := b.db.QueryContext(ctx, `SELECT u.email FROM booking_participants bp JOIN users u ON u.id = bp.userid WHERE bp.bookingid = $1 ORDER BY u.email`, bookingId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var emails []string
+	for rows.Next() {
+		var email string
+		if err := rows.Scan(&email); err != nil {
+			return nil, err
+		}
+		emails = append(emails, email)
+	}
+	return emails, rows.Err()
+}
+
+// attachBookingDetails mengisi BookingDetails dari setiap booking dengan satu query
+func (b *bookingRepository) attachBookingDetails(ctx context.Context, bookings []model.Booking, conditions []string, args []any) error {
+	if len(bookings) == 0 {
+		return nil
+	}
+
+	bookingIds := make([]string, len(bookings))
+	for i, booking := range bookings {
+		bookingIds[i] = booking.Id
+	}
+
+	details, err := b.getBookingDetailsByBookingIDs(ctx, bookingIds, conditions, args)
+	if err != nil {
+		return err
+	}
+	for i := range bookings {
+		bookings[i].BookingDetails = details[bookings[i].Id]
+	}
+	return nil
+}
+
+// getBookingDetailsByBookingIDs mengambil booking details untuk banyak booking dalam satu query,
+// hasilnya dikelompokkan per booking id
+func (b *bookingRepository) getBookingDetailsByBookingIDs(ctx context.Context, bookingIds []string, conditions []string, args []any) (map[string][]model.BookingDetail, error) {
+	where := whereClause(append([]string{"bd.bookingid = ANY($1)"}, conditions...))
+	rows, err := b.db.QueryContext(ctx, `SELECT bd.bookingid, bd.id, bd.bookingdate, bd.bookingdateend, bd.status, bd.description, bd.createdat, bd.updatedat, r.id, r.roomtype, r.capacity, r.status, r.createdat, r.updatedat, f.id, f.roomdescription, f.fwifi, f.fsoundsystem, f.fprojector, f.fscreenprojector, f.fchairs, f.ftables, f.fsoundproof, f.fsmonkingarea, f.ftelevison, f.fac, f.fbathroom, f.fcoffemaker, f.createdat, f.updatedat, `+roomLocationColumns+`, `+bookingLayoutColumns+`
+	FROM
+	booking_details bd JOIN rooms r ON r.id = bd.roomid
+	JOIN v_room_facilities f ON f.roomid = r.id
+	`+roomLocationJoins+` `+bookingLayoutJoin+`
+	`+where+` ORDER BY bd.bookingdate`, append([]any{pq.Array(bookingIds)}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bookingDetails := make(map[string][]model.BookingDetail)
+	for rows.Next() {
+		var bookingDetail model.BookingDetail
+		dest := []any{
+			&bookingDetail.BookingId,
+			&bookingDetail.Id,
+			&bookingDetail.BookingDate,
+			&bookingDetail.BookingDateEnd,
+			&bookingDetail.Status,
+			&bookingDetail.Description,
+			&bookingDetail.CreatedAt,
+			&bookingDetail.UpdatedAt,
+			&bookingDetail.Rooms.Id,
+			&bookingDetail.Rooms.RoomType,
+			&bookingDetail.Rooms.MaxCapacity,
+			&bookingDetail.Rooms.Status,
+			&bookingDetail.Rooms.CreatedAt,
+			&bookingDetail.Rooms.UpdatedAt,
+			&bookingDetail.Rooms.Facility.Id,
+			&bookingDetail.Rooms.Facility.RoomDescription,
+			&bookingDetail.Rooms.Facility.Fwifi,
+			&bookingDetail.Rooms.Facility.FsoundSystem,
+			&bookingDetail.Rooms.Facility.Fprojector,
+			&bookingDetail.Rooms.Facility.FscreenProjector,
+			&bookingDetail.Rooms.Facility.Fchairs,
+			&bookingDetail.Rooms.Facility.Ftables,
+			&bookingDetail.Rooms.Facility.FsoundProof,
+			&bookingDetail.Rooms.Facility.FsmonkingArea,
+			&bookingDetail.Rooms.Facility.Ftelevison,
+			&bookingDetail.Rooms.Facility.FAc,
+			&bookingDetail.Rooms.Facility.Fbathroom,
+			&bookingDetail.Rooms.Facility.FcoffeMaker,
+			&bookingDetail.Rooms.Facility.CreatedAt,
+			&bookingDetail.Rooms.Facility.UpdatedAt,
+		}
+		var layout layoutRow
+		dest = append(dest, roomLocationDest(&bookingDetail.Rooms)...)
+		err := rows.Scan(append(dest, layout.dest(&bookingDetail)...)...)
+		if err != nil {
+			return nil, err
+		}
+		bookingDetail.Rooms.FloorId = bookingDetail.Rooms.Location.FloorId
+		bookingDetail.Layout = layout.toLayout(bookingDetail.Rooms.Id)
+		bookingDetails[bookingDetail.BookingId] = append(bookingDetails[bookingDetail.BookingId], bookingDetail)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	groups := make([][]model.BookingDetail, 0, len(bookingDetails))
+	for _, details := range bookingDetails {
+		groups = append(groups, details)
+	}
+	if err := b.attachRoomAmenities(ctx, groups...); err != nil {
+		return nil, err
+	}
+	if err := b.attachEquipment(ctx, groups...); err != nil {
+		return nil, err
+	}
+
+	return bookingDetails, nil
+}
+
+// attachRoomAmenities mengisi amenity ruangan pada booking details dengan satu query
+func (b *bookingRepository) attachRoomAmenities(ctx context.Context, groups ...[]model.BookingDetail) error {
+	var roomIds []string
+	seen := make(map[string]bool)
+	for _, details := range groups {
+		for _, detail := range details {
+			if !seen[detail.Rooms.Id] {
+				seen[detail.Rooms.Id] = true
+				roomIds = append(roomIds, detail.Rooms.Id)
+			}
+		}
+	}
+
+	amenities, err := getRoomAmenities(ctx, b.db, roomIds)
+	if err != nil {
+		return err
+	}
+	for _, details := range groups {
+		for i := range details {
+			details[i].Rooms.Amenities = amenities[details[i].Rooms.Id]
+		}
+	}
+	return nil
+}
+
+// attachEquipment mengisi equipment yang dipesan pada booking details dengan satu query
+func (b *bookingRepository) attachEquipment(ctx context.Context, groups ...[]model.BookingDetail) error {
+	var detailIds []string
+	for _, details := range groups {
+		for _, detail := range details {
+			detailIds = append(detailIds, detail.Id)
+		}
+	}
+
+	equipment, err := getBookingEquipment(ctx, b.db, detailIds)
+	if err != nil {
+		return err
+	}
+	for _, details := range groups {
+		for i := range details {
+			details[i].Equipment = equipment[details[i].Id]
+		}
+	}
+	return nil
+}
+
+// participantOf cocok jika user pada placeholder %d diikutkan ke booking b sebagai attendee atau delegate
+const participantOf = `EXISTS (SELECT 1 FROM booking_participants bp WHERE bp.bookingid = b.id AND bp.userid = $%d)`
+
+// roomBookingOnly menyaring booking (alias b) desk, parkir dan phone booth dari endpoint booking ruangan
+const roomBookingOnly = `NOT EXISTS (SELECT 1 FROM booking_details rd WHERE rd.bookingid = b.id AND rd.resourcetype <> 'room')`
+
+// bookingConditions menyusun kondisi WHERE untuk booking (alias b) dan pemesannya (alias u)
+func bookingConditions(filter dto.BookingFilter, argIndex int) ([]string, []any) {
+	var conditions []string
+	var args []any
+
+	if filter.RequesterId != "" {
+		conditions = append(conditions, fmt.Sprintf("b.userid = $%d", argIndex+len(args)))
+		args = append(args, filter.RequesterId)
+	}
+	if filter.UserId != "" {
+		n := argIndex + len(args)
+		conditions = append(conditions, fmt.Sprintf("(b.userid = $%d OR "+participantOf+")", n, n))
+		args = append(args, filter.UserId)
+	}
+	if filter.Divisi != "" {
+		conditions = append(conditions, fmt.Sprintf("LOWER(u.divisi) = LOWER($%d)", argIndex+len(args)))
+		args = append(args, filter.Divisi)
+	}
+
+	return conditions, args
+}
+
+// bookingDetailConditions menyusun kondisi WHERE untuk booking_details (alias bd) dari filter,
+// placeholder dimulai dari nomor argIndex
+func bookingDetailConditions(filter dto.BookingFilter, argIndex int) ([]string, []any) {
+	conditions := []string{"bd.resourcetype = 'room'"}
+	var args []any
+
+	if filter.Status != "" {
+		conditions = append(conditions, fmt.Sprintf("bd.status = $%d", argIndex+len(args)))
+		args = append(args, filter.Status)
+	}
+	if filter.RoomId != "" {
+		conditions = append(conditions, fmt.Sprintf("bd.roomid = $%d", argIndex+len(args)))
+		args = append(args, filter.RoomId)
+	}
+	// booking yang beririsan dengan rentang tanggal, endDate dihitung sampai akhir hari
+	if !filter.StartDate.IsZero() {
+		conditions = append(conditions, fmt.Sprintf("bd.bookingdateend >= $%d", argIndex+len(args)))
+		args = append(args, filter.StartDate)
+	}
+	if !filter.EndDate.IsZero() {
+		conditions = append(conditions, fmt.Sprintf("bd.bookingdate < $%d", argIndex+len(args)))
+		args = append(args, filter.EndDate.AddDate(0, 0, 1))
+	}
+	if filter.Keyword != "" {
+		conditions = append(conditions, fmt.Sprintf(`bd.description ILIKE '%%' || $%d || '%%'`, argIndex+len(args)))
+		args = append(args, likeEscaper.Replace(filter.Keyword))
+	}
+	// filter lokasi lewat subquery ke ruangan supaya query booking tidak perlu join lokasi
+	if locConditions, locArgs := locationConditions(filter.Location, argIndex+len(args)); len(locConditions) > 0 {
+		conditions = append(conditions, "bd.roomid IN (SELECT r.id FROM rooms r "+roomLocationJoins+" "+whereClause(locConditions)+")")
+		args = append(args, locArgs...)
+	}
+
+	return conditions, args
+}
+
+// bookingLayoutColumns dan bookingLayoutJoin menambahkan peserta, rentang terpakai, link conference dan layout pada query booking details
+const bookingLayoutColumns = `bd.attendees, bd.blockedfrom, bd.blockeduntil, COALESCE(bd.conferenceurl, ''), l.id, l.name, l.capacity, l.setupminutes, l.teardownminutes`
+const bookingLayoutJoin = `LEFT JOIN room_layouts l ON l.id = bd.layoutid`
+
+// layoutRow menampung kolom layout yang bisa NULL karena LEFT JOIN
+type layoutRow struct {
+	id, name                                sql.NullString
+	capacity, setupMinutes, teardownMinutes sql.NullInt64
+}
+
+func (l *layoutRow) dest(detail *model.BookingDetail) []any {
+	return []any{&detail.Attendees, &detail.BlockedFrom, &detail.BlockedUntil, &detail.ConferenceURL, &l.id, &l.name, &l.capacity, &l.setupMinutes, &l.teardownMinutes}
+}
+
+func (l layoutRow) toLayout(roomId string) *model.RoomLayout {
+	if !l.id.Valid {
+		return nil
+	}
+	return &model.RoomLayout{
+		Id:              l.id.String,
+		RoomId:          roomId,
+		Name:            l.name.String,
+		Capacity:        int(l.capacity.Int64),
+		SetupMinutes:    int(l.setupMinutes.Int64),
+		TeardownMinutes: int(l.teardownMinutes.Int64),
+	}
+}
+
+// karakter wildcard LIKE dari input user di-escape supaya dicari apa adanya
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(conditions, " AND ")
+}
+
 // Create implements BookingRepository.
-func (b *bookingRepository) Create(payload model.Booking, userId string) (model.Booking, error) {
-	tx, err := b.db.Begin()
+func (b *bookingRepository) Create(ctx context.Context, payload model.Booking, userId string) (model.Booking, error) {
+	tx, err := b.db.BeginTx(ctx, nil)
 	fmt.Println(userId)
 	if err != nil {
 		return model.Booking{}, err
 	}
 
+	// simpan dalam UTC, kolom timestamptz menyimpan instant bukan jam lokal server
+	now := time.Now().UTC()
+
 	var booking model.Booking
-	err = tx.QueryRow(`INSERT INTO booking (userId, updatedAt) VALUES ($1,$2) RETURNING id,userId,createdAt, updatedAt`, userId, time.Now()).Scan(
+	err = tx.QueryRowContext(ctx, `INSERT INTO booking (userId, updatedAt) VALUES ($1,$2) RETURNING id,userId,createdAt, updatedAt`, userId, now).Scan(
 		&booking.Id,
 		&booking.Users.Id,
 		&booking.CreatedAt,
@@ -280,22 +873,49 @@ func (b *bookingRepository) Create(payload model.Booking, userId string) (model.
 	)
 
 	if err != nil {
-		return model.Booking{}, tx.Rollback()
+		tx.Rollback()
+		return model.Booking{}, err
+	}
+
+	for _, participant := range payload.Participants {
+		_, err = tx.ExecContext(ctx, `INSERT INTO booking_participants (bookingid, userid, role) VALUES ($1, $2, $3)`, booking.Id, participant.UserId, participant.Role)
+		if err != nil {
+			tx.Rollback()
+			return model.Booking{}, err
+		}
 	}
 
 	var bookingDetails []model.BookingDetail
 	for _, v := range payload.BookingDetails {
 		var bookingDetail model.BookingDetail
 
-		// convert booking date end, 3 hari setelah start
-		now := time.Now()
-		threeHours := 3 * time.Hour
-		threeHoursLater := now.Add(threeHours)
+		// jadwal dari request yang sudah divalidasi usecase
+		start, end := v.BookingDate.UTC(), v.BookingDateEnd.UTC()
 
 		// status awal booking : pending
 		bdStatus := "pending"
 
-		err = tx.QueryRow(`INSERT INTO booking_details (bookingid, roomid, bookingdate, bookingdateend, status, description, updatedat) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, bookingid, roomid, bookingdate, bookingdateend, status, description, createdat, updatedat`, booking.Id, v.Rooms.Id, time.Now(), threeHoursLater, bdStatus, v.Description, time.Now()).Scan(
+		// rentang terpakai ikut menghitung setup dan teardown layout yang dipilih
+		blockedFrom, blockedUntil := v.Layout.Buffer(start, end)
+		var layoutId sql.NullString
+		if v.Layout != nil {
+			layoutId = sql.NullString{String: v.Layout.Id, Valid: true}
+		}
+
+		// booking accept di ruangan yang sama, ruangan gabungan atau bagiannya yang beririsan dengan rentang terpakai
+		var conflicts int
+		err = tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM booking_details bd WHERE bd.roomid IN (`+linkedRoomsOf("$1::uuid")+`)
+		AND bd.status = 'accept' AND bd.blockedfrom < $3 AND $2 < bd.blockeduntil`, v.Rooms.Id, blockedFrom, blockedUntil).Scan(&conflicts)
+		if err != nil {
+			tx.Rollback()
+			return model.Booking{}, err
+		}
+		if conflicts > 0 {
+			tx.Rollback()
+			return model.Booking{}, fmt.Errorf("room with id %s is already booked or overlaps with a booking in a combined or partial room for the requested time", v.Rooms.Id)
+		}
+
+		err = tx.QueryRowContext(ctx, `INSERT INTO booking_details (bookingid, roomid, resourceid, bookingdate, bookingdateend, status, description, updatedat, layoutid, attendees, blockedfrom, blockeduntil) VALUES ($1, $2, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id, bookingid, roomid, bookingdate, bookingdateend, status, description, createdat, updatedat`, booking.Id, v.Rooms.Id, start, end, bdStatus, v.Description, now, layoutId, v.Attendees, blockedFrom, blockedUntil).Scan(
 			&bookingDetail.Id,
 			&bookingDetail.BookingId,
 			&bookingDetail.Rooms.Id,
@@ -308,16 +928,28 @@ func (b *bookingRepository) Create(payload model.Booking, userId string) (model.
 		)
 
 		if err != nil {
-			return model.Booking{}, tx.Rollback()
+			tx.Rollback()
+			return model.Booking{}, err
 		}
 
 		bookingDetail.Rooms = v.Rooms
+		bookingDetail.Layout = v.Layout
+		bookingDetail.Attendees = v.Attendees
+		bookingDetail.BlockedFrom = blockedFrom
+		bookingDetail.BlockedUntil = blockedUntil
+		bookingDetail.Equipment = v.Equipment
+
+		if err := reserveEquipment(ctx, tx, bookingDetail); err != nil {
+			tx.Rollback()
+			return model.Booking{}, err
+		}
 		bookingDetails = append(bookingDetails, bookingDetail)
 
 	}
 
 	booking.Users = payload.Users
 	booking.BookingDetails = bookingDetails
+	booking.Participants = payload.Participants
 
 	if err := tx.Commit(); err != nil {
 		return model.Booking{}, err
@@ -326,19 +958,19 @@ func (b *bookingRepository) Create(payload model.Booking, userId string) (model.
 }
 
 // Get implements BookingRepository.
-func (b *bookingRepository) Get(id string, userId string, roleUser string) (model.Booking, error) {
+func (b *bookingRepository) Get(ctx context.Context, id string, userId string, roleUser string) (model.Booking, error) {
 	var booking model.Booking
 	var isAdminRole string
 	if roleUser == "admin" || roleUser == "GA" {
 		isAdminRole = "b.id = $1 OR b.id = $2" // Harus begini karena kalo OR b.userid nanti ambil datanya salah tidak sesuai
 	} else {
-		isAdminRole = "b.id = $1 AND b.userid =$2"
+		isAdminRole = "b.id = $1 AND (b.userid = $2 OR " + fmt.Sprintf(participantOf, 2) + ")"
 	}
-	err := b.db.QueryRow(`
+	err := b.db.QueryRowContext(ctx, `
 		SELECT b.id, u.id, u.name, u.divisi, u.jabatan, u.email, u.role, u.createdat, u.updatedat, b.createdat, b.updatedat 
 		FROM booking b 
 		JOIN users u ON u.id = b.userid
-		WHERE `+isAdminRole, id, userId).Scan(
+		WHERE (`+isAdminRole+`) AND `+roomBookingOnly, id, userId).Scan(
 		&booking.Id,
 		&booking.Users.Id,
 		&booking.Users.Name,
@@ -357,7 +989,7 @@ func (b *bookingRepository) Get(id string, userId string, roleUser string) (mode
 	}
 
 	// Menggunakan getBookingDetailsByBookingID untuk mendapatkan data booking details
-	bookingDetails, err := b.GetBookingDetailsByBookingID(id)
+	bookingDetails, err := b.GetBookingDetailsByBookingID(ctx, id)
 	if err != nil {
 		return model.Booking{}, err
 	}
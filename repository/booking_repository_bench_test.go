@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"final-project-booking-room/model/dto"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+// Benchmark ini butuh Postgres sungguhan, contoh:
+//
+//	BENCH_DB_DSN="host=localhost port=5432 user=postgres password=12345 dbname=booking_room sslmode=disable" go test -bench=Booking -run=^$ ./repository
+//
+// Data di-seed ke schema booking_bench yang dihapus lagi setelah benchmark selesai.
+const (
+	benchUsers             = 200
+	benchRooms             = 50
+	benchBookings          = 5000
+	benchDetailsPerBooking = 3
+)
+
+func openBenchDB(b *testing.B) *sql.DB {
+	dsn := os.Getenv("BENCH_DB_DSN")
+	if dsn == "" {
+		b.Skip("BENCH_DB_DSN is not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		b.Fatal(err)
+	}
+	// satu koneksi supaya search_path berlaku untuk semua query
+	db.SetMaxOpenConns(1)
+
+	statements := []string{
+		`DROP SCHEMA IF EXISTS booking_bench CASCADE`,
+		`CREATE SCHEMA booking_bench`,
+		`SET search_path TO booking_bench`,
+		`CREATE TABLE users (id UUID PRIMARY KEY DEFAULT gen_random_uuid(), name VARCHAR(100), divisi VARCHAR(100), jabatan VARCHAR(100), email VARCHAR(100), password VARCHAR(100), role VARCHAR(100), createdat TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP, updatedat TIMESTAMPTZ)`,
+		`CREATE TABLE facilities (id UUID PRIMARY KEY DEFAULT gen_random_uuid(), roomdescription TEXT, createdat TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP, updatedat TIMESTAMPTZ)`,
+		`CREATE TABLE sites (id UUID PRIMARY KEY DEFAULT gen_random_uuid(), name VARCHAR(100), address TEXT NOT NULL DEFAULT '', timezone VARCHAR(100) NOT NULL DEFAULT '', createdat TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP, updatedat TIMESTAMPTZ)`,
+		`CREATE TABLE buildings (id UUID PRIMARY KEY DEFAULT gen_random_uuid(), siteid UUID REFERENCES sites(id), name VARCHAR(100), address TEXT NOT NULL DEFAULT '', timezone VARCHAR(100) NOT NULL DEFAULT '', createdat TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP, updatedat TIMESTAMPTZ)`,
+		`CREATE TABLE floors (id UUID PRIMARY KEY DEFAULT gen_random_uuid(), buildingid UUID REFERENCES buildings(id), name VARCHAR(100), level INT NOT NULL DEFAULT 0, createdat TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP, updatedat TIMESTAMPTZ)`,
+		`CREATE TABLE rooms (id UUID PRIMARY KEY DEFAULT gen_random_uuid(), name VARCHAR(100), code VARCHAR(50) UNIQUE, roomtype VARCHAR(100), capacity INT, facilities UUID REFERENCES facilities(id), floorid UUID REFERENCES floors(id), status VARCHAR(100), createdat TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP, updatedat TIMESTAMPTZ, archivedat TIMESTAMPTZ)`,
+		`CREATE TABLE room_combinations (combinedroomid UUID REFERENCES rooms(id), partroomid UUID REFERENCES rooms(id), PRIMARY KEY (combinedroomid, partroomid))`,
+		`CREATE TABLE room_access_rules (roomid UUID REFERENCES rooms(id), effect VARCHAR(10), field VARCHAR(20), value VARCHAR(100), PRIMARY KEY (roomid, effect, field, value))`,
+		`CREATE TABLE room_layouts (id UUID PRIMARY KEY DEFAULT gen_random_uuid(), roomid UUID REFERENCES rooms(id), name VARCHAR(100), capacity INT, setupminutes INT NOT NULL DEFAULT 0, teardownminutes INT NOT NULL DEFAULT 0, createdat TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP, updatedat TIMESTAMPTZ)`,
+		`CREATE TABLE amenities (id UUID PRIMARY KEY DEFAULT gen_random_uuid(), code VARCHAR(100) UNIQUE, name VARCHAR(100), icon VARCHAR(100), type VARCHAR(20), createdat TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP, updatedat TIMESTAMPTZ)`,
+		`CREATE TABLE room_amenities (roomid UUID REFERENCES rooms(id), amenityid UUID REFERENCES amenities(id), quantity INT NOT NULL DEFAULT 1, PRIMARY KEY (roomid, amenityid))`,
+		`CREATE VIEW v_room_facilities AS SELECT r.id AS roomid, f.id, f.roomdescription,
+			CASE WHEN bool_or(a.code = 'wifi') THEN 'ada' ELSE 'tidak' END AS fwifi,
+			CASE WHEN bool_or(a.code = 'sound_system') THEN 'ada' ELSE 'tidak' END AS fsoundsystem,
+			CASE WHEN bool_or(a.code = 'projector') THEN 'ada' ELSE 'tidak' END AS fprojector,
+			CASE WHEN bool_or(a.code = 'screen_projector') THEN 'ada' ELSE 'tidak' END AS fscreenprojector,
+			COALESCE(MAX(CASE WHEN a.code = 'chairs' THEN ra.quantity END)::TEXT, 'tidak') AS fchairs,
+			COALESCE(MAX(CASE WHEN a.code = 'tables' THEN ra.quantity END)::TEXT, 'tidak') AS ftables,
+			CASE WHEN bool_or(a.code = 'sound_proof') THEN 'ada' ELSE 'tidak' END AS fsoundproof,
+			CASE WHEN bool_or(a.code = 'smoking_area') THEN 'ada' ELSE 'tidak' END AS fsmonkingarea,
+			CASE WHEN bool_or(a.code = 'television') THEN 'ada' ELSE 'tidak' END AS ftelevison,
+			CASE WHEN bool_or(a.code = 'ac') THEN 'ada' ELSE 'tidak' END AS fac,
+			CASE WHEN bool_or(a.code = 'bathroom') THEN 'ada' ELSE 'tidak' END AS fbathroom,
+			CASE WHEN bool_or(a.code = 'coffee_maker') THEN 'ada' ELSE 'tidak' END AS fcoffemaker,
+			f.createdat, f.updatedat
+			FROM rooms r JOIN facilities f ON f.id = r.facilities
+			LEFT JOIN room_amenities ra ON ra.roomid = r.id AND ra.quantity > 0
+			LEFT JOIN amenities a ON a.id = ra.amenityid
+			GROUP BY r.id, f.id`,
+		`CREATE TABLE booking (id UUID PRIMARY KEY DEFAULT gen_random_uuid(), userid UUID REFERENCES users(id), createdat TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP, updatedat TIMESTAMPTZ)`,
+		`CREATE TABLE booking_details (id UUID PRIMARY KEY DEFAULT gen_random_uuid(), bookingid UUID REFERENCES booking(id), roomid UUID REFERENCES rooms(id), resourcetype VARCHAR(50) NOT NULL DEFAULT 'room', resourceid UUID, bookingdate TIMESTAMPTZ, bookingdateend TIMESTAMPTZ, status VARCHAR(100), description TEXT, createdat TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP, updatedat TIMESTAMPTZ, layoutid UUID REFERENCES room_layouts(id) ON DELETE SET NULL, attendees INT NOT NULL DEFAULT 0, blockedfrom TIMESTAMPTZ, blockeduntil TIMESTAMPTZ, checkedinat TIMESTAMPTZ, conferenceid VARCHAR(100), conferenceurl TEXT, invitesequence INT NOT NULL DEFAULT 0)`,
+		`CREATE TABLE equipment (id UUID PRIMARY KEY DEFAULT gen_random_uuid(), siteid UUID REFERENCES sites(id), code VARCHAR(100), name VARCHAR(100), quantity INT NOT NULL, createdat TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP, updatedat TIMESTAMPTZ, UNIQUE (siteid, code))`,
+		`CREATE TABLE booking_detail_equipment (bookingdetailid UUID REFERENCES booking_details(id), equipmentid UUID REFERENCES equipment(id), quantity INT NOT NULL, PRIMARY KEY (bookingdetailid, equipmentid))`,
+		`CREATE TABLE booking_participants (bookingid UUID REFERENCES booking(id), userid UUID REFERENCES users(id), role VARCHAR(20) NOT NULL, createdat TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP, PRIMARY KEY (bookingid, userid))`,
+		`CREATE INDEX ON booking(userid)`,
+		`CREATE INDEX ON booking_details(bookingid)`,
+		`CREATE INDEX ON booking_details(roomid, bookingdate)`,
+		fmt.Sprintf(`INSERT INTO users (name, divisi, jabatan, email, password, role, updatedat)
+			SELECT 'user ' || i, 'divisi ' || (i %% 8), 'staff', 'user' || i || '@mail.com', '-', 'employee', now()
+			FROM generate_series(1, %d) i`, benchUsers),
+		fmt.Sprintf(`INSERT INTO facilities (roomdescription, updatedat)
+			SELECT 'room ' || i, now()
+			FROM generate_series(1, %d) i`, benchRooms),
+		`INSERT INTO amenities (code, name, icon, type, updatedat) VALUES
+			('wifi', 'Wifi', 'wifi', 'boolean', now()), ('projector', 'Projector', 'projector', 'boolean', now()), ('chairs', 'Chairs', 'chair', 'quantity', now())`,
+		`INSERT INTO sites (name, timezone, updatedat) VALUES ('HQ', 'Asia/Jakarta', now())`,
+		`INSERT INTO buildings (siteid, name, updatedat) SELECT id, 'Tower A', now() FROM sites`,
+		`INSERT INTO floors (buildingid, name, level, updatedat) SELECT id, 'Lantai 1', 1, now() FROM buildings`,
+		`INSERT INTO rooms (name, roomtype, capacity, facilities, floorid, status, updatedat)
+			SELECT 'room ' || row_number() OVER (), 'meeting', 10, id, (SELECT id FROM floors LIMIT 1), 'available', now() FROM facilities`,
+		`INSERT INTO room_amenities (roomid, amenityid, quantity)
+			SELECT r.id, a.id, CASE WHEN a.type = 'quantity' THEN 10 ELSE 1 END FROM rooms r CROSS JOIN amenities a`,
+		fmt.Sprintf(`INSERT INTO booking (userid, updatedat)
+			SELECT (SELECT id FROM users ORDER BY random() + i LIMIT 1), now()
+			FROM generate_series(1, %d) i`, benchBookings),
+		fmt.Sprintf(`INSERT INTO booking_details (bookingid, roomid, bookingdate, bookingdateend, status, description, updatedat)
+			SELECT b.id, (SELECT id FROM rooms ORDER BY random() + n LIMIT 1), now() - (n || ' hours')::interval, now() - (n || ' hours')::interval + interval '2 hours',
+				(ARRAY['pending', 'accept', 'decline'])[1 + n %% 3], 'rapat mingguan ' || n, now()
+			FROM booking b CROSS JOIN generate_series(1, %d) n`, benchDetailsPerBooking),
+		`UPDATE booking_details SET blockedfrom = bookingdate, blockeduntil = bookingdateend, resourceid = roomid`,
+		`ANALYZE`,
+	}
+	for _, statement := range statements {
+		if _, err := db.Exec(statement); err != nil {
+			b.Fatalf("seeding benchmark data: %v", err)
+		}
+	}
+
+	b.Cleanup(func() {
+		db.Exec(`DROP SCHEMA IF EXISTS booking_bench CASCADE`)
+		db.Close()
+	})
+	return db
+}
+
+func BenchmarkBookingRepository_GetAll(b *testing.B) {
+	repo := NewBookingRepository(openBenchDB(b))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		bookings, err := repo.GetAll(context.Background())
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(bookings) != benchBookings {
+			b.Fatalf("expected %d bookings, got %d", benchBookings, len(bookings))
+		}
+	}
+}
+
+func BenchmarkBookingRepository_GetAllByStatus(b *testing.B) {
+	repo := NewBookingRepository(openBenchDB(b))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := repo.GetAllByStatus(context.Background(), "pending"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBookingRepository_GetCalendar(b *testing.B) {
+	repo := NewBookingRepository(openBenchDB(b))
+	// satu minggu untuk semua ruangan, seperti widget kalender mingguan
+	filter := dto.CalendarFilter{From: time.Now().AddDate(0, 0, -7), Until: time.Now()}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := repo.GetCalendar(context.Background(), filter); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBookingRepository_GetUtilization(b *testing.B) {
+	repo := NewBookingRepository(openBenchDB(b))
+	// satu bulan untuk semua ruangan, seperti laporan utilisasi bulanan
+	filter := dto.UtilizationFilter{From: time.Now().AddDate(0, -1, 0), Until: time.Now()}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := repo.GetUtilization(context.Background(), filter); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBookingRepository_Search(b *testing.B) {
+	repo := NewBookingRepository(openBenchDB(b))
+	filter := dto.BookingFilter{
+		Status:    "accept",
+		Keyword:   "mingguan",
+		StartDate: time.Now().AddDate(0, 0, -1),
+		Page:      1,
+		Size:      50,
+	}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, _, err := repo.Search(context.Background(), filter); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -1,13 +1,16 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"final-project-booking-room/model"
+	"final-project-booking-room/model/dto"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
@@ -31,6 +34,14 @@ func TestBillRepositoryTestSuite(t *testing.T) {
 	suite.Run(t, new(BookingRepositoryTestSuite))
 }
 
+var bookingDetailBatchColumns = []string{"bookingid", "id", "bookingdate", "bookingdateend", "status", "description", "createdat", "updatedat", "rooms.id", "rooms.roomtype", "rooms.capacity", "rooms.status", "rooms.createdat", "rooms.updatedat", "rooms.facility.id", "rooms.facility.roomdescription", "rooms.facility.fwifi", "rooms.facility.fsoundsystem", "rooms.facility.fprojector", "rooms.facility.fscreenprojector", "rooms.facility.fchairs", "rooms.facility.ftables", "rooms.facility.fsoundproof", "rooms.facility.fsmonkingarea", "rooms.facility.ftelevison", "rooms.facility.fac", "rooms.facility.fbathroom", "rooms.facility.fcoffemaker", "rooms.facility.createdat", "rooms.facility.updatedat", "rooms.name", "rooms.code", "rooms.location.floorid", "rooms.location.floorname", "rooms.location.floorlevel", "rooms.location.buildingid", "rooms.location.buildingname", "rooms.location.siteid", "rooms.location.sitename", "rooms.location.timezone", "attendees", "blockedfrom", "blockeduntil", "conferenceurl", "layout.id", "layout.name", "layout.capacity", "layout.setupminutes", "layout.teardownminutes"}
+
+// expectBookingEquipment menyiapkan query equipment untuk booking detail dengan id yang diberikan
+func expectBookingEquipment(mock sqlmock.Sqlmock, bookingDetailId string) {
+	mock.ExpectQuery(`FROM booking_detail_equipment bde JOIN equipment e`).WillReturnRows(
+		sqlmock.NewRows([]string{"bookingdetailid", "id", "name", "quantity"}).AddRow(bookingDetailId, "e1", "Projector", 1))
+}
+
 func (suite *BookingRepositoryTestSuite) TestGetBookStatus_Success() {
 	mockBookingDetail := model.BookingDetail{
 		Id:        "1",
@@ -46,7 +57,7 @@ func (suite *BookingRepositoryTestSuite) TestGetBookStatus_Success() {
 	rows := sqlmock.NewRows([]string{"status"}).AddRow(mockBookingDetail.Status)
 	suite.mockSql.ExpectQuery("SELECT status FROM booking_details").WillReturnRows(rows)
 
-	actual, err := suite.repo.GetBookStatus(mockBookingDetail.BookingId)
+	actual, err := suite.repo.GetBookStatus(context.Background(), mockBookingDetail.BookingId)
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), mockBookingDetail.Status, actual)
@@ -66,7 +77,7 @@ func (suite *BookingRepositoryTestSuite) TestGetBookStatus_Fail() {
 
 	suite.mockSql.ExpectQuery("SELECT status FROM booking_details").WillReturnError(errors.New("failed to get status"))
 
-	_, err := suite.repo.GetBookStatus(mockBookingDetail.BookingId)
+	_, err := suite.repo.GetBookStatus(context.Background(), mockBookingDetail.BookingId)
 	assert.Error(suite.T(), err)
 }
 
@@ -87,7 +98,9 @@ func (suite *BookingRepositoryTestSuite) TestCreateBooking_Success() {
 					RoomType: "ruang_santai",
 					Status:   "available",
 				},
-				Status: "pending",
+				Status:         "pending",
+				BookingDate:    time.Date(2026, 11, 2, 2, 0, 0, 0, time.UTC),
+				BookingDateEnd: time.Date(2026, 11, 2, 4, 30, 0, 0, time.UTC),
 			},
 		},
 		CreatedAt: time.Now(),
@@ -102,16 +115,73 @@ func (suite *BookingRepositoryTestSuite) TestCreateBooking_Success() {
 	for _, v := range mockBooking.BookingDetails {
 		rows := sqlmock.NewRows([]string{"id", "bookingid", "roomid", "bookingdate", "boookingdateend", "status", "description", "created_at", "updated_at"}).AddRow(v.Id, v.BookingId, v.Rooms.Id, v.BookingDate, v.BookingDateEnd, v.Status, v.Description, v.CreatedAt, v.UpdatedAt)
 
-		suite.mockSql.ExpectQuery("INSERT INTO booking_details").WillReturnRows(rows)
+		// tanpa layout rentang terpakai sama dengan jadwal request
+		suite.mockSql.ExpectQuery("SELECT COUNT").WithArgs(v.Rooms.Id, v.BookingDate, v.BookingDateEnd).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		suite.mockSql.ExpectQuery("INSERT INTO booking_details").
+			WithArgs(mockBooking.Id, v.Rooms.Id, v.BookingDate, v.BookingDateEnd, "pending", v.Description, sqlmock.AnyArg(), sqlmock.AnyArg(), v.Attendees, v.BookingDate, v.BookingDateEnd).
+			WillReturnRows(rows)
 
 		suite.mockSql.ExpectCommit()
-		actual, err := suite.repo.Create(mockBooking, mockBooking.Users.Id)
+		actual, err := suite.repo.Create(context.Background(), mockBooking, mockBooking.Users.Id)
 		assert.Nil(suite.T(), err)
 		assert.NoError(suite.T(), err)
 		assert.Equal(suite.T(), mockBooking.Id, actual.Id)
 	}
 }
 
+func (suite *BookingRepositoryTestSuite) TestCreateBooking_WithParticipants() {
+	start, end := time.Date(2026, 11, 2, 2, 0, 0, 0, time.UTC), time.Date(2026, 11, 2, 4, 0, 0, 0, time.UTC)
+	payload := model.Booking{
+		Users:          model.User{Id: "1"},
+		BookingDetails: []model.BookingDetail{{Rooms: model.Room{Id: "r1"}, BookingDate: start, BookingDateEnd: end}},
+		Participants:   []model.Participant{{UserId: "2", Role: model.ParticipantAttendee}, {UserId: "3", Role: model.ParticipantDelegate}},
+	}
+
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery("INSERT INTO booking ").WillReturnRows(sqlmock.NewRows([]string{"id", "userId", "createdat", "updatedat"}).AddRow("b1", "1", time.Now(), time.Now()))
+	suite.mockSql.ExpectExec("INSERT INTO booking_participants").WithArgs("b1", "2", "attendee").WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockSql.ExpectExec("INSERT INTO booking_participants").WithArgs("b1", "3", "delegate").WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockSql.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	suite.mockSql.ExpectQuery("INSERT INTO booking_details").WillReturnRows(sqlmock.NewRows([]string{"id", "bookingid", "roomid", "bookingdate", "boookingdateend", "status", "description", "created_at", "updated_at"}).
+		AddRow("bd1", "b1", "r1", start, end, "pending", "", time.Now(), time.Now()))
+	suite.mockSql.ExpectCommit()
+
+	actual, err := suite.repo.Create(context.Background(), payload, "1")
+
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), payload.Participants, actual.Participants)
+}
+
+func (suite *BookingRepositoryTestSuite) TestCreateBooking_EquipmentUnavailable() {
+	mockBooking := model.Booking{
+		Id:    "1",
+		Users: model.User{Id: "1"},
+		BookingDetails: []model.BookingDetail{
+			{
+				Id:        "1",
+				Rooms:     model.Room{Id: "1"},
+				Status:    "pending",
+				Equipment: []model.EquipmentReservation{{EquipmentId: "e1", Quantity: 2}},
+			},
+		},
+	}
+
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery("INSERT INTO booking").WillReturnRows(sqlmock.NewRows([]string{"id", "userId", "createdat", "updatedat"}).AddRow("1", "1", time.Now(), time.Now()))
+	suite.mockSql.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	suite.mockSql.ExpectQuery("INSERT INTO booking_details").WillReturnRows(sqlmock.NewRows([]string{"id", "bookingid", "roomid", "bookingdate", "boookingdateend", "status", "description", "created_at", "updated_at"}).
+		AddRow("1", "1", "1", time.Now(), time.Now(), "pending", "", time.Now(), time.Now()))
+	suite.mockSql.ExpectQuery("FROM equipment e .* FOR UPDATE").WithArgs("e1", "1").WillReturnRows(sqlmock.NewRows([]string{"name", "quantity"}).AddRow("Projector", 3))
+	suite.mockSql.ExpectQuery("SELECT COALESCE\\(SUM\\(bde.quantity\\), 0\\)").WithArgs("e1", sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(2))
+	suite.mockSql.ExpectRollback()
+
+	_, err := suite.repo.Create(context.Background(), mockBooking, "1")
+
+	assert.EqualError(suite.T(), err, "equipment Projector only has 1 unit(s) left for the booking time")
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
 func (suite *BookingRepositoryTestSuite) TestGetBookingDetailsByBookingID_Success() {
 
 	expectedBookingDetails := model.BookingDetail{
@@ -127,7 +197,7 @@ func (suite *BookingRepositoryTestSuite) TestGetBookingDetailsByBookingID_Succes
 				Fwifi:            "",
 				FsoundSystem:     "",
 				Fprojector:       "",
-				FscreenProjector: "",
+				FscreenProjector: "ada",
 				Fchairs:          "",
 				Ftables:          "",
 				FsoundProof:      "",
@@ -152,7 +222,7 @@ func (suite *BookingRepositoryTestSuite) TestGetBookingDetailsByBookingID_Succes
 	}
 
 	suite.mockSql.ExpectQuery("^SELECT .*").WithArgs("booking_id_value").WillReturnRows(sqlmock.NewRows(
-		[]string{"id", "bookingdate", "bookingdateend", "status", "description", "createdat", "updatedat", "rooms.id", "rooms.roomtype", "rooms.capacity", "rooms.status", "rooms.createdat", "rooms.updatedat", "rooms.facility.id", "rooms.facility.roomdescription", "rooms.facility.fwifi", "rooms.facility.fsoundsystem", "rooms.facility.fprojector", "rooms.facility.fchairs", "rooms.facility.ftables", "rooms.facility.fsoundproof", "rooms.facility.fsmonkingarea", "rooms.facility.ftelevison", "rooms.facility.fac", "rooms.facility.fbathroom", "rooms.facility.fcoffemaker", "rooms.facility.createdat", "rooms.facility.updatedat"},
+		[]string{"id", "bookingdate", "bookingdateend", "status", "description", "createdat", "updatedat", "rooms.id", "rooms.roomtype", "rooms.capacity", "rooms.status", "rooms.createdat", "rooms.updatedat", "rooms.facility.id", "rooms.facility.roomdescription", "rooms.facility.fwifi", "rooms.facility.fsoundsystem", "rooms.facility.fprojector", "rooms.facility.fscreenprojector", "rooms.facility.fchairs", "rooms.facility.ftables", "rooms.facility.fsoundproof", "rooms.facility.fsmonkingarea", "rooms.facility.ftelevison", "rooms.facility.fac", "rooms.facility.fbathroom", "rooms.facility.fcoffemaker", "rooms.facility.createdat", "rooms.facility.updatedat", "rooms.name", "rooms.code", "rooms.location.floorid", "rooms.location.floorname", "rooms.location.floorlevel", "rooms.location.buildingid", "rooms.location.buildingname", "rooms.location.siteid", "rooms.location.sitename", "rooms.location.timezone", "attendees", "blockedfrom", "blockeduntil", "conferenceurl", "layout.id", "layout.name", "layout.capacity", "layout.setupminutes", "layout.teardownminutes"},
 	).AddRow(
 		expectedBookingDetails.Id,
 		expectedBookingDetails.BookingDate,
@@ -172,6 +242,7 @@ func (suite *BookingRepositoryTestSuite) TestGetBookingDetailsByBookingID_Succes
 		expectedBookingDetails.Rooms.Facility.Fwifi,
 		expectedBookingDetails.Rooms.Facility.FsoundSystem,
 		expectedBookingDetails.Rooms.Facility.Fprojector,
+		expectedBookingDetails.Rooms.Facility.FscreenProjector,
 		expectedBookingDetails.Rooms.Facility.Fchairs,
 		expectedBookingDetails.Rooms.Facility.Ftables,
 		expectedBookingDetails.Rooms.Facility.FsoundProof,
@@ -182,15 +253,18 @@ func (suite *BookingRepositoryTestSuite) TestGetBookingDetailsByBookingID_Succes
 		expectedBookingDetails.Rooms.Facility.FcoffeMaker,
 		expectedBookingDetails.Rooms.Facility.UpdatedAt,
 		expectedBookingDetails.Rooms.Facility.CreatedAt,
+		expectedBookingDetails.Rooms.Name, expectedBookingDetails.Rooms.Code, "", "", 0, "", "", "", "", "", 0, time.Time{}, time.Time{}, "", nil, nil, nil, nil, nil,
 	))
+	expectRoomAmenities(suite.mockSql, "1")
+	expectBookingEquipment(suite.mockSql, "1")
 
-	results, err := suite.repo.GetBookingDetailsByBookingID("booking_id_value")
+	results, err := suite.repo.GetBookingDetailsByBookingID(context.Background(), "booking_id_value")
 
 	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
 	assert.Len(suite.T(), results, 1)
-
+	assert.Equal(suite.T(), "ada", results[0].Rooms.Facility.FscreenProjector)
 }
 
 func (suite *BookingRepositoryTestSuite) TestGet_Success() {
@@ -235,7 +309,7 @@ func (suite *BookingRepositoryTestSuite) TestGet_Success() {
 
 	for _, v := range mockBooking.BookingDetails {
 		suite.mockSql.ExpectQuery("^SELECT .*").WithArgs("booking_id_value").WillReturnRows(sqlmock.NewRows(
-			[]string{"id", "bookingdate", "bookingdateend", "status", "description", "createdat", "updatedat", "rooms.id", "rooms.roomtype", "rooms.capacity", "rooms.status", "rooms.createdat", "rooms.updatedat", "rooms.facility.id", "rooms.facility.roomdescription", "rooms.facility.fwifi", "rooms.facility.fsoundsystem", "rooms.facility.fprojector", "rooms.facility.fchairs", "rooms.facility.ftables", "rooms.facility.fsoundproof", "rooms.facility.fsmonkingarea", "rooms.facility.ftelevison", "rooms.facility.fac", "rooms.facility.fbathroom", "rooms.facility.fcoffemaker", "rooms.facility.createdat", "rooms.facility.updatedat"},
+			[]string{"id", "bookingdate", "bookingdateend", "status", "description", "createdat", "updatedat", "rooms.id", "rooms.roomtype", "rooms.capacity", "rooms.status", "rooms.createdat", "rooms.updatedat", "rooms.facility.id", "rooms.facility.roomdescription", "rooms.facility.fwifi", "rooms.facility.fsoundsystem", "rooms.facility.fprojector", "rooms.facility.fscreenprojector", "rooms.facility.fchairs", "rooms.facility.ftables", "rooms.facility.fsoundproof", "rooms.facility.fsmonkingarea", "rooms.facility.ftelevison", "rooms.facility.fac", "rooms.facility.fbathroom", "rooms.facility.fcoffemaker", "rooms.facility.createdat", "rooms.facility.updatedat", "rooms.name", "rooms.code", "rooms.location.floorid", "rooms.location.floorname", "rooms.location.floorlevel", "rooms.location.buildingid", "rooms.location.buildingname", "rooms.location.siteid", "rooms.location.sitename", "rooms.location.timezone", "attendees", "blockedfrom", "blockeduntil", "conferenceurl", "layout.id", "layout.name", "layout.capacity", "layout.setupminutes", "layout.teardownminutes"},
 		).AddRow(
 			v.Id,
 			v.BookingDate,
@@ -255,6 +329,7 @@ func (suite *BookingRepositoryTestSuite) TestGet_Success() {
 			v.Rooms.Facility.Fwifi,
 			v.Rooms.Facility.FsoundSystem,
 			v.Rooms.Facility.Fprojector,
+			v.Rooms.Facility.FscreenProjector,
 			v.Rooms.Facility.Fchairs,
 			v.Rooms.Facility.Ftables,
 			v.Rooms.Facility.FsoundProof,
@@ -265,9 +340,12 @@ func (suite *BookingRepositoryTestSuite) TestGet_Success() {
 			v.Rooms.Facility.FcoffeMaker,
 			v.Rooms.Facility.UpdatedAt,
 			v.Rooms.Facility.CreatedAt,
+			v.Rooms.Name, v.Rooms.Code, "", "", 0, "", "", "", "", "", 0, time.Time{}, time.Time{}, "", nil, nil, nil, nil, nil,
 		))
+		expectRoomAmenities(suite.mockSql, v.Rooms.Id)
+		expectBookingEquipment(suite.mockSql, "1")
 	}
-	result, err := suite.repo.Get("booking_id_value", "userId", "admin")
+	result, err := suite.repo.Get(context.Background(), "booking_id_value", "userId", "admin")
 
 	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
 
@@ -319,10 +397,10 @@ func (suite *BookingRepositoryTestSuite) TestGetAll_Success() {
 			x.UpdatedAt,
 		))
 
+		rows := sqlmock.NewRows(bookingDetailBatchColumns)
 		for _, v := range x.BookingDetails {
-			suite.mockSql.ExpectQuery("^SELECT .*").WithArgs(v.BookingId).WillReturnRows(sqlmock.NewRows(
-				[]string{"id", "bookingdate", "bookingdateend", "status", "description", "createdat", "updatedat", "rooms.id", "rooms.roomtype", "rooms.capacity", "rooms.status", "rooms.createdat", "rooms.updatedat", "rooms.facility.id", "rooms.facility.roomdescription", "rooms.facility.fwifi", "rooms.facility.fsoundsystem", "rooms.facility.fprojector", "rooms.facility.fchairs", "rooms.facility.ftables", "rooms.facility.fsoundproof", "rooms.facility.fsmonkingarea", "rooms.facility.ftelevison", "rooms.facility.fac", "rooms.facility.fbathroom", "rooms.facility.fcoffemaker", "rooms.facility.createdat", "rooms.facility.updatedat"},
-			).AddRow(
+			rows.AddRow(
+				v.BookingId,
 				v.Id,
 				v.BookingDate,
 				v.BookingDateEnd,
@@ -341,6 +419,7 @@ func (suite *BookingRepositoryTestSuite) TestGetAll_Success() {
 				v.Rooms.Facility.Fwifi,
 				v.Rooms.Facility.FsoundSystem,
 				v.Rooms.Facility.Fprojector,
+				v.Rooms.Facility.FscreenProjector,
 				v.Rooms.Facility.Fchairs,
 				v.Rooms.Facility.Ftables,
 				v.Rooms.Facility.FsoundProof,
@@ -349,18 +428,24 @@ func (suite *BookingRepositoryTestSuite) TestGetAll_Success() {
 				v.Rooms.Facility.FAc,
 				v.Rooms.Facility.Fbathroom,
 				v.Rooms.Facility.FcoffeMaker,
-				v.Rooms.Facility.UpdatedAt,
 				v.Rooms.Facility.CreatedAt,
-			))
+				v.Rooms.Facility.UpdatedAt,
+				v.Rooms.Name, v.Rooms.Code, "", "", 0, "", "", "", "", "", 0, time.Time{}, time.Time{}, "", nil, nil, nil, nil, nil,
+			)
 		}
+		// semua booking details diambil dengan satu query
+		suite.mockSql.ExpectQuery("WHERE bd.bookingid = ANY\\(\\$1\\)").WillReturnRows(rows)
+		expectRoomAmenities(suite.mockSql, x.BookingDetails[0].Rooms.Id)
+		expectBookingEquipment(suite.mockSql, "1")
 	}
-	result, err := suite.repo.GetAll()
+	result, err := suite.repo.GetAll(context.Background())
 
 	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
 
 	assert.NoError(suite.T(), err)
 
 	assert.Equal(suite.T(), mockBooking[0].Id, result[0].Id)
+	assert.Len(suite.T(), result[0].BookingDetails, 1)
 }
 
 func (suite *BookingRepositoryTestSuite) TestGetAllByStatus_Success() {
@@ -405,10 +490,10 @@ func (suite *BookingRepositoryTestSuite) TestGetAllByStatus_Success() {
 			x.UpdatedAt,
 		))
 
+		rows := sqlmock.NewRows(bookingDetailBatchColumns)
 		for _, v := range x.BookingDetails {
-			suite.mockSql.ExpectQuery("^SELECT .*").WithArgs(v.BookingId).WillReturnRows(sqlmock.NewRows(
-				[]string{"id", "bookingdate", "bookingdateend", "status", "description", "createdat", "updatedat", "rooms.id", "rooms.roomtype", "rooms.capacity", "rooms.status", "rooms.createdat", "rooms.updatedat", "rooms.facility.id", "rooms.facility.roomdescription", "rooms.facility.fwifi", "rooms.facility.fsoundsystem", "rooms.facility.fprojector", "rooms.facility.fchairs", "rooms.facility.ftables", "rooms.facility.fsoundproof", "rooms.facility.fsmonkingarea", "rooms.facility.ftelevison", "rooms.facility.fac", "rooms.facility.fbathroom", "rooms.facility.fcoffemaker", "rooms.facility.createdat", "rooms.facility.updatedat"},
-			).AddRow(
+			rows.AddRow(
+				v.BookingId,
 				v.Id,
 				v.BookingDate,
 				v.BookingDateEnd,
@@ -427,6 +512,7 @@ func (suite *BookingRepositoryTestSuite) TestGetAllByStatus_Success() {
 				v.Rooms.Facility.Fwifi,
 				v.Rooms.Facility.FsoundSystem,
 				v.Rooms.Facility.Fprojector,
+				v.Rooms.Facility.FscreenProjector,
 				v.Rooms.Facility.Fchairs,
 				v.Rooms.Facility.Ftables,
 				v.Rooms.Facility.FsoundProof,
@@ -435,12 +521,17 @@ func (suite *BookingRepositoryTestSuite) TestGetAllByStatus_Success() {
 				v.Rooms.Facility.FAc,
 				v.Rooms.Facility.Fbathroom,
 				v.Rooms.Facility.FcoffeMaker,
-				v.Rooms.Facility.UpdatedAt,
 				v.Rooms.Facility.CreatedAt,
-			))
+				v.Rooms.Facility.UpdatedAt,
+				v.Rooms.Name, v.Rooms.Code, "", "", 0, "", "", "", "", "", 0, time.Time{}, time.Time{}, "", nil, nil, nil, nil, nil,
+			)
 		}
+		// semua booking details diambil dengan satu query
+		suite.mockSql.ExpectQuery("WHERE bd.bookingid = ANY\\(\\$1\\)").WillReturnRows(rows)
+		expectRoomAmenities(suite.mockSql, x.BookingDetails[0].Rooms.Id)
+		expectBookingEquipment(suite.mockSql, "1")
 	}
-	result, err := suite.repo.GetAllByStatus("status")
+	result, err := suite.repo.GetAllByStatus(context.Background(), "status")
 
 	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
 
@@ -448,3 +539,184 @@ func (suite *BookingRepositoryTestSuite) TestGetAllByStatus_Success() {
 
 	assert.Equal(suite.T(), mockBooking[0].Id, result[0].Id)
 }
+
+func (suite *BookingRepositoryTestSuite) TestGet_EmployeeIncludesParticipant() {
+	suite.mockSql.ExpectQuery("WHERE \\(b.id = \\$1 AND \\(b.userid = \\$2 OR EXISTS \\(SELECT 1 FROM booking_participants bp WHERE bp.bookingid = b.id AND bp.userid = \\$2\\)\\)\\)").
+		WithArgs("booking_id_value", "userId").WillReturnError(sql.ErrNoRows)
+
+	_, err := suite.repo.Get(context.Background(), "booking_id_value", "userId", "employee")
+
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+	assert.ErrorIs(suite.T(), err, sql.ErrNoRows)
+}
+
+func (suite *BookingRepositoryTestSuite) TestGetAllByUser_Success() {
+	filter := dto.BookingFilter{Status: "pending", Page: 1, Size: 10}
+
+	suite.mockSql.ExpectQuery("SELECT COUNT\\(DISTINCT b.id\\) .* WHERE \\(b.userid = \\$1 OR EXISTS \\(SELECT 1 FROM booking_participants bp WHERE bp.bookingid = b.id AND bp.userid = \\$1\\)\\) AND bd.resourcetype = 'room' AND bd.status = \\$2").WithArgs("userId", "pending").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	suite.mockSql.ExpectQuery("GROUP BY b.id, u.id ORDER BY MIN\\(bd.bookingdate\\) DESC LIMIT").WithArgs("userId", "pending", 10, 0).WillReturnRows(sqlmock.NewRows(
+		[]string{"id", "users.id", "users.name", "users.divisi", "users.jabatan", "users.email", "users.role", "users.createdat", "users.updatedat", "createdat", "updatedat"},
+	).AddRow("1", "userId", "Siapa", "", "", "", "employee", time.Now(), time.Now(), time.Now(), time.Now()))
+
+	suite.mockSql.ExpectQuery("WHERE bd.bookingid = ANY\\(\\$1\\) AND bd.resourcetype = 'room' AND bd.status = \\$2").WillReturnRows(sqlmock.NewRows(
+		bookingDetailBatchColumns,
+	).AddRow("1", "10", time.Now(), time.Now(), "pending", "", time.Now(), time.Now(), "1", "kolam", 5, "available", time.Now(), time.Now(), "1", "", "", "", "", "", "", "", "", "", "", "", "", "", time.Now(), time.Now(), "kolam", "", "", "", 0, "", "", "", "", "", 15, time.Now(), time.Now(), "", "l1", "classroom", 20, 30, 30))
+	expectRoomAmenities(suite.mockSql, "1")
+	expectBookingEquipment(suite.mockSql, "10")
+
+	result, paging, err := suite.repo.GetAllByUser(context.Background(), "userId", filter)
+
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 1)
+	assert.Len(suite.T(), result[0].BookingDetails, 1)
+	assert.Equal(suite.T(), 15, result[0].BookingDetails[0].Attendees)
+	assert.Equal(suite.T(), "classroom", result[0].BookingDetails[0].Layout.Name)
+	assert.Equal(suite.T(), []model.EquipmentReservation{{EquipmentId: "e1", Name: "Projector", Quantity: 1}}, result[0].BookingDetails[0].Equipment)
+	assert.Equal(suite.T(), dto.Paging{Page: 1, Size: 10, TotalRows: 1, TotalPages: 1}, paging)
+}
+
+func (suite *BookingRepositoryTestSuite) TestSearch_Success() {
+	filter := dto.BookingFilter{Divisi: "HR", Keyword: "50%", SortBy: "createdAt", Order: "asc", Page: 2, Size: 5}
+
+	suite.mockSql.ExpectQuery("SELECT COUNT\\(DISTINCT b.id\\) .* WHERE LOWER\\(u.divisi\\) = LOWER\\(\\$1\\) AND bd.resourcetype = 'room' AND bd.description ILIKE '%' \\|\\| \\$2 \\|\\| '%'").
+		WithArgs("HR", `50\%`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(6))
+
+	suite.mockSql.ExpectQuery("GROUP BY b.id, u.id ORDER BY b.createdat ASC LIMIT \\$3 OFFSET \\$4").WithArgs("HR", `50\%`, 5, 5).WillReturnRows(sqlmock.NewRows(
+		[]string{"id", "users.id", "users.name", "users.divisi", "users.jabatan", "users.email", "users.role", "users.createdat", "users.updatedat", "createdat", "updatedat"},
+	).AddRow("6", "userId", "Siapa", "HR", "", "", "employee", time.Now(), time.Now(), time.Now(), time.Now()))
+
+	suite.mockSql.ExpectQuery("WHERE bd.bookingid = ANY\\(\\$1\\) AND bd.resourcetype = 'room' AND bd.description ILIKE").WillReturnRows(sqlmock.NewRows(
+		bookingDetailBatchColumns,
+	))
+
+	result, paging, err := suite.repo.Search(context.Background(), filter)
+
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 1)
+	assert.Equal(suite.T(), dto.Paging{Page: 2, Size: 5, TotalRows: 6, TotalPages: 2}, paging)
+}
+
+func (suite *BookingRepositoryTestSuite) TestGetCalendar_Success() {
+	from := time.Date(2023, 11, 19, 10, 0, 0, 0, time.UTC)
+	until := time.Date(2023, 11, 21, 12, 0, 0, 0, time.UTC)
+	start := time.Date(2023, 11, 20, 2, 0, 0, 0, time.UTC)
+
+	suite.mockSql.ExpectQuery(`SELECT r.id, COALESCE\(r.name, r.roomtype\), .* FROM rooms r LEFT JOIN floors fl .* WHERE r.archivedat IS NULL AND r.id = ANY\(\$1\) AND fl.id = \$2 ORDER BY`).
+		WithArgs(sqlmock.AnyArg(), "f1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "timezone"}).AddRow("1", "Melati", "Asia/Jakarta").AddRow("2", "Mawar", "Asia/Jakarta"))
+	suite.mockSql.ExpectQuery(`FROM booking_details bd JOIN booking b ON b.id = bd.bookingid JOIN users u ON u.id = b.userid WHERE bd.roomid = ANY\(\$1\) AND bd.status IN \('pending', 'accept'\)`).
+		WithArgs(sqlmock.AnyArg(), from, until).
+		WillReturnRows(sqlmock.NewRows([]string{"roomid", "bookingid", "id", "userid", "name", "divisi", "status", "description", "bookingdate", "bookingdateend", "blockedfrom", "blockeduntil"}).
+			AddRow("2", "b1", "bd1", "u1", "Budi", "HR", "accept", "interview", start, start.Add(time.Hour), start, start.Add(time.Hour)))
+
+	result, err := suite.repo.GetCalendar(context.Background(), dto.CalendarFilter{RoomIds: []string{"1", "2"}, Location: dto.LocationFilter{FloorId: "f1"}, From: from, Until: until})
+
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 2)
+	assert.Empty(suite.T(), result[0].Bookings)
+	assert.Equal(suite.T(), "Budi", result[1].Bookings[0].Owner.Name)
+	assert.Equal(suite.T(), "interview", result[1].Bookings[0].Description)
+}
+
+func (suite *BookingRepositoryTestSuite) TestGetUtilization_Success() {
+	from := time.Date(2023, 10, 31, 10, 0, 0, 0, time.UTC)
+	until := time.Date(2023, 12, 1, 12, 0, 0, 0, time.UTC)
+	start := time.Date(2023, 11, 20, 2, 0, 0, 0, time.UTC)
+
+	suite.mockSql.ExpectQuery(`SELECT r.id, COALESCE\(r.name, r.roomtype\), r.roomtype, .* FROM rooms r LEFT JOIN floors fl .* WHERE \(r.archivedat IS NULL OR r.archivedat > \$1\) AND r.roomtype = \$2 AND bg.id = \$3 ORDER BY`).
+		WithArgs(from, "meeting", "b1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "roomtype", "buildingid", "buildingname", "timezone", "capacity"}).
+			AddRow("1", "Melati", "meeting", "b1", "Tower A", "Asia/Jakarta", 10))
+	suite.mockSql.ExpectQuery(`FROM booking_details bd JOIN booking b ON b.id = bd.bookingid JOIN users u ON u.id = b.userid WHERE bd.roomid = ANY\(\$1\) AND bd.bookingdate >= \$2 AND bd.bookingdate < \$3`).
+		WithArgs(sqlmock.AnyArg(), from, until).
+		WillReturnRows(sqlmock.NewRows([]string{"roomid", "divisi", "status", "attendees", "bookingdate", "bookingdateend", "checkedin"}).
+			AddRow("1", "HR", "accept", 6, start, start.Add(2*time.Hour), true))
+
+	result, err := suite.repo.GetUtilization(context.Background(), dto.UtilizationFilter{RoomType: "meeting", Location: dto.LocationFilter{BuildingId: "b1"}, From: from, Until: until})
+
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []dto.UtilizationRoom{{RoomId: "1", RoomName: "Melati", RoomType: "meeting", BuildingId: "b1", BuildingName: "Tower A", Timezone: "Asia/Jakarta", Capacity: 10,
+		Bookings: []dto.UtilizationBooking{{Division: "HR", Status: "accept", Attendees: 6, Start: start, End: start.Add(2 * time.Hour), CheckedIn: true}}}}, result)
+}
+
+func (suite *BookingRepositoryTestSuite) TestUpdateStatus_DeclineCancelsOwnServiceOrders() {
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery(`UPDATE booking_details SET status = \$1`).
+		WithArgs("decline", "bd1").
+		WillReturnRows(sqlmock.NewRows([]string{"bookingid", "roomid"}).AddRow("b1", "r1"))
+	suite.mockSql.ExpectExec(`UPDATE rooms SET status = \$1 WHERE id = \$2`).
+		WithArgs("available", "r1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockSql.ExpectExec(`UPDATE service_orders so SET approvalstatus = 'cancelled'.*so.bookingdetailid = ANY\(\$2\)`).
+		WithArgs(sqlmock.AnyArg(), pq.Array([]string{"bd1"})).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	suite.mockSql.ExpectCommit()
+	suite.mockSql.ExpectQuery(`FROM booking b`).WithArgs("b1").WillReturnError(sql.ErrNoRows)
+
+	_, err := suite.repo.UpdateStatus(context.Background(), "bd1", "decline")
+
+	assert.ErrorIs(suite.T(), err, sql.ErrNoRows)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *BookingRepositoryTestSuite) TestUpdateStatus_AcceptConflictsWithLinkedRoom() {
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery(`UPDATE booking_details SET status = \$1`).
+		WithArgs("accept", "bd1").
+		WillReturnRows(sqlmock.NewRows([]string{"bookingid", "roomid"}).AddRow("b1", "r1"))
+	suite.mockSql.ExpectQuery(`SELECT COUNT\(\*\) FROM booking_details bd JOIN booking_details me ON me.id = \$1\s+WHERE bd.roomid IN \(SELECT \$2::uuid UNION .*room_combinations.*\) AND bd.id <> me.id\s+AND bd.status = 'accept' AND bd.blockedfrom < me.blockeduntil AND me.blockedfrom < bd.blockeduntil`).
+		WithArgs("bd1", "r1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	suite.mockSql.ExpectRollback()
+
+	_, err := suite.repo.UpdateStatus(context.Background(), "bd1", "accept")
+
+	assert.ErrorIs(suite.T(), err, ErrBookingConflict)
+	assert.EqualError(suite.T(), err, "room is already booked for the requested time in room r1 or a combined or partial room")
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *BookingRepositoryTestSuite) TestCreateBooking_ConflictQueryFailed() {
+	start, end := time.Date(2026, 11, 2, 2, 0, 0, 0, time.UTC), time.Date(2026, 11, 2, 4, 0, 0, 0, time.UTC)
+	payload := model.Booking{BookingDetails: []model.BookingDetail{{Rooms: model.Room{Id: "r1"}, BookingDate: start, BookingDateEnd: end}}}
+
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery("INSERT INTO booking ").WillReturnRows(sqlmock.NewRows([]string{"id", "userId", "createdat", "updatedat"}).AddRow("b1", "1", time.Now(), time.Now()))
+	suite.mockSql.ExpectQuery("SELECT COUNT").WillReturnError(sql.ErrConnDone)
+	suite.mockSql.ExpectRollback()
+
+	actual, err := suite.repo.Create(context.Background(), payload, "1")
+
+	assert.ErrorIs(suite.T(), err, sql.ErrConnDone)
+	assert.Empty(suite.T(), actual.Id)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *BookingRepositoryTestSuite) TestGetParticipantEmails_Success() {
+	suite.mockSql.ExpectQuery(`SELECT u.email FROM booking_participants bp JOIN users u ON u.id = bp.userid WHERE bp.bookingid = \$1`).
+		WithArgs("b1").
+		WillReturnRows(sqlmock.NewRows([]string{"email"}).AddRow("budi@mail.com").AddRow("sari@mail.com"))
+
+	emails, err := suite.repo.GetParticipantEmails(context.Background(), "b1")
+
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"budi@mail.com", "sari@mail.com"}, emails)
+}
+
+func (suite *BookingRepositoryTestSuite) TestCheckIn_NotAllowed() {
+	at := time.Date(2023, 11, 20, 2, 0, 0, 0, time.UTC)
+	suite.mockSql.ExpectExec(`UPDATE booking_details bd SET checkedinat = \$3, updatedat = \$3 FROM booking b`).
+		WithArgs("bd1", "u1", at).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := suite.repo.CheckIn(context.Background(), "bd1", "u1", at)
+
+	assert.ErrorIs(suite.T(), err, ErrCheckInNotAllowed)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"final-project-booking-room/model"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type EquipmentRepository interface {
+	Create(ctx context.Context, payload model.Equipment) (model.Equipment, error)
+	Get(ctx context.Context, id string) (model.Equipment, error)
+	GetAll(ctx context.Context, siteId string) ([]model.Equipment, error)
+	Update(ctx context.Context, id string, payload model.Equipment) (model.Equipment, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type equipmentRepository struct {
+	db *sql.DB
+}
+
+const equipmentColumns = `id, siteid, code, name, quantity, createdat, updatedat`
+
+func scanEquipment(row rowScanner) (model.Equipment, error) {
+	var equipment model.Equipment
+	var updatedAt sql.NullTime
+	err := row.Scan(&equipment.Id, &equipment.SiteId, &equipment.Code, &equipment.Name, &equipment.Quantity, &equipment.CreatedAt, &updatedAt)
+	equipment.UpdatedAt = updatedAt.Time
+	return equipment, err
+}
+
+func (e *equipmentRepository) Create(ctx context.Context, payload model.Equipment) (model.Equipment, error) {
+	return scanEquipment(e.db.QueryRowContext(ctx, `INSERT INTO equipment (siteid, code, name, quantity, updatedat) VALUES ($1, $2, $3, $4, $5) RETURNING `+equipmentColumns,
+		payload.SiteId, payload.Code, payload.Name, payload.Quantity, time.Now()))
+}
+
+func (e *equipmentRepository) Get(ctx context.Context, id string) (model.Equipment, error) {
+	return scanEquipment(e.db.QueryRowContext(ctx, `SELECT `+equipmentColumns+` FROM equipment WHERE id = $1`, id))
+}
+
+// GetAll mengambil semua equipment, siteId kosong berarti semua site
+func (e *equipmentRepository) GetAll(ctx context.Context, siteId string) ([]model.Equipment, error) {
+	rows, err := e.db.QueryContext(ctx, `SELECT `+equipmentColumns+` FROM equipment WHERE $1 = '' OR siteid::text = $1 ORDER BY name`, siteId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	equipments := []model.Equipment{}
+	for rows.Next() {
+		equipment, err := scanEquipment(rows)
+		if err != nil {
+			return nil, err
+		}
+		equipments = append(equipments, equipment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return equipments, nil
+}
+
+func (e *equipmentRepository) Update(ctx context.Context, id string, payload model.Equipment) (model.Equipment, error) {
+	return scanEquipment(e.db.QueryRowContext(ctx, `UPDATE equipment SET code = $1, name = $2, quantity = $3, updatedat = $4 WHERE id = $5 RETURNING `+equipmentColumns,
+		payload.Code, payload.Name, payload.Quantity, time.Now(), id))
+}
+
+// Delete gagal lewat foreign key jika equipment pernah dipesan, riwayat booking tetap utuh
+func (e *equipmentRepository) Delete(ctx context.Context, id string) error {
+	_, err := e.db.ExecContext(ctx, `DELETE FROM equipment WHERE id = $1`, id)
+	return err
+}
+
+// equipmentInUse menjumlahkan unit equipment yang dipesan booking aktif pada rentang from-until
+const equipmentInUse = `SELECT COALESCE(SUM(bde.quantity), 0) FROM booking_detail_equipment bde
+	JOIN booking_details bd ON bd.id = bde.bookingdetailid
+	WHERE bde.equipmentid = $1 AND bd.status IN ('pending', 'accept') AND bd.bookingdate < $3 AND $2 < bd.bookingdateend`
+
+// reserveEquipment menyimpan equipment untuk satu booking detail setelah memastikan stok di site ruangan cukup.
+// Baris equipment dikunci supaya dua booking bersamaan tidak memakai unit yang sama.
+func reserveEquipment(ctx context.Context, tx *sql.Tx, detail model.BookingDetail) error {
+	for _, item := range detail.Equipment {
+		var name string
+		var quantity int
+		err := tx.QueryRowContext(ctx, `SELECT e.name, e.quantity FROM equipment e
+		WHERE e.id = $1 AND e.siteid = (SELECT bg.siteid FROM rooms r JOIN floors fl ON fl.id = r.floorid JOIN buildings bg ON bg.id = fl.buildingid WHERE r.id = $2)
+		FOR UPDATE`, item.EquipmentId, detail.Rooms.Id).Scan(&name, &quantity)
+		if err != nil {
+			return fmt.Errorf("equipment with id %s is not available at the room's site", item.EquipmentId)
+		}
+
+		var inUse int
+		if err := tx.QueryRowContext(ctx, equipmentInUse, item.EquipmentId, detail.BookingDate, detail.BookingDateEnd).Scan(&inUse); err != nil {
+			return err
+		}
+		if inUse+item.Quantity > quantity {
+			return fmt.Errorf("equipment %s only has %d unit(s) left for the booking time", name, quantity-inUse)
+		}
+
+		if _, err := tx.ExecContext(ctx, `INSERT INTO booking_detail_equipment (bookingdetailid, equipmentid, quantity) VALUES ($1, $2, $3)`,
+			detail.Id, item.EquipmentId, item.Quantity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkApprovedEquipment memastikan equipment booking detail masih cukup jika dihitung bersama booking yang sudah accept,
+// misalnya jika stok dikurangi setelah booking dibuat
+// ErrEquipmentUnavailable dikembalikan saat accept jika stok equipment booking sudah terpakai booking accept lain
+var ErrEquipmentUnavailable = errors.New("equipment is no longer available for this booking")
+
+func checkApprovedEquipment(ctx context.Context, tx *sql.Tx, bookingDetailId string) error {
+	var name string
+	err := tx.QueryRowContext(ctx, `SELECT e.name FROM booking_detail_equipment mine
+	JOIN equipment e ON e.id = mine.equipmentid
+	JOIN booking_details me ON me.id = mine.bookingdetailid
+	WHERE mine.bookingdetailid = $1 AND e.quantity < mine.quantity + (
+		SELECT COALESCE(SUM(other.quantity), 0) FROM booking_detail_equipment other
+		JOIN booking_details obd ON obd.id = other.bookingdetailid
+		WHERE other.equipmentid = mine.equipmentid AND obd.id <> me.id AND obd.status = 'accept'
+			AND obd.bookingdate < me.bookingdateend AND me.bookingdate < obd.bookingdateend)
+	LIMIT 1`, bookingDetailId).Scan(&name)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("%w: %s", ErrEquipmentUnavailable, name)
+}
+
+// getBookingEquipment mengambil equipment untuk banyak booking detail sekaligus, dikelompokkan per booking detail id
+func getBookingEquipment(ctx context.Context, q queryer, bookingDetailIds []string) (map[string][]model.EquipmentReservation, error) {
+	reservations := make(map[string][]model.EquipmentReservation)
+	if len(bookingDetailIds) == 0 {
+		return reservations, nil
+	}
+
+	rows, err := q.QueryContext(ctx, `SELECT bde.bookingdetailid, e.id, e.name, bde.quantity
+	FROM booking_detail_equipment bde JOIN equipment e ON e.id = bde.equipmentid
+	WHERE bde.bookingdetailid = ANY($1) ORDER BY e.name`, pq.Array(bookingDetailIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var bookingDetailId string
+		var item model.EquipmentReservation
+		if err := rows.Scan(&bookingDetailId, &item.EquipmentId, &item.Name, &item.Quantity); err != nil {
+			return nil, err
+		}
+		reservations[bookingDetailId] = append(reservations[bookingDetailId], item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return reservations, nil
+}
+
+func NewEquipmentRepository(db *sql.DB) EquipmentRepository {
+	return &equipmentRepository{db: db}
+}
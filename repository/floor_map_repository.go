@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"final-project-booking-room/model"
+	"time"
+)
+
+type FloorMapRepository interface {
+	SetRoomShape(ctx context.Context, roomId string, shape model.RoomShape) error
+	DeleteRoomShape(ctx context.Context, roomId string) error
+	GetFloorRooms(ctx context.Context, floorId string, at time.Time) ([]model.FloorMapRoom, error)
+}
+
+type floorMapRepository struct {
+	db *sql.DB
+}
+
+func (f *floorMapRepository) SetRoomShape(ctx context.Context, roomId string, shape model.RoomShape) error {
+	points, err := json.Marshal(shape.Points)
+	if err != nil {
+		return err
+	}
+	_, err = f.db.ExecContext(ctx, `INSERT INTO room_map_shapes (roomid, type, points, updatedat) VALUES ($1, $2, $3, $4)
+	ON CONFLICT (roomid) DO UPDATE SET type = EXCLUDED.type, points = EXCLUDED.points, updatedat = EXCLUDED.updatedat`,
+		roomId, shape.Type, string(points), time.Now())
+	return err
+}
+
+func (f *floorMapRepository) DeleteRoomShape(ctx context.Context, roomId string) error {
+	_, err := f.db.ExecContext(ctx, `DELETE FROM room_map_shapes WHERE roomid = $1`, roomId)
+	return err
+}
+
+// GetFloorRooms mengambil semua ruangan di satu floor beserta shape dan ketersediaannya pada waktu at.
+// Ruangan dianggap booked jika statusnya booked atau ada booking accept yang sedang berjalan (termasuk setup/teardown),
+// pending jika ada booking pending yang sedang berjalan. Bagian dan ruangan gabungannya ikut diperhitungkan.
+func (f *floorMapRepository) GetFloorRooms(ctx context.Context, floorId string, at time.Time) ([]model.FloorMapRoom, error) {
+	rows, err := f.db.QueryContext(ctx, `SELECT r.id, COALESCE(r.name, r.roomtype), COALESCE(r.code, ''), r.capacity,
+		COALESCE(ms.type, ''), COALESCE(ms.points::text, '[]'),
+		CASE
+			WHEN EXISTS (SELECT 1 FROM rooms lr WHERE lr.status = 'booked' AND lr.id IN (`+linkedRoomsOf("r.id")+`))
+				OR EXISTS (SELECT 1 FROM booking_details bd WHERE bd.roomid IN (`+linkedRoomsOf("r.id")+`) AND bd.status = 'accept' AND bd.blockedfrom <= $2 AND bd.blockeduntil > $2) THEN 'booked'
+			WHEN EXISTS (SELECT 1 FROM booking_details bd WHERE bd.roomid IN (`+linkedRoomsOf("r.id")+`) AND bd.status = 'pending' AND bd.blockedfrom <= $2 AND bd.blockeduntil > $2) THEN 'pending'
+			ELSE 'available'
+		END
+	FROM rooms r LEFT JOIN room_map_shapes ms ON ms.roomid = r.id
+	WHERE r.floorid = $1 AND r.archivedat IS NULL
+	ORDER BY 2`, floorId, at)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rooms := []model.FloorMapRoom{}
+	for rows.Next() {
+		var room model.FloorMapRoom
+		var points string
+		if err := rows.Scan(&room.RoomId, &room.Name, &room.Code, &room.Capacity, &room.Shape.Type, &points, &room.Availability); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(points), &room.Shape.Points); err != nil {
+			return nil, err
+		}
+		rooms = append(rooms, room)
+	}
+	return rooms, rows.Err()
+}
+
+func NewFloorMapRepository(db *sql.DB) FloorMapRepository {
+	return &floorMapRepository{db: db}
+}
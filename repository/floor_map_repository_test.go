@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"final-project-booking-room/model"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type FloorMapRepositoryTestSuite struct {
+	suite.Suite
+	mockDB  *sql.DB
+	mockSql sqlmock.Sqlmock
+	repo    FloorMapRepository
+}
+
+func (suite *FloorMapRepositoryTestSuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	assert.NoError(suite.T(), err)
+	suite.mockDB = db
+	suite.mockSql = mock
+	suite.repo = NewFloorMapRepository(suite.mockDB)
+}
+
+func TestFloorMapRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(FloorMapRepositoryTestSuite))
+}
+
+func (suite *FloorMapRepositoryTestSuite) TestSetRoomShape_Upsert() {
+	shape := model.RoomShape{Type: model.ShapePoint, Points: []model.MapPoint{{X: 10.5, Y: 20}}}
+
+	suite.mockSql.ExpectExec(`INSERT INTO room_map_shapes .* ON CONFLICT \(roomid\) DO UPDATE`).
+		WithArgs("1", "point", `[{"x":10.5,"y":20}]`, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := suite.repo.SetRoomShape(context.Background(), "1", shape)
+
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *FloorMapRepositoryTestSuite) TestGetFloorRooms_Success() {
+	at := time.Date(2023, 11, 1, 3, 0, 0, 0, time.UTC)
+
+	suite.mockSql.ExpectQuery(`FROM rooms r LEFT JOIN room_map_shapes ms ON ms.roomid = r.id\s+WHERE r.floorid = \$1`).
+		WithArgs("f1", at).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "code", "capacity", "type", "points", "availability"}).
+			AddRow("1", "Ruang Rapat", "R-301", 8, "polygon", `[{"x":0,"y":0},{"x":10,"y":0},{"x":10,"y":10}]`, "booked").
+			AddRow("2", "Huddle", "", 2, "", "[]", "available"))
+
+	actual, err := suite.repo.GetFloorRooms(context.Background(), "f1", at)
+
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), actual, 2)
+	assert.Equal(suite.T(), "booked", actual[0].Availability)
+	assert.Len(suite.T(), actual[0].Shape.Points, 3)
+	assert.True(suite.T(), actual[1].Shape.IsEmpty())
+}
@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"final-project-booking-room/model"
+	"time"
+)
+
+type LocationRepository interface {
+	CreateSite(ctx context.Context, payload model.Site) (model.Site, error)
+	GetSite(ctx context.Context, id string) (model.Site, error)
+	GetAllSites(ctx context.Context) ([]model.Site, error)
+	UpdateSite(ctx context.Context, id string, payload model.Site) (model.Site, error)
+	DeleteSite(ctx context.Context, id string) error
+
+	CreateBuilding(ctx context.Context, payload model.Building) (model.Building, error)
+	GetBuilding(ctx context.Context, id string) (model.Building, error)
+	GetAllBuildings(ctx context.Context, siteId string) ([]model.Building, error)
+	UpdateBuilding(ctx context.Context, id string, payload model.Building) (model.Building, error)
+	DeleteBuilding(ctx context.Context, id string) error
+
+	CreateFloor(ctx context.Context, payload model.Floor) (model.Floor, error)
+	GetFloor(ctx context.Context, id string) (model.Floor, error)
+	GetAllFloors(ctx context.Context, buildingId string) ([]model.Floor, error)
+	UpdateFloor(ctx context.Context, id string, payload model.Floor) (model.Floor, error)
+	DeleteFloor(ctx context.Context, id string) error
+}
+
+type locationRepository struct {
+	db *sql.DB
+}
+
+const (
+	siteColumns     = `id, name, address, timezone, createdat, updatedat`
+	buildingColumns = `id, siteid, name, address, timezone, createdat, updatedat`
+	floorColumns    = `id, buildingid, name, level, mapwidth, mapheight, createdat, updatedat`
+)
+
+func scanSite(row rowScanner) (model.Site, error) {
+	var site model.Site
+	err := row.Scan(&site.Id, &site.Name, &site.Address, &site.Timezone, &site.CreatedAt, &site.UpdatedAt)
+	return site, err
+}
+
+func scanBuilding(row rowScanner) (model.Building, error) {
+	var building model.Building
+	err := row.Scan(&building.Id, &building.SiteId, &building.Name, &building.Address, &building.Timezone, &building.CreatedAt, &building.UpdatedAt)
+	return building, err
+}
+
+func scanFloor(row rowScanner) (model.Floor, error) {
+	var floor model.Floor
+	err := row.Scan(&floor.Id, &floor.BuildingId, &floor.Name, &floor.Level, &floor.MapWidth, &floor.MapHeight, &floor.CreatedAt, &floor.UpdatedAt)
+	return floor, err
+}
+
+// deleteUnused menjalankan DELETE yang hanya berhasil jika data tidak lagi dipakai (kondisi NOT EXISTS di query)
+func (l *locationRepository) deleteUnused(ctx context.Context, query string, id string, inUse error) error {
+	result, err := l.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return inUse
+	}
+	return nil
+}
+
+func (l *locationRepository) CreateSite(ctx context.Context, payload model.Site) (model.Site, error) {
+	return scanSite(l.db.QueryRowContext(ctx, `INSERT INTO sites (name, address, timezone, updatedat) VALUES ($1, $2, $3, $4) RETURNING `+siteColumns,
+		payload.Name, payload.Address, payload.Timezone, time.Now()))
+}
+
+func (l *locationRepository) GetSite(ctx context.Context, id string) (model.Site, error) {
+	return scanSite(l.db.QueryRowContext(ctx, `SELECT `+siteColumns+` FROM sites WHERE id = $1`, id))
+}
+
+func (l *locationRepository) GetAllSites(ctx context.Context) ([]model.Site, error) {
+	rows, err := l.db.QueryContext(ctx, `SELECT `+siteColumns+` FROM sites ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sites := []model.Site{}
+	for rows.Next() {
+		site, err := scanSite(rows)
+		if err != nil {
+			return nil, err
+		}
+		sites = append(sites, site)
+	}
+	return sites, rows.Err()
+}
+
+func (l *locationRepository) UpdateSite(ctx context.Context, id string, payload model.Site) (model.Site, error) {
+	return scanSite(l.db.QueryRowContext(ctx, `UPDATE sites SET name = $1, address = $2, timezone = $3, updatedat = $4 WHERE id = $5 RETURNING `+siteColumns,
+		payload.Name, payload.Address, payload.Timezone, time.Now(), id))
+}
+
+func (l *locationRepository) DeleteSite(ctx context.Context, id string) error {
+	return l.deleteUnused(ctx, `DELETE FROM sites WHERE id = $1 AND NOT EXISTS (SELECT 1 FROM buildings WHERE siteid = $1)`,
+		id, errors.New("site still has buildings"))
+}
+
+func (l *locationRepository) CreateBuilding(ctx context.Context, payload model.Building) (model.Building, error) {
+	return scanBuilding(l.db.QueryRowContext(ctx, `INSERT INTO buildings (siteid, name, address, timezone, updatedat) VALUES ($1, $2, $3, $4, $5) RETURNING `+buildingColumns,
+		payload.SiteId, payload.Name, payload.Address, payload.Timezone, time.Now()))
+}
+
+func (l *locationRepository) GetBuilding(ctx context.Context, id string) (model.Building, error) {
+	return scanBuilding(l.db.QueryRowContext(ctx, `SELECT `+buildingColumns+` FROM buildings WHERE id = $1`, id))
+}
+
+// GetAllBuildings mengembalikan semua building, atau hanya milik satu site jika siteId diisi
+func (l *locationRepository) GetAllBuildings(ctx context.Context, siteId string) ([]model.Building, error) {
+	rows, err := l.db.QueryContext(ctx, `SELECT `+buildingColumns+` FROM buildings WHERE ($1 = '' OR siteid::text = $1) ORDER BY name`, siteId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	buildings := []model.Building{}
+	for rows.Next() {
+		building, err := scanBuilding(rows)
+		if err != nil {
+			return nil, err
+		}
+		buildings = append(buildings, building)
+	}
+	return buildings, rows.Err()
+}
+
+func (l *locationRepository) UpdateBuilding(ctx context.Context, id string, payload model.Building) (model.Building, error) {
+	return scanBuilding(l.db.QueryRowContext(ctx, `UPDATE buildings SET siteid = $1, name = $2, address = $3, timezone = $4, updatedat = $5 WHERE id = $6 RETURNING `+buildingColumns,
+		payload.SiteId, payload.Name, payload.Address, payload.Timezone, time.Now(), id))
+}
+
+func (l *locationRepository) DeleteBuilding(ctx context.Context, id string) error {
+	return l.deleteUnused(ctx, `DELETE FROM buildings WHERE id = $1 AND NOT EXISTS (SELECT 1 FROM floors WHERE buildingid = $1)`,
+		id, errors.New("building still has floors"))
+}
+
+func (l *locationRepository) CreateFloor(ctx context.Context, payload model.Floor) (model.Floor, error) {
+	return scanFloor(l.db.QueryRowContext(ctx, `INSERT INTO floors (buildingid, name, level, mapwidth, mapheight, updatedat) VALUES ($1, $2, $3, $4, $5, $6) RETURNING `+floorColumns,
+		payload.BuildingId, payload.Name, payload.Level, payload.MapWidth, payload.MapHeight, time.Now()))
+}
+
+func (l *locationRepository) GetFloor(ctx context.Context, id string) (model.Floor, error) {
+	return scanFloor(l.db.QueryRowContext(ctx, `SELECT `+floorColumns+` FROM floors WHERE id = $1`, id))
+}
+
+// GetAllFloors mengembalikan semua floor, atau hanya milik satu building jika buildingId diisi
+func (l *locationRepository) GetAllFloors(ctx context.Context, buildingId string) ([]model.Floor, error) {
+	rows, err := l.db.QueryContext(ctx, `SELECT `+floorColumns+` FROM floors WHERE ($1 = '' OR buildingid::text = $1) ORDER BY level, name`, buildingId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	floors := []model.Floor{}
+	for rows.Next() {
+		floor, err := scanFloor(rows)
+		if err != nil {
+			return nil, err
+		}
+		floors = append(floors, floor)
+	}
+	return floors, rows.Err()
+}
+
+func (l *locationRepository) UpdateFloor(ctx context.Context, id string, payload model.Floor) (model.Floor, error) {
+	return scanFloor(l.db.QueryRowContext(ctx, `UPDATE floors SET buildingid = $1, name = $2, level = $3, mapwidth = $4, mapheight = $5, updatedat = $6 WHERE id = $7 RETURNING `+floorColumns,
+		payload.BuildingId, payload.Name, payload.Level, payload.MapWidth, payload.MapHeight, time.Now(), id))
+}
+
+func (l *locationRepository) DeleteFloor(ctx context.Context, id string) error {
+	return l.deleteUnused(ctx, `DELETE FROM floors WHERE id = $1 AND NOT EXISTS (SELECT 1 FROM rooms WHERE floorid = $1)`,
+		id, errors.New("floor still has rooms"))
+}
+
+func NewLocationRepository(db *sql.DB) LocationRepository {
+	return &locationRepository{db: db}
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"final-project-booking-room/model"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type LocationRepositoryTestSuite struct {
+	suite.Suite
+	mockDB  *sql.DB
+	mockSql sqlmock.Sqlmock
+	repo    LocationRepository
+}
+
+func (suite *LocationRepositoryTestSuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	assert.NoError(suite.T(), err)
+	suite.mockDB = db
+	suite.mockSql = mock
+	suite.repo = NewLocationRepository(suite.mockDB)
+}
+
+func TestLocationRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(LocationRepositoryTestSuite))
+}
+
+func (suite *LocationRepositoryTestSuite) TestCreateSite_Success() {
+	mockSite := model.Site{Id: "s1", Name: "Jakarta HQ", Address: "Jl. Sudirman 1", Timezone: "Asia/Jakarta"}
+
+	suite.mockSql.ExpectQuery("INSERT INTO sites").
+		WithArgs(mockSite.Name, mockSite.Address, mockSite.Timezone, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "address", "timezone", "createdat", "updatedat"}).
+			AddRow(mockSite.Id, mockSite.Name, mockSite.Address, mockSite.Timezone, time.Time{}, time.Time{}))
+
+	actual, err := suite.repo.CreateSite(context.Background(), mockSite)
+
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), mockSite, actual)
+}
+
+func (suite *LocationRepositoryTestSuite) TestGetAllBuildings_BySite() {
+	suite.mockSql.ExpectQuery(`FROM buildings WHERE \(\$1 = '' OR siteid::text = \$1\)`).
+		WithArgs("s1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "siteid", "name", "address", "timezone", "createdat", "updatedat"}).
+			AddRow("b1", "s1", "Tower A", "", "", time.Time{}, time.Time{}).
+			AddRow("b2", "s1", "Tower B", "", "Asia/Makassar", time.Time{}, time.Time{}))
+
+	actual, err := suite.repo.GetAllBuildings(context.Background(), "s1")
+
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), actual, 2)
+	assert.Equal(suite.T(), "Asia/Makassar", actual[1].Timezone)
+}
+
+func (suite *LocationRepositoryTestSuite) TestDeleteFloor_StillHasRooms() {
+	suite.mockSql.ExpectExec(`DELETE FROM floors WHERE id = \$1 AND NOT EXISTS \(SELECT 1 FROM rooms WHERE floorid = \$1\)`).
+		WithArgs("f1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := suite.repo.DeleteFloor(context.Background(), "f1")
+
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+	assert.EqualError(suite.T(), err, "floor still has rooms")
+}
+
+func (suite *LocationRepositoryTestSuite) TestCreateFloor_MapSize() {
+	mockFloor := model.Floor{Id: "f1", BuildingId: "b1", Name: "Lantai 1", Level: 1, MapWidth: 1200, MapHeight: 800}
+
+	suite.mockSql.ExpectQuery("INSERT INTO floors").
+		WithArgs(mockFloor.BuildingId, mockFloor.Name, mockFloor.Level, mockFloor.MapWidth, mockFloor.MapHeight, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "buildingid", "name", "level", "mapwidth", "mapheight", "createdat", "updatedat"}).
+			AddRow(mockFloor.Id, mockFloor.BuildingId, mockFloor.Name, mockFloor.Level, mockFloor.MapWidth, mockFloor.MapHeight, time.Time{}, time.Time{}))
+
+	actual, err := suite.repo.CreateFloor(context.Background(), mockFloor)
+
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), mockFloor, actual)
+}
@@ -0,0 +1,387 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"final-project-booking-room/model"
+	"final-project-booking-room/model/dto"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type ResourceRepository interface {
+	ListTypes(ctx context.Context) ([]model.ResourceType, error)
+	GetType(ctx context.Context, code string) (model.ResourceType, error)
+	CreateNeighborhood(ctx context.Context, payload model.Neighborhood) (model.Neighborhood, error)
+	GetNeighborhood(ctx context.Context, id string) (model.Neighborhood, error)
+	ListNeighborhoods(ctx context.Context, floorId string) ([]model.Neighborhood, error)
+	Create(ctx context.Context, payload model.Resource) (model.Resource, error)
+	Get(ctx context.Context, id string) (model.Resource, error)
+	List(ctx context.Context, filter dto.ResourceFilter) ([]model.Resource, dto.Paging, error)
+	Update(ctx context.Context, id string, payload model.Resource) (model.Resource, error)
+	Archive(ctx context.Context, id string, at time.Time) (int, error)
+	Book(ctx context.Context, payload model.ResourceBooking, limit dto.ResourceBookingLimit) (model.ResourceBooking, error)
+	ListBookings(ctx context.Context, userId string, from time.Time) ([]model.ResourceBooking, error)
+	CancelBooking(ctx context.Context, id string, userId string, at time.Time) error
+}
+
+var (
+	// ErrResourceBooked dikembalikan Book jika resource sudah dibooking di waktu yang beririsan
+	ErrResourceBooked = errors.New("resource is already booked at that time")
+	// ErrResourceLimitReached dikembalikan Book jika user sudah mencapai batas booking per hari untuk tipe resource ini
+	ErrResourceLimitReached = errors.New("daily booking limit reached")
+	// ErrResourceBookingNotFound dikembalikan CancelBooking jika booking tidak ada, bukan milik user atau sudah selesai
+	ErrResourceBookingNotFound = errors.New("resource booking not found or can't be cancelled")
+)
+
+// resource memakai alias r supaya bisa memakai roomLocationJoins dan locationConditions
+const selectResource = `SELECT r.id, r.resourcetype, r.code, r.name, COALESCE(r.neighborhoodid::text, ''), COALESCE(nb.name, ''), COALESCE(nb.division, ''),
+r.attributes, ` + locationColumns + `, r.archivedat, r.createdat, r.updatedat
+FROM resources r LEFT JOIN neighborhoods nb ON nb.id = r.neighborhoodid ` + roomLocationJoins
+
+const (
+	resourceTypeColumns = `code, name, bookingmode, maxdurationminutes, maxadvancedays, maxperuserperday, attributes`
+	neighborhoodColumns = `id, floorid, name, division, createdat, updatedat`
+)
+
+type resourceRepository struct {
+	db *sql.DB
+}
+
+func scanResource(row rowScanner) (model.Resource, error) {
+	var resource model.Resource
+	var attributes []byte
+	var updatedAt sql.NullTime
+	dest := []any{&resource.Id, &resource.ResourceType, &resource.Code, &resource.Name, &resource.NeighborhoodId, &resource.NeighborhoodName, &resource.Division, &attributes}
+	dest = append(dest, locationDest(&resource.Location)...)
+	dest = append(dest, &resource.ArchivedAt, &resource.CreatedAt, &updatedAt)
+	if err := row.Scan(dest...); err != nil {
+		return model.Resource{}, err
+	}
+	resource.FloorId = resource.Location.FloorId
+	resource.UpdatedAt = updatedAt.Time
+	resource.Attributes = map[string]string{}
+	if err := json.Unmarshal(attributes, &resource.Attributes); err != nil {
+		return model.Resource{}, err
+	}
+	return resource, nil
+}
+
+func scanResourceType(row rowScanner) (model.ResourceType, error) {
+	var resourceType model.ResourceType
+	err := row.Scan(&resourceType.Code, &resourceType.Name, &resourceType.BookingMode, &resourceType.MaxDurationMinutes, &resourceType.MaxAdvanceDays,
+		&resourceType.MaxPerUserPerDay, pq.Array(&resourceType.Attributes))
+	return resourceType, err
+}
+
+func scanNeighborhood(row rowScanner) (model.Neighborhood, error) {
+	var neighborhood model.Neighborhood
+	var updatedAt sql.NullTime
+	err := row.Scan(&neighborhood.Id, &neighborhood.FloorId, &neighborhood.Name, &neighborhood.Division, &neighborhood.CreatedAt, &updatedAt)
+	neighborhood.UpdatedAt = updatedAt.Time
+	return neighborhood, err
+}
+
+// ListTypes implements ResourceRepository.
+func (r *resourceRepository) ListTypes(ctx context.Context) ([]model.ResourceType, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+resourceTypeColumns+` FROM resource_types ORDER BY code`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	resourceTypes := []model.ResourceType{}
+	for rows.Next() {
+		resourceType, err := scanResourceType(rows)
+		if err != nil {
+			return nil, err
+		}
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return resourceTypes, nil
+}
+
+// GetType implements ResourceRepository.
+func (r *resourceRepository) GetType(ctx context.Context, code string) (model.ResourceType, error) {
+	return scanResourceType(r.db.QueryRowContext(ctx, `SELECT `+resourceTypeColumns+` FROM resource_types WHERE code = $1`, code))
+}
+
+// CreateNeighborhood implements ResourceRepository.
+func (r *resourceRepository) CreateNeighborhood(ctx context.Context, payload model.Neighborhood) (model.Neighborhood, error) {
+	return scanNeighborhood(r.db.QueryRowContext(ctx, `INSERT INTO neighborhoods (floorid, name, division, updatedat) VALUES ($1, $2, $3, $4) RETURNING `+neighborhoodColumns,
+		payload.FloorId, payload.Name, payload.Division, time.Now().UTC()))
+}
+
+// GetNeighborhood implements ResourceRepository.
+func (r *resourceRepository) GetNeighborhood(ctx context.Context, id string) (model.Neighborhood, error) {
+	return scanNeighborhood(r.db.QueryRowContext(ctx, `SELECT `+neighborhoodColumns+` FROM neighborhoods WHERE id = $1`, id))
+}
+
+// ListNeighborhoods implements ResourceRepository, floorId kosong berarti semua floor
+func (r *resourceRepository) ListNeighborhoods(ctx context.Context, floorId string) ([]model.Neighborhood, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+neighborhoodColumns+` FROM neighborhoods WHERE $1 = '' OR floorid::text = $1 ORDER BY name`, floorId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	neighborhoods := []model.Neighborhood{}
+	for rows.Next() {
+		neighborhood, err := scanNeighborhood(rows)
+		if err != nil {
+			return nil, err
+		}
+		neighborhoods = append(neighborhoods, neighborhood)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return neighborhoods, nil
+}
+
+func resourceAttributes(attributes map[string]string) ([]byte, error) {
+	if attributes == nil {
+		attributes = map[string]string{}
+	}
+	return json.Marshal(attributes)
+}
+
+// Create implements ResourceRepository.
+func (r *resourceRepository) Create(ctx context.Context, payload model.Resource) (model.Resource, error) {
+	attributes, err := resourceAttributes(payload.Attributes)
+	if err != nil {
+		return model.Resource{}, err
+	}
+
+	var id string
+	err = r.db.QueryRowContext(ctx, `INSERT INTO resources (resourcetype, code, name, floorid, neighborhoodid, attributes, updatedat)
+	VALUES ($1, $2, $3, NULLIF($4, '')::uuid, NULLIF($5, '')::uuid, $6, $7) RETURNING id`,
+		payload.ResourceType, payload.Code, payload.Name, payload.FloorId, payload.NeighborhoodId, attributes, time.Now().UTC()).Scan(&id)
+	if err != nil {
+		return model.Resource{}, err
+	}
+	return r.Get(ctx, id)
+}
+
+// Get implements ResourceRepository, resource yang diarsipkan tetap dikembalikan
+func (r *resourceRepository) Get(ctx context.Context, id string) (model.Resource, error) {
+	return scanResource(r.db.QueryRowContext(ctx, selectResource+` WHERE r.id = $1`, id))
+}
+
+// List implements ResourceRepository.
+func (r *resourceRepository) List(ctx context.Context, filter dto.ResourceFilter) ([]model.Resource, dto.Paging, error) {
+	conditions := []string{"r.archivedat IS NULL"}
+	var args []any
+	if filter.ResourceType != "" {
+		conditions = append(conditions, fmt.Sprintf("r.resourcetype = $%d", len(args)+1))
+		args = append(args, filter.ResourceType)
+	}
+	if filter.NeighborhoodId != "" {
+		conditions = append(conditions, fmt.Sprintf("r.neighborhoodid = $%d", len(args)+1))
+		args = append(args, filter.NeighborhoodId)
+	}
+	locations, locationArgs := locationConditions(filter.Location, len(args)+1)
+	conditions = append(conditions, locations...)
+	args = append(args, locationArgs...)
+	// tanggal dibaca di zona masing-masing resource
+	if filter.AvailableOn != "" {
+		n := len(args) + 1
+		conditions = append(conditions, fmt.Sprintf(`NOT EXISTS (SELECT 1 FROM booking_details bd WHERE bd.resourceid = r.id AND bd.status IN ('pending', 'accept')
+		AND bd.bookingdate < (($%d::date + 1)::timestamp AT TIME ZONE %s) AND ($%d::date::timestamp AT TIME ZONE %s) < bd.bookingdateend)`, n, locationZone, n, locationZone))
+		args = append(args, filter.AvailableOn)
+	}
+	where := whereClause(conditions)
+
+	var totalRows int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM resources r `+roomLocationJoins+` `+where, args...).Scan(&totalRows); err != nil {
+		return nil, dto.Paging{}, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, selectResource+` `+where+fmt.Sprintf(` ORDER BY r.resourcetype, r.code LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2),
+		append(args, filter.Size, (filter.Page-1)*filter.Size)...)
+	if err != nil {
+		return nil, dto.Paging{}, err
+	}
+	defer rows.Close()
+
+	resources := []model.Resource{}
+	for rows.Next() {
+		resource, err := scanResource(rows)
+		if err != nil {
+			return nil, dto.Paging{}, err
+		}
+		resources = append(resources, resource)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, dto.Paging{}, err
+	}
+
+	paging := dto.Paging{
+		Page:       filter.Page,
+		Size:       filter.Size,
+		TotalRows:  totalRows,
+		TotalPages: (totalRows + filter.Size - 1) / filter.Size,
+	}
+	return resources, paging, nil
+}
+
+// Update implements ResourceRepository, tipe resource tidak bisa diubah
+func (r *resourceRepository) Update(ctx context.Context, id string, payload model.Resource) (model.Resource, error) {
+	attributes, err := resourceAttributes(payload.Attributes)
+	if err != nil {
+		return model.Resource{}, err
+	}
+
+	_, err = r.db.ExecContext(ctx, `UPDATE resources SET code = $2, name = $3, floorid = NULLIF($4, '')::uuid, neighborhoodid = NULLIF($5, '')::uuid, attributes = $6, updatedat = $7
+	WHERE id = $1`, id, payload.Code, payload.Name, payload.FloorId, payload.NeighborhoodId, attributes, time.Now().UTC())
+	if err != nil {
+		return model.Resource{}, err
+	}
+	return r.Get(ctx, id)
+}
+
+// Archive implements ResourceRepository.
+// Booking yang belum selesai ikut dibatalkan dalam transaksi yang sama, jumlahnya dikembalikan.
+func (r *resourceRepository) Archive(ctx context.Context, id string, at time.Time) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `UPDATE resources SET archivedat = $2, updatedat = $2 WHERE id = $1`, id, at); err != nil {
+		return 0, err
+	}
+	result, err := tx.ExecContext(ctx, `UPDATE booking_details SET status = 'cancel', updatedat = $2
+	WHERE resourceid = $1 AND resourcetype <> 'room' AND status IN ('pending', 'accept') AND bookingdateend > $2`, id, at)
+	if err != nil {
+		return 0, err
+	}
+	cancelled, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return int(cancelled), nil
+}
+
+// Book implements ResourceRepository.
+// Booking resource langsung accept tanpa approval GA, bentrokan dan batas per hari dicek di dalam transaksi.
+func (r *resourceRepository) Book(ctx context.Context, payload model.ResourceBooking, limit dto.ResourceBookingLimit) (model.ResourceBooking, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return model.ResourceBooking{}, err
+	}
+	defer tx.Rollback()
+
+	// kunci resource supaya dua booking di waktu yang sama tidak lolos bersamaan
+	var resourceId string
+	err = tx.QueryRowContext(ctx, `SELECT id FROM resources WHERE id = $1 AND archivedat IS NULL FOR UPDATE`, payload.ResourceId).Scan(&resourceId)
+	if err != nil {
+		return model.ResourceBooking{}, err
+	}
+
+	var conflicts int
+	err = tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM booking_details WHERE resourceid = $1 AND status IN ('pending', 'accept') AND bookingdate < $3 AND $2 < bookingdateend`,
+		payload.ResourceId, payload.Start, payload.End).Scan(&conflicts)
+	if err != nil {
+		return model.ResourceBooking{}, err
+	}
+	if conflicts > 0 {
+		return model.ResourceBooking{}, ErrResourceBooked
+	}
+
+	if limit.MaxPerUser > 0 {
+		// kunci user supaya booking paralel di resource lain tetap terhitung
+		var userId string
+		if err := tx.QueryRowContext(ctx, `SELECT id FROM users WHERE id = $1 FOR UPDATE`, payload.UserId).Scan(&userId); err != nil {
+			return model.ResourceBooking{}, err
+		}
+		var booked int
+		err = tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM booking_details bd JOIN booking b ON b.id = bd.bookingid
+		WHERE b.userid = $1 AND bd.resourcetype = $2 AND bd.status IN ('pending', 'accept') AND bd.bookingdate < $4 AND $3 < bd.bookingdateend`,
+			payload.UserId, payload.ResourceType, limit.DayStart, limit.DayEnd).Scan(&booked)
+		if err != nil {
+			return model.ResourceBooking{}, err
+		}
+		if booked >= limit.MaxPerUser {
+			return model.ResourceBooking{}, ErrResourceLimitReached
+		}
+	}
+
+	now := time.Now().UTC()
+	booking := payload
+	if err := tx.QueryRowContext(ctx, `INSERT INTO booking (userid, updatedat) VALUES ($1, $2) RETURNING id`, payload.UserId, now).Scan(&booking.BookingId); err != nil {
+		return model.ResourceBooking{}, err
+	}
+	err = tx.QueryRowContext(ctx, `INSERT INTO booking_details (bookingid, resourcetype, resourceid, bookingdate, bookingdateend, blockedfrom, blockeduntil, status, description, updatedat)
+	VALUES ($1, $2, $3, $4, $5, $4, $5, 'accept', $6, $7) RETURNING id, status, createdat`,
+		booking.BookingId, payload.ResourceType, payload.ResourceId, payload.Start, payload.End, payload.Description, now).Scan(&booking.Id, &booking.Status, &booking.CreatedAt)
+	if err != nil {
+		return model.ResourceBooking{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return model.ResourceBooking{}, err
+	}
+	return booking, nil
+}
+
+// ListBookings implements ResourceRepository, booking resource milik user yang berakhir setelah from
+func (r *resourceRepository) ListBookings(ctx context.Context, userId string, from time.Time) ([]model.ResourceBooking, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT bd.id, b.id, bd.resourceid, bd.resourcetype, r.name, b.userid, bd.bookingdate, bd.bookingdateend, bd.status,
+	COALESCE(bd.description, ''), bd.createdat
+	FROM booking_details bd JOIN booking b ON b.id = bd.bookingid JOIN resources r ON r.id = bd.resourceid
+	WHERE b.userid = $1 AND bd.resourcetype <> 'room' AND bd.bookingdateend > $2 ORDER BY bd.bookingdate, bd.id`, userId, from)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bookings := []model.ResourceBooking{}
+	for rows.Next() {
+		var booking model.ResourceBooking
+		err := rows.Scan(&booking.Id, &booking.BookingId, &booking.ResourceId, &booking.ResourceType, &booking.ResourceName, &booking.UserId,
+			&booking.Start, &booking.End, &booking.Status, &booking.Description, &booking.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		bookings = append(bookings, booking)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return bookings, nil
+}
+
+// CancelBooking implements ResourceRepository.
+func (r *resourceRepository) CancelBooking(ctx context.Context, id string, userId string, at time.Time) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE booking_details bd SET status = 'cancel', updatedat = $3 FROM booking b
+	WHERE b.id = bd.bookingid AND bd.id = $1 AND b.userid = $2 AND bd.resourcetype <> 'room' AND bd.status IN ('pending', 'accept') AND bd.bookingdateend > $3`,
+		id, userId, at)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrResourceBookingNotFound
+	}
+	return nil
+}
+
+func NewResourceRepository(db *sql.DB) ResourceRepository {
+	return &resourceRepository{db: db}
+}
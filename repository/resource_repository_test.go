@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"final-project-booking-room/model"
+	"final-project-booking-room/model/dto"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ResourceRepositoryTestSuite struct {
+	suite.Suite
+	mockDB  *sql.DB
+	mockSql sqlmock.Sqlmock
+	repo    ResourceRepository
+}
+
+func (suite *ResourceRepositoryTestSuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	assert.NoError(suite.T(), err)
+	suite.mockDB = db
+	suite.mockSql = mock
+	suite.repo = NewResourceRepository(suite.mockDB)
+}
+
+func TestResourceRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(ResourceRepositoryTestSuite))
+}
+
+var mockResourceBooking = model.ResourceBooking{ResourceId: "d1", ResourceType: "desk", UserId: "u1",
+	Start: time.Date(2023, 11, 20, 0, 0, 0, 0, time.UTC), End: time.Date(2023, 11, 21, 0, 0, 0, 0, time.UTC)}
+
+func (suite *ResourceRepositoryTestSuite) TestBook_Success() {
+	limit := dto.ResourceBookingLimit{DayStart: mockResourceBooking.Start, DayEnd: mockResourceBooking.End, MaxPerUser: 1}
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery(`SELECT id FROM resources WHERE id = \$1 AND archivedat IS NULL FOR UPDATE`).WithArgs("d1").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("d1"))
+	suite.mockSql.ExpectQuery(`SELECT COUNT\(\*\) FROM booking_details WHERE resourceid = \$1`).WithArgs("d1", mockResourceBooking.Start, mockResourceBooking.End).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	suite.mockSql.ExpectQuery(`SELECT id FROM users WHERE id = \$1 FOR UPDATE`).WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("u1"))
+	suite.mockSql.ExpectQuery(`SELECT COUNT\(\*\) FROM booking_details bd JOIN booking b .* bd.resourcetype = \$2`).WithArgs("u1", "desk", limit.DayStart, limit.DayEnd).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	suite.mockSql.ExpectQuery("INSERT INTO booking ").WithArgs("u1", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("b1"))
+	suite.mockSql.ExpectQuery("INSERT INTO booking_details").WithArgs("b1", "desk", "d1", mockResourceBooking.Start, mockResourceBooking.End, "", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status", "createdat"}).AddRow("bd1", "accept", time.Now()))
+	suite.mockSql.ExpectCommit()
+
+	booking, err := suite.repo.Book(context.Background(), mockResourceBooking, limit)
+
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+	assert.Equal(suite.T(), "bd1", booking.Id)
+	assert.Equal(suite.T(), "b1", booking.BookingId)
+}
+
+func (suite *ResourceRepositoryTestSuite) TestBook_LimitReached() {
+	limit := dto.ResourceBookingLimit{DayStart: mockResourceBooking.Start, DayEnd: mockResourceBooking.End, MaxPerUser: 1}
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery(`SELECT id FROM resources`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("d1"))
+	suite.mockSql.ExpectQuery(`SELECT COUNT\(\*\) FROM booking_details WHERE resourceid`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	suite.mockSql.ExpectQuery(`SELECT id FROM users`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("u1"))
+	suite.mockSql.ExpectQuery(`SELECT COUNT\(\*\) FROM booking_details bd JOIN booking b`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	suite.mockSql.ExpectRollback()
+
+	_, err := suite.repo.Book(context.Background(), mockResourceBooking, limit)
+
+	assert.ErrorIs(suite.T(), err, ErrResourceLimitReached)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *ResourceRepositoryTestSuite) TestList_AvailableOn() {
+	suite.mockSql.ExpectQuery(`SELECT COUNT\(\*\) FROM resources r .* WHERE r.archivedat IS NULL AND r.resourcetype = \$1 AND NOT EXISTS \(.*\(\(\$2::date \+ 1\)::timestamp AT TIME ZONE COALESCE`).
+		WithArgs("desk", "2023-11-20").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	suite.mockSql.ExpectQuery(`FROM resources r LEFT JOIN neighborhoods nb .* ORDER BY r.resourcetype, r.code LIMIT \$3 OFFSET \$4`).
+		WithArgs("desk", "2023-11-20", 10, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "resourcetype", "code", "name", "neighborhoodid", "neighborhoodname", "division", "attributes",
+			"floorid", "floorname", "floorlevel", "buildingid", "buildingname", "siteid", "sitename", "timezone", "archivedat", "createdat", "updatedat"}).
+			AddRow("d1", "desk", "D-101", "Desk 101", "n1", "Finance Corner", "Finance", []byte(`{"monitor":"2"}`),
+				"f1", "Lantai 1", 1, "b1", "Tower A", "s1", "Jakarta", "Asia/Jakarta", nil, time.Now(), nil))
+
+	resources, paging, err := suite.repo.List(context.Background(), dto.ResourceFilter{ResourceType: "desk", AvailableOn: "2023-11-20", Page: 1, Size: 10})
+
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+	assert.Equal(suite.T(), 1, paging.TotalRows)
+	assert.Equal(suite.T(), map[string]string{"monitor": "2"}, resources[0].Attributes)
+	assert.Equal(suite.T(), "f1", resources[0].FloorId)
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"final-project-booking-room/model"
+
+	"github.com/lib/pq"
+)
+
+// attachRoomAccessRules mengisi AccessRules beberapa ruangan dengan satu query
+func attachRoomAccessRules(ctx context.Context, q queryer, rooms []*model.Room) error {
+	if len(rooms) == 0 {
+		return nil
+	}
+	roomIds := make([]string, len(rooms))
+	for i, room := range rooms {
+		roomIds[i] = room.Id
+	}
+
+	rows, err := q.QueryContext(ctx, `SELECT roomid, effect, field, value FROM room_access_rules
+	WHERE roomid = ANY($1) ORDER BY roomid, effect, field, value`, pq.Array(roomIds))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	rules := make(map[string][]model.RoomAccessRule)
+	for rows.Next() {
+		var roomId string
+		var rule model.RoomAccessRule
+		if err := rows.Scan(&roomId, &rule.Effect, &rule.Field, &rule.Value); err != nil {
+			return err
+		}
+		rules[roomId] = append(rules[roomId], rule)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, room := range rooms {
+		room.AccessRules = rules[room.Id]
+	}
+	return nil
+}
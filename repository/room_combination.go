@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"final-project-booking-room/model"
+
+	"github.com/lib/pq"
+)
+
+// linkedRoomsOf mengembalikan subquery id ruangan yang saling memblokir dengan ruangan ref:
+// ruangan itu sendiri, bagian-bagiannya dan ruangan gabungan yang memuatnya.
+// ref berupa kolom (r.id) atau placeholder yang sudah di-cast ($1::uuid).
+func linkedRoomsOf(ref string) string {
+	return `SELECT ` + ref + ` UNION SELECT rc.partroomid FROM room_combinations rc WHERE rc.combinedroomid = ` + ref +
+		` UNION SELECT rc.combinedroomid FROM room_combinations rc WHERE rc.partroomid = ` + ref
+}
+
+// attachRoomCombinations mengisi PartIds dan CombinedIn beberapa ruangan dengan satu query
+func attachRoomCombinations(ctx context.Context, q queryer, rooms []*model.Room) error {
+	if len(rooms) == 0 {
+		return nil
+	}
+	roomIds := make([]string, len(rooms))
+	for i, room := range rooms {
+		roomIds[i] = room.Id
+	}
+
+	rows, err := q.QueryContext(ctx, `SELECT combinedroomid, partroomid FROM room_combinations
+	WHERE combinedroomid = ANY($1) OR partroomid = ANY($1) ORDER BY combinedroomid, partroomid`, pq.Array(roomIds))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	parts := make(map[string][]string)
+	combinedIn := make(map[string][]string)
+	for rows.Next() {
+		var combinedId, partId string
+		if err := rows.Scan(&combinedId, &partId); err != nil {
+			return err
+		}
+		parts[combinedId] = append(parts[combinedId], partId)
+		combinedIn[partId] = append(combinedIn[partId], combinedId)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, room := range rooms {
+		room.PartIds = parts[room.Id]
+		room.CombinedIn = combinedIn[room.Id]
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"final-project-booking-room/model"
+
+	"github.com/lib/pq"
+)
+
+type RoomImageRepository interface {
+	Create(ctx context.Context, payload model.RoomImage) (model.RoomImage, error)
+	Get(ctx context.Context, id string) (model.RoomImage, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type roomImageRepository struct {
+	db *sql.DB
+}
+
+const roomImageColumns = `id, roomid, kind, url, thumbnailurl, contenttype, size, storagekey, thumbnailkey, createdat`
+
+func scanRoomImage(row rowScanner) (model.RoomImage, error) {
+	var image model.RoomImage
+	err := row.Scan(&image.Id, &image.RoomId, &image.Kind, &image.Url, &image.ThumbnailUrl, &image.ContentType, &image.Size,
+		&image.StorageKey, &image.ThumbnailKey, &image.CreatedAt)
+	return image, err
+}
+
+func (r *roomImageRepository) Create(ctx context.Context, payload model.RoomImage) (model.RoomImage, error) {
+	return scanRoomImage(r.db.QueryRowContext(ctx, `INSERT INTO room_images (roomid, kind, url, thumbnailurl, contenttype, size, storagekey, thumbnailkey)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING `+roomImageColumns,
+		payload.RoomId, payload.Kind, payload.Url, payload.ThumbnailUrl, payload.ContentType, payload.Size, payload.StorageKey, payload.ThumbnailKey))
+}
+
+func (r *roomImageRepository) Get(ctx context.Context, id string) (model.RoomImage, error) {
+	return scanRoomImage(r.db.QueryRowContext(ctx, `SELECT `+roomImageColumns+` FROM room_images WHERE id = $1`, id))
+}
+
+func (r *roomImageRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM room_images WHERE id = $1`, id)
+	return err
+}
+
+// getRoomImages mengambil gambar beberapa ruangan sekaligus, dikelompokkan per room id
+func getRoomImages(ctx context.Context, q queryer, roomIds []string) (map[string][]model.RoomImage, error) {
+	roomImages := make(map[string][]model.RoomImage)
+	if len(roomIds) == 0 {
+		return roomImages, nil
+	}
+
+	rows, err := q.QueryContext(ctx, `SELECT `+roomImageColumns+` FROM room_images WHERE roomid = ANY($1) ORDER BY kind, createdat`, pq.Array(roomIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		image, err := scanRoomImage(rows)
+		if err != nil {
+			return nil, err
+		}
+		roomImages[image.RoomId] = append(roomImages[image.RoomId], image)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return roomImages, nil
+}
+
+func NewRoomImageRepository(db *sql.DB) RoomImageRepository {
+	return &roomImageRepository{db: db}
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"final-project-booking-room/model"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type RoomLayoutRepository interface {
+	Create(ctx context.Context, payload model.RoomLayout) (model.RoomLayout, error)
+	Get(ctx context.Context, id string) (model.RoomLayout, error)
+	Update(ctx context.Context, payload model.RoomLayout) (model.RoomLayout, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type roomLayoutRepository struct {
+	db *sql.DB
+}
+
+const roomLayoutColumns = `id, roomid, name, capacity, setupminutes, teardownminutes, createdat, updatedat`
+
+func scanRoomLayout(row rowScanner) (model.RoomLayout, error) {
+	var layout model.RoomLayout
+	var updatedAt sql.NullTime
+	err := row.Scan(&layout.Id, &layout.RoomId, &layout.Name, &layout.Capacity, &layout.SetupMinutes, &layout.TeardownMinutes, &layout.CreatedAt, &updatedAt)
+	layout.UpdatedAt = updatedAt.Time
+	return layout, err
+}
+
+func (r *roomLayoutRepository) Create(ctx context.Context, payload model.RoomLayout) (model.RoomLayout, error) {
+	return scanRoomLayout(r.db.QueryRowContext(ctx, `INSERT INTO room_layouts (roomid, name, capacity, setupminutes, teardownminutes)
+	VALUES ($1, $2, $3, $4, $5) RETURNING `+roomLayoutColumns,
+		payload.RoomId, payload.Name, payload.Capacity, payload.SetupMinutes, payload.TeardownMinutes))
+}
+
+func (r *roomLayoutRepository) Get(ctx context.Context, id string) (model.RoomLayout, error) {
+	return scanRoomLayout(r.db.QueryRowContext(ctx, `SELECT `+roomLayoutColumns+` FROM room_layouts WHERE id = $1`, id))
+}
+
+func (r *roomLayoutRepository) Update(ctx context.Context, payload model.RoomLayout) (model.RoomLayout, error) {
+	return scanRoomLayout(r.db.QueryRowContext(ctx, `UPDATE room_layouts SET name = $1, capacity = $2, setupminutes = $3, teardownminutes = $4, updatedat = $5
+	WHERE id = $6 RETURNING `+roomLayoutColumns,
+		payload.Name, payload.Capacity, payload.SetupMinutes, payload.TeardownMinutes, time.Now().UTC(), payload.Id))
+}
+
+// Delete tidak mengubah booking lama, layoutid di booking_details menjadi NULL lewat ON DELETE SET NULL
+func (r *roomLayoutRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM room_layouts WHERE id = $1`, id)
+	return err
+}
+
+// getRoomLayouts mengambil layout beberapa ruangan sekaligus, dikelompokkan per room id
+func getRoomLayouts(ctx context.Context, q queryer, roomIds []string) (map[string][]model.RoomLayout, error) {
+	roomLayouts := make(map[string][]model.RoomLayout)
+	if len(roomIds) == 0 {
+		return roomLayouts, nil
+	}
+
+	rows, err := q.QueryContext(ctx, `SELECT `+roomLayoutColumns+` FROM room_layouts WHERE roomid = ANY($1) ORDER BY capacity DESC, name`, pq.Array(roomIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		layout, err := scanRoomLayout(rows)
+		if err != nil {
+			return nil, err
+		}
+		roomLayouts[layout.RoomId] = append(roomLayouts[layout.RoomId], layout)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return roomLayouts, nil
+}
+
+func NewRoomLayoutRepository(db *sql.DB) RoomLayoutRepository {
+	return &roomLayoutRepository{db: db}
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"final-project-booking-room/model/dto"
+
+	"github.com/lib/pq"
+)
+
+// Recommend mengambil ruangan available yang muat untuk filter.Attendees beserta amenity yang diminta yang dimilikinya
+// dan interval terpakai di rentang filter.From-filter.Until. Ruangan yang amenity-nya kurang tetap dikembalikan.
+func (r *roomRepository) Recommend(ctx context.Context, filter dto.RecommendationFilter) ([]dto.RoomCandidate, error) {
+	conditions, args := roomConditions(dto.RoomFilter{
+		Status:      "available",
+		MinCapacity: filter.Attendees,
+		Location:    filter.Location,
+		Viewer:      filter.Viewer,
+	}, 2)
+
+	rows, err := r.db.QueryContext(ctx, `SELECT r.id, COALESCE(r.name, r.roomtype), r.roomtype, r.capacity, COALESCE(bg.id::text, ''), COALESCE(bg.name, ''),
+	ARRAY(SELECT DISTINCT a.code FROM room_amenities ra JOIN amenities a ON a.id = ra.amenityid WHERE ra.roomid = r.id AND ra.quantity > 0 AND a.code = ANY($1) ORDER BY a.code)
+	FROM rooms r `+roomLocationJoins+` `+whereClause(conditions)+` ORDER BY r.capacity, r.id`, append([]any{pq.Array(filter.Amenities)}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	candidates := []dto.RoomCandidate{}
+	candidateIndex := make(map[string]int)
+	var roomIds []string
+	for rows.Next() {
+		var candidate dto.RoomCandidate
+		err := rows.Scan(&candidate.RoomId, &candidate.RoomName, &candidate.RoomType, &candidate.Capacity, &candidate.BuildingId, &candidate.BuildingName,
+			pq.Array(&candidate.Amenities))
+		if err != nil {
+			return nil, err
+		}
+		candidateIndex[candidate.RoomId] = len(candidates)
+		candidates = append(candidates, candidate)
+		roomIds = append(roomIds, candidate.RoomId)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return candidates, nil
+	}
+
+	// booking di ruangan gabungan atau bagiannya ikut membuat ruangan terpakai
+	busyRows, err := r.db.QueryContext(ctx, `SELECT c.id, COALESCE(bd.blockedfrom, bd.bookingdate), COALESCE(bd.blockeduntil, bd.bookingdateend)
+	FROM rooms c JOIN booking_details bd ON bd.roomid IN (`+linkedRoomsOf("c.id")+`)
+	WHERE c.id = ANY($1) AND bd.status IN ('pending', 'accept') AND COALESCE(bd.blockedfrom, bd.bookingdate) < $3 AND $2 < COALESCE(bd.blockeduntil, bd.bookingdateend)
+	ORDER BY 2`, pq.Array(roomIds), filter.From, filter.Until)
+	if err != nil {
+		return nil, err
+	}
+	defer busyRows.Close()
+
+	for busyRows.Next() {
+		var roomId string
+		var busy dto.BusyInterval
+		if err := busyRows.Scan(&roomId, &busy.From, &busy.Until); err != nil {
+			return nil, err
+		}
+		i := candidateIndex[roomId]
+		candidates[i].Busy = append(candidates[i].Busy, busy)
+	}
+	if err := busyRows.Err(); err != nil {
+		return nil, err
+	}
+
+	return candidates, nil
+}
+
+// UsualBuilding mengembalikan gedung yang paling sering dibooking user (booking accept), kosong jika belum ada riwayat
+func (r *roomRepository) UsualBuilding(ctx context.Context, userId string) (string, error) {
+	var buildingId string
+	err := r.db.QueryRowContext(ctx, `SELECT fl.buildingid FROM booking_details bd JOIN booking b ON b.id = bd.bookingid
+	JOIN rooms r ON r.id = bd.roomid JOIN floors fl ON fl.id = r.floorid
+	WHERE b.userid = $1 AND bd.status = 'accept' GROUP BY fl.buildingid ORDER BY COUNT(*) DESC, MAX(bd.bookingdate) DESC LIMIT 1`, userId).Scan(&buildingId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return buildingId, nil
+}
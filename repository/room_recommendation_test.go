@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"final-project-booking-room/model/dto"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *RoomRepositoryTestSuite) TestRecommend_Success() {
+	start := time.Date(2023, 11, 20, 9, 0, 0, 0, time.UTC)
+	filter := dto.RecommendationFilter{Attendees: 3, Amenities: []string{"projector", "wifi"}, From: start.Add(-3 * time.Hour), Until: start.Add(4 * time.Hour)}
+
+	suite.mockSql.ExpectQuery(`SELECT r.id, .* ARRAY\(SELECT DISTINCT a.code .* a.code = ANY\(\$1\) ORDER BY a.code\) FROM rooms r .* WHERE r.archivedat IS NULL AND \(r.status = 'available' .*\) AND r.capacity >= \$2 ORDER BY r.capacity, r.id`).
+		WithArgs(sqlmock.AnyArg(), 3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "roomtype", "capacity", "buildingid", "buildingname", "amenities"}).
+			AddRow("r6", "Mawar", "meeting", 6, "b1", "Tower A", "{projector,wifi}").
+			AddRow("r8", "Melati", "meeting", 8, "b1", "Tower A", "{wifi}"))
+	suite.mockSql.ExpectQuery(`FROM rooms c JOIN booking_details bd ON bd.roomid IN \(SELECT c.id UNION .*\) WHERE c.id = ANY\(\$1\) AND bd.status IN \('pending', 'accept'\)`).
+		WithArgs(sqlmock.AnyArg(), filter.From, filter.Until).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "blockedfrom", "blockeduntil"}).
+			AddRow("r8", start, start.Add(time.Hour)))
+
+	candidates, err := suite.repo.Recommend(context.Background(), filter)
+
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+	assert.Equal(suite.T(), []dto.RoomCandidate{
+		{RoomId: "r6", RoomName: "Mawar", RoomType: "meeting", Capacity: 6, BuildingId: "b1", BuildingName: "Tower A", Amenities: []string{"projector", "wifi"}},
+		{RoomId: "r8", RoomName: "Melati", RoomType: "meeting", Capacity: 8, BuildingId: "b1", BuildingName: "Tower A", Amenities: []string{"wifi"},
+			Busy: []dto.BusyInterval{{From: start, Until: start.Add(time.Hour)}}},
+	}, candidates)
+}
+
+func (suite *RoomRepositoryTestSuite) TestUsualBuilding_NoHistory() {
+	suite.mockSql.ExpectQuery(`SELECT fl.buildingid FROM booking_details bd .* WHERE b.userid = \$1 AND bd.status = 'accept' GROUP BY fl.buildingid`).
+		WithArgs("u1").
+		WillReturnError(sql.ErrNoRows)
+
+	buildingId, err := suite.repo.UsualBuilding(context.Background(), "u1")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "", buildingId)
+}
@@ -1,71 +1,65 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"final-project-booking-room/model"
+	"final-project-booking-room/model/dto"
 	"fmt"
+	"strings"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 type RoomRepository interface {
-	Create(payload model.Room) (model.Room, error)
-	Get(id string) (model.Room, error)
-	GetByRoomType(roomType string) (model.Room, error)
-	GetAllRoom() ([]model.Room, error)
-	Delete(id string) (model.Room, error)
-	Update(id string, payload model.Room) (model.Room, error)
-	GetStatus(id string) (string, error)
-	GetStatusByBd(id string) (string, error)
-	ChangeStatus(id string) error
-	GetAllRoomByStatus(status string) ([]model.Room, error)
+	Create(ctx context.Context, payload model.Room) (model.Room, error)
+	Get(ctx context.Context, id string) (model.Room, error)
+	List(ctx context.Context, filter dto.RoomFilter) ([]model.Room, dto.Paging, error)
+	Archive(ctx context.Context, id string, option dto.ArchiveOption, at time.Time) ([]dto.AffectedBooking, error)
+	FutureBookings(ctx context.Context, id string, at time.Time) ([]dto.AffectedBooking, error)
+	Restore(ctx context.Context, id string) error
+	SetParts(ctx context.Context, id string, partIds []string) error
+	SetAccessRules(ctx context.Context, id string, rules []model.RoomAccessRule) error
+	Import(ctx context.Context, rows []dto.RoomImportRow, dryRun bool) (dto.RoomImportResult, error)
+	Update(ctx context.Context, id string, payload model.Room) (model.Room, error)
+	GetStatus(ctx context.Context, id string) (string, error)
+	GetStatusByBd(ctx context.Context, id string) (string, error)
+	ChangeStatus(ctx context.Context, id string) error
+	Recommend(ctx context.Context, filter dto.RecommendationFilter) ([]dto.RoomCandidate, error)
+	UsualBuilding(ctx context.Context, userId string) (string, error)
 }
 
+// kolom nama, kode dan lokasi ruangan (alias r), dipakai juga oleh query booking details.
+// Ruangan lama yang belum punya floor menghasilkan lokasi kosong. locationColumns dipakai juga oleh resource.
+const (
+	roomLocationColumns = `COALESCE(r.name, r.roomtype), COALESCE(r.code, ''), ` + locationColumns
+	locationColumns     = `COALESCE(fl.id::text, ''), COALESCE(fl.name, ''), COALESCE(fl.level, 0), COALESCE(bg.id::text, ''), COALESCE(bg.name, ''), COALESCE(s.id::text, ''), COALESCE(s.name, ''), COALESCE(NULLIF(bg.timezone, ''), s.timezone, '')`
+	roomLocationJoins   = `LEFT JOIN floors fl ON fl.id = r.floorid LEFT JOIN buildings bg ON bg.id = fl.buildingid LEFT JOIN sites s ON s.id = bg.siteid`
+	// zona lokal dari roomLocationJoins, sama dengan RoomLocation.Zone yang memakai UTC jika lokasinya kosong
+	locationZone = `COALESCE(NULLIF(bg.timezone, ''), NULLIF(s.timezone, ''), 'UTC')`
+)
+
+// kolom facility lama dibaca dari view v_room_facilities yang diturunkan dari room_amenities
+const selectRoom = `SELECT r.id, r.roomtype, r.capacity, f.id, f.roomdescription, f.fwifi, f.fsoundsystem, f.fprojector, f.fscreenprojector, f.fchairs, f.ftables, f.fsoundproof, f.fsmonkingarea, f.ftelevison, f.fac, f.fbathroom, f.fcoffemaker, f.createdat, f.updatedat, r.status, r.createdat, r.updatedat, ` + roomLocationColumns + `, r.archivedat FROM rooms AS r JOIN v_room_facilities AS f ON f.roomid = r.id ` + roomLocationJoins
+
 type roomRepository struct {
 	db *sql.DB
 }
 
-func (r *roomRepository) Create(payload model.Room) (model.Room, error) {
-
-	var room model.Room
-
-	var roomFacility model.RoomFacility
-	err := r.db.QueryRow(`INSERT INTO facilities (roomdescription, fwifi, fsoundsystem, fprojector, fscreenprojector, fchairs, ftables, fsoundproof, fsmonkingarea, ftelevison, fac, fbathroom, fcoffemaker, updatedat) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14) RETURNING id, roomdescription, fwifi, fsoundsystem, fprojector, fscreenprojector, fchairs, ftables, fsoundproof, fsmonkingarea, ftelevison, fac, fbathroom, fcoffemaker,createdat, updatedat`, payload.Facility.RoomDescription, payload.Facility.Fwifi, payload.Facility.FsoundSystem, payload.Facility.Fprojector, payload.Facility.FscreenProjector, payload.Facility.Fchairs, payload.Facility.Ftables, payload.Facility.FsoundProof, payload.Facility.FsmonkingArea, payload.Facility.Ftelevison, payload.Facility.FAc, payload.Facility.Fbathroom, payload.Facility.FcoffeMaker, time.Now()).Scan(
-		&roomFacility.Id,
-		&roomFacility.RoomDescription,
-		&roomFacility.Fwifi,
-		&roomFacility.FsoundSystem,
-		&roomFacility.Fprojector,
-		&roomFacility.FscreenProjector,
-		&roomFacility.Fchairs,
-		&roomFacility.Ftables,
-		&roomFacility.FsoundProof,
-		&roomFacility.FsmonkingArea,
-		&roomFacility.Ftelevison,
-		&roomFacility.FAc,
-		&roomFacility.Fbathroom,
-		&roomFacility.FcoffeMaker,
-		&roomFacility.CreatedAt,
-		&roomFacility.UpdatedAt,
-	)
-	if err != nil {
-		return model.Room{}, err
-	}
-
-	room.Facility.Id = roomFacility.Id
-	room.Facility = roomFacility
-
-	err = r.db.QueryRow(`INSERT INTO rooms (roomtype, capacity, facilities ,status, updatedat) VALUES ($1, $2, $3, $4, $5) RETURNING id, roomtype, capacity, status, createdat, updatedat`, payload.RoomType, payload.MaxCapacity, roomFacility.Id, payload.Status, time.Now()).Scan(
-		&room.Id, &room.RoomType, &room.MaxCapacity, &room.Status, &room.CreatedAt, &room.UpdatedAt)
-	if err != nil {
-		return model.Room{}, err
-	}
+type rowScanner interface {
+	Scan(dest ...any) error
+}
 
-	return room, err
+type rowQueryer interface {
+	queryer
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
 }
 
-func (r *roomRepository) Get(id string) (model.Room, error) {
+func scanRoom(row rowScanner) (model.Room, error) {
 	var room model.Room
-	err := r.db.QueryRow(`SELECT r.id, r.roomtype, r.capacity, f.id, f.roomdescription, f.fwifi, f.fsoundsystem, f.fprojector, f.fscreenprojector, f.fchairs, f.ftables, f.fsoundproof, f.fsmonkingarea, f.ftelevison, f.fac, f.fbathroom, f.fcoffemaker, f.createdat, f.updatedat, r.status, r.createdat, r.updatedat FROM rooms AS r JOIN facilities AS f ON f.id = r.facilities WHERE r.id = $1;`, id).Scan(
+	dest := []any{
 		&room.Id,
 		&room.RoomType,
 		&room.MaxCapacity,
@@ -88,221 +82,621 @@ func (r *roomRepository) Get(id string) (model.Room, error) {
 		&room.Status,
 		&room.CreatedAt,
 		&room.UpdatedAt,
-	)
+	}
+	dest = append(dest, roomLocationDest(&room)...)
+	dest = append(dest, &room.ArchivedAt)
+	err := row.Scan(dest...)
+	room.FloorId = room.Location.FloorId
+	return room, err
+}
+
+// roomLocationDest adalah tujuan Scan untuk roomLocationColumns
+func roomLocationDest(room *model.Room) []any {
+	return append([]any{
+		&room.Name,
+		&room.Code,
+	}, locationDest(&room.Location)...)
+}
+
+// locationDest adalah tujuan Scan untuk locationColumns
+func locationDest(location *model.RoomLocation) []any {
+	return []any{
+		&location.FloorId,
+		&location.FloorName,
+		&location.FloorLevel,
+		&location.BuildingId,
+		&location.BuildingName,
+		&location.SiteId,
+		&location.SiteName,
+		&location.Timezone,
+	}
+}
+
+// locationConditions menyusun kondisi WHERE lokasi terhadap alias fl, bg dan s dari roomLocationJoins
+func locationConditions(filter dto.LocationFilter, argIndex int) ([]string, []any) {
+	var conditions []string
+	var args []any
+
+	if filter.SiteId != "" {
+		conditions = append(conditions, fmt.Sprintf("s.id = $%d", argIndex+len(args)))
+		args = append(args, filter.SiteId)
+	}
+	if filter.BuildingId != "" {
+		conditions = append(conditions, fmt.Sprintf("bg.id = $%d", argIndex+len(args)))
+		args = append(args, filter.BuildingId)
+	}
+	if filter.FloorId != "" {
+		conditions = append(conditions, fmt.Sprintf("fl.id = $%d", argIndex+len(args)))
+		args = append(args, filter.FloorId)
+	}
+
+	return conditions, args
+}
+
+// roomSortColumns memetakan sortBy dari query ke kolom ruangan
+var roomSortColumns = map[string]string{
+	"name":      "COALESCE(r.name, r.roomtype)",
+	"capacity":  "r.capacity",
+	"createdAt": "r.createdat",
+}
+
+// roomAvailable bernilai true jika ruangan, bagiannya dan ruangan gabungannya tidak sedang booked
+// dan tidak ada tiket critical yang belum resolved
+var roomAvailable = `(r.status = 'available' AND NOT EXISTS (SELECT 1 FROM rooms lr WHERE lr.status = 'booked' AND lr.id IN (` + linkedRoomsOf("r.id") + `)) AND NOT ` + criticalTicketOpen("r.id") + `)`
+
+// roomConditions menyusun kondisi WHERE list ruangan (alias r) beserta lokasinya
+func roomConditions(filter dto.RoomFilter, argIndex int) ([]string, []any) {
+	conditions := []string{"r.archivedat IS NULL"}
+	var args []any
+
+	if filter.RoomType != "" {
+		conditions = append(conditions, fmt.Sprintf("r.roomtype = $%d", argIndex+len(args)))
+		args = append(args, filter.RoomType)
+	}
+	switch filter.Status {
+	case "available":
+		conditions = append(conditions, roomAvailable)
+	case "booked":
+		conditions = append(conditions, "NOT "+roomAvailable+" AND NOT "+criticalTicketOpen("r.id"))
+	case "maintenance":
+		conditions = append(conditions, criticalTicketOpen("r.id"))
+	}
+	if filter.MinCapacity > 0 {
+		conditions = append(conditions, fmt.Sprintf("r.capacity >= $%d", argIndex+len(args)))
+		args = append(args, filter.MinCapacity)
+	}
+	if filter.MaxCapacity > 0 {
+		conditions = append(conditions, fmt.Sprintf("r.capacity <= $%d", argIndex+len(args)))
+		args = append(args, filter.MaxCapacity)
+	}
+	// ruangan harus punya semua amenity yang diminta
+	if len(filter.Amenities) > 0 {
+		conditions = append(conditions, fmt.Sprintf(`(SELECT COUNT(DISTINCT a.code) FROM room_amenities ra JOIN amenities a ON a.id = ra.amenityid
+		WHERE ra.roomid = r.id AND ra.quantity > 0 AND a.code = ANY($%d)) = $%d`, argIndex+len(args), argIndex+len(args)+1))
+		args = append(args, pq.Array(filter.Amenities), len(filter.Amenities))
+	}
+
+	locations, locationArgs := locationConditions(filter.Location, argIndex+len(args))
+	conditions = append(conditions, locations...)
+	args = append(args, locationArgs...)
+
+	// aturan yang sama dengan model.Room.AllowsUser: deny menang, rule allow membatasi ke user yang cocok
+	if filter.Viewer != nil {
+		matches := fmt.Sprintf(`LOWER(ar.value) = LOWER(CASE ar.field WHEN 'divisi' THEN $%d WHEN 'jabatan' THEN $%d ELSE $%d END)`,
+			argIndex+len(args), argIndex+len(args)+1, argIndex+len(args)+2)
+		conditions = append(conditions,
+			`NOT EXISTS (SELECT 1 FROM room_access_rules ar WHERE ar.roomid = r.id AND ar.effect = 'deny' AND `+matches+`)`,
+			`(NOT EXISTS (SELECT 1 FROM room_access_rules ar WHERE ar.roomid = r.id AND ar.effect = 'allow') OR EXISTS (SELECT 1 FROM room_access_rules ar WHERE ar.roomid = r.id AND ar.effect = 'allow' AND `+matches+`))`)
+		args = append(args, strings.TrimSpace(filter.Viewer.Divisi), strings.TrimSpace(filter.Viewer.Jabatan), strings.TrimSpace(filter.Viewer.Role))
+	}
+
+	return conditions, args
+}
+
+func (r *roomRepository) Create(ctx context.Context, payload model.Room) (model.Room, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return model.Room{}, err
 	}
+	defer tx.Rollback()
 
-	return room, err
+	roomId, err := insertRoom(ctx, tx, payload)
+	if err != nil {
+		return model.Room{}, err
+	}
+
+	room, err := r.getRoom(ctx, tx, roomId)
+	if err != nil {
+		return model.Room{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return model.Room{}, err
+	}
+	return room, nil
 }
 
-func (r *roomRepository) GetAllRoomByStatus(status string) ([]model.Room, error) {
-	var rooms []model.Room
+// insertRoom menyimpan ruangan baru beserta facility dan amenitynya, mengembalikan id ruangan
+func insertRoom(ctx context.Context, tx *sql.Tx, payload model.Room) (string, error) {
+	var facilityId string
+	err := tx.QueryRowContext(ctx, `INSERT INTO facilities (roomdescription, updatedat) VALUES ($1, $2) RETURNING id`, payload.Facility.RoomDescription, time.Now()).Scan(&facilityId)
+	if err != nil {
+		return "", err
+	}
 
-	rows, err := r.db.Query(`SELECT r.id, r.roomtype, r.capacity, f.id, f.roomdescription, f.fwifi, f.fsoundsystem, f.fprojector, f.fscreenprojector, f.fchairs, f.ftables, f.fsoundproof, f.fsmonkingarea, f.ftelevison, f.fac, f.fbathroom, f.fcoffemaker, f.createdat, f.updatedat, r.status, r.createdat, r.updatedat FROM rooms AS r JOIN facilities AS f ON f.id = r.facilities WHERE r.status = $1`, status)
+	var roomId string
+	err = tx.QueryRowContext(ctx, `INSERT INTO rooms (name, code, roomtype, capacity, facilities, floorid, status, updatedat) VALUES ($1, NULLIF($2, ''), $3, $4, $5, NULLIF($6, '')::uuid, $7, $8) RETURNING id`,
+		payload.Name, payload.Code, payload.RoomType, payload.MaxCapacity, facilityId, payload.FloorId, payload.Status, time.Now()).Scan(&roomId)
 	if err != nil {
-		return []model.Room{}, err
+		return "", err
+	}
+
+	if err := replaceRoomAmenities(ctx, tx, roomId, payload.Amenities); err != nil {
+		return "", err
 	}
-	if status != "available" {
-		return []model.Room{}, fmt.Errorf("room with status %s not found", status)
+	return roomId, nil
+}
+
+// ErrAmenityNotFound dikembalikan Create dan Update jika payload ruangan merujuk amenity yang tidak ada
+var ErrAmenityNotFound = errors.New("amenity not found")
+
+// replaceRoomAmenities mengganti seluruh amenity sebuah ruangan dengan daftar yang baru.
+// Amenity bertipe boolean selalu disimpan dengan quantity 1.
+func replaceRoomAmenities(ctx context.Context, tx *sql.Tx, roomId string, amenities []model.RoomAmenity) error {
+	_, err := tx.ExecContext(ctx, `DELETE FROM room_amenities WHERE roomid = $1`, roomId)
+	if err != nil {
+		return err
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		var room model.Room
-		err := rows.Scan(
-			&room.Id,
-			&room.RoomType,
-			&room.MaxCapacity,
-			&room.Facility.Id,
-			&room.Facility.RoomDescription,
-			&room.Facility.Fwifi,
-			&room.Facility.FsoundSystem,
-			&room.Facility.Fprojector,
-			&room.Facility.FscreenProjector,
-			&room.Facility.Fchairs,
-			&room.Facility.Ftables,
-			&room.Facility.FsoundProof,
-			&room.Facility.FsmonkingArea,
-			&room.Facility.Ftelevison,
-			&room.Facility.FAc,
-			&room.Facility.Fbathroom,
-			&room.Facility.FcoffeMaker,
-			&room.Facility.CreatedAt,
-			&room.Facility.UpdatedAt,
-			&room.Status,
-			&room.CreatedAt,
-			&room.UpdatedAt,
-		)
+	for _, amenity := range amenities {
+		result, err := tx.ExecContext(ctx, `INSERT INTO room_amenities (roomid, amenityid, quantity)
+		SELECT $1, a.id, CASE WHEN a.type = 'boolean' THEN 1 ELSE $3 END FROM amenities a WHERE a.id = $2`, roomId, amenity.Id, amenity.Quantity)
 		if err != nil {
-			return []model.Room{}, err
+			return err
 		}
-		rooms = append(rooms, room)
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return fmt.Errorf("%w: %s", ErrAmenityNotFound, amenity.Id)
+		}
+	}
+	return nil
+}
+
+// getRoom membaca satu ruangan beserta amenity, gambar dan layoutnya, q bisa berupa *sql.DB atau *sql.Tx
+func (r *roomRepository) getRoom(ctx context.Context, q rowQueryer, id string) (model.Room, error) {
+	room, err := scanRoom(q.QueryRowContext(ctx, selectRoom+` WHERE r.id = $1`, id))
+	if err != nil {
+		return model.Room{}, err
+	}
+
+	amenities, err := getRoomAmenities(ctx, q, []string{room.Id})
+	if err != nil {
+		return model.Room{}, err
 	}
+	room.Amenities = amenities[room.Id]
+
+	images, err := getRoomImages(ctx, q, []string{room.Id})
 	if err != nil {
-		return []model.Room{}, err
+		return model.Room{}, err
+	}
+	room.Images = images[room.Id]
+
+	layouts, err := getRoomLayouts(ctx, q, []string{room.Id})
+	if err != nil {
+		return model.Room{}, err
 	}
+	room.Layouts = layouts[room.Id]
 
-	return rooms, err
+	if err := attachRoomCombinations(ctx, q, []*model.Room{&room}); err != nil {
+		return model.Room{}, err
+	}
+	if err := attachRoomAccessRules(ctx, q, []*model.Room{&room}); err != nil {
+		return model.Room{}, err
+	}
 
+	return room, nil
 }
 
-func (r *roomRepository) GetAllRoom() ([]model.Room, error) {
-	var rooms []model.Room
+func (r *roomRepository) Get(ctx context.Context, id string) (model.Room, error) {
+	return r.getRoom(ctx, r.db, id)
+}
 
-	rows, err := r.db.Query(`SELECT r.id, r.roomtype, r.capacity, f.id, f.roomdescription, f.fwifi, f.fsoundsystem, f.fprojector, f.fscreenprojector, f.fchairs, f.ftables, f.fsoundproof, f.fsmonkingarea, f.ftelevison, f.fac, f.fbathroom, f.fcoffemaker, f.createdat, f.updatedat, r.status, r.createdat, r.updatedat FROM rooms AS r JOIN facilities AS f ON f.id = r.facilities`)
+// getRooms menjalankan query ruangan lalu mengisi amenity, gambar dan layout semua ruangan, masing-masing dengan satu query tambahan
+func (r *roomRepository) getRooms(ctx context.Context, query string, args ...any) ([]model.Room, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
+	var rooms []model.Room
+	var roomIds []string
 	for rows.Next() {
-		var room model.Room
-		err := rows.Scan(
-			&room.Id,
-			&room.RoomType,
-			&room.MaxCapacity,
-			&room.Facility.Id,
-			&room.Facility.RoomDescription,
-			&room.Facility.Fwifi,
-			&room.Facility.FsoundSystem,
-			&room.Facility.Fprojector,
-			&room.Facility.FscreenProjector,
-			&room.Facility.Fchairs,
-			&room.Facility.Ftables,
-			&room.Facility.FsoundProof,
-			&room.Facility.FsmonkingArea,
-			&room.Facility.Ftelevison,
-			&room.Facility.FAc,
-			&room.Facility.Fbathroom,
-			&room.Facility.FcoffeMaker,
-			&room.Facility.CreatedAt,
-			&room.Facility.UpdatedAt,
-			&room.Status,
-			&room.CreatedAt,
-			&room.UpdatedAt,
-		)
+		room, err := scanRoom(rows)
 		if err != nil {
 			return nil, err
 		}
 		rooms = append(rooms, room)
+		roomIds = append(roomIds, room.Id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
+
+	amenities, err := getRoomAmenities(ctx, r.db, roomIds)
 	if err != nil {
 		return nil, err
 	}
+	images, err := getRoomImages(ctx, r.db, roomIds)
+	if err != nil {
+		return nil, err
+	}
+	layouts, err := getRoomLayouts(ctx, r.db, roomIds)
+	if err != nil {
+		return nil, err
+	}
+	targets := make([]*model.Room, len(rooms))
+	for i := range rooms {
+		rooms[i].Amenities = amenities[rooms[i].Id]
+		rooms[i].Images = images[rooms[i].Id]
+		rooms[i].Layouts = layouts[rooms[i].Id]
+		targets[i] = &rooms[i]
+	}
+	if err := attachRoomCombinations(ctx, r.db, targets); err != nil {
+		return nil, err
+	}
+	if err := attachRoomAccessRules(ctx, r.db, targets); err != nil {
+		return nil, err
+	}
+
+	return rooms, nil
+}
+
+// List mengambil ruangan yang cocok dengan filter per halaman, ruangan yang diarsipkan tidak ikut
+func (r *roomRepository) List(ctx context.Context, filter dto.RoomFilter) ([]model.Room, dto.Paging, error) {
+	conditions, args := roomConditions(filter, 1)
+	where := whereClause(conditions)
+
+	var totalRows int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM rooms AS r `+roomLocationJoins+` `+where, args...).Scan(&totalRows)
+	if err != nil {
+		return nil, dto.Paging{}, err
+	}
 
-	return rooms, err
+	sortColumn, ok := roomSortColumns[filter.SortBy]
+	if !ok {
+		sortColumn = roomSortColumns["name"]
+	}
+	order := "ASC"
+	if strings.EqualFold(filter.Order, "desc") {
+		order = "DESC"
+	}
+
+	// r.id sebagai urutan kedua supaya paging stabil untuk nilai sort yang sama
+	limit := fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	rooms, err := r.getRooms(ctx, selectRoom+" "+where+" ORDER BY "+sortColumn+" "+order+", r.id"+limit, append(args, filter.Size, (filter.Page-1)*filter.Size)...)
+	if err != nil {
+		return nil, dto.Paging{}, err
+	}
+	if rooms == nil {
+		rooms = []model.Room{}
+	}
+
+	paging := dto.Paging{
+		Page:       filter.Page,
+		Size:       filter.Size,
+		TotalRows:  totalRows,
+		TotalPages: (totalRows + filter.Size - 1) / filter.Size,
+	}
+	return rooms, paging, nil
 }
 
-func (r *roomRepository) ChangeStatus(id string) error {
+func (r *roomRepository) ChangeStatus(ctx context.Context, id string) error {
 	status := "available"
-	_, err := r.db.Exec(`UPDATE rooms SET status = $1 WHERE id = $2`, status, id)
+	_, err := r.db.ExecContext(ctx, `UPDATE rooms SET status = $1 WHERE id = $2`, status, id)
 	if err != nil {
 		return err
 	}
 	return err
 }
 
-func (r *roomRepository) GetStatusByBd(bdId string) (string, error) {
+func (r *roomRepository) GetStatusByBd(ctx context.Context, bdId string) (string, error) {
 	var status string
-	err := r.db.QueryRow("SELECT r.status FROM rooms r JOIN booking_details bd ON bd.roomid = r.id WHERE bd.id = $1", bdId).Scan(&status)
+	err := r.db.QueryRowContext(ctx, "SELECT r.status FROM rooms r JOIN booking_details bd ON bd.roomid = r.id WHERE bd.id = $1", bdId).Scan(&status)
 	if err != nil {
 		return "Can't get room status from booking_details ID", err
 	}
 	return status, nil
 }
 
-func (r *roomRepository) GetStatus(id string) (string, error) {
+func (r *roomRepository) GetStatus(ctx context.Context, id string) (string, error) {
 	var status string
-	err := r.db.QueryRow("SELECT status FROM rooms WHERE id = $1", id).Scan(&status)
+	// status efektif: maintenance jika ada tiket critical yang belum resolved di ruangan ini atau bagiannya,
+	// booked jika ruangan ini, bagiannya atau ruangan gabungannya booked
+	err := r.db.QueryRowContext(ctx, `SELECT CASE WHEN `+criticalTicketOpen("$1::uuid")+` THEN 'maintenance' WHEN bool_or(status = 'booked') THEN 'booked' ELSE 'available' END
+	FROM rooms WHERE id IN (`+linkedRoomsOf("$1::uuid")+`) HAVING bool_or(id = $1)`, id).Scan(&status)
 	if err != nil {
 		return "Can't get room status", err
 	}
 	return status, nil
 }
 
-func (r *roomRepository) GetByRoomType(roomType string) (model.Room, error) {
-	var room model.Room
-	err := r.db.QueryRow(`SELECT r.id, r.roomtype, r.capacity, f.id, f.roomdescription, f.fwifi, f.fsoundsystem, f.fprojector, f.fscreenprojector, f.fchairs, f.ftables, f.fsoundproof, f.fsmonkingarea, f.ftelevison, f.fac, f.fbathroom, f.fcoffemaker, f.createdat, f.updatedat, r.status, r.createdat, r.updatedat FROM rooms AS r JOIN facilities AS f ON f.id = r.facilities WHERE r.roomtype = $1;`, roomType).Scan(
-		&room.Id,
-		&room.RoomType,
-		&room.MaxCapacity,
-		&room.Facility.Id,
-		&room.Facility.RoomDescription,
-		&room.Facility.Fwifi,
-		&room.Facility.FsoundSystem,
-		&room.Facility.Fprojector,
-		&room.Facility.FscreenProjector,
-		&room.Facility.Fchairs,
-		&room.Facility.Ftables,
-		&room.Facility.FsoundProof,
-		&room.Facility.FsmonkingArea,
-		&room.Facility.Ftelevison,
-		&room.Facility.FAc,
-		&room.Facility.Fbathroom,
-		&room.Facility.FcoffeMaker,
-		&room.Facility.CreatedAt,
-		&room.Facility.UpdatedAt,
-		&room.Status,
-		&room.CreatedAt,
-		&room.UpdatedAt,
-	)
+func (r *roomRepository) Update(ctx context.Context, id string, payload model.Room) (model.Room, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return model.Room{}, err
 	}
-	return room, err
-}
+	defer tx.Rollback()
 
-func (r *roomRepository) Update(id string, payload model.Room) (model.Room, error) {
-	var room model.Room
-	// var facilitie model.RoomFacility
+	if err := updateRoom(ctx, tx, id, payload); err != nil {
+		return model.Room{}, err
+	}
 
-	err := r.db.QueryRow(`UPDATE rooms SET roomtype = $1, capacity = $2, status = $3, updatedat = $4 WHERE id = $5 RETURNING facilities, id, roomtype, capacity, status, createdat, updatedat`, payload.RoomType, payload.MaxCapacity, payload.Status, time.Now(), id).Scan(
-		&room.Facility.Id,
-		&room.Id,
-		&room.RoomType,
-		&room.MaxCapacity,
-		&room.Status,
-		&room.CreatedAt,
-		&room.UpdatedAt,
-	)
+	room, err := r.getRoom(ctx, tx, id)
 	if err != nil {
 		return model.Room{}, err
 	}
 
-	// facilitie.Id = room.Facility.Id
+	if err := tx.Commit(); err != nil {
+		return model.Room{}, err
+	}
+	return room, nil
+}
 
-	err = r.db.QueryRow(`UPDATE facilities SET roomdescription = $1, fwifi = $2, fsoundsystem = $3, fprojector = $4, fscreenprojector = $5, fchairs = $6, ftables = $7, fsoundproof = $8, fsmonkingarea = $9, ftelevison = $10, fac = $11, fbathroom = $12, fcoffemaker = $13, updatedat = $14 WHERE id = $15 RETURNING id, roomdescription, fwifi, fsoundsystem, fprojector, fscreenprojector, fchairs, ftables, fsoundproof, fsmonkingarea, ftelevison, fac, fbathroom, fcoffemaker,createdat, updatedat`, payload.Facility.RoomDescription, payload.Facility.Fwifi, payload.Facility.FsoundSystem, payload.Facility.Fprojector, payload.Facility.FscreenProjector, payload.Facility.Fchairs, payload.Facility.Ftables, payload.Facility.FsoundProof, payload.Facility.FsmonkingArea, payload.Facility.Ftelevison, payload.Facility.FAc, payload.Facility.Fbathroom, payload.Facility.FcoffeMaker, time.Now(), room.Facility.Id).Scan(
-		&room.Facility.Id,
-		&room.Facility.RoomDescription,
-		&room.Facility.Fwifi,
-		&room.Facility.FsoundSystem,
-		&room.Facility.Fprojector,
-		&room.Facility.FscreenProjector,
-		&room.Facility.Fchairs,
-		&room.Facility.Ftables,
-		&room.Facility.FsoundProof,
-		&room.Facility.FsmonkingArea,
-		&room.Facility.Ftelevison,
-		&room.Facility.FAc,
-		&room.Facility.Fbathroom,
-		&room.Facility.FcoffeMaker,
-		&room.Facility.CreatedAt,
-		&room.Facility.UpdatedAt,
-	)
+// updateRoom mengubah data ruangan beserta deskripsi facility dan amenitynya
+func updateRoom(ctx context.Context, tx *sql.Tx, id string, payload model.Room) error {
+	var facilityId string
+	err := tx.QueryRowContext(ctx, `UPDATE rooms SET name = $1, code = NULLIF($2, ''), roomtype = $3, capacity = $4, floorid = NULLIF($5, '')::uuid, status = $6, updatedat = $7 WHERE id = $8 RETURNING facilities`,
+		payload.Name, payload.Code, payload.RoomType, payload.MaxCapacity, payload.FloorId, payload.Status, time.Now(), id).Scan(&facilityId)
 	if err != nil {
-		return model.Room{}, err
+		return err
 	}
 
-	return room, err
+	_, err = tx.ExecContext(ctx, `UPDATE facilities SET roomdescription = $1, updatedat = $2 WHERE id = $3`, payload.Facility.RoomDescription, time.Now(), facilityId)
+	if err != nil {
+		return err
+	}
+
+	return replaceRoomAmenities(ctx, tx, id, payload.Amenities)
 }
 
-func (r *roomRepository) Delete(id string) (model.Room, error) {
-	_, err := r.db.Exec(`DELETE FROM rooms WHERE id = $1`, id)
+// ErrRoomHasFutureBookings dikembalikan Archive jika ruangan masih punya booking yang akan datang dan cascade belum dipilih
+var ErrRoomHasFutureBookings = errors.New("room has future bookings")
+
+// futureBookingCondition memilih booking aktif (pending/accept) di ruangan $1 yang belum selesai pada waktu $2
+const futureBookingCondition = `bd.roomid = $1 AND bd.status IN ('pending', 'accept') AND bd.bookingdateend > $2`
+
+func futureBookings(ctx context.Context, q queryer, roomId string, at time.Time) ([]dto.AffectedBooking, error) {
+	rows, err := q.QueryContext(ctx, `SELECT bd.id, bd.bookingid, u.name, u.email, bd.status, bd.bookingdate, bd.bookingdateend,
+	COALESCE(bd.description, ''), COALESCE(bd.conferenceid, ''), COALESCE(bd.conferenceurl, ''), bd.invitesequence, b.userid,
+	ARRAY(SELECT pu.email FROM booking_participants bp JOIN users pu ON pu.id = bp.userid WHERE bp.bookingid = b.id ORDER BY pu.email)
+	FROM booking_details bd JOIN booking b ON b.id = bd.bookingid JOIN users u ON u.id = b.userid
+	WHERE `+futureBookingCondition+` ORDER BY bd.bookingdate`, roomId, at)
 	if err != nil {
-		return model.Room{}, err
+		return nil, err
+	}
+	defer rows.Close()
+
+	var affected []dto.AffectedBooking
+	for rows.Next() {
+		var booking dto.AffectedBooking
+		err := rows.Scan(&booking.BookingDetailId, &booking.BookingId, &booking.UserName, &booking.UserEmail, &booking.Status, &booking.BookingDate, &booking.BookingDateEnd,
+			&booking.Description, &booking.ConferenceId, &booking.ConferenceURL, &booking.InviteSequence, &booking.UserId, pq.Array(&booking.Participants))
+		if err != nil {
+			return nil, err
+		}
+		affected = append(affected, booking)
+	}
+	return affected, rows.Err()
+}
+
+// FutureBookings mengambil booking aktif ruangan yang belum selesai pada waktu at, dipakai usecase sebelum Archive
+func (r *roomRepository) FutureBookings(ctx context.Context, id string, at time.Time) ([]dto.AffectedBooking, error) {
+	return futureBookings(ctx, r.db, id, at)
+}
+
+// reassignBookings memindahkan booking yang akan datang ke ruangan lain jika ruangan itu aktif, tidak sedang
+// punya tiket critical, muat untuk jumlah peserta tiap booking dan jadwalnya tidak bentrok
+func reassignBookings(ctx context.Context, tx *sql.Tx, roomId string, targetId string, at time.Time) error {
+	var capacity int
+	var critical bool
+	err := tx.QueryRowContext(ctx, `SELECT r.capacity, `+criticalTicketOpen("r.id")+` FROM rooms r
+	WHERE r.id = $1 AND r.id <> $2 AND r.archivedat IS NULL FOR UPDATE OF r`, targetId, roomId).Scan(&capacity, &critical)
+	if err != nil {
+		return fmt.Errorf("room with id %s not found or archived", targetId)
+	}
+	if critical {
+		return fmt.Errorf("room with id %s has an open critical maintenance ticket", targetId)
+	}
+
+	// layout dilepas saat pindah, jadi kapasitas tujuan dibandingkan dengan MaxCapacity
+	var tooLarge int
+	err = tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM booking_details bd WHERE `+futureBookingCondition+` AND bd.attendees > $3`, roomId, at, capacity).Scan(&tooLarge)
+	if err != nil {
+		return err
+	}
+	if tooLarge > 0 {
+		return fmt.Errorf("room with id %s only fits %d attendees, %d booking(s) have more", targetId, capacity, tooLarge)
+	}
+
+	var conflicts int
+	err = tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM booking_details bd
+	JOIN booking_details existing ON existing.roomid IN (`+linkedRoomsOf("$3::uuid")+`) AND existing.roomid <> $1 AND existing.status IN ('pending', 'accept')
+		AND existing.blockedfrom < bd.bookingdateend AND bd.bookingdate < existing.blockeduntil
+	WHERE `+futureBookingCondition, roomId, at, targetId).Scan(&conflicts)
+	if err != nil {
+		return err
+	}
+	if conflicts > 0 {
+		return fmt.Errorf("room with id %s has %d conflicting bookings", targetId, conflicts)
+	}
+
+	// layout milik ruangan lama tidak berlaku di ruangan tujuan, rentang terpakai kembali ke jam booking.
+	// invitesequence naik karena pemesan dikirimi invite baru dengan lokasi ruangan tujuan.
+	_, err = tx.ExecContext(ctx, `UPDATE booking_details bd SET roomid = $3, resourceid = $3, layoutid = NULL, blockedfrom = bd.bookingdate, blockeduntil = bd.bookingdateend,
+	invitesequence = bd.invitesequence + 1, updatedat = $2 WHERE `+futureBookingCondition, roomId, at, targetId)
+	return err
+}
+
+// Archive menyembunyikan ruangan dari pencarian dan booking baru tanpa menghapus riwayatnya.
+// Booking yang akan datang dibatalkan atau dipindahkan sesuai option.Cascade.
+func (r *roomRepository) Archive(ctx context.Context, id string, option dto.ArchiveOption, at time.Time) ([]dto.AffectedBooking, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// kunci baris ruangan supaya tidak ada perubahan lain selama proses arsip
+	var archivedAt sql.NullTime
+	if err := tx.QueryRowContext(ctx, `SELECT archivedat FROM rooms WHERE id = $1 FOR UPDATE`, id).Scan(&archivedAt); err != nil {
+		return nil, err
+	}
+	if archivedAt.Valid {
+		return nil, errors.New("room is already archived")
+	}
+
+	affected, err := futureBookings(ctx, tx, id, at)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(affected) > 0 {
+		switch option.Cascade {
+		case "cancel":
+			// meeting di provider dihapus usecase setelah commit memakai ConferenceId dari affected,
+			// invitesequence naik untuk invite CANCEL
+			_, err = tx.ExecContext(ctx, `UPDATE booking_details bd SET status = 'cancel', conferenceid = NULL, conferenceurl = NULL, invitesequence = bd.invitesequence + 1, updatedat = $2 WHERE `+futureBookingCondition, id, at)
+			if err == nil {
+				ids := make([]string, len(affected))
+				for i, booking := range affected {
+					ids[i] = booking.BookingDetailId
+				}
+				err = cancelOpenServiceOrders(ctx, tx, ids, at)
+			}
+		case "reassign":
+			err = reassignBookings(ctx, tx, id, option.ReassignTo, at)
+		default:
+			return affected, ErrRoomHasFutureBookings
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE rooms SET archivedat = $1, updatedat = $1 WHERE id = $2`, at, id); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return affected, nil
+}
+
+func (r *roomRepository) Restore(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE rooms SET archivedat = NULL, updatedat = $1 WHERE id = $2 AND archivedat IS NOT NULL`, time.Now(), id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.New("room is not archived")
+	}
+	return nil
+}
+
+// SetParts mengganti daftar bagian ruangan gabungan, partIds kosong berarti ruangan tidak lagi gabungan
+func (r *roomRepository) SetParts(ctx context.Context, id string, partIds []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM room_combinations WHERE combinedroomid = $1`, id); err != nil {
+		return err
+	}
+	for _, partId := range partIds {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO room_combinations (combinedroomid, partroomid) VALUES ($1, $2)`, id, partId); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE rooms SET updatedat = $1 WHERE id = $2`, time.Now(), id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SetAccessRules mengganti semua rule akses ruangan, rules kosong berarti ruangan terbuka untuk semua user
+func (r *roomRepository) SetAccessRules(ctx context.Context, id string, rules []model.RoomAccessRule) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM room_access_rules WHERE roomid = $1`, id); err != nil {
+		return err
+	}
+	for _, rule := range rules {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO room_access_rules (roomid, effect, field, value) VALUES ($1, $2, $3, $4)`,
+			id, rule.Effect, rule.Field, rule.Value); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE rooms SET updatedat = $1 WHERE id = $2`, time.Now(), id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Import menyimpan semua baris dalam satu transaksi, ruangan dengan kode yang sudah ada diupdate dan sisanya dibuat baru.
+// Status ruangan yang sudah ada tidak diubah. Pada dry run transaksi selalu di-rollback sehingga hanya hitungannya yang dikembalikan.
+func (r *roomRepository) Import(ctx context.Context, rows []dto.RoomImportRow, dryRun bool) (dto.RoomImportResult, error) {
+	result := dto.RoomImportResult{DryRun: dryRun, TotalRows: len(rows)}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return dto.RoomImportResult{}, err
+	}
+	defer tx.Rollback()
+
+	for _, row := range rows {
+		var roomId, status string
+		err := tx.QueryRowContext(ctx, `SELECT id, status FROM rooms WHERE code = $1 FOR UPDATE`, row.Room.Code).Scan(&roomId, &status)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			if _, err := insertRoom(ctx, tx, row.Room); err != nil {
+				return dto.RoomImportResult{}, fmt.Errorf("row %d: %v", row.Row, err)
+			}
+			result.Created++
+		case err != nil:
+			return dto.RoomImportResult{}, fmt.Errorf("row %d: %v", row.Row, err)
+		default:
+			row.Room.Status = status
+			if err := updateRoom(ctx, tx, roomId, row.Room); err != nil {
+				return dto.RoomImportResult{}, fmt.Errorf("row %d: %v", row.Row, err)
+			}
+			result.Updated++
+		}
+	}
+
+	if dryRun {
+		return result, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return dto.RoomImportResult{}, err
 	}
-	return model.Room{}, err
+	return result, nil
 }
 
 func NewRoomRepository(db *sql.DB) RoomRepository {
@@ -1,12 +1,16 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"final-project-booking-room/model"
+	"final-project-booking-room/model/dto"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
@@ -26,6 +30,69 @@ func (suite *RoomRepositoryTestSuite) SetupTest() {
 	suite.repo = NewRoomRepository(suite.mockDB)
 }
 
+var roomColumns = []string{
+	"id", "roomtype", "capacity",
+	"facilityid", "roomdescription", "fwifi", "fsoundsystem", "fprojector", "fscreenprojector", "fchairs", "ftables", "fsoundproof", "fsmonkingarea", "ftelevison", "fac", "fbathroom", "fcoffemaker", "createdat", "updatedat",
+	"status", "createdat", "updatedat",
+	"name", "code", "floorid", "floorname", "floorlevel", "buildingid", "buildingname", "siteid", "sitename", "timezone", "archivedat",
+}
+
+// expectRoomAmenities menyiapkan query amenity untuk satu ruangan dan mengembalikan hasil yang diharapkan
+func expectRoomAmenities(mock sqlmock.Sqlmock, roomId string) []model.RoomAmenity {
+	amenities := []model.RoomAmenity{
+		{Id: "a1", Code: "wifi", Name: "Wifi", Icon: "wifi", Type: "boolean", Available: true, Quantity: 1},
+		{Id: "a2", Code: "chairs", Name: "Chairs", Icon: "chair", Type: "quantity", Available: true, Quantity: 12},
+	}
+	rows := sqlmock.NewRows([]string{"roomid", "id", "code", "name", "icon", "type", "quantity"})
+	for _, amenity := range amenities {
+		rows.AddRow(roomId, amenity.Id, amenity.Code, amenity.Name, amenity.Icon, amenity.Type, amenity.Quantity)
+	}
+	mock.ExpectQuery(`FROM room_amenities ra JOIN amenities a ON a.id = ra.amenityid`).WillReturnRows(rows)
+	return amenities
+}
+
+// expectRoomImages menyiapkan query gambar untuk satu ruangan dan mengembalikan hasil yang diharapkan
+func expectRoomImages(mock sqlmock.Sqlmock, roomId string) []model.RoomImage {
+	images := []model.RoomImage{
+		{Id: "i1", RoomId: roomId, Kind: model.RoomImagePhoto, Url: "/uploads/rooms/" + roomId + "/a.jpg", ThumbnailUrl: "/uploads/rooms/" + roomId + "/a_thumb.jpg",
+			ContentType: "image/jpeg", Size: 2048, StorageKey: "rooms/" + roomId + "/a.jpg", ThumbnailKey: "rooms/" + roomId + "/a_thumb.jpg"},
+	}
+	rows := sqlmock.NewRows([]string{"id", "roomid", "kind", "url", "thumbnailurl", "contenttype", "size", "storagekey", "thumbnailkey", "createdat"})
+	for _, image := range images {
+		rows.AddRow(image.Id, image.RoomId, image.Kind, image.Url, image.ThumbnailUrl, image.ContentType, image.Size, image.StorageKey, image.ThumbnailKey, image.CreatedAt)
+	}
+	mock.ExpectQuery(`FROM room_images WHERE roomid = ANY`).WillReturnRows(rows)
+	return images
+}
+
+// expectRoomLayouts menyiapkan query layout untuk satu ruangan dan mengembalikan hasil yang diharapkan
+func expectRoomLayouts(mock sqlmock.Sqlmock, roomId string) []model.RoomLayout {
+	layouts := []model.RoomLayout{
+		{Id: "l1", RoomId: roomId, Name: "theatre", Capacity: 40, SetupMinutes: 15, TeardownMinutes: 15},
+		{Id: "l2", RoomId: roomId, Name: "classroom", Capacity: 20, SetupMinutes: 30, TeardownMinutes: 30},
+	}
+	rows := sqlmock.NewRows([]string{"id", "roomid", "name", "capacity", "setupminutes", "teardownminutes", "createdat", "updatedat"})
+	for _, layout := range layouts {
+		rows.AddRow(layout.Id, layout.RoomId, layout.Name, layout.Capacity, layout.SetupMinutes, layout.TeardownMinutes, layout.CreatedAt, layout.UpdatedAt)
+	}
+	mock.ExpectQuery(`FROM room_layouts WHERE roomid = ANY`).WillReturnRows(rows)
+	return layouts
+}
+
+// expectRoomCombinations menyiapkan query ruangan gabungan, roomId dianggap bagian dari ruangan gabungan "99"
+func expectRoomCombinations(mock sqlmock.Sqlmock, roomId string) []string {
+	mock.ExpectQuery(`FROM room_combinations`).
+		WillReturnRows(sqlmock.NewRows([]string{"combinedroomid", "partroomid"}).AddRow("99", roomId))
+	return []string{"99"}
+}
+
+// expectRoomAccessRules menyiapkan query rule akses, roomId dianggap hanya boleh dibooking divisi HR
+func expectRoomAccessRules(mock sqlmock.Sqlmock, roomId string) []model.RoomAccessRule {
+	mock.ExpectQuery(`FROM room_access_rules`).
+		WillReturnRows(sqlmock.NewRows([]string{"roomid", "effect", "field", "value"}).AddRow(roomId, "allow", "divisi", "HR"))
+	return []model.RoomAccessRule{{Effect: "allow", Field: "divisi", Value: "HR"}}
+}
+
 func TestRoomRepositoryTestSuite(t *testing.T) {
 	suite.Run(t, new(RoomRepositoryTestSuite))
 }
@@ -58,43 +125,67 @@ func (suite *RoomRepositoryTestSuite) TestCreateRoom_Success() {
 		UpdatedAt: time.Time{},
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "roomdescription", "fwifi", "fsoundsystem", "fprojector", "fscreenprojector", "fchairs", "ftables", "fsoundproof", "fsmonkingarea", "ftelevison", "fac", "fbathroom", "fcoffemaker", "createdat", "updatedat"}).AddRow(
-		mockRoom.Facility.Id,
-		mockRoom.Facility.RoomDescription,
-		mockRoom.Facility.Fwifi,
-		mockRoom.Facility.FsoundSystem,
-		mockRoom.Facility.Fprojector,
-		mockRoom.Facility.FscreenProjector,
-		mockRoom.Facility.Fchairs,
-		mockRoom.Facility.Ftables,
-		mockRoom.Facility.FsoundProof,
-		mockRoom.Facility.FsmonkingArea,
-		mockRoom.Facility.Ftelevison,
-		mockRoom.Facility.FAc,
-		mockRoom.Facility.Fbathroom,
-		mockRoom.Facility.FcoffeMaker,
-		mockRoom.Facility.CreatedAt,
-		mockRoom.Facility.UpdatedAt)
-	suite.mockSql.ExpectQuery("INSERT INTO facilities").WillReturnRows(rows)
-
-	rows = sqlmock.NewRows([]string{"id", "roomtype", "capacity", "status", "createdat", "updatedat"}).AddRow(
-		mockRoom.Id,
-		mockRoom.RoomType,
-		mockRoom.MaxCapacity,
-		mockRoom.Status,
-		mockRoom.CreatedAt,
-		mockRoom.UpdatedAt)
-	suite.mockSql.ExpectQuery("INSERT INTO rooms").WillReturnRows(rows)
-
+	mockRoom.Name = "Ruang Test"
+	mockRoom.FloorId = "f1"
+	mockRoom.Amenities = []model.RoomAmenity{{Id: "a1", Quantity: 1}}
+
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery("INSERT INTO facilities").
+		WithArgs(mockRoom.Facility.RoomDescription, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(mockRoom.Facility.Id))
+	suite.mockSql.ExpectQuery("INSERT INTO rooms").
+		WithArgs(mockRoom.Name, mockRoom.Code, mockRoom.RoomType, mockRoom.MaxCapacity, mockRoom.Facility.Id, mockRoom.FloorId, mockRoom.Status, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(mockRoom.Id))
+	suite.mockSql.ExpectExec(`DELETE FROM room_amenities WHERE roomid = \$1`).
+		WithArgs(mockRoom.Id).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	suite.mockSql.ExpectExec("INSERT INTO room_amenities").
+		WithArgs(mockRoom.Id, "a1", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockSql.ExpectQuery(`FROM rooms AS r JOIN v_room_facilities AS f ON f.roomid = r.id .* WHERE r.id = \$1`).
+		WithArgs(mockRoom.Id).
+		WillReturnRows(sqlmock.NewRows(roomColumns).AddRow(
+			mockRoom.Id, mockRoom.RoomType, mockRoom.MaxCapacity,
+			mockRoom.Facility.Id, mockRoom.Facility.RoomDescription, "ada", "tidak", "tidak", "tidak", "-", "-", "tidak", "tidak", "tidak", "tidak", "tidak", "tidak", time.Time{}, time.Time{},
+			mockRoom.Status, time.Time{}, time.Time{},
+			mockRoom.Name, "", "", "", 0, "", "", "", "", "", nil))
+	expectRoomAmenities(suite.mockSql, mockRoom.Id)
+	expectRoomImages(suite.mockSql, mockRoom.Id)
+	expectRoomLayouts(suite.mockSql, mockRoom.Id)
+	expectRoomCombinations(suite.mockSql, mockRoom.Id)
+	mockRoom.AccessRules = expectRoomAccessRules(suite.mockSql, mockRoom.Id)
 	suite.mockSql.ExpectCommit()
-	actual, err := suite.repo.Create(mockRoom)
+
+	actual, err := suite.repo.Create(context.Background(), mockRoom)
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
 	assert.Equal(suite.T(), mockRoom.Id, actual.Id)
+	assert.Equal(suite.T(), "ada", actual.Facility.Fwifi)
+	assert.Len(suite.T(), actual.Amenities, 2)
+}
 
+func (suite *RoomRepositoryTestSuite) TestCreateRoom_UnknownAmenity() {
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery("INSERT INTO facilities").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("101"))
+	suite.mockSql.ExpectQuery("INSERT INTO rooms").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+	suite.mockSql.ExpectExec("DELETE FROM room_amenities").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	suite.mockSql.ExpectExec("INSERT INTO room_amenities").
+		WithArgs("1", "unknown", 0).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	suite.mockSql.ExpectRollback()
+
+	_, err := suite.repo.Create(context.Background(), model.Room{RoomType: "test", Amenities: []model.RoomAmenity{{Id: "unknown"}}})
+
+	assert.ErrorIs(suite.T(), err, ErrAmenityNotFound)
+	assert.EqualError(suite.T(), err, "amenity not found: unknown")
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
 }
 
-func (suite *RoomRepositoryTestSuite) TestGetByRoomType() {
+func (suite *RoomRepositoryTestSuite) TestList_FilterByTypeAndCapacity() {
 	mockRoom := model.Room{
 		Id:          "1",
 		RoomType:    "room test",
@@ -125,7 +216,8 @@ func (suite *RoomRepositoryTestSuite) TestGetByRoomType() {
 	rows := sqlmock.NewRows([]string{
 		"id", "roomtype", "capacity",
 		"facilityid", "roomdescription", "fwifi", "fsoundsystem", "fprojector", "fscreenprojector", "fchairs", "ftables", "fsoundproof", "fsmonkingarea", "ftelevison", "fac", "fbathroom", "fcoffemaker", "updatedat", "createdat",
-		"status", "createdat", "updatedat"}).AddRow(
+		"status", "createdat", "updatedat",
+		"name", "code", "floorid", "floorname", "floorlevel", "buildingid", "buildingname", "siteid", "sitename", "timezone", "archivedat"}).AddRow(
 		mockRoom.Id,
 		mockRoom.RoomType,
 		mockRoom.MaxCapacity,
@@ -148,18 +240,40 @@ func (suite *RoomRepositoryTestSuite) TestGetByRoomType() {
 		mockRoom.Status,
 		mockRoom.CreatedAt,
 		mockRoom.UpdatedAt,
+		mockRoom.Name,
+		mockRoom.Code,
+		mockRoom.Location.FloorId,
+		mockRoom.Location.FloorName,
+		mockRoom.Location.FloorLevel,
+		mockRoom.Location.BuildingId,
+		mockRoom.Location.BuildingName,
+		mockRoom.Location.SiteId,
+		mockRoom.Location.SiteName,
+		mockRoom.Location.Timezone,
+		nil,
 	)
 
-	suite.mockSql.ExpectQuery(`SELECT r.id, r.roomtype, r.capacity, f.id, f.roomdescription, f.fwifi, f.fsoundsystem, f.fprojector, f.fscreenprojector, f.fchairs, f.ftables, f.fsoundproof, f.fsmonkingarea, f.ftelevison, f.fac, f.fbathroom, f.fcoffemaker, f.createdat, f.updatedat, r.status, r.createdat, r.updatedat FROM rooms AS r JOIN facilities AS f ON f.id = r.facilities WHERE r.roomtype = \$1;`).
-		WithArgs("room test").
+	suite.mockSql.ExpectQuery(`SELECT COUNT\(\*\) FROM rooms AS r LEFT JOIN floors fl .* WHERE r.archivedat IS NULL AND r.roomtype = \$1 AND r.capacity >= \$2 AND r.capacity <= \$3 AND \(SELECT COUNT\(DISTINCT a.code\) .* a.code = ANY\(\$4\)\) = \$5`).
+		WithArgs("room test", 5, 20, sqlmock.AnyArg(), 2).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	suite.mockSql.ExpectQuery(`SELECT r.id, r.roomtype, r.capacity, f.id, .* FROM rooms AS r JOIN v_room_facilities AS f ON f.roomid = r.id LEFT JOIN floors fl ON fl.id = r.floorid LEFT JOIN buildings bg ON bg.id = fl.buildingid LEFT JOIN sites s ON s.id = bg.siteid WHERE r.archivedat IS NULL AND r.roomtype = \$1 .* ORDER BY r.capacity DESC, r.id LIMIT \$6 OFFSET \$7`).
+		WithArgs("room test", 5, 20, sqlmock.AnyArg(), 2, 10, 10).
 		WillReturnRows(rows)
 
-	result, err := suite.repo.GetByRoomType("room test")
+	mockRoom.Amenities = expectRoomAmenities(suite.mockSql, mockRoom.Id)
+	mockRoom.Images = expectRoomImages(suite.mockSql, mockRoom.Id)
+	mockRoom.Layouts = expectRoomLayouts(suite.mockSql, mockRoom.Id)
+	mockRoom.CombinedIn = expectRoomCombinations(suite.mockSql, mockRoom.Id)
+	mockRoom.AccessRules = expectRoomAccessRules(suite.mockSql, mockRoom.Id)
+
+	filter := dto.RoomFilter{RoomType: "room test", MinCapacity: 5, MaxCapacity: 20, Amenities: []string{"wifi", "projector"}, SortBy: "capacity", Order: "desc", Page: 2, Size: 10}
+	result, paging, err := suite.repo.List(context.Background(), filter)
 
 	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
-	assert.Equal(suite.T(), mockRoom, result)
+	assert.Equal(suite.T(), []model.Room{mockRoom}, result)
+	assert.Equal(suite.T(), dto.Paging{Page: 2, Size: 10, TotalRows: 1, TotalPages: 1}, paging)
 
 }
 
@@ -186,6 +300,19 @@ func (suite *RoomRepositoryTestSuite) TestGet_Success() {
 			CreatedAt:        time.Time{},
 			UpdatedAt:        time.Time{},
 		},
+		Name:    "Ruang Merapi",
+		Code:    "JKT-3-01",
+		FloorId: "f1",
+		Location: model.RoomLocation{
+			SiteId:       "s1",
+			SiteName:     "Jakarta HQ",
+			BuildingId:   "b1",
+			BuildingName: "Tower A",
+			FloorId:      "f1",
+			FloorName:    "Lantai 3",
+			FloorLevel:   3,
+			Timezone:     "Asia/Jakarta",
+		},
 		Status:    "available",
 		CreatedAt: time.Time{},
 		UpdatedAt: time.Time{},
@@ -195,6 +322,7 @@ func (suite *RoomRepositoryTestSuite) TestGet_Success() {
 		"id", "roomtype", "capacity",
 		"facilityid", "roomdescription", "fwifi", "fsoundsystem", "fprojector", "fscreenprojector", "fchairs", "ftables", "fsoundproof", "fsmonkingarea", "ftelevison", "fac", "fbathroom", "fcoffemaker", "updatedat", "createdat",
 		"status", "createdat", "updatedat",
+		"name", "code", "floorid", "floorname", "floorlevel", "buildingid", "buildingname", "siteid", "sitename", "timezone", "archivedat",
 	}).
 		AddRow(
 			mockRoom.Id,
@@ -219,13 +347,30 @@ func (suite *RoomRepositoryTestSuite) TestGet_Success() {
 			mockRoom.Status,
 			mockRoom.CreatedAt,
 			mockRoom.UpdatedAt,
+			mockRoom.Name,
+			mockRoom.Code,
+			mockRoom.Location.FloorId,
+			mockRoom.Location.FloorName,
+			mockRoom.Location.FloorLevel,
+			mockRoom.Location.BuildingId,
+			mockRoom.Location.BuildingName,
+			mockRoom.Location.SiteId,
+			mockRoom.Location.SiteName,
+			mockRoom.Location.Timezone,
+			nil,
 		)
 
-	suite.mockSql.ExpectQuery(`SELECT r.id, r.roomtype, r.capacity, f.id, f.roomdescription, f.fwifi, f.fsoundsystem, f.fprojector, f.fscreenprojector, f.fchairs, f.ftables, f.fsoundproof, f.fsmonkingarea, f.ftelevison, f.fac, f.fbathroom, f.fcoffemaker, f.createdat, f.updatedat, r.status, r.createdat, r.updatedat FROM rooms AS r JOIN facilities AS f ON f.id = r.facilities WHERE r.id = \$1;`).
+	suite.mockSql.ExpectQuery(`SELECT r.id, r.roomtype, r.capacity, f.id, .* FROM rooms AS r JOIN v_room_facilities AS f ON f.roomid = r.id LEFT JOIN floors fl ON fl.id = r.floorid LEFT JOIN buildings bg ON bg.id = fl.buildingid LEFT JOIN sites s ON s.id = bg.siteid WHERE r.id = \$1`).
 		WithArgs("1").
 		WillReturnRows(rows)
 
-	result, err := suite.repo.Get("1")
+	mockRoom.Amenities = expectRoomAmenities(suite.mockSql, mockRoom.Id)
+	mockRoom.Images = expectRoomImages(suite.mockSql, mockRoom.Id)
+	mockRoom.Layouts = expectRoomLayouts(suite.mockSql, mockRoom.Id)
+	mockRoom.CombinedIn = expectRoomCombinations(suite.mockSql, mockRoom.Id)
+	mockRoom.AccessRules = expectRoomAccessRules(suite.mockSql, mockRoom.Id)
+
+	result, err := suite.repo.Get(context.Background(), "1")
 
 	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
 	assert.Nil(suite.T(), err)
@@ -233,7 +378,7 @@ func (suite *RoomRepositoryTestSuite) TestGet_Success() {
 	assert.Equal(suite.T(), mockRoom, result)
 }
 
-func (suite *RoomRepositoryTestSuite) TestGetAllRoomByStatus_Success() {
+func (suite *RoomRepositoryTestSuite) TestList_AvailableForViewer() {
 	mockRoom := []model.Room{
 		{
 			Id:          "1",
@@ -266,6 +411,7 @@ func (suite *RoomRepositoryTestSuite) TestGetAllRoomByStatus_Success() {
 		"id", "roomtype", "capacity",
 		"facilityid", "roomdescription", "fwifi", "fsoundsystem", "fprojector", "fscreenprojector", "fchairs", "ftables", "fsoundproof", "fsmonkingarea", "ftelevison", "fac", "fbathroom", "fcoffemaker", "updatedat", "createdat",
 		"status", "createdat", "updatedat",
+		"name", "code", "floorid", "floorname", "floorlevel", "buildingid", "buildingname", "siteid", "sitename", "timezone", "archivedat",
 	}).
 		AddRow(
 			mockRoom[0].Id,
@@ -290,13 +436,35 @@ func (suite *RoomRepositoryTestSuite) TestGetAllRoomByStatus_Success() {
 			mockRoom[0].Status,
 			mockRoom[0].CreatedAt,
 			mockRoom[0].UpdatedAt,
+			mockRoom[0].Name,
+			mockRoom[0].Code,
+			mockRoom[0].Location.FloorId,
+			mockRoom[0].Location.FloorName,
+			mockRoom[0].Location.FloorLevel,
+			mockRoom[0].Location.BuildingId,
+			mockRoom[0].Location.BuildingName,
+			mockRoom[0].Location.SiteId,
+			mockRoom[0].Location.SiteName,
+			mockRoom[0].Location.Timezone,
+			nil,
 		)
 
-	suite.mockSql.ExpectQuery(`SELECT r.id, r.roomtype, r.capacity, f.id, f.roomdescription, f.fwifi, f.fsoundsystem, f.fprojector, f.fscreenprojector, f.fchairs, f.ftables, f.fsoundproof, f.fsmonkingarea, f.ftelevison, f.fac, f.fbathroom, f.fcoffemaker, f.createdat, f.updatedat, r.status, r.createdat, r.updatedat FROM rooms AS r JOIN facilities AS f ON f.id = r.facilities WHERE r.status = \$1`).
-		WithArgs("available").
+	suite.mockSql.ExpectQuery(`SELECT COUNT\(\*\) FROM rooms AS r`).
+		WithArgs("b1", "HR", "Senior", "employee").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	suite.mockSql.ExpectQuery(`SELECT r.id, r.roomtype, r.capacity, f.id, .* WHERE r.archivedat IS NULL AND \(r.status = 'available' AND NOT EXISTS \(SELECT 1 FROM rooms lr WHERE lr.status = 'booked' AND lr.id IN \(SELECT r.id UNION .*\)\) AND NOT EXISTS \(SELECT 1 FROM maintenance_tickets mt .*\)\) AND bg.id = \$1 AND NOT EXISTS \(SELECT 1 FROM room_access_rules ar WHERE ar.roomid = r.id AND ar.effect = 'deny' AND LOWER\(ar.value\) = LOWER\(CASE ar.field WHEN 'divisi' THEN \$2 WHEN 'jabatan' THEN \$3 ELSE \$4 END\)\) AND \(NOT EXISTS .* ORDER BY COALESCE\(r.name, r.roomtype\) ASC, r.id LIMIT \$5 OFFSET \$6`).
+		WithArgs("b1", "HR", "Senior", "employee", 10, 0).
 		WillReturnRows(rows)
 
-	result, err := suite.repo.GetAllRoomByStatus("available")
+	mockRoom[0].Amenities = expectRoomAmenities(suite.mockSql, mockRoom[0].Id)
+	mockRoom[0].Images = expectRoomImages(suite.mockSql, mockRoom[0].Id)
+	mockRoom[0].Layouts = expectRoomLayouts(suite.mockSql, mockRoom[0].Id)
+	mockRoom[0].CombinedIn = expectRoomCombinations(suite.mockSql, mockRoom[0].Id)
+	mockRoom[0].AccessRules = expectRoomAccessRules(suite.mockSql, mockRoom[0].Id)
+
+	viewer := model.User{Divisi: "HR", Jabatan: "Senior", Role: "employee"}
+	filter := dto.RoomFilter{Status: "available", Location: dto.LocationFilter{BuildingId: "b1"}, Viewer: &viewer, Page: 1, Size: 10}
+	result, _, err := suite.repo.List(context.Background(), filter)
 
 	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
 	assert.Nil(suite.T(), err)
@@ -304,7 +472,7 @@ func (suite *RoomRepositoryTestSuite) TestGetAllRoomByStatus_Success() {
 	assert.Equal(suite.T(), mockRoom, result)
 }
 
-func (suite *RoomRepositoryTestSuite) TestGetAllRoom() {
+func (suite *RoomRepositoryTestSuite) TestList_Default() {
 	mockRoom := []model.Room{
 		{
 			Id:          "1",
@@ -338,6 +506,7 @@ func (suite *RoomRepositoryTestSuite) TestGetAllRoom() {
 		"id", "roomtype", "capacity",
 		"facilityid", "roomdescription", "fwifi", "fsoundsystem", "fprojector", "fscreenprojector", "fchairs", "ftables", "fsoundproof", "fsmonkingarea", "ftelevison", "fac", "fbathroom", "fcoffemaker", "updatedat", "createdat",
 		"status", "createdat", "updatedat",
+		"name", "code", "floorid", "floorname", "floorlevel", "buildingid", "buildingname", "siteid", "sitename", "timezone", "archivedat",
 	}).
 		AddRow(
 			mockRoom[0].Id,
@@ -362,17 +531,38 @@ func (suite *RoomRepositoryTestSuite) TestGetAllRoom() {
 			mockRoom[0].Status,
 			mockRoom[0].CreatedAt,
 			mockRoom[0].UpdatedAt,
+			mockRoom[0].Name,
+			mockRoom[0].Code,
+			mockRoom[0].Location.FloorId,
+			mockRoom[0].Location.FloorName,
+			mockRoom[0].Location.FloorLevel,
+			mockRoom[0].Location.BuildingId,
+			mockRoom[0].Location.BuildingName,
+			mockRoom[0].Location.SiteId,
+			mockRoom[0].Location.SiteName,
+			mockRoom[0].Location.Timezone,
+			nil,
 		)
 
-	suite.mockSql.ExpectQuery(`SELECT r.id, r.roomtype, r.capacity, f.id, f.roomdescription, f.fwifi, f.fsoundsystem, f.fprojector, f.fscreenprojector, f.fchairs, f.ftables, f.fsoundproof, f.fsmonkingarea, f.ftelevison, f.fac, f.fbathroom, f.fcoffemaker, f.createdat, f.updatedat, r.status, r.createdat, r.updatedat FROM rooms AS r JOIN facilities AS f ON f.id = r.facilities`).
+	suite.mockSql.ExpectQuery(`SELECT COUNT\(\*\) FROM rooms AS r .* WHERE r.archivedat IS NULL$`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(11))
+	suite.mockSql.ExpectQuery(`SELECT r.id, r.roomtype, r.capacity, f.id, .* FROM rooms AS r JOIN v_room_facilities AS f ON f.roomid = r.id LEFT JOIN floors fl ON fl.id = r.floorid LEFT JOIN buildings bg ON bg.id = fl.buildingid LEFT JOIN sites s ON s.id = bg.siteid WHERE r.archivedat IS NULL ORDER BY`).
+		WithArgs(10, 0).
 		WillReturnRows(rows)
 
-	result, err := suite.repo.GetAllRoom()
+	mockRoom[0].Amenities = expectRoomAmenities(suite.mockSql, mockRoom[0].Id)
+	mockRoom[0].Images = expectRoomImages(suite.mockSql, mockRoom[0].Id)
+	mockRoom[0].Layouts = expectRoomLayouts(suite.mockSql, mockRoom[0].Id)
+	mockRoom[0].CombinedIn = expectRoomCombinations(suite.mockSql, mockRoom[0].Id)
+	mockRoom[0].AccessRules = expectRoomAccessRules(suite.mockSql, mockRoom[0].Id)
+
+	result, paging, err := suite.repo.List(context.Background(), dto.RoomFilter{Page: 1, Size: 10})
 
 	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), mockRoom, result)
+	assert.Equal(suite.T(), 2, paging.TotalPages)
 }
 
 func (suite *RoomRepositoryTestSuite) TestChangeStatus_Success() {
@@ -382,10 +572,239 @@ func (suite *RoomRepositoryTestSuite) TestChangeStatus_Success() {
 		WithArgs("available", roomId).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
-	err := suite.repo.ChangeStatus(roomId)
+	err := suite.repo.ChangeStatus(context.Background(), roomId)
 
 	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), roomId, roomId)
 }
+
+var affectedColumns = []string{"id", "bookingid", "name", "email", "status", "bookingdate", "bookingdateend", "description", "conferenceid", "conferenceurl", "invitesequence", "userid", "participants"}
+
+func (suite *RoomRepositoryTestSuite) TestArchive_FutureBookingsWithoutCascade() {
+	at := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	start := at.Add(24 * time.Hour)
+
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery(`SELECT archivedat FROM rooms WHERE id = \$1 FOR UPDATE`).
+		WithArgs("1").
+		WillReturnRows(sqlmock.NewRows([]string{"archivedat"}).AddRow(nil))
+	suite.mockSql.ExpectQuery(`SELECT bd.id, bd.bookingid, u.name, u.email`).
+		WithArgs("1", at).
+		WillReturnRows(sqlmock.NewRows(affectedColumns).AddRow("bd1", "b1", "budi", "budi@mail.com", "accept", start, start.Add(time.Hour), "weekly sync", "", "", 0, "u1", "{}"))
+	suite.mockSql.ExpectRollback()
+
+	affected, err := suite.repo.Archive(context.Background(), "1", dto.ArchiveOption{}, at)
+
+	assert.ErrorIs(suite.T(), err, ErrRoomHasFutureBookings)
+	assert.Len(suite.T(), affected, 1)
+	assert.Equal(suite.T(), "budi@mail.com", affected[0].UserEmail)
+	assert.Equal(suite.T(), "u1", affected[0].UserId)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *RoomRepositoryTestSuite) TestArchive_CascadeCancel() {
+	at := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	start := at.Add(24 * time.Hour)
+
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery(`SELECT archivedat FROM rooms WHERE id = \$1 FOR UPDATE`).
+		WithArgs("1").
+		WillReturnRows(sqlmock.NewRows([]string{"archivedat"}).AddRow(nil))
+	suite.mockSql.ExpectQuery(`SELECT bd.id, bd.bookingid, u.name, u.email`).
+		WithArgs("1", at).
+		WillReturnRows(sqlmock.NewRows(affectedColumns).AddRow("bd1", "b1", "budi", "budi@mail.com", "pending", start, start.Add(time.Hour), "weekly sync", "abc123", "https://meet.local/abc123", 1, "u1", "{sari@mail.com,tono@mail.com}"))
+	suite.mockSql.ExpectExec(`UPDATE booking_details bd SET status = 'cancel', conferenceid = NULL, conferenceurl = NULL`).
+		WithArgs("1", at).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockSql.ExpectExec(`UPDATE service_orders so SET approvalstatus = 'cancelled'.*so.bookingdetailid = ANY\(\$2\)`).
+		WithArgs(at, pq.Array([]string{"bd1"})).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	suite.mockSql.ExpectExec(`UPDATE rooms SET archivedat = \$1, updatedat = \$1 WHERE id = \$2`).
+		WithArgs(at, "1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockSql.ExpectCommit()
+
+	affected, err := suite.repo.Archive(context.Background(), "1", dto.ArchiveOption{Cascade: "cancel"}, at)
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), affected, 1)
+	assert.Equal(suite.T(), "abc123", affected[0].ConferenceId)
+	assert.Equal(suite.T(), 1, affected[0].InviteSequence)
+	assert.Equal(suite.T(), []string{"sari@mail.com", "tono@mail.com"}, affected[0].Participants)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *RoomRepositoryTestSuite) TestRestore_NotArchived() {
+	suite.mockSql.ExpectExec(`UPDATE rooms SET archivedat = NULL`).
+		WithArgs(sqlmock.AnyArg(), "1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := suite.repo.Restore(context.Background(), "1")
+
+	assert.Error(suite.T(), err)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *RoomRepositoryTestSuite) TestGetStatus_BlockedByCombinedRoom() {
+	suite.mockSql.ExpectQuery(`SELECT CASE WHEN EXISTS \(SELECT 1 FROM maintenance_tickets mt .*\) THEN 'maintenance' WHEN bool_or\(status = 'booked'\) THEN 'booked' ELSE 'available' END FROM rooms WHERE id IN \(SELECT \$1::uuid UNION .*\) HAVING bool_or\(id = \$1\)`).
+		WithArgs("1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("booked"))
+
+	status, err := suite.repo.GetStatus(context.Background(), "1")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "booked", status)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *RoomRepositoryTestSuite) TestSetParts_Success() {
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectExec(`DELETE FROM room_combinations WHERE combinedroomid = \$1`).WithArgs("ab").WillReturnResult(sqlmock.NewResult(0, 0))
+	suite.mockSql.ExpectExec(`INSERT INTO room_combinations`).WithArgs("ab", "a").WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockSql.ExpectExec(`INSERT INTO room_combinations`).WithArgs("ab", "b").WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockSql.ExpectExec(`UPDATE rooms SET updatedat`).WithArgs(sqlmock.AnyArg(), "ab").WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockSql.ExpectCommit()
+
+	err := suite.repo.SetParts(context.Background(), "ab", []string{"a", "b"})
+
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *RoomRepositoryTestSuite) TestImport_UpsertByCode() {
+	rows := []dto.RoomImportRow{
+		{Row: 2, Room: model.Room{Code: "R-01", Name: "Melati", RoomType: "meeting", MaxCapacity: 8, Status: "available", Amenities: []model.RoomAmenity{{Id: "a1", Quantity: 1}}}},
+		{Row: 3, Room: model.Room{Code: "R-02", Name: "Mawar", RoomType: "meeting", MaxCapacity: 12, Status: "available", Amenities: []model.RoomAmenity{}}},
+	}
+
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery(`SELECT id, status FROM rooms WHERE code = \$1 FOR UPDATE`).
+		WithArgs("R-01").
+		WillReturnError(sql.ErrNoRows)
+	suite.mockSql.ExpectQuery("INSERT INTO facilities").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("f1"))
+	suite.mockSql.ExpectQuery("INSERT INTO rooms").
+		WithArgs("Melati", "R-01", "meeting", 8, "f1", "", "available", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+	suite.mockSql.ExpectExec("DELETE FROM room_amenities").
+		WithArgs("1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	suite.mockSql.ExpectExec("INSERT INTO room_amenities").
+		WithArgs("1", "a1", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockSql.ExpectQuery(`SELECT id, status FROM rooms WHERE code = \$1 FOR UPDATE`).
+		WithArgs("R-02").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow("2", "booked"))
+	// status ruangan yang sudah ada dipertahankan
+	suite.mockSql.ExpectQuery("UPDATE rooms SET").
+		WithArgs("Mawar", "R-02", "meeting", 12, "", "booked", sqlmock.AnyArg(), "2").
+		WillReturnRows(sqlmock.NewRows([]string{"facilities"}).AddRow("f2"))
+	suite.mockSql.ExpectExec("UPDATE facilities SET").
+		WithArgs("", sqlmock.AnyArg(), "f2").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockSql.ExpectExec("DELETE FROM room_amenities").
+		WithArgs("2").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	suite.mockSql.ExpectCommit()
+
+	result, err := suite.repo.Import(context.Background(), rows, false)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), dto.RoomImportResult{TotalRows: 2, Created: 1, Updated: 1}, result)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *RoomRepositoryTestSuite) TestImport_DryRunRollsBack() {
+	rows := []dto.RoomImportRow{{Row: 2, Room: model.Room{Code: "R-01", RoomType: "meeting", MaxCapacity: 8}}}
+
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery(`SELECT id, status FROM rooms WHERE code = \$1 FOR UPDATE`).
+		WithArgs("R-01").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow("1", "available"))
+	suite.mockSql.ExpectQuery("UPDATE rooms SET").
+		WillReturnRows(sqlmock.NewRows([]string{"facilities"}).AddRow("f1"))
+	suite.mockSql.ExpectExec("UPDATE facilities SET").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockSql.ExpectExec("DELETE FROM room_amenities").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	suite.mockSql.ExpectRollback()
+
+	result, err := suite.repo.Import(context.Background(), rows, true)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), dto.RoomImportResult{DryRun: true, TotalRows: 1, Updated: 1}, result)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *RoomRepositoryTestSuite) TestImport_FailedRowRollsBack() {
+	rows := []dto.RoomImportRow{{Row: 4, Room: model.Room{Code: "R-01", RoomType: "meeting", MaxCapacity: 8, FloorId: "missing"}}}
+
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery(`SELECT id, status FROM rooms WHERE code = \$1 FOR UPDATE`).
+		WillReturnError(sql.ErrNoRows)
+	suite.mockSql.ExpectQuery("INSERT INTO facilities").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("f1"))
+	suite.mockSql.ExpectQuery("INSERT INTO rooms").
+		WillReturnError(errors.New("violates foreign key constraint"))
+	suite.mockSql.ExpectRollback()
+
+	_, err := suite.repo.Import(context.Background(), rows, false)
+
+	assert.EqualError(suite.T(), err, "row 4: violates foreign key constraint")
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *RoomRepositoryTestSuite) TestGetStatus_CriticalTicketOpen() {
+	suite.mockSql.ExpectQuery(`SELECT CASE WHEN EXISTS \(SELECT 1 FROM maintenance_tickets mt WHERE mt.severity = 'critical' AND mt.status <> 'resolved' AND mt.roomid IN \(SELECT \$1::uuid UNION SELECT rc.partroomid .*\)\) THEN 'maintenance'`).
+		WithArgs("1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("maintenance"))
+
+	status, err := suite.repo.GetStatus(context.Background(), "1")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "maintenance", status)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *RoomRepositoryTestSuite) TestArchive_ReassignRejectedTarget() {
+	at := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	start := at.Add(24 * time.Hour)
+	tests := []struct {
+		name     string
+		critical bool
+		tooLarge int
+		want     string
+	}{
+		{name: "critical ticket", critical: true, want: "room with id 2 has an open critical maintenance ticket"},
+		{name: "too small", tooLarge: 1, want: "room with id 2 only fits 8 attendees, 1 booking(s) have more"},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			suite.SetupTest()
+			suite.mockSql.ExpectBegin()
+			suite.mockSql.ExpectQuery(`SELECT archivedat FROM rooms WHERE id = \$1 FOR UPDATE`).
+				WithArgs("1").
+				WillReturnRows(sqlmock.NewRows([]string{"archivedat"}).AddRow(nil))
+			suite.mockSql.ExpectQuery(`SELECT bd.id, bd.bookingid, u.name, u.email`).
+				WithArgs("1", at).
+				WillReturnRows(sqlmock.NewRows(affectedColumns).AddRow("bd1", "b1", "budi", "budi@mail.com", "accept", start, start.Add(time.Hour), "weekly sync", "", "", 0, "u1", "{}"))
+			suite.mockSql.ExpectQuery(`SELECT r.capacity, EXISTS \(SELECT 1 FROM maintenance_tickets mt WHERE mt.severity = 'critical'.* FOR UPDATE OF r`).
+				WithArgs("2", "1").
+				WillReturnRows(sqlmock.NewRows([]string{"capacity", "critical"}).AddRow(8, tt.critical))
+			if !tt.critical {
+				suite.mockSql.ExpectQuery(`SELECT COUNT\(\*\) FROM booking_details bd WHERE .* AND bd.attendees > \$3`).
+					WithArgs("1", at, 8).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tt.tooLarge))
+			}
+			suite.mockSql.ExpectRollback()
+
+			_, err := suite.repo.Archive(context.Background(), "1", dto.ArchiveOption{Cascade: "reassign", ReassignTo: "2"}, at)
+
+			assert.EqualError(suite.T(), err, tt.want)
+			assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"final-project-booking-room/model"
+	"final-project-booking-room/model/dto"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type ServiceRepository interface {
+	GetTarget(ctx context.Context, bookingDetailId string) (dto.ServiceTarget, error)
+	Create(ctx context.Context, payload model.ServiceOrder) (model.ServiceOrder, error)
+	Get(ctx context.Context, id string) (model.ServiceOrder, error)
+	ListByBookingDetail(ctx context.Context, bookingDetailId string) ([]model.ServiceOrder, error)
+	List(ctx context.Context, filter dto.ServiceOrderFilter) ([]model.ServiceOrder, dto.Paging, error)
+	ListDaily(ctx context.Context, filter dto.ServiceDailyFilter) ([]model.ServiceOrder, error)
+	Respond(ctx context.Context, id string, approvalStatus string, handledBy string, note string, at time.Time) error
+	UpdateFulfillment(ctx context.Context, id string, status string, from []string, handledBy string, note string, at time.Time) error
+	Cancel(ctx context.Context, id string, userId string, at time.Time) error
+}
+
+// ErrServiceOrderStatus dikembalikan Respond, UpdateFulfillment dan Cancel jika order tidak ada atau statusnya tidak sesuai
+var ErrServiceOrderStatus = errors.New("service order not found or status doesn't allow this action")
+
+// order memakai ruangan booking detail dengan alias r supaya bisa memakai roomLocationJoins dan locationConditions
+const selectServiceOrder = `SELECT so.id, so.bookingdetailid, r.id, COALESCE(r.name, r.roomtype), ` + locationColumns + `, so.requestedby, u.name, so.servicetype, so.quantity,
+so.deliverat, COALESCE(so.notes, ''), so.approvalstatus, so.fulfillmentstatus, COALESCE(so.handledby::text, ''), COALESCE(so.responsenote, ''), so.createdat, so.updatedat
+FROM service_orders so JOIN booking_details bd ON bd.id = so.bookingdetailid JOIN users u ON u.id = so.requestedby JOIN rooms r ON r.id = bd.roomid ` + roomLocationJoins
+
+// cancelOpenServiceOrders membatalkan order yang belum selesai dari booking detail bookingDetailIds yang sudah cancel atau decline.
+// Dipanggil di transaksi yang membatalkan atau menolak booking tersebut.
+func cancelOpenServiceOrders(ctx context.Context, tx *sql.Tx, bookingDetailIds []string, at time.Time) error {
+	_, err := tx.ExecContext(ctx, `UPDATE service_orders so SET approvalstatus = 'cancelled', updatedat = $1 FROM booking_details bd
+	WHERE bd.id = so.bookingdetailid AND so.bookingdetailid = ANY($2) AND so.approvalstatus IN ('pending', 'approved') AND so.fulfillmentstatus <> 'fulfilled'
+	AND bd.status IN ('cancel', 'decline')`, at, pq.Array(bookingDetailIds))
+	return err
+}
+
+type serviceRepository struct {
+	db *sql.DB
+}
+
+func scanServiceOrder(row rowScanner) (model.ServiceOrder, error) {
+	var order model.ServiceOrder
+	var updatedAt sql.NullTime
+	dest := []any{&order.Id, &order.BookingDetailId, &order.RoomId, &order.RoomName}
+	dest = append(dest, locationDest(&order.Location)...)
+	dest = append(dest, &order.RequestedBy, &order.RequesterName, &order.ServiceType, &order.Quantity, &order.DeliverAt, &order.Notes, &order.ApprovalStatus,
+		&order.FulfillmentStatus, &order.HandledBy, &order.ResponseNote, &order.CreatedAt, &updatedAt)
+	if err := row.Scan(dest...); err != nil {
+		return model.ServiceOrder{}, err
+	}
+	order.UpdatedAt = updatedAt.Time
+	return order, nil
+}
+
+func (s *serviceRepository) list(ctx context.Context, query string, args ...any) ([]model.ServiceOrder, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orders := []model.ServiceOrder{}
+	for rows.Next() {
+		order, err := scanServiceOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// GetTarget implements ServiceRepository, hanya booking ruangan yang bisa punya order layanan
+func (s *serviceRepository) GetTarget(ctx context.Context, bookingDetailId string) (dto.ServiceTarget, error) {
+	var target dto.ServiceTarget
+	err := s.db.QueryRowContext(ctx, `SELECT b.userid, bd.status, bd.bookingdate, bd.bookingdateend FROM booking_details bd JOIN booking b ON b.id = bd.bookingid
+	WHERE bd.id = $1 AND bd.resourcetype = 'room'`, bookingDetailId).Scan(&target.UserId, &target.Status, &target.BookingDate, &target.BookingDateEnd)
+	if err != nil {
+		return dto.ServiceTarget{}, err
+	}
+	return target, nil
+}
+
+// Create implements ServiceRepository.
+func (s *serviceRepository) Create(ctx context.Context, payload model.ServiceOrder) (model.ServiceOrder, error) {
+	var id string
+	err := s.db.QueryRowContext(ctx, `INSERT INTO service_orders (bookingdetailid, requestedby, servicetype, quantity, deliverat, notes, approvalstatus, fulfillmentstatus, updatedat)
+	VALUES ($1, $2, $3, $4, $5, $6, 'pending', 'pending', $7) RETURNING id`,
+		payload.BookingDetailId, payload.RequestedBy, payload.ServiceType, payload.Quantity, payload.DeliverAt, payload.Notes, time.Now().UTC()).Scan(&id)
+	if err != nil {
+		return model.ServiceOrder{}, err
+	}
+	return s.Get(ctx, id)
+}
+
+// Get implements ServiceRepository.
+func (s *serviceRepository) Get(ctx context.Context, id string) (model.ServiceOrder, error) {
+	return scanServiceOrder(s.db.QueryRowContext(ctx, selectServiceOrder+` WHERE so.id = $1`, id))
+}
+
+// ListByBookingDetail implements ServiceRepository.
+func (s *serviceRepository) ListByBookingDetail(ctx context.Context, bookingDetailId string) ([]model.ServiceOrder, error) {
+	return s.list(ctx, selectServiceOrder+` WHERE so.bookingdetailid = $1 ORDER BY so.deliverat, so.createdat`, bookingDetailId)
+}
+
+// List implements ServiceRepository.
+func (s *serviceRepository) List(ctx context.Context, filter dto.ServiceOrderFilter) ([]model.ServiceOrder, dto.Paging, error) {
+	var conditions []string
+	var args []any
+	if filter.ApprovalStatus != "" {
+		conditions = append(conditions, fmt.Sprintf("so.approvalstatus = $%d", len(args)+1))
+		args = append(args, filter.ApprovalStatus)
+	}
+	if filter.FulfillmentStatus != "" {
+		conditions = append(conditions, fmt.Sprintf("so.fulfillmentstatus = $%d", len(args)+1))
+		args = append(args, filter.FulfillmentStatus)
+	}
+	where := whereClause(conditions)
+
+	var totalRows int
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM service_orders so `+where, args...).Scan(&totalRows); err != nil {
+		return nil, dto.Paging{}, err
+	}
+
+	orders, err := s.list(ctx, selectServiceOrder+` `+where+fmt.Sprintf(` ORDER BY so.deliverat, so.createdat LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2),
+		append(args, filter.Size, (filter.Page-1)*filter.Size)...)
+	if err != nil {
+		return nil, dto.Paging{}, err
+	}
+
+	paging := dto.Paging{
+		Page:       filter.Page,
+		Size:       filter.Size,
+		TotalRows:  totalRows,
+		TotalPages: (totalRows + filter.Size - 1) / filter.Size,
+	}
+	return orders, paging, nil
+}
+
+// ListDaily implements ServiceRepository.
+// Hanya order approved dari booking accept yang dikirim pada tanggal tersebut.
+func (s *serviceRepository) ListDaily(ctx context.Context, filter dto.ServiceDailyFilter) ([]model.ServiceOrder, error) {
+	conditions := []string{
+		"so.approvalstatus = 'approved'",
+		"bd.status = 'accept'",
+		fmt.Sprintf("so.deliverat >= ($1::date::timestamp AT TIME ZONE %s) AND so.deliverat < (($1::date + 1)::timestamp AT TIME ZONE %s)", locationZone, locationZone),
+	}
+	args := []any{filter.Date}
+	locations, locationArgs := locationConditions(filter.Location, len(args)+1)
+	conditions = append(conditions, locations...)
+	args = append(args, locationArgs...)
+
+	return s.list(ctx, selectServiceOrder+` `+whereClause(conditions)+` ORDER BY so.deliverat, r.name`, args...)
+}
+
+// Respond implements ServiceRepository, hanya order pending dari booking yang masih pending atau accept
+func (s *serviceRepository) Respond(ctx context.Context, id string, approvalStatus string, handledBy string, note string, at time.Time) error {
+	return s.updateStatus(ctx, `UPDATE service_orders so SET approvalstatus = $2, handledby = $3, responsenote = $4, updatedat = $5 FROM booking_details bd
+	WHERE bd.id = so.bookingdetailid AND so.id = $1 AND so.approvalstatus = 'pending' AND bd.status IN ('pending', 'accept')`, id, approvalStatus, handledBy, note, at)
+}
+
+// UpdateFulfillment implements ServiceRepository, hanya order approved yang fulfillment-nya salah satu dari from
+func (s *serviceRepository) UpdateFulfillment(ctx context.Context, id string, status string, from []string, handledBy string, note string, at time.Time) error {
+	return s.updateStatus(ctx, `UPDATE service_orders SET fulfillmentstatus = $2, handledby = $3, responsenote = COALESCE(NULLIF($4, ''), responsenote), updatedat = $5
+	WHERE id = $1 AND approvalstatus = 'approved' AND fulfillmentstatus = ANY($6)`, id, status, handledBy, note, at, pq.Array(from))
+}
+
+// Cancel implements ServiceRepository, order milik user yang belum diproses vendor
+func (s *serviceRepository) Cancel(ctx context.Context, id string, userId string, at time.Time) error {
+	return s.updateStatus(ctx, `UPDATE service_orders SET approvalstatus = 'cancelled', updatedat = $3
+	WHERE id = $1 AND requestedby = $2 AND approvalstatus IN ('pending', 'approved') AND fulfillmentstatus = 'pending'`, id, userId, at)
+}
+
+func (s *serviceRepository) updateStatus(ctx context.Context, query string, args ...any) error {
+	result, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrServiceOrderStatus
+	}
+	return nil
+}
+
+func NewServiceRepository(db *sql.DB) ServiceRepository {
+	return &serviceRepository{db: db}
+}
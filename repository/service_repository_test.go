@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"final-project-booking-room/model/dto"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ServiceRepositoryTestSuite struct {
+	suite.Suite
+	mockDB  *sql.DB
+	mockSql sqlmock.Sqlmock
+	repo    ServiceRepository
+}
+
+func (suite *ServiceRepositoryTestSuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	assert.NoError(suite.T(), err)
+	suite.mockDB = db
+	suite.mockSql = mock
+	suite.repo = NewServiceRepository(suite.mockDB)
+}
+
+func TestServiceRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(ServiceRepositoryTestSuite))
+}
+
+var serviceOrderColumns = []string{"id", "bookingdetailid", "roomid", "roomname", "floorid", "floorname", "level", "buildingid", "buildingname", "siteid", "sitename", "timezone",
+	"requestedby", "requestername", "servicetype", "quantity", "deliverat", "notes", "approvalstatus", "fulfillmentstatus", "handledby", "responsenote", "createdat", "updatedat"}
+
+func (suite *ServiceRepositoryTestSuite) TestListDaily_ApprovedOnly() {
+	deliverAt := time.Date(2026, 10, 20, 5, 0, 0, 0, time.UTC)
+	suite.mockSql.ExpectQuery("WHERE so.approvalstatus = 'approved' AND bd.status = 'accept' AND so.deliverat >= (.+) AND bg.id = \\$2 ORDER BY so.deliverat").
+		WithArgs("2026-10-20", "b1").
+		WillReturnRows(sqlmock.NewRows(serviceOrderColumns).AddRow("so1", "bd1", "r1", "Ruang Rapat 1", "f1", "Lantai 3", 3, "b1", "Tower A", "s1", "Jakarta HQ", "Asia/Jakarta",
+			"u1", "Budi", "lunch", 12, deliverAt, "", "approved", "pending", "s1", "", deliverAt, nil))
+
+	orders, err := suite.repo.ListDaily(context.Background(), dto.ServiceDailyFilter{Date: "2026-10-20", Location: dto.LocationFilter{BuildingId: "b1"}})
+
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 12, orders[0].Quantity)
+	assert.Equal(suite.T(), "Ruang Rapat 1", orders[0].RoomName)
+}
+
+func (suite *ServiceRepositoryTestSuite) TestUpdateFulfillment_NotApproved() {
+	at := time.Now().UTC()
+	suite.mockSql.ExpectExec("UPDATE service_orders SET fulfillmentstatus = \\$2").
+		WithArgs("so1", "fulfilled", "s1", "", at, pq.Array([]string{"pending", "in_progress"})).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := suite.repo.UpdateFulfillment(context.Background(), "so1", "fulfilled", []string{"pending", "in_progress"}, "s1", "", at)
+
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+	assert.ErrorIs(suite.T(), err, ErrServiceOrderStatus)
+}
+
+func (suite *ServiceRepositoryTestSuite) TestCancel_Success() {
+	at := time.Now().UTC()
+	suite.mockSql.ExpectExec("UPDATE service_orders SET approvalstatus = 'cancelled'").
+		WithArgs("so1", "u1", at).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := suite.repo.Cancel(context.Background(), "so1", "u1", at)
+
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+	assert.NoError(suite.T(), err)
+}
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"final-project-booking-room/model"
+	"final-project-booking-room/model/dto"
+	"fmt"
+	"time"
+)
+
+type TicketRepository interface {
+	GetFeedbackTarget(ctx context.Context, bookingDetailId string, userId string) (dto.FeedbackTarget, error)
+	CreateFeedback(ctx context.Context, payload model.RoomFeedback) (model.RoomFeedback, error)
+	List(ctx context.Context, filter dto.TicketFilter) ([]model.MaintenanceTicket, dto.Paging, error)
+	Get(ctx context.Context, id string) (model.MaintenanceTicket, error)
+	Assign(ctx context.Context, id string, assigneeId string) error
+	Resolve(ctx context.Context, id string, resolution string, at time.Time) error
+}
+
+// criticalTicketOpen bernilai true jika ruangan ref atau salah satu bagiannya punya tiket critical yang belum resolved
+func criticalTicketOpen(ref string) string {
+	return `EXISTS (SELECT 1 FROM maintenance_tickets mt WHERE mt.severity = 'critical' AND mt.status <> 'resolved'
+	AND mt.roomid IN (SELECT ` + ref + ` UNION SELECT rc.partroomid FROM room_combinations rc WHERE rc.combinedroomid = ` + ref + `))`
+}
+
+// ErrTicketResolved dikembalikan Assign dan Resolve jika tiket tidak ada atau sudah resolved
+var ErrTicketResolved = errors.New("ticket not found or already resolved")
+
+const selectTicket = `SELECT mt.id, mt.roomid, COALESCE(r.name, r.roomtype), COALESCE(mt.bookingdetailid::text, ''), mt.reportedby, mt.category, mt.severity,
+COALESCE(mt.description, ''), mt.status, COALESCE(mt.assignedto::text, ''), COALESCE(mt.resolution, ''), mt.createdat, mt.updatedat, mt.resolvedat
+FROM maintenance_tickets mt JOIN rooms r ON r.id = mt.roomid`
+
+type ticketRepository struct {
+	db *sql.DB
+}
+
+func scanTicket(row rowScanner) (model.MaintenanceTicket, error) {
+	var ticket model.MaintenanceTicket
+	var updatedAt sql.NullTime
+	err := row.Scan(&ticket.Id, &ticket.RoomId, &ticket.RoomName, &ticket.BookingDetailId, &ticket.ReportedBy, &ticket.Category, &ticket.Severity,
+		&ticket.Description, &ticket.Status, &ticket.AssignedTo, &ticket.Resolution, &ticket.CreatedAt, &updatedAt, &ticket.ResolvedAt)
+	ticket.UpdatedAt = updatedAt.Time
+	return ticket, err
+}
+
+// GetFeedbackTarget implements TicketRepository.
+func (t *ticketRepository) GetFeedbackTarget(ctx context.Context, bookingDetailId string, userId string) (dto.FeedbackTarget, error) {
+	var target dto.FeedbackTarget
+	err := t.db.QueryRowContext(ctx, `SELECT bd.roomid, b.userid, `+fmt.Sprintf(participantOf, 2)+`, bd.status, bd.bookingdateend,
+	EXISTS (SELECT 1 FROM room_feedback f WHERE f.bookingdetailid = bd.id AND f.userid = $2)
+	FROM booking_details bd JOIN booking b ON b.id = bd.bookingid WHERE bd.id = $1`, bookingDetailId, userId).
+		Scan(&target.RoomId, &target.UserId, &target.Participant, &target.Status, &target.BookingDateEnd, &target.HasFeedback)
+	if err != nil {
+		return dto.FeedbackTarget{}, err
+	}
+	return target, nil
+}
+
+// CreateFeedback implements TicketRepository.
+// Feedback dan tiket dari laporan masalahnya disimpan dalam satu transaksi.
+func (t *ticketRepository) CreateFeedback(ctx context.Context, payload model.RoomFeedback) (model.RoomFeedback, error) {
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return model.RoomFeedback{}, err
+	}
+	defer tx.Rollback()
+
+	feedback := payload
+	err = tx.QueryRowContext(ctx, `INSERT INTO room_feedback (bookingdetailid, roomid, userid, rating, comment) VALUES ($1, $2, $3, $4, $5) RETURNING id, createdat`,
+		payload.BookingDetailId, payload.RoomId, payload.UserId, payload.Rating, payload.Comment).Scan(&feedback.Id, &feedback.CreatedAt)
+	if err != nil {
+		return model.RoomFeedback{}, err
+	}
+
+	feedback.Tickets = make([]model.MaintenanceTicket, 0, len(payload.Tickets))
+	for _, ticket := range payload.Tickets {
+		err := tx.QueryRowContext(ctx, `INSERT INTO maintenance_tickets (roomid, bookingdetailid, reportedby, category, severity, description, status, updatedat)
+		VALUES ($1, $2, $3, $4, $5, $6, 'open', $7) RETURNING id, status, createdat, updatedat`,
+			payload.RoomId, payload.BookingDetailId, payload.UserId, ticket.Category, ticket.Severity, ticket.Description, time.Now().UTC()).
+			Scan(&ticket.Id, &ticket.Status, &ticket.CreatedAt, &ticket.UpdatedAt)
+		if err != nil {
+			return model.RoomFeedback{}, err
+		}
+		ticket.RoomId = payload.RoomId
+		ticket.BookingDetailId = payload.BookingDetailId
+		ticket.ReportedBy = payload.UserId
+		feedback.Tickets = append(feedback.Tickets, ticket)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return model.RoomFeedback{}, err
+	}
+	return feedback, nil
+}
+
+// List implements TicketRepository.
+// Tiket diurutkan dari yang paling parah lalu yang paling lama dibuka.
+func (t *ticketRepository) List(ctx context.Context, filter dto.TicketFilter) ([]model.MaintenanceTicket, dto.Paging, error) {
+	var conditions []string
+	var args []any
+	if filter.Status != "" {
+		conditions = append(conditions, fmt.Sprintf("mt.status = $%d", len(args)+1))
+		args = append(args, filter.Status)
+	}
+	if filter.Severity != "" {
+		conditions = append(conditions, fmt.Sprintf("mt.severity = $%d", len(args)+1))
+		args = append(args, filter.Severity)
+	}
+	if filter.RoomId != "" {
+		conditions = append(conditions, fmt.Sprintf("mt.roomid = $%d", len(args)+1))
+		args = append(args, filter.RoomId)
+	}
+	where := whereClause(conditions)
+
+	var totalRows int
+	if err := t.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM maintenance_tickets mt `+where, args...).Scan(&totalRows); err != nil {
+		return nil, dto.Paging{}, err
+	}
+
+	limit := fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	rows, err := t.db.QueryContext(ctx, selectTicket+` `+where+`
+	ORDER BY CASE mt.severity WHEN 'critical' THEN 0 WHEN 'high' THEN 1 WHEN 'medium' THEN 2 ELSE 3 END, mt.createdat, mt.id`+limit,
+		append(args, filter.Size, (filter.Page-1)*filter.Size)...)
+	if err != nil {
+		return nil, dto.Paging{}, err
+	}
+	defer rows.Close()
+
+	tickets := []model.MaintenanceTicket{}
+	for rows.Next() {
+		ticket, err := scanTicket(rows)
+		if err != nil {
+			return nil, dto.Paging{}, err
+		}
+		tickets = append(tickets, ticket)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, dto.Paging{}, err
+	}
+
+	paging := dto.Paging{
+		Page:       filter.Page,
+		Size:       filter.Size,
+		TotalRows:  totalRows,
+		TotalPages: (totalRows + filter.Size - 1) / filter.Size,
+	}
+	return tickets, paging, nil
+}
+
+// Get implements TicketRepository.
+func (t *ticketRepository) Get(ctx context.Context, id string) (model.MaintenanceTicket, error) {
+	return scanTicket(t.db.QueryRowContext(ctx, selectTicket+` WHERE mt.id = $1`, id))
+}
+
+// Assign implements TicketRepository.
+func (t *ticketRepository) Assign(ctx context.Context, id string, assigneeId string) error {
+	result, err := t.db.ExecContext(ctx, `UPDATE maintenance_tickets SET assignedto = $2, status = 'assigned', updatedat = $3 WHERE id = $1 AND status <> 'resolved'`,
+		id, assigneeId, time.Now().UTC())
+	return ticketUpdated(result, err)
+}
+
+// Resolve implements TicketRepository.
+func (t *ticketRepository) Resolve(ctx context.Context, id string, resolution string, at time.Time) error {
+	result, err := t.db.ExecContext(ctx, `UPDATE maintenance_tickets SET resolution = $2, status = 'resolved', resolvedat = $3, updatedat = $3 WHERE id = $1 AND status <> 'resolved'`,
+		id, resolution, at)
+	return ticketUpdated(result, err)
+}
+
+func ticketUpdated(result sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrTicketResolved
+	}
+	return nil
+}
+
+func NewTicketRepository(db *sql.DB) TicketRepository {
+	return &ticketRepository{db: db}
+}
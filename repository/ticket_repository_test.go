@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"final-project-booking-room/model"
+	"final-project-booking-room/model/dto"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type TicketRepositoryTestSuite struct {
+	suite.Suite
+	mockDB  *sql.DB
+	mockSql sqlmock.Sqlmock
+	repo    TicketRepository
+}
+
+func (suite *TicketRepositoryTestSuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	assert.NoError(suite.T(), err)
+	suite.mockDB = db
+	suite.mockSql = mock
+	suite.repo = NewTicketRepository(suite.mockDB)
+}
+
+func TestTicketRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(TicketRepositoryTestSuite))
+}
+
+func (suite *TicketRepositoryTestSuite) TestGetFeedbackTarget_Participant() {
+	end := time.Date(2026, 1, 5, 3, 0, 0, 0, time.UTC)
+	suite.mockSql.ExpectQuery(`SELECT bd.roomid, b.userid, EXISTS \(SELECT 1 FROM booking_participants bp WHERE bp.bookingid = b.id AND bp.userid = \$2\), bd.status, bd.bookingdateend,\s+EXISTS \(SELECT 1 FROM room_feedback f WHERE f.bookingdetailid = bd.id AND f.userid = \$2\)`).
+		WithArgs("bd1", "u2").
+		WillReturnRows(sqlmock.NewRows([]string{"roomid", "userid", "participant", "status", "bookingdateend", "hasfeedback"}).AddRow("r1", "u1", true, "accept", end, false))
+
+	target, err := suite.repo.GetFeedbackTarget(context.Background(), "bd1", "u2")
+
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), dto.FeedbackTarget{RoomId: "r1", UserId: "u1", Participant: true, Status: "accept", BookingDateEnd: end}, target)
+}
+
+func (suite *TicketRepositoryTestSuite) TestCreateFeedback_WithTicket() {
+	now := time.Now()
+	payload := model.RoomFeedback{BookingDetailId: "bd1", RoomId: "r1", UserId: "u1", Rating: 2, Comment: "proyektor mati",
+		Tickets: []model.MaintenanceTicket{{Category: "projector", Severity: "critical", Description: "tidak mau menyala"}}}
+
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery("INSERT INTO room_feedback").
+		WithArgs("bd1", "r1", "u1", 2, "proyektor mati").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "createdat"}).AddRow("f1", now))
+	suite.mockSql.ExpectQuery("INSERT INTO maintenance_tickets").
+		WithArgs("r1", "bd1", "u1", "projector", "critical", "tidak mau menyala", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status", "createdat", "updatedat"}).AddRow("t1", "open", now, now))
+	suite.mockSql.ExpectCommit()
+
+	feedback, err := suite.repo.CreateFeedback(context.Background(), payload)
+
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "f1", feedback.Id)
+	assert.Equal(suite.T(), "t1", feedback.Tickets[0].Id)
+	assert.Equal(suite.T(), "open", feedback.Tickets[0].Status)
+	assert.Equal(suite.T(), "r1", feedback.Tickets[0].RoomId)
+}
+
+func (suite *TicketRepositoryTestSuite) TestList_OpenCritical() {
+	now := time.Now()
+	suite.mockSql.ExpectQuery(`SELECT COUNT\(\*\) FROM maintenance_tickets mt WHERE mt.status = \$1 AND mt.severity = \$2`).
+		WithArgs("open", "critical").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	suite.mockSql.ExpectQuery(`FROM maintenance_tickets mt JOIN rooms r ON r.id = mt.roomid WHERE mt.status = \$1 AND mt.severity = \$2 ORDER BY CASE mt.severity .* LIMIT \$3 OFFSET \$4`).
+		WithArgs("open", "critical", 10, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "roomid", "roomname", "bookingdetailid", "reportedby", "category", "severity", "description", "status", "assignedto", "resolution", "createdat", "updatedat", "resolvedat"}).
+			AddRow("t1", "r1", "Melati", "bd1", "u1", "projector", "critical", "tidak mau menyala", "open", "", "", now, nil, nil))
+
+	tickets, paging, err := suite.repo.List(context.Background(), dto.TicketFilter{Status: "open", Severity: "critical", Page: 1, Size: 10})
+
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), dto.Paging{Page: 1, Size: 10, TotalRows: 1, TotalPages: 1}, paging)
+	assert.Equal(suite.T(), "Melati", tickets[0].RoomName)
+	assert.Nil(suite.T(), tickets[0].ResolvedAt)
+}
+
+func (suite *TicketRepositoryTestSuite) TestResolve_AlreadyResolved() {
+	at := time.Now()
+	suite.mockSql.ExpectExec(`UPDATE maintenance_tickets SET resolution = \$2, status = 'resolved', resolvedat = \$3, updatedat = \$3 WHERE id = \$1 AND status <> 'resolved'`).
+		WithArgs("t1", "sudah diganti", at).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := suite.repo.Resolve(context.Background(), "t1", "sudah diganti", at)
+
+	assert.ErrorIs(suite.T(), err, ErrTicketResolved)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
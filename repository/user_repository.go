@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"final-project-booking-room/model"
@@ -11,12 +12,12 @@ import (
 )
 
 type UserRepository interface {
-	GetById(id string) (model.User, error)
-	Create(payload model.User) (model.User, error)
-	UpdateUserById(userId string, payload model.User) (model.User, error)
-	DeleteUserById(id string) (model.User, error)
-	GetAllUser() ([]model.User, error)
-	GetByEmail(email string) (model.User, error)
+	GetById(ctx context.Context, id string) (model.User, error)
+	Create(ctx context.Context, payload model.User) (model.User, error)
+	UpdateUserById(ctx context.Context, userId string, payload model.User) (model.User, error)
+	DeleteUserById(ctx context.Context, id string) (model.User, error)
+	GetAllUser(ctx context.Context) ([]model.User, error)
+	GetByEmail(ctx context.Context, email string) (model.User, error)
 }
 
 type userRepository struct {
@@ -24,9 +25,9 @@ type userRepository struct {
 }
 
 // MENCARI USER BERDASARKAN EMAIL => UNTUK LOGIN
-func (u *userRepository) GetByEmail(email string) (model.User, error) {
+func (u *userRepository) GetByEmail(ctx context.Context, email string) (model.User, error) {
 	var user model.User
-	err := u.db.QueryRow(common.GetByEmail, email).Scan(
+	err := u.db.QueryRowContext(ctx, common.GetByEmail, email).Scan(
 		&user.Id,
 		&user.Name,
 		&user.Divisi,
@@ -43,9 +44,9 @@ func (u *userRepository) GetByEmail(email string) (model.User, error) {
 }
 
 // !MENCARI USER BERDASARKAN ID
-func (u *userRepository) GetById(id string) (model.User, error) {
+func (u *userRepository) GetById(ctx context.Context, id string) (model.User, error) {
 	var user model.User
-	err := u.db.QueryRow(common.GetUserById, id).
+	err := u.db.QueryRowContext(ctx, common.GetUserById, id).
 		Scan(&user.Id, &user.Name, &user.Divisi, &user.Jabatan, &user.Email, &user.Role, &user.CreatedAt, &user.UpdatedAt)
 
 	if err != nil {
@@ -56,9 +57,9 @@ func (u *userRepository) GetById(id string) (model.User, error) {
 }
 
 // !MEMBUAT USER BARU
-func (u *userRepository) Create(payload model.User) (model.User, error) {
+func (u *userRepository) Create(ctx context.Context, payload model.User) (model.User, error) {
 	var user model.User
-	err := u.db.QueryRow(common.CreateUser, payload.Name, payload.Divisi, payload.Jabatan,
+	err := u.db.QueryRowContext(ctx, common.CreateUser, payload.Name, payload.Divisi, payload.Jabatan,
 		payload.Email, payload.Password, payload.Role, time.Now()).
 		Scan(&user.Id, &user.Name, &user.Divisi, &user.Jabatan, &user.Email, &user.Role, &user.CreatedAt, &user.UpdatedAt)
 
@@ -70,11 +71,11 @@ func (u *userRepository) Create(payload model.User) (model.User, error) {
 }
 
 // !MENGUPDATE USER BERDASARKAN ID
-func (u *userRepository) UpdateUserById(userId string, payload model.User) (model.User, error) {
+func (u *userRepository) UpdateUserById(ctx context.Context, userId string, payload model.User) (model.User, error) {
 
 	var user model.User
 	fmt.Println(userId)
-	err := u.db.QueryRow(common.UpdateUser, payload.Name, payload.Divisi, payload.Jabatan,
+	err := u.db.QueryRowContext(ctx, common.UpdateUser, payload.Name, payload.Divisi, payload.Jabatan,
 		payload.Email, payload.Password, payload.Role, time.Now(), userId).
 		Scan(&user.Id, &user.Name, &user.Divisi, &user.Jabatan, &user.Email, &user.Role, &user.UpdatedAt)
 
@@ -86,8 +87,8 @@ func (u *userRepository) UpdateUserById(userId string, payload model.User) (mode
 }
 
 // !MENGHAPUS USER BERDASARKAN ID
-func (u *userRepository) DeleteUserById(id string) (model.User, error) {
-	_, err := u.db.Exec(common.DeleteUser, id)
+func (u *userRepository) DeleteUserById(ctx context.Context, id string) (model.User, error) {
+	_, err := u.db.ExecContext(ctx, common.DeleteUser, id)
 	if err != nil {
 		return model.User{}, errors.New("failed to delete user")
 	}
@@ -96,10 +97,10 @@ func (u *userRepository) DeleteUserById(id string) (model.User, error) {
 }
 
 // !MENAMPILKAN SEMUA USER
-func (u *userRepository) GetAllUser() ([]model.User, error) {
+func (u *userRepository) GetAllUser(ctx context.Context) ([]model.User, error) {
 	var users []model.User
 
-	rows, err := u.db.Query(common.GetAllUser)
+	rows, err := u.db.QueryContext(ctx, common.GetAllUser)
 	if err != nil {
 		return nil, errors.New("failed to get all user")
 	}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"final-project-booking-room/model"
@@ -50,7 +51,7 @@ func (s *UserRepositoryTestSuite) TestCreateUser_Success() {
 		AddRow(mockUser.Id, mockUser.Name, mockUser.Divisi, mockUser.Jabatan, mockUser.Email, mockUser.Role, time.Now(), time.Now())
 	s.mockSql.ExpectQuery("INSERT INTO users").WillReturnRows(rows)
 
-	result, err := s.repo.Create(mockUser)
+	result, err := s.repo.Create(context.Background(), mockUser)
 
 	require.NoError(s.T(), s.mockSql.ExpectationsWereMet())
 
@@ -76,7 +77,7 @@ func (s *UserRepositoryTestSuite) TestGetAllUser_Success() {
 		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "divisi", "jabatan", "email", "role", "createdat", "updatedat"}).
 			AddRow(mockUsers[0].Id, mockUsers[0].Name, mockUsers[0].Divisi, mockUsers[0].Jabatan, mockUsers[0].Email, mockUsers[0].Role, mockUsers[0].CreatedAt, mockUsers[0].UpdatedAt))
 
-	actualUsers, err := s.repo.GetAllUser()
+	actualUsers, err := s.repo.GetAllUser(context.Background())
 
 	assert.NoError(s.T(), err, "Unexpected error in GetAllUser method")
 	assert.Equal(s.T(), mockUsers, actualUsers, "Returned users do not match expected users")
@@ -89,7 +90,7 @@ func (s *UserRepositoryTestSuite) TestGetAllUser_Fail() {
 	s.mockSql.ExpectQuery(common.GetAllUser).
 		WillReturnError(errors.New("db error"))
 
-	actualUsers, err := s.repo.GetAllUser()
+	actualUsers, err := s.repo.GetAllUser(context.Background())
 
 	expectedErrorMessage := "Expected error in GetAllUser method with 'db error' message"
 	assert.Error(s.T(), err, expectedErrorMessage)
@@ -104,7 +105,7 @@ func (s *UserRepositoryTestSuite) TestDeleteUserByID_Fail() {
 	s.mockSql.ExpectExec("DELETE FROM users WHERE id = ?").WithArgs(userId).
 		WillReturnError(errors.New("failed to delete user"))
 
-	_, err := s.repo.DeleteUserById(userId)
+	_, err := s.repo.DeleteUserById(context.Background(), userId)
 
 	expectedErrorMessage := "failed to delete user"
 	assert.EqualError(s.T(), err, expectedErrorMessage, "Unexpected error in DeleteUserById method")
@@ -117,7 +118,7 @@ func (s *UserRepositoryTestSuite) TestDeleteUserByID_Success() {
 
 	s.mockSql.ExpectExec("DELETE FROM users WHERE id = ?").WithArgs(userID).WillReturnResult(sqlmock.NewResult(0, 1))
 
-	result, err := s.repo.DeleteUserById(userID)
+	result, err := s.repo.DeleteUserById(context.Background(), userID)
 
 	require.NoError(s.T(), s.mockSql.ExpectationsWereMet())
 
@@ -142,7 +143,7 @@ func (s *UserRepositoryTestSuite) TestGetUserById_Success() {
 		AddRow(mockUser.Id, mockUser.Name, mockUser.Divisi, mockUser.Jabatan, mockUser.Email, mockUser.Role, mockUser.CreatedAt, mockUser.UpdatedAt)
 	s.mockSql.ExpectQuery("SELECT id,name,divisi,jabatan,email,role,createdat,updatedat FROM users WHERE id = ?").WithArgs(mockUser.Id).WillReturnRows(rows)
 
-	result, err := s.repo.GetById(mockUser.Id)
+	result, err := s.repo.GetById(context.Background(), mockUser.Id)
 
 	require.NoError(s.T(), s.mockSql.ExpectationsWereMet())
 
@@ -156,7 +157,7 @@ func (s *UserRepositoryTestSuite) TestGetUserById_Fail() {
 		WithArgs(userId).
 		WillReturnError(errors.New("id not found"))
 
-	_, err := s.repo.GetById(userId)
+	_, err := s.repo.GetById(context.Background(), userId)
 
 	expectedErrorMessage := "id not found"
 	assert.EqualError(s.T(), err, expectedErrorMessage, "Unexpected error in GetById method")
@@ -181,7 +182,7 @@ func (s *UserRepositoryTestSuite) TestGetUserByEmail_Success() {
 		AddRow(mockUser.Id, mockUser.Name, mockUser.Divisi, mockUser.Jabatan, mockUser.Email, mockUser.Password, mockUser.Role)
 	s.mockSql.ExpectQuery("SELECT id, name, divisi, jabatan, email, password, role FROM users WHERE email = ?").WithArgs("john.doe@example.com").WillReturnRows(rows)
 
-	result, err := s.repo.GetByEmail(email)
+	result, err := s.repo.GetByEmail(context.Background(), email)
 
 	require.NoError(s.T(), s.mockSql.ExpectationsWereMet())
 
@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"final-project-booking-room/model"
+	"final-project-booking-room/model/dto"
+	"fmt"
+	"time"
+)
+
+type VisitorRepository interface {
+	GetTarget(ctx context.Context, bookingDetailId string) (dto.VisitorTarget, error)
+	CreateMany(ctx context.Context, bookingDetailId string, registeredBy string, payload []model.Visitor) ([]model.Visitor, error)
+	Get(ctx context.Context, id string) (model.Visitor, error)
+	ListByBookingDetail(ctx context.Context, bookingDetailId string) ([]model.Visitor, error)
+	ListByDay(ctx context.Context, filter dto.VisitorFilter) ([]model.Visitor, error)
+	CheckIn(ctx context.Context, id string, at time.Time) error
+	CheckOut(ctx context.Context, id string, at time.Time) error
+	Purge(ctx context.Context, before time.Time) (int, error)
+}
+
+// ErrVisitorStatus dikembalikan CheckIn dan CheckOut jika visitor tidak ada atau statusnya tidak sesuai
+var ErrVisitorStatus = errors.New("visitor not found or status doesn't allow this action")
+
+// visitor memakai ruangan booking detail dengan alias r supaya bisa memakai roomLocationJoins dan locationConditions
+const selectVisitor = `SELECT v.id, v.bookingdetailid, r.id, COALESCE(r.name, r.roomtype), ` + locationColumns + `, v.registeredby, u.name, v.name, COALESCE(v.company, ''),
+v.email, v.expectedarrival, v.status, v.checkedinat, v.checkedoutat, v.createdat
+FROM visitors v JOIN booking_details bd ON bd.id = v.bookingdetailid JOIN users u ON u.id = v.registeredby JOIN rooms r ON r.id = bd.roomid ` + roomLocationJoins
+
+type visitorRepository struct {
+	db *sql.DB
+}
+
+func scanVisitor(row rowScanner) (model.Visitor, error) {
+	var visitor model.Visitor
+	dest := []any{&visitor.Id, &visitor.BookingDetailId, &visitor.RoomId, &visitor.RoomName}
+	dest = append(dest, locationDest(&visitor.Location)...)
+	dest = append(dest, &visitor.RegisteredBy, &visitor.HostName, &visitor.Name, &visitor.Company, &visitor.Email, &visitor.ExpectedArrival, &visitor.Status,
+		&visitor.CheckedInAt, &visitor.CheckedOutAt, &visitor.CreatedAt)
+	if err := row.Scan(dest...); err != nil {
+		return model.Visitor{}, err
+	}
+	return visitor, nil
+}
+
+func (v *visitorRepository) list(ctx context.Context, query string, args ...any) ([]model.Visitor, error) {
+	rows, err := v.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	visitors := []model.Visitor{}
+	for rows.Next() {
+		visitor, err := scanVisitor(rows)
+		if err != nil {
+			return nil, err
+		}
+		visitors = append(visitors, visitor)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return visitors, nil
+}
+
+// GetTarget implements VisitorRepository, hanya booking ruangan yang bisa punya visitor
+func (v *visitorRepository) GetTarget(ctx context.Context, bookingDetailId string) (dto.VisitorTarget, error) {
+	var target dto.VisitorTarget
+	dest := []any{&target.UserId, &target.HostName, &target.Status, &target.BookingDate, &target.BookingDateEnd, &target.RoomId, &target.RoomName}
+	dest = append(dest, locationDest(&target.Location)...)
+	err := v.db.QueryRowContext(ctx, `SELECT b.userid, u.name, bd.status, bd.bookingdate, bd.bookingdateend, r.id, COALESCE(r.name, r.roomtype), `+locationColumns+`
+	FROM booking_details bd JOIN booking b ON b.id = bd.bookingid JOIN users u ON u.id = b.userid JOIN rooms r ON r.id = bd.roomid `+roomLocationJoins+`
+	WHERE bd.id = $1 AND bd.resourcetype = 'room'`, bookingDetailId).Scan(dest...)
+	if err != nil {
+		return dto.VisitorTarget{}, err
+	}
+	return target, nil
+}
+
+// CreateMany implements VisitorRepository, semua visitor disimpan dalam satu transaksi
+func (v *visitorRepository) CreateMany(ctx context.Context, bookingDetailId string, registeredBy string, payload []model.Visitor) ([]model.Visitor, error) {
+	tx, err := v.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	ids := make([]string, 0, len(payload))
+	for _, visitor := range payload {
+		var id string
+		err := tx.QueryRowContext(ctx, `INSERT INTO visitors (bookingdetailid, registeredby, name, company, email, expectedarrival, status, updatedat)
+		VALUES ($1, $2, $3, $4, $5, $6, 'expected', $7) RETURNING id`,
+			bookingDetailId, registeredBy, visitor.Name, visitor.Company, visitor.Email, visitor.ExpectedArrival, time.Now().UTC()).Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	visitors := make([]model.Visitor, 0, len(ids))
+	for _, id := range ids {
+		visitor, err := v.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		visitors = append(visitors, visitor)
+	}
+	return visitors, nil
+}
+
+// Get implements VisitorRepository.
+func (v *visitorRepository) Get(ctx context.Context, id string) (model.Visitor, error) {
+	return scanVisitor(v.db.QueryRowContext(ctx, selectVisitor+` WHERE v.id = $1`, id))
+}
+
+// ListByBookingDetail implements VisitorRepository.
+func (v *visitorRepository) ListByBookingDetail(ctx context.Context, bookingDetailId string) ([]model.Visitor, error) {
+	return v.list(ctx, selectVisitor+` WHERE v.bookingdetailid = $1 ORDER BY v.expectedarrival, v.name`, bookingDetailId)
+}
+
+// ListByDay implements VisitorRepository.
+// Visitor dari booking yang dibatalkan atau ditolak tidak ditampilkan.
+func (v *visitorRepository) ListByDay(ctx context.Context, filter dto.VisitorFilter) ([]model.Visitor, error) {
+	conditions := []string{
+		"bd.status IN ('pending', 'accept')",
+		fmt.Sprintf("v.expectedarrival >= ($1::date::timestamp AT TIME ZONE %s) AND v.expectedarrival < (($1::date + 1)::timestamp AT TIME ZONE %s)", locationZone, locationZone),
+	}
+	args := []any{filter.Date}
+	locations, locationArgs := locationConditions(filter.Location, len(args)+1)
+	conditions = append(conditions, locations...)
+	args = append(args, locationArgs...)
+
+	return v.list(ctx, selectVisitor+` `+whereClause(conditions)+` ORDER BY v.expectedarrival, v.name`, args...)
+}
+
+// CheckIn implements VisitorRepository, hanya visitor expected dari booking yang sudah accept
+func (v *visitorRepository) CheckIn(ctx context.Context, id string, at time.Time) error {
+	return v.updateStatus(ctx, `UPDATE visitors v SET status = 'checked_in', checkedinat = $2, updatedat = $2 FROM booking_details bd
+	WHERE bd.id = v.bookingdetailid AND v.id = $1 AND v.status = 'expected' AND bd.status = 'accept'`, id, at)
+}
+
+// CheckOut implements VisitorRepository.
+func (v *visitorRepository) CheckOut(ctx context.Context, id string, at time.Time) error {
+	return v.updateStatus(ctx, `UPDATE visitors SET status = 'checked_out', checkedoutat = $2, updatedat = $2 WHERE id = $1 AND status = 'checked_in'`, id, at)
+}
+
+func (v *visitorRepository) updateStatus(ctx context.Context, query string, id string, at time.Time) error {
+	result, err := v.db.ExecContext(ctx, query, id, at)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrVisitorStatus
+	}
+	return nil
+}
+
+// Purge implements VisitorRepository.
+// Menghapus visitor yang kunjungannya terakhir tercatat sebelum before, jumlahnya dikembalikan.
+func (v *visitorRepository) Purge(ctx context.Context, before time.Time) (int, error) {
+	result, err := v.db.ExecContext(ctx, `DELETE FROM visitors WHERE COALESCE(checkedoutat, checkedinat, expectedarrival) < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	purged, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(purged), nil
+}
+
+func NewVisitorRepository(db *sql.DB) VisitorRepository {
+	return &visitorRepository{db: db}
+}
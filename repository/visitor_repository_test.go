@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"final-project-booking-room/model"
+	"final-project-booking-room/model/dto"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type VisitorRepositoryTestSuite struct {
+	suite.Suite
+	mockDB  *sql.DB
+	mockSql sqlmock.Sqlmock
+	repo    VisitorRepository
+}
+
+func (suite *VisitorRepositoryTestSuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	assert.NoError(suite.T(), err)
+	suite.mockDB = db
+	suite.mockSql = mock
+	suite.repo = NewVisitorRepository(suite.mockDB)
+}
+
+func TestVisitorRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(VisitorRepositoryTestSuite))
+}
+
+var visitorColumns = []string{"id", "bookingdetailid", "roomid", "roomname", "floorid", "floorname", "level", "buildingid", "buildingname", "siteid", "sitename", "timezone",
+	"registeredby", "hostname", "name", "company", "email", "expectedarrival", "status", "checkedinat", "checkedoutat", "createdat"}
+
+func (suite *VisitorRepositoryTestSuite) TestCreateMany_Success() {
+	arrival := time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC)
+
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery("INSERT INTO visitors").
+		WithArgs("bd1", "u1", "Siti", "PT Maju", "siti@maju.co.id", arrival, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("v1"))
+	suite.mockSql.ExpectCommit()
+	suite.mockSql.ExpectQuery("FROM visitors v JOIN booking_details bd (.+) WHERE v.id = \\$1").
+		WithArgs("v1").
+		WillReturnRows(sqlmock.NewRows(visitorColumns).AddRow("v1", "bd1", "r1", "Ruang Rapat 1", "f1", "Lantai 3", 3, "b1", "Tower A", "s1", "Jakarta HQ", "Asia/Jakarta",
+			"u1", "Budi", "Siti", "PT Maju", "siti@maju.co.id", arrival, "expected", nil, nil, arrival))
+
+	visitors, err := suite.repo.CreateMany(context.Background(), "bd1", "u1", []model.Visitor{{Name: "Siti", Company: "PT Maju", Email: "siti@maju.co.id", ExpectedArrival: arrival}})
+
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "v1", visitors[0].Id)
+	assert.Equal(suite.T(), "Budi", visitors[0].HostName)
+	assert.Equal(suite.T(), "Tower A", visitors[0].Location.BuildingName)
+}
+
+func (suite *VisitorRepositoryTestSuite) TestListByDay_WithSite() {
+	suite.mockSql.ExpectQuery("WHERE bd.status IN \\('pending', 'accept'\\) AND v.expectedarrival >= (.+) AND s.id = \\$2 ORDER BY v.expectedarrival").
+		WithArgs("2026-10-20", "s1").
+		WillReturnRows(sqlmock.NewRows(visitorColumns))
+
+	visitors, err := suite.repo.ListByDay(context.Background(), dto.VisitorFilter{Date: "2026-10-20", Location: dto.LocationFilter{SiteId: "s1"}})
+
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), visitors)
+}
+
+func (suite *VisitorRepositoryTestSuite) TestCheckOut_NotCheckedIn() {
+	at := time.Now().UTC()
+	suite.mockSql.ExpectExec("UPDATE visitors SET status = 'checked_out'").
+		WithArgs("v1", at).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := suite.repo.CheckOut(context.Background(), "v1", at)
+
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+	assert.ErrorIs(suite.T(), err, ErrVisitorStatus)
+}
+
+func (suite *VisitorRepositoryTestSuite) TestPurge_Success() {
+	before := time.Now().UTC().Add(-30 * 24 * time.Hour)
+	suite.mockSql.ExpectExec("DELETE FROM visitors WHERE COALESCE\\(checkedoutat, checkedinat, expectedarrival\\) < \\$1").
+		WithArgs(before).
+		WillReturnResult(sqlmock.NewResult(0, 4))
+
+	purged, err := suite.repo.Purge(context.Background(), before)
+
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 4, purged)
+}
@@ -0,0 +1,37 @@
+package repositorymock
+
+import (
+	"context"
+	"final-project-booking-room/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type AmenityRepositoryMock struct {
+	mock.Mock
+}
+
+func (a *AmenityRepositoryMock) Create(ctx context.Context, payload model.Amenity) (model.Amenity, error) {
+	args := a.Called(ctx, payload)
+	return args.Get(0).(model.Amenity), args.Error(1)
+}
+
+func (a *AmenityRepositoryMock) Get(ctx context.Context, id string) (model.Amenity, error) {
+	args := a.Called(ctx, id)
+	return args.Get(0).(model.Amenity), args.Error(1)
+}
+
+func (a *AmenityRepositoryMock) GetAll(ctx context.Context) ([]model.Amenity, error) {
+	args := a.Called(ctx)
+	return args.Get(0).([]model.Amenity), args.Error(1)
+}
+
+func (a *AmenityRepositoryMock) Update(ctx context.Context, id string, payload model.Amenity) (model.Amenity, error) {
+	args := a.Called(ctx, id, payload)
+	return args.Get(0).(model.Amenity), args.Error(1)
+}
+
+func (a *AmenityRepositoryMock) Delete(ctx context.Context, id string) error {
+	args := a.Called(ctx, id)
+	return args.Error(0)
+}
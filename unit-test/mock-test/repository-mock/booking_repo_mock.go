@@ -1,7 +1,10 @@
 package repositorymock
 
 import (
+	"context"
 	"final-project-booking-room/model"
+	"final-project-booking-room/model/dto"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -10,42 +13,82 @@ type BookingRepoMock struct {
 	mock.Mock
 }
 
-func (b *BookingRepoMock) Create(payload model.Booking, userId string) (model.Booking, error) {
-	args := b.Called(payload, userId)
+func (b *BookingRepoMock) Create(ctx context.Context, payload model.Booking, userId string) (model.Booking, error) {
+	args := b.Called(ctx, payload, userId)
 	return args.Get(0).(model.Booking), args.Error(1)
 }
 
-func (b *BookingRepoMock) Get(id string, userId string, roleUser string) (model.Booking, error) {
-	args := b.Called(id, userId, roleUser)
+func (b *BookingRepoMock) Get(ctx context.Context, id string, userId string, roleUser string) (model.Booking, error) {
+	args := b.Called(ctx, id, userId, roleUser)
 	return args.Get(0).(model.Booking), args.Error(1)
 }
 
-func (b *BookingRepoMock) GetAll() ([]model.Booking, error) {
-	args := b.Called()
+func (b *BookingRepoMock) GetAll(ctx context.Context) ([]model.Booking, error) {
+	args := b.Called(ctx)
 	return args.Get(0).([]model.Booking), args.Error(1)
 }
 
-func (b *BookingRepoMock) GetAllByStatus(status string) ([]model.Booking, error) {
-	args := b.Called(status)
+func (b *BookingRepoMock) GetAllByStatus(ctx context.Context, status string) ([]model.Booking, error) {
+	args := b.Called(ctx, status)
 	return args.Get(0).([]model.Booking), args.Error(1)
 }
 
-func (b *BookingRepoMock) UpdateStatus(id string, approval string) (model.Booking, error) {
-	args := b.Called(id, approval)
+func (b *BookingRepoMock) UpdateStatus(ctx context.Context, id string, approval string) (model.Booking, error) {
+	args := b.Called(ctx, id, approval)
 	return args.Get(0).(model.Booking), args.Error(1)
 }
 
-func (b *BookingRepoMock) GetBookStatus(id string) (string, error) {
-	args := b.Called(id)
+func (b *BookingRepoMock) GetBookStatus(ctx context.Context, id string) (string, error) {
+	args := b.Called(ctx, id)
 	return args.String(0), args.Error(1)
 }
 
-func (b *BookingRepoMock) GetBookingDetailsByBookingID(bookingID string) ([]model.BookingDetail, error) {
-	args := b.Called(bookingID)
+func (b *BookingRepoMock) GetBookingDetailsByBookingID(ctx context.Context, bookingID string) ([]model.BookingDetail, error) {
+	args := b.Called(ctx, bookingID)
 	return args.Get(0).([]model.BookingDetail), args.Error(1)
 }
 
-func (b *BookingRepoMock) GetReport(requestJSON string) ([]model.Booking, error) {
-	args := b.Called(requestJSON)
+func (b *BookingRepoMock) GetReport(ctx context.Context, requestJSON string) ([]model.Booking, error) {
+	args := b.Called(ctx, requestJSON)
 	return args.Get(0).([]model.Booking), args.Error(1)
 }
+
+func (b *BookingRepoMock) GetAllByUser(ctx context.Context, userId string, filter dto.BookingFilter) ([]model.Booking, dto.Paging, error) {
+	args := b.Called(ctx, userId, filter)
+	return args.Get(0).([]model.Booking), args.Get(1).(dto.Paging), args.Error(2)
+}
+
+func (b *BookingRepoMock) Search(ctx context.Context, filter dto.BookingFilter) ([]model.Booking, dto.Paging, error) {
+	args := b.Called(ctx, filter)
+	return args.Get(0).([]model.Booking), args.Get(1).(dto.Paging), args.Error(2)
+}
+
+func (b *BookingRepoMock) GetCalendar(ctx context.Context, filter dto.CalendarFilter) ([]dto.CalendarRoom, error) {
+	args := b.Called(ctx, filter)
+	return args.Get(0).([]dto.CalendarRoom), args.Error(1)
+}
+
+func (b *BookingRepoMock) GetUtilization(ctx context.Context, filter dto.UtilizationFilter) ([]dto.UtilizationRoom, error) {
+	args := b.Called(ctx, filter)
+	return args.Get(0).([]dto.UtilizationRoom), args.Error(1)
+}
+
+func (b *BookingRepoMock) CheckIn(ctx context.Context, id string, userId string, at time.Time) error {
+	args := b.Called(ctx, id, userId, at)
+	return args.Error(0)
+}
+
+func (b *BookingRepoMock) SetConference(ctx context.Context, id string, conferenceId string, conferenceURL string) error {
+	args := b.Called(ctx, id, conferenceId, conferenceURL)
+	return args.Error(0)
+}
+
+func (b *BookingRepoMock) NextInviteSequence(ctx context.Context, id string) (int, error) {
+	args := b.Called(ctx, id)
+	return args.Int(0), args.Error(1)
+}
+
+func (b *BookingRepoMock) GetParticipantEmails(ctx context.Context, bookingId string) ([]string, error) {
+	args := b.Called(ctx, bookingId)
+	return args.Get(0).([]string), args.Error(1)
+}
@@ -0,0 +1,37 @@
+package repositorymock
+
+import (
+	"context"
+	"final-project-booking-room/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type EquipmentRepositoryMock struct {
+	mock.Mock
+}
+
+func (e *EquipmentRepositoryMock) Create(ctx context.Context, payload model.Equipment) (model.Equipment, error) {
+	args := e.Called(ctx, payload)
+	return args.Get(0).(model.Equipment), args.Error(1)
+}
+
+func (e *EquipmentRepositoryMock) Get(ctx context.Context, id string) (model.Equipment, error) {
+	args := e.Called(ctx, id)
+	return args.Get(0).(model.Equipment), args.Error(1)
+}
+
+func (e *EquipmentRepositoryMock) GetAll(ctx context.Context, siteId string) ([]model.Equipment, error) {
+	args := e.Called(ctx, siteId)
+	return args.Get(0).([]model.Equipment), args.Error(1)
+}
+
+func (e *EquipmentRepositoryMock) Update(ctx context.Context, id string, payload model.Equipment) (model.Equipment, error) {
+	args := e.Called(ctx, id, payload)
+	return args.Get(0).(model.Equipment), args.Error(1)
+}
+
+func (e *EquipmentRepositoryMock) Delete(ctx context.Context, id string) error {
+	args := e.Called(ctx, id)
+	return args.Error(0)
+}
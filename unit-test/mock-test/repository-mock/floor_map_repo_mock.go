@@ -0,0 +1,28 @@
+package repositorymock
+
+import (
+	"context"
+	"final-project-booking-room/model"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type FloorMapRepositoryMock struct {
+	mock.Mock
+}
+
+func (f *FloorMapRepositoryMock) SetRoomShape(ctx context.Context, roomId string, shape model.RoomShape) error {
+	args := f.Called(ctx, roomId, shape)
+	return args.Error(0)
+}
+
+func (f *FloorMapRepositoryMock) DeleteRoomShape(ctx context.Context, roomId string) error {
+	args := f.Called(ctx, roomId)
+	return args.Error(0)
+}
+
+func (f *FloorMapRepositoryMock) GetFloorRooms(ctx context.Context, floorId string, at time.Time) ([]model.FloorMapRoom, error) {
+	args := f.Called(ctx, floorId, at)
+	return args.Get(0).([]model.FloorMapRoom), args.Error(1)
+}
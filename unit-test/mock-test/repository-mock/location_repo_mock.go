@@ -0,0 +1,87 @@
+package repositorymock
+
+import (
+	"context"
+	"final-project-booking-room/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type LocationRepositoryMock struct {
+	mock.Mock
+}
+
+func (l *LocationRepositoryMock) CreateSite(ctx context.Context, payload model.Site) (model.Site, error) {
+	args := l.Called(ctx, payload)
+	return args.Get(0).(model.Site), args.Error(1)
+}
+
+func (l *LocationRepositoryMock) GetSite(ctx context.Context, id string) (model.Site, error) {
+	args := l.Called(ctx, id)
+	return args.Get(0).(model.Site), args.Error(1)
+}
+
+func (l *LocationRepositoryMock) GetAllSites(ctx context.Context) ([]model.Site, error) {
+	args := l.Called(ctx)
+	return args.Get(0).([]model.Site), args.Error(1)
+}
+
+func (l *LocationRepositoryMock) UpdateSite(ctx context.Context, id string, payload model.Site) (model.Site, error) {
+	args := l.Called(ctx, id, payload)
+	return args.Get(0).(model.Site), args.Error(1)
+}
+
+func (l *LocationRepositoryMock) DeleteSite(ctx context.Context, id string) error {
+	args := l.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (l *LocationRepositoryMock) CreateBuilding(ctx context.Context, payload model.Building) (model.Building, error) {
+	args := l.Called(ctx, payload)
+	return args.Get(0).(model.Building), args.Error(1)
+}
+
+func (l *LocationRepositoryMock) GetBuilding(ctx context.Context, id string) (model.Building, error) {
+	args := l.Called(ctx, id)
+	return args.Get(0).(model.Building), args.Error(1)
+}
+
+func (l *LocationRepositoryMock) GetAllBuildings(ctx context.Context, siteId string) ([]model.Building, error) {
+	args := l.Called(ctx, siteId)
+	return args.Get(0).([]model.Building), args.Error(1)
+}
+
+func (l *LocationRepositoryMock) UpdateBuilding(ctx context.Context, id string, payload model.Building) (model.Building, error) {
+	args := l.Called(ctx, id, payload)
+	return args.Get(0).(model.Building), args.Error(1)
+}
+
+func (l *LocationRepositoryMock) DeleteBuilding(ctx context.Context, id string) error {
+	args := l.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (l *LocationRepositoryMock) CreateFloor(ctx context.Context, payload model.Floor) (model.Floor, error) {
+	args := l.Called(ctx, payload)
+	return args.Get(0).(model.Floor), args.Error(1)
+}
+
+func (l *LocationRepositoryMock) GetFloor(ctx context.Context, id string) (model.Floor, error) {
+	args := l.Called(ctx, id)
+	return args.Get(0).(model.Floor), args.Error(1)
+}
+
+func (l *LocationRepositoryMock) GetAllFloors(ctx context.Context, buildingId string) ([]model.Floor, error) {
+	args := l.Called(ctx, buildingId)
+	return args.Get(0).([]model.Floor), args.Error(1)
+}
+
+func (l *LocationRepositoryMock) UpdateFloor(ctx context.Context, id string, payload model.Floor) (model.Floor, error) {
+	args := l.Called(ctx, id, payload)
+	return args.Get(0).(model.Floor), args.Error(1)
+}
+
+func (l *LocationRepositoryMock) DeleteFloor(ctx context.Context, id string) error {
+	args := l.Called(ctx, id)
+	return args.Error(0)
+}
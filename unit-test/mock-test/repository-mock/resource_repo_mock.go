@@ -0,0 +1,79 @@
+package repositorymock
+
+import (
+	"context"
+	"final-project-booking-room/model"
+	"final-project-booking-room/model/dto"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ResourceRepositoryMock struct {
+	mock.Mock
+}
+
+func (r *ResourceRepositoryMock) ListTypes(ctx context.Context) ([]model.ResourceType, error) {
+	args := r.Called(ctx)
+	return args.Get(0).([]model.ResourceType), args.Error(1)
+}
+
+func (r *ResourceRepositoryMock) GetType(ctx context.Context, code string) (model.ResourceType, error) {
+	args := r.Called(ctx, code)
+	return args.Get(0).(model.ResourceType), args.Error(1)
+}
+
+func (r *ResourceRepositoryMock) CreateNeighborhood(ctx context.Context, payload model.Neighborhood) (model.Neighborhood, error) {
+	args := r.Called(ctx, payload)
+	return args.Get(0).(model.Neighborhood), args.Error(1)
+}
+
+func (r *ResourceRepositoryMock) GetNeighborhood(ctx context.Context, id string) (model.Neighborhood, error) {
+	args := r.Called(ctx, id)
+	return args.Get(0).(model.Neighborhood), args.Error(1)
+}
+
+func (r *ResourceRepositoryMock) ListNeighborhoods(ctx context.Context, floorId string) ([]model.Neighborhood, error) {
+	args := r.Called(ctx, floorId)
+	return args.Get(0).([]model.Neighborhood), args.Error(1)
+}
+
+func (r *ResourceRepositoryMock) Create(ctx context.Context, payload model.Resource) (model.Resource, error) {
+	args := r.Called(ctx, payload)
+	return args.Get(0).(model.Resource), args.Error(1)
+}
+
+func (r *ResourceRepositoryMock) Get(ctx context.Context, id string) (model.Resource, error) {
+	args := r.Called(ctx, id)
+	return args.Get(0).(model.Resource), args.Error(1)
+}
+
+func (r *ResourceRepositoryMock) List(ctx context.Context, filter dto.ResourceFilter) ([]model.Resource, dto.Paging, error) {
+	args := r.Called(ctx, filter)
+	return args.Get(0).([]model.Resource), args.Get(1).(dto.Paging), args.Error(2)
+}
+
+func (r *ResourceRepositoryMock) Update(ctx context.Context, id string, payload model.Resource) (model.Resource, error) {
+	args := r.Called(ctx, id, payload)
+	return args.Get(0).(model.Resource), args.Error(1)
+}
+
+func (r *ResourceRepositoryMock) Archive(ctx context.Context, id string, at time.Time) (int, error) {
+	args := r.Called(ctx, id, at)
+	return args.Int(0), args.Error(1)
+}
+
+func (r *ResourceRepositoryMock) Book(ctx context.Context, payload model.ResourceBooking, limit dto.ResourceBookingLimit) (model.ResourceBooking, error) {
+	args := r.Called(ctx, payload, limit)
+	return args.Get(0).(model.ResourceBooking), args.Error(1)
+}
+
+func (r *ResourceRepositoryMock) ListBookings(ctx context.Context, userId string, from time.Time) ([]model.ResourceBooking, error) {
+	args := r.Called(ctx, userId, from)
+	return args.Get(0).([]model.ResourceBooking), args.Error(1)
+}
+
+func (r *ResourceRepositoryMock) CancelBooking(ctx context.Context, id string, userId string, at time.Time) error {
+	args := r.Called(ctx, id, userId, at)
+	return args.Error(0)
+}
@@ -0,0 +1,27 @@
+package repositorymock
+
+import (
+	"context"
+	"final-project-booking-room/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type RoomImageRepositoryMock struct {
+	mock.Mock
+}
+
+func (r *RoomImageRepositoryMock) Create(ctx context.Context, payload model.RoomImage) (model.RoomImage, error) {
+	args := r.Called(ctx, payload)
+	return args.Get(0).(model.RoomImage), args.Error(1)
+}
+
+func (r *RoomImageRepositoryMock) Get(ctx context.Context, id string) (model.RoomImage, error) {
+	args := r.Called(ctx, id)
+	return args.Get(0).(model.RoomImage), args.Error(1)
+}
+
+func (r *RoomImageRepositoryMock) Delete(ctx context.Context, id string) error {
+	args := r.Called(ctx, id)
+	return args.Error(0)
+}
@@ -0,0 +1,32 @@
+package repositorymock
+
+import (
+	"context"
+	"final-project-booking-room/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type RoomLayoutRepositoryMock struct {
+	mock.Mock
+}
+
+func (r *RoomLayoutRepositoryMock) Create(ctx context.Context, payload model.RoomLayout) (model.RoomLayout, error) {
+	args := r.Called(ctx, payload)
+	return args.Get(0).(model.RoomLayout), args.Error(1)
+}
+
+func (r *RoomLayoutRepositoryMock) Get(ctx context.Context, id string) (model.RoomLayout, error) {
+	args := r.Called(ctx, id)
+	return args.Get(0).(model.RoomLayout), args.Error(1)
+}
+
+func (r *RoomLayoutRepositoryMock) Update(ctx context.Context, payload model.RoomLayout) (model.RoomLayout, error) {
+	args := r.Called(ctx, payload)
+	return args.Get(0).(model.RoomLayout), args.Error(1)
+}
+
+func (r *RoomLayoutRepositoryMock) Delete(ctx context.Context, id string) error {
+	args := r.Called(ctx, id)
+	return args.Error(0)
+}
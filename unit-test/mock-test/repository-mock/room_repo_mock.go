@@ -1,7 +1,10 @@
 package repositorymock
 
 import (
+	"context"
 	"final-project-booking-room/model"
+	"final-project-booking-room/model/dto"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -10,53 +13,77 @@ type RoomRepositoryMock struct {
 	mock.Mock
 }
 
-// GetByRoomType implements repository.RoomRepository.
-func (r *RoomRepositoryMock) GetByRoomType(roomType string) (model.Room, error) {
-	args := r.Called(roomType)
+func (r *RoomRepositoryMock) Create(ctx context.Context, payload model.Room) (model.Room, error) {
+	args := r.Called(ctx, payload)
 	return args.Get(0).(model.Room), args.Error(1)
 }
 
-func (r *RoomRepositoryMock) Create(payload model.Room) (model.Room, error) {
-	args := r.Called(payload)
+func (r *RoomRepositoryMock) Get(ctx context.Context, id string) (model.Room, error) {
+	args := r.Called(ctx, id)
 	return args.Get(0).(model.Room), args.Error(1)
 }
 
-func (r *RoomRepositoryMock) Get(id string) (model.Room, error) {
-	args := r.Called(id)
-	return args.Get(0).(model.Room), args.Error(1)
+func (r *RoomRepositoryMock) List(ctx context.Context, filter dto.RoomFilter) ([]model.Room, dto.Paging, error) {
+	args := r.Called(ctx, filter)
+	return args.Get(0).([]model.Room), args.Get(1).(dto.Paging), args.Error(2)
 }
 
-func (r *RoomRepositoryMock) GetAllRoom() ([]model.Room, error) {
-	args := r.Called()
-	return args.Get(0).([]model.Room), args.Error(1)
+func (r *RoomRepositoryMock) Archive(ctx context.Context, id string, option dto.ArchiveOption, at time.Time) ([]dto.AffectedBooking, error) {
+	args := r.Called(ctx, id, option, at)
+	return args.Get(0).([]dto.AffectedBooking), args.Error(1)
 }
 
-func (r *RoomRepositoryMock) Delete(id string) (model.Room, error) {
-	args := r.Called(id)
-	return args.Get(0).(model.Room), args.Error(1)
+func (r *RoomRepositoryMock) FutureBookings(ctx context.Context, id string, at time.Time) ([]dto.AffectedBooking, error) {
+	args := r.Called(ctx, id, at)
+	return args.Get(0).([]dto.AffectedBooking), args.Error(1)
+}
+
+func (r *RoomRepositoryMock) SetParts(ctx context.Context, id string, partIds []string) error {
+	args := r.Called(ctx, id, partIds)
+	return args.Error(0)
 }
 
-func (r *RoomRepositoryMock) Update(id string, payload model.Room) (model.Room, error) {
-	args := r.Called(id, payload)
+func (r *RoomRepositoryMock) SetAccessRules(ctx context.Context, id string, rules []model.RoomAccessRule) error {
+	args := r.Called(ctx, id, rules)
+	return args.Error(0)
+}
+
+func (r *RoomRepositoryMock) Restore(ctx context.Context, id string) error {
+	args := r.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (r *RoomRepositoryMock) Update(ctx context.Context, id string, payload model.Room) (model.Room, error) {
+	args := r.Called(ctx, id, payload)
 	return args.Get(0).(model.Room), args.Error(1)
 }
 
-func (r *RoomRepositoryMock) GetStatus(id string) (string, error) {
-	args := r.Called(id)
+func (r *RoomRepositoryMock) GetStatus(ctx context.Context, id string) (string, error) {
+	args := r.Called(ctx, id)
 	return args.Get(0).(string), args.Error(1)
 }
 
-func (r *RoomRepositoryMock) GetStatusByBd(id string) (string, error) {
-	args := r.Called(id)
+func (r *RoomRepositoryMock) GetStatusByBd(ctx context.Context, id string) (string, error) {
+	args := r.Called(ctx, id)
 	return args.Get(0).(string), args.Error(1)
 }
 
-func (r *RoomRepositoryMock) ChangeStatus(id string) error {
-	args := r.Called(id)
+func (r *RoomRepositoryMock) ChangeStatus(ctx context.Context, id string) error {
+	args := r.Called(ctx, id)
 	return args.Error(1)
 }
 
-func (r *RoomRepositoryMock) GetAllRoomByStatus(status string) ([]model.Room, error) {
-	args := r.Called(status)
-	return args.Get(0).([]model.Room), args.Error(1)
+func (r *RoomRepositoryMock) Import(ctx context.Context, rows []dto.RoomImportRow, dryRun bool) (dto.RoomImportResult, error) {
+	args := r.Called(ctx, rows, dryRun)
+	return args.Get(0).(dto.RoomImportResult), args.Error(1)
+}
+
+func (r *RoomRepositoryMock) Recommend(ctx context.Context, filter dto.RecommendationFilter) ([]dto.RoomCandidate, error) {
+	args := r.Called(ctx, filter)
+	return args.Get(0).([]dto.RoomCandidate), args.Error(1)
+}
+
+func (r *RoomRepositoryMock) UsualBuilding(ctx context.Context, userId string) (string, error) {
+	args := r.Called(ctx, userId)
+	return args.Get(0).(string), args.Error(1)
 }
@@ -0,0 +1,59 @@
+package repositorymock
+
+import (
+	"context"
+	"final-project-booking-room/model"
+	"final-project-booking-room/model/dto"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ServiceRepositoryMock struct {
+	mock.Mock
+}
+
+func (s *ServiceRepositoryMock) GetTarget(ctx context.Context, bookingDetailId string) (dto.ServiceTarget, error) {
+	args := s.Called(ctx, bookingDetailId)
+	return args.Get(0).(dto.ServiceTarget), args.Error(1)
+}
+
+func (s *ServiceRepositoryMock) Create(ctx context.Context, payload model.ServiceOrder) (model.ServiceOrder, error) {
+	args := s.Called(ctx, payload)
+	return args.Get(0).(model.ServiceOrder), args.Error(1)
+}
+
+func (s *ServiceRepositoryMock) Get(ctx context.Context, id string) (model.ServiceOrder, error) {
+	args := s.Called(ctx, id)
+	return args.Get(0).(model.ServiceOrder), args.Error(1)
+}
+
+func (s *ServiceRepositoryMock) ListByBookingDetail(ctx context.Context, bookingDetailId string) ([]model.ServiceOrder, error) {
+	args := s.Called(ctx, bookingDetailId)
+	return args.Get(0).([]model.ServiceOrder), args.Error(1)
+}
+
+func (s *ServiceRepositoryMock) List(ctx context.Context, filter dto.ServiceOrderFilter) ([]model.ServiceOrder, dto.Paging, error) {
+	args := s.Called(ctx, filter)
+	return args.Get(0).([]model.ServiceOrder), args.Get(1).(dto.Paging), args.Error(2)
+}
+
+func (s *ServiceRepositoryMock) ListDaily(ctx context.Context, filter dto.ServiceDailyFilter) ([]model.ServiceOrder, error) {
+	args := s.Called(ctx, filter)
+	return args.Get(0).([]model.ServiceOrder), args.Error(1)
+}
+
+func (s *ServiceRepositoryMock) Respond(ctx context.Context, id string, approvalStatus string, handledBy string, note string, at time.Time) error {
+	args := s.Called(ctx, id, approvalStatus, handledBy, note, at)
+	return args.Error(0)
+}
+
+func (s *ServiceRepositoryMock) UpdateFulfillment(ctx context.Context, id string, status string, from []string, handledBy string, note string, at time.Time) error {
+	args := s.Called(ctx, id, status, from, handledBy, note, at)
+	return args.Error(0)
+}
+
+func (s *ServiceRepositoryMock) Cancel(ctx context.Context, id string, userId string, at time.Time) error {
+	args := s.Called(ctx, id, userId, at)
+	return args.Error(0)
+}
@@ -0,0 +1,44 @@
+package repositorymock
+
+import (
+	"context"
+	"final-project-booking-room/model"
+	"final-project-booking-room/model/dto"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type TicketRepositoryMock struct {
+	mock.Mock
+}
+
+func (t *TicketRepositoryMock) GetFeedbackTarget(ctx context.Context, bookingDetailId string, userId string) (dto.FeedbackTarget, error) {
+	args := t.Called(ctx, bookingDetailId, userId)
+	return args.Get(0).(dto.FeedbackTarget), args.Error(1)
+}
+
+func (t *TicketRepositoryMock) CreateFeedback(ctx context.Context, payload model.RoomFeedback) (model.RoomFeedback, error) {
+	args := t.Called(ctx, payload)
+	return args.Get(0).(model.RoomFeedback), args.Error(1)
+}
+
+func (t *TicketRepositoryMock) List(ctx context.Context, filter dto.TicketFilter) ([]model.MaintenanceTicket, dto.Paging, error) {
+	args := t.Called(ctx, filter)
+	return args.Get(0).([]model.MaintenanceTicket), args.Get(1).(dto.Paging), args.Error(2)
+}
+
+func (t *TicketRepositoryMock) Get(ctx context.Context, id string) (model.MaintenanceTicket, error) {
+	args := t.Called(ctx, id)
+	return args.Get(0).(model.MaintenanceTicket), args.Error(1)
+}
+
+func (t *TicketRepositoryMock) Assign(ctx context.Context, id string, assigneeId string) error {
+	args := t.Called(ctx, id, assigneeId)
+	return args.Error(0)
+}
+
+func (t *TicketRepositoryMock) Resolve(ctx context.Context, id string, resolution string, at time.Time) error {
+	args := t.Called(ctx, id, resolution, at)
+	return args.Error(0)
+}
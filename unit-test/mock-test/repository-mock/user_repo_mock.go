@@ -1,6 +1,7 @@
 package repositorymock
 
 import (
+	"context"
 	"final-project-booking-room/model"
 
 	"github.com/stretchr/testify/mock"
@@ -10,29 +11,29 @@ type UserRepositoryMock struct {
 	mock.Mock
 }
 
-func (u *UserRepositoryMock) GetById(id string) (model.User, error) {
-	args := u.Called(id)
+func (u *UserRepositoryMock) GetById(ctx context.Context, id string) (model.User, error) {
+	args := u.Called(ctx, id)
 	return args.Get(0).(model.User), args.Error(1)
 }
 
-func (u *UserRepositoryMock) Create(payload model.User) (model.User, error) {
-	args := u.Called(payload)
+func (u *UserRepositoryMock) Create(ctx context.Context, payload model.User) (model.User, error) {
+	args := u.Called(ctx, payload)
 	return args.Get(0).(model.User), args.Error(1)
 }
 
-func (u *UserRepositoryMock) UpdateUserById(userId string, payload model.User) (model.User, error) {
-	args := u.Called(userId, payload)
+func (u *UserRepositoryMock) UpdateUserById(ctx context.Context, userId string, payload model.User) (model.User, error) {
+	args := u.Called(ctx, userId, payload)
 	return args.Get(0).(model.User), args.Error(1)
 }
-func (u *UserRepositoryMock) DeleteUserById(id string) (model.User, error) {
-	args := u.Called(id)
+func (u *UserRepositoryMock) DeleteUserById(ctx context.Context, id string) (model.User, error) {
+	args := u.Called(ctx, id)
 	return args.Get(0).(model.User), args.Error(1)
 }
-func (u *UserRepositoryMock) GetAllUser() ([]model.User, error) {
-	args := u.Called()
+func (u *UserRepositoryMock) GetAllUser(ctx context.Context) ([]model.User, error) {
+	args := u.Called(ctx)
 	return args.Get(0).([]model.User), args.Error(1)
 }
-func (u *UserRepositoryMock) GetByEmail(email string) (model.User, error) {
-	args := u.Called(email)
+func (u *UserRepositoryMock) GetByEmail(ctx context.Context, email string) (model.User, error) {
+	args := u.Called(ctx, email)
 	return args.Get(0).(model.User), args.Error(1)
 }
@@ -0,0 +1,54 @@
+package repositorymock
+
+import (
+	"context"
+	"final-project-booking-room/model"
+	"final-project-booking-room/model/dto"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type VisitorRepositoryMock struct {
+	mock.Mock
+}
+
+func (v *VisitorRepositoryMock) GetTarget(ctx context.Context, bookingDetailId string) (dto.VisitorTarget, error) {
+	args := v.Called(ctx, bookingDetailId)
+	return args.Get(0).(dto.VisitorTarget), args.Error(1)
+}
+
+func (v *VisitorRepositoryMock) CreateMany(ctx context.Context, bookingDetailId string, registeredBy string, payload []model.Visitor) ([]model.Visitor, error) {
+	args := v.Called(ctx, bookingDetailId, registeredBy, payload)
+	return args.Get(0).([]model.Visitor), args.Error(1)
+}
+
+func (v *VisitorRepositoryMock) Get(ctx context.Context, id string) (model.Visitor, error) {
+	args := v.Called(ctx, id)
+	return args.Get(0).(model.Visitor), args.Error(1)
+}
+
+func (v *VisitorRepositoryMock) ListByBookingDetail(ctx context.Context, bookingDetailId string) ([]model.Visitor, error) {
+	args := v.Called(ctx, bookingDetailId)
+	return args.Get(0).([]model.Visitor), args.Error(1)
+}
+
+func (v *VisitorRepositoryMock) ListByDay(ctx context.Context, filter dto.VisitorFilter) ([]model.Visitor, error) {
+	args := v.Called(ctx, filter)
+	return args.Get(0).([]model.Visitor), args.Error(1)
+}
+
+func (v *VisitorRepositoryMock) CheckIn(ctx context.Context, id string, at time.Time) error {
+	args := v.Called(ctx, id, at)
+	return args.Error(0)
+}
+
+func (v *VisitorRepositoryMock) CheckOut(ctx context.Context, id string, at time.Time) error {
+	args := v.Called(ctx, id, at)
+	return args.Error(0)
+}
+
+func (v *VisitorRepositoryMock) Purge(ctx context.Context, before time.Time) (int, error) {
+	args := v.Called(ctx, before)
+	return args.Int(0), args.Error(1)
+}
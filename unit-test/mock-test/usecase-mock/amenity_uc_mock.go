@@ -0,0 +1,37 @@
+package usecasemock
+
+import (
+	"context"
+	"final-project-booking-room/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type AmenityUseCaseMock struct {
+	mock.Mock
+}
+
+func (a *AmenityUseCaseMock) RegisterNewAmenity(ctx context.Context, payload model.Amenity) (model.Amenity, error) {
+	args := a.Called(ctx, payload)
+	return args.Get(0).(model.Amenity), args.Error(1)
+}
+
+func (a *AmenityUseCaseMock) FindById(ctx context.Context, id string) (model.Amenity, error) {
+	args := a.Called(ctx, id)
+	return args.Get(0).(model.Amenity), args.Error(1)
+}
+
+func (a *AmenityUseCaseMock) ViewAllAmenities(ctx context.Context) ([]model.Amenity, error) {
+	args := a.Called(ctx)
+	return args.Get(0).([]model.Amenity), args.Error(1)
+}
+
+func (a *AmenityUseCaseMock) UpdateById(ctx context.Context, id string, payload model.Amenity) (model.Amenity, error) {
+	args := a.Called(ctx, id, payload)
+	return args.Get(0).(model.Amenity), args.Error(1)
+}
+
+func (a *AmenityUseCaseMock) DeleteById(ctx context.Context, id string) error {
+	args := a.Called(ctx, id)
+	return args.Error(0)
+}
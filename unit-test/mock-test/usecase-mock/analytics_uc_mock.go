@@ -0,0 +1,17 @@
+package usecasemock
+
+import (
+	"context"
+	"final-project-booking-room/model/dto"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type AnalyticsUseCaseMock struct {
+	mock.Mock
+}
+
+func (a *AnalyticsUseCaseMock) RoomUtilization(ctx context.Context, filter dto.UtilizationFilter) (dto.UtilizationReport, error) {
+	args := a.Called(ctx, filter)
+	return args.Get(0).(dto.UtilizationReport), args.Error(1)
+}
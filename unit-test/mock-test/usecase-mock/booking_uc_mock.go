@@ -1,6 +1,7 @@
 package usecasemock
 
 import (
+	"context"
 	"final-project-booking-room/model"
 	"final-project-booking-room/model/dto"
 
@@ -12,47 +13,67 @@ type BookingUseCaseMock struct {
 }
 
 // SendReport implements usecase.BookingUseCase.
-func (b *BookingUseCaseMock) SendReport(requestJSON string) ([]model.Booking, error) {
-	args := b.Called(requestJSON)
+func (b *BookingUseCaseMock) SendReport(ctx context.Context, requestJSON string) ([]model.Booking, error) {
+	args := b.Called(ctx, requestJSON)
 	return args.Get(0).([]model.Booking), args.Error(1)
 }
 
-func (b *BookingUseCaseMock) RegisterNewBooking(payload dto.BookingRequestDto, userId string) (model.Booking, error) {
-	args := b.Called(payload, userId)
+func (b *BookingUseCaseMock) RegisterNewBooking(ctx context.Context, payload dto.BookingRequestDto, userId string) (model.Booking, error) {
+	args := b.Called(ctx, payload, userId)
 	return args.Get(0).(model.Booking), args.Error(1)
 }
 
-func (b *BookingUseCaseMock) FindById(id string, userId string, roleUser string) (model.Booking, error) {
-	args := b.Called(id, userId, roleUser)
+func (b *BookingUseCaseMock) FindById(ctx context.Context, id string, userId string, roleUser string) (model.Booking, error) {
+	args := b.Called(ctx, id, userId, roleUser)
 	return args.Get(0).(model.Booking), args.Error(1)
 }
 
-func (b *BookingUseCaseMock) ViewAllBooking() ([]model.Booking, error) {
-	args := b.Called()
+func (b *BookingUseCaseMock) ViewAllBooking(ctx context.Context) ([]model.Booking, error) {
+	args := b.Called(ctx)
 	return args.Get(0).([]model.Booking), args.Error(1)
 }
 
-func (b *BookingUseCaseMock) ViewAllBookingByStatus(status string) ([]model.Booking, error) {
-	args := b.Called(status)
+func (b *BookingUseCaseMock) ViewAllBookingByStatus(ctx context.Context, status string) ([]model.Booking, error) {
+	args := b.Called(ctx, status)
 	return args.Get(0).([]model.Booking), args.Error(1)
 }
 
-func (b *BookingUseCaseMock) UpdateStatusBookAndRoom(id string, approval string) (model.Booking, error) {
-	args := b.Called(id, approval)
+func (b *BookingUseCaseMock) UpdateStatusBookAndRoom(ctx context.Context, id string, approval string) (model.Booking, error) {
+	args := b.Called(ctx, id, approval)
 	return args.Get(0).(model.Booking), args.Error(1)
 }
 
-func (b *BookingUseCaseMock) DownloadReport() ([]model.Booking, error) {
-	args := b.Called()
+func (b *BookingUseCaseMock) DownloadReport(ctx context.Context, filter dto.BookingFilter) ([]model.Booking, error) {
+	args := b.Called(ctx, filter)
 	return args.Get(0).([]model.Booking), args.Error(1)
 }
 
-func (b *BookingUseCaseMock) GetBookStatus(id string) (string, error) {
-	args := b.Called(id)
+func (b *BookingUseCaseMock) GetBookStatus(ctx context.Context, id string) (string, error) {
+	args := b.Called(ctx, id)
 	return args.String(0), args.Error(1)
 }
 
-func (b *BookingUseCaseMock) GetBookingDetailsByBookingID(bookingID string) ([]model.BookingDetail, error) {
-	args := b.Called(bookingID)
+func (b *BookingUseCaseMock) GetBookingDetailsByBookingID(ctx context.Context, bookingID string) ([]model.BookingDetail, error) {
+	args := b.Called(ctx, bookingID)
 	return args.Get(0).([]model.BookingDetail), args.Error(1)
 }
+
+func (b *BookingUseCaseMock) ViewMyBookings(ctx context.Context, userId string, filter dto.BookingFilter) ([]model.Booking, dto.Paging, error) {
+	args := b.Called(ctx, userId, filter)
+	return args.Get(0).([]model.Booking), args.Get(1).(dto.Paging), args.Error(2)
+}
+
+func (b *BookingUseCaseMock) SearchBooking(ctx context.Context, filter dto.BookingFilter) ([]model.Booking, dto.Paging, error) {
+	args := b.Called(ctx, filter)
+	return args.Get(0).([]model.Booking), args.Get(1).(dto.Paging), args.Error(2)
+}
+
+func (b *BookingUseCaseMock) ViewCalendar(ctx context.Context, filter dto.CalendarFilter, userId string, roleUser string) ([]dto.CalendarRoom, error) {
+	args := b.Called(ctx, filter, userId, roleUser)
+	return args.Get(0).([]dto.CalendarRoom), args.Error(1)
+}
+
+func (b *BookingUseCaseMock) CheckIn(ctx context.Context, id string, userId string) error {
+	args := b.Called(ctx, id, userId)
+	return args.Error(0)
+}
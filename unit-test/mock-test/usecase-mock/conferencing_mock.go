@@ -0,0 +1,27 @@
+package usecasemock
+
+import (
+	"context"
+	"final-project-booking-room/utils/modelutil"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ConferencingMock struct {
+	mock.Mock
+}
+
+func (c *ConferencingMock) CreateMeeting(ctx context.Context, req modelutil.ConferenceRequest) (modelutil.ConferenceMeeting, error) {
+	args := c.Called(ctx, req)
+	return args.Get(0).(modelutil.ConferenceMeeting), args.Error(1)
+}
+
+func (c *ConferencingMock) UpdateMeeting(ctx context.Context, meetingId string, req modelutil.ConferenceRequest) error {
+	args := c.Called(ctx, meetingId, req)
+	return args.Error(0)
+}
+
+func (c *ConferencingMock) DeleteMeeting(ctx context.Context, meetingId string) error {
+	args := c.Called(ctx, meetingId)
+	return args.Error(0)
+}
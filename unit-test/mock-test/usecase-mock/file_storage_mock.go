@@ -0,0 +1,21 @@
+package usecasemock
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type FileStorageMock struct {
+	mock.Mock
+}
+
+func (f *FileStorageMock) Save(ctx context.Context, key string, contentType string, data []byte) (string, error) {
+	args := f.Called(ctx, key, contentType, data)
+	return args.String(0), args.Error(1)
+}
+
+func (f *FileStorageMock) Delete(ctx context.Context, key string) error {
+	args := f.Called(ctx, key)
+	return args.Error(0)
+}
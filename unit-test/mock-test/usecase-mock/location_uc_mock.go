@@ -0,0 +1,87 @@
+package usecasemock
+
+import (
+	"context"
+	"final-project-booking-room/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type LocationUseCaseMock struct {
+	mock.Mock
+}
+
+func (l *LocationUseCaseMock) RegisterNewSite(ctx context.Context, payload model.Site) (model.Site, error) {
+	args := l.Called(ctx, payload)
+	return args.Get(0).(model.Site), args.Error(1)
+}
+
+func (l *LocationUseCaseMock) FindSiteById(ctx context.Context, id string) (model.Site, error) {
+	args := l.Called(ctx, id)
+	return args.Get(0).(model.Site), args.Error(1)
+}
+
+func (l *LocationUseCaseMock) ViewAllSites(ctx context.Context) ([]model.Site, error) {
+	args := l.Called(ctx)
+	return args.Get(0).([]model.Site), args.Error(1)
+}
+
+func (l *LocationUseCaseMock) UpdateSiteById(ctx context.Context, id string, payload model.Site) (model.Site, error) {
+	args := l.Called(ctx, id, payload)
+	return args.Get(0).(model.Site), args.Error(1)
+}
+
+func (l *LocationUseCaseMock) DeleteSiteById(ctx context.Context, id string) error {
+	args := l.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (l *LocationUseCaseMock) RegisterNewBuilding(ctx context.Context, payload model.Building) (model.Building, error) {
+	args := l.Called(ctx, payload)
+	return args.Get(0).(model.Building), args.Error(1)
+}
+
+func (l *LocationUseCaseMock) FindBuildingById(ctx context.Context, id string) (model.Building, error) {
+	args := l.Called(ctx, id)
+	return args.Get(0).(model.Building), args.Error(1)
+}
+
+func (l *LocationUseCaseMock) ViewAllBuildings(ctx context.Context, siteId string) ([]model.Building, error) {
+	args := l.Called(ctx, siteId)
+	return args.Get(0).([]model.Building), args.Error(1)
+}
+
+func (l *LocationUseCaseMock) UpdateBuildingById(ctx context.Context, id string, payload model.Building) (model.Building, error) {
+	args := l.Called(ctx, id, payload)
+	return args.Get(0).(model.Building), args.Error(1)
+}
+
+func (l *LocationUseCaseMock) DeleteBuildingById(ctx context.Context, id string) error {
+	args := l.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (l *LocationUseCaseMock) RegisterNewFloor(ctx context.Context, payload model.Floor) (model.Floor, error) {
+	args := l.Called(ctx, payload)
+	return args.Get(0).(model.Floor), args.Error(1)
+}
+
+func (l *LocationUseCaseMock) FindFloorById(ctx context.Context, id string) (model.Floor, error) {
+	args := l.Called(ctx, id)
+	return args.Get(0).(model.Floor), args.Error(1)
+}
+
+func (l *LocationUseCaseMock) ViewAllFloors(ctx context.Context, buildingId string) ([]model.Floor, error) {
+	args := l.Called(ctx, buildingId)
+	return args.Get(0).([]model.Floor), args.Error(1)
+}
+
+func (l *LocationUseCaseMock) UpdateFloorById(ctx context.Context, id string, payload model.Floor) (model.Floor, error) {
+	args := l.Called(ctx, id, payload)
+	return args.Get(0).(model.Floor), args.Error(1)
+}
+
+func (l *LocationUseCaseMock) DeleteFloorById(ctx context.Context, id string) error {
+	args := l.Called(ctx, id)
+	return args.Error(0)
+}
@@ -1,7 +1,10 @@
 package usecasemock
 
 import (
+	"context"
 	"final-project-booking-room/model"
+	"final-project-booking-room/model/dto"
+	"io"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -10,60 +13,76 @@ type RoomUseCaseMock struct {
 	mock.Mock
 }
 
-// DeleteById implements usecase.RoomUseCase.
-func (r *RoomUseCaseMock) DeleteById(id string) (model.Room, error) {
-	args := r.Called(id)
+// ArchiveById implements usecase.RoomUseCase.
+func (r *RoomUseCaseMock) ArchiveById(ctx context.Context, id string, option dto.ArchiveOption) (dto.ArchiveResult, error) {
+	args := r.Called(ctx, id, option)
+	return args.Get(0).(dto.ArchiveResult), args.Error(1)
+}
+
+// SetRoomAccess implements usecase.RoomUseCase.
+func (r *RoomUseCaseMock) SetRoomAccess(ctx context.Context, id string, rules []model.RoomAccessRule) (model.Room, error) {
+	args := r.Called(ctx, id, rules)
 	return args.Get(0).(model.Room), args.Error(1)
 }
 
-// FindByRoomType implements usecase.RoomUseCase.
-func (r *RoomUseCaseMock) FindByRoomType(roomType string) (model.Room, error) {
-	args := r.Called(roomType)
+// SetRoomParts implements usecase.RoomUseCase.
+func (r *RoomUseCaseMock) SetRoomParts(ctx context.Context, id string, partIds []string) (model.Room, error) {
+	args := r.Called(ctx, id, partIds)
 	return args.Get(0).(model.Room), args.Error(1)
 }
 
-// GetAllRoomByStatus implements usecase.RoomUseCase.
-func (r *RoomUseCaseMock) GetAllRoomByStatus(status string) ([]model.Room, error) {
-	args := r.Called(status)
-	return args.Get(0).([]model.Room), args.Error(1)
+// RestoreById implements usecase.RoomUseCase.
+func (r *RoomUseCaseMock) RestoreById(ctx context.Context, id string) (model.Room, error) {
+	args := r.Called(ctx, id)
+	return args.Get(0).(model.Room), args.Error(1)
 }
 
 // GetRoomStatus implements usecase.RoomUseCase.
-func (r *RoomUseCaseMock) GetRoomStatus(id string) (string, error) {
-	args := r.Called(id)
+func (r *RoomUseCaseMock) GetRoomStatus(ctx context.Context, id string) (string, error) {
+	args := r.Called(ctx, id)
 	return args.String(0), args.Error(1)
 }
 
 // GetRoomStatusByBdId implements usecase.RoomUseCase.
-func (r *RoomUseCaseMock) GetRoomStatusByBdId(id string) (string, error) {
-	args := r.Called(id)
+func (r *RoomUseCaseMock) GetRoomStatusByBdId(ctx context.Context, id string) (string, error) {
+	args := r.Called(ctx, id)
 	return args.String(0), args.Error(1)
 }
 
 // RegisterNewRoom implements usecase.RoomUseCase.
-func (r *RoomUseCaseMock) RegisterNewRoom(payload model.Room) (model.Room, error) {
-	args := r.Called(payload)
+func (r *RoomUseCaseMock) RegisterNewRoom(ctx context.Context, payload model.Room) (model.Room, error) {
+	args := r.Called(ctx, payload)
 	return args.Get(0).(model.Room), args.Error(1)
 }
 
 // UpdateById implements usecase.RoomUseCase.
-func (r *RoomUseCaseMock) UpdateById(id string, payload model.Room) (model.Room, error) {
-	args := r.Called(id, payload)
+func (r *RoomUseCaseMock) UpdateById(ctx context.Context, id string, payload model.Room) (model.Room, error) {
+	args := r.Called(ctx, id, payload)
 	return args.Get(0).(model.Room), args.Error(1)
 }
 
-// ViewAllRooms implements usecase.RoomUseCase.
-func (r *RoomUseCaseMock) ViewAllRooms() ([]model.Room, error) {
-	args := r.Called()
-	return args.Get(0).([]model.Room), args.Error(1)
+// ListRooms implements usecase.RoomUseCase.
+func (r *RoomUseCaseMock) ListRooms(ctx context.Context, filter dto.RoomFilter, userId string, role string) ([]model.Room, dto.Paging, error) {
+	args := r.Called(ctx, filter, userId, role)
+	return args.Get(0).([]model.Room), args.Get(1).(dto.Paging), args.Error(2)
 }
 
-func (r *RoomUseCaseMock) FindById(id string) (model.Room, error) {
-	args := r.Called(id)
+func (r *RoomUseCaseMock) FindById(ctx context.Context, id string) (model.Room, error) {
+	args := r.Called(ctx, id)
 	return args.Get(0).(model.Room), args.Error(1)
 }
 
-func (r *RoomUseCaseMock) ChangeRoomStatus(id string) error {
-	args := r.Called(id)
+func (r *RoomUseCaseMock) ChangeRoomStatus(ctx context.Context, id string) error {
+	args := r.Called(ctx, id)
 	return args.Error(0)
 }
+
+func (r *RoomUseCaseMock) ImportRooms(ctx context.Context, filename string, file io.Reader, dryRun bool) (dto.RoomImportResult, error) {
+	args := r.Called(ctx, filename, file, dryRun)
+	return args.Get(0).(dto.RoomImportResult), args.Error(1)
+}
+
+func (r *RoomUseCaseMock) RecommendRooms(ctx context.Context, filter dto.RecommendationFilter, userId string, role string) (dto.RecommendationResult, error) {
+	args := r.Called(ctx, filter, userId, role)
+	return args.Get(0).(dto.RecommendationResult), args.Error(1)
+}
@@ -1,7 +1,10 @@
 package usecasemock
 
 import (
+	"context"
 	"final-project-booking-room/model"
+	"final-project-booking-room/model/dto"
+	"io"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -10,52 +13,67 @@ type RoomUsecaseMock struct {
 	mock.Mock
 }
 
-func (r *RoomUsecaseMock) RegisterNewRoom(payload model.Room) (model.Room, error) {
-	args := r.Called(payload)
+func (r *RoomUsecaseMock) RegisterNewRoom(ctx context.Context, payload model.Room) (model.Room, error) {
+	args := r.Called(ctx, payload)
 	return args.Get(0).(model.Room), args.Error(1)
 }
 
-func (r *RoomUsecaseMock) FindById(id string) (model.Room, error) {
-	args := r.Called(id)
+func (r *RoomUsecaseMock) FindById(ctx context.Context, id string) (model.Room, error) {
+	args := r.Called(ctx, id)
 	return args.Get(0).(model.Room), args.Error(1)
 }
 
-func (r *RoomUsecaseMock) FindByRoomType(roomType string) (model.Room, error) {
-	args := r.Called(roomType)
+func (r *RoomUsecaseMock) ListRooms(ctx context.Context, filter dto.RoomFilter, userId string, role string) ([]model.Room, dto.Paging, error) {
+	args := r.Called(ctx, filter, userId, role)
+	return args.Get(0).([]model.Room), args.Get(1).(dto.Paging), args.Error(2)
+}
+
+func (r *RoomUsecaseMock) ArchiveById(ctx context.Context, id string, option dto.ArchiveOption) (dto.ArchiveResult, error) {
+	args := r.Called(ctx, id, option)
+	return args.Get(0).(dto.ArchiveResult), args.Error(1)
+}
+
+func (r *RoomUsecaseMock) SetRoomAccess(ctx context.Context, id string, rules []model.RoomAccessRule) (model.Room, error) {
+	args := r.Called(ctx, id, rules)
 	return args.Get(0).(model.Room), args.Error(1)
 }
 
-func (r *RoomUsecaseMock) ViewAllRows() ([]model.Room, error) {
-	args := r.Called()
-	return args.Get(0).([]model.Room), args.Error(1)
+func (r *RoomUsecaseMock) SetRoomParts(ctx context.Context, id string, partIds []string) (model.Room, error) {
+	args := r.Called(ctx, id, partIds)
+	return args.Get(0).(model.Room), args.Error(1)
 }
 
-func (r *RoomUsecaseMock) DeleteById(id string) (model.Room, error) {
-	args := r.Called(id)
+func (r *RoomUsecaseMock) RestoreById(ctx context.Context, id string) (model.Room, error) {
+	args := r.Called(ctx, id)
 	return args.Get(0).(model.Room), args.Error(1)
 }
 
-func (r *RoomUsecaseMock) UpdateById(id string, payload model.Room) (model.Room, error) {
-	args := r.Called(id, payload)
+func (r *RoomUsecaseMock) UpdateById(ctx context.Context, id string, payload model.Room) (model.Room, error) {
+	args := r.Called(ctx, id, payload)
 	return args.Get(0).(model.Room), args.Error(1)
 }
 
-func (r *RoomUsecaseMock) GetRoomStatus(id string) (string, error) {
-	args := r.Called(id)
+func (r *RoomUsecaseMock) GetRoomStatus(ctx context.Context, id string) (string, error) {
+	args := r.Called(ctx, id)
 	return args.Get(0).(string), args.Error(1)
 }
 
-func (r *RoomUsecaseMock) GetRoomStatusByBdId(id string) (string, error) {
-	args := r.Called(id)
+func (r *RoomUsecaseMock) GetRoomStatusByBdId(ctx context.Context, id string) (string, error) {
+	args := r.Called(ctx, id)
 	return args.Get(0).(string), args.Error(1)
 }
 
-func (r *RoomUsecaseMock) ChangeRoomStatus(id string) error {
-	args := r.Called(id)
+func (r *RoomUsecaseMock) ChangeRoomStatus(ctx context.Context, id string) error {
+	args := r.Called(ctx, id)
 	return args.Error(1)
 }
 
-func (r *RoomUsecaseMock) GetAllRoomByStatus(status string) ([]model.Room, error) {
-	args := r.Called(status)
-	return args.Get(0).([]model.Room), args.Error(1)
+func (r *RoomUsecaseMock) ImportRooms(ctx context.Context, filename string, file io.Reader, dryRun bool) (dto.RoomImportResult, error) {
+	args := r.Called(ctx, filename, file, dryRun)
+	return args.Get(0).(dto.RoomImportResult), args.Error(1)
+}
+
+func (r *RoomUsecaseMock) RecommendRooms(ctx context.Context, filter dto.RecommendationFilter, userId string, role string) (dto.RecommendationResult, error) {
+	args := r.Called(ctx, filter, userId, role)
+	return args.Get(0).(dto.RecommendationResult), args.Error(1)
 }
@@ -1,6 +1,7 @@
 package usecasemock
 
 import (
+	"context"
 	"final-project-booking-room/model"
 	"final-project-booking-room/utils/modelutil"
 
@@ -15,37 +16,37 @@ type UserUseCaseMock struct {
 }
 
 // DeleteUser implements usecase.UserUseCase.
-func (u *UserUseCaseMock) DeleteUser(id string) (model.User, error) {
-	args := u.Called(id)
+func (u *UserUseCaseMock) DeleteUser(ctx context.Context, id string) (model.User, error) {
+	args := u.Called(ctx, id)
 	return args.Get(0).(model.User), args.Error(1)
 }
 
 // FindByEmailPassword implements usecase.UserUseCase.
-func (u *UserUseCaseMock) FindByEmailPassword(email string, password string) (model.User, error) {
-	args := u.Called(email, password)
+func (u *UserUseCaseMock) FindByEmailPassword(ctx context.Context, email string, password string) (model.User, error) {
+	args := u.Called(ctx, email, password)
 	return args.Get(0).(model.User), args.Error(1)
 }
 
 // RegisterNewUser implements usecase.UserUseCase.
-func (u *UserUseCaseMock) RegisterNewUser(payload model.User) (model.User, error) {
-	args := u.Called(payload)
+func (u *UserUseCaseMock) RegisterNewUser(ctx context.Context, payload model.User) (model.User, error) {
+	args := u.Called(ctx, payload)
 	return args.Get(0).(model.User), args.Error(1)
 }
 
 // UpdateUserById implements usecase.UserUseCase.
-func (u *UserUseCaseMock) UpdateUserById(userId string, payload model.User) (model.User, error) {
-	args := u.Called(userId, payload)
+func (u *UserUseCaseMock) UpdateUserById(ctx context.Context, userId string, payload model.User) (model.User, error) {
+	args := u.Called(ctx, userId, payload)
 	return args.Get(0).(model.User), args.Error(1)
 }
 
 // ViewAllUser implements usecase.UserUseCase.
-func (u *UserUseCaseMock) ViewAllUser() ([]model.User, error) {
-	args := u.Called()
+func (u *UserUseCaseMock) ViewAllUser(ctx context.Context) ([]model.User, error) {
+	args := u.Called(ctx)
 	return args.Get(0).([]model.User), args.Error(1)
 }
 
-func (u *UserUseCaseMock) FindById(id string) (model.User, error) {
-	args := u.Called(id)
+func (u *UserUseCaseMock) FindById(ctx context.Context, id string) (model.User, error) {
+	args := u.Called(ctx, id)
 	return args.Get(0).(model.User), args.Error(1)
 }
 
@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"final-project-booking-room/model"
+	"final-project-booking-room/repository"
+	"fmt"
+	"strings"
+)
+
+type AmenityUseCase interface {
+	RegisterNewAmenity(ctx context.Context, payload model.Amenity) (model.Amenity, error)
+	FindById(ctx context.Context, id string) (model.Amenity, error)
+	ViewAllAmenities(ctx context.Context) ([]model.Amenity, error)
+	UpdateById(ctx context.Context, id string, payload model.Amenity) (model.Amenity, error)
+	DeleteById(ctx context.Context, id string) error
+}
+
+type amenityUseCase struct {
+	repo repository.AmenityRepository
+}
+
+func validateAmenity(payload model.Amenity) error {
+	if strings.TrimSpace(payload.Code) == "" || strings.TrimSpace(payload.Name) == "" {
+		return errors.New("code and name are required")
+	}
+	if !payload.IsValidType() {
+		return fmt.Errorf("invalid amenity type %s, must be boolean or quantity", payload.Type)
+	}
+	return nil
+}
+
+// RegisterNewAmenity implements AmenityUseCase.
+func (a *amenityUseCase) RegisterNewAmenity(ctx context.Context, payload model.Amenity) (model.Amenity, error) {
+	if err := validateAmenity(payload); err != nil {
+		return model.Amenity{}, err
+	}
+
+	amenity, err := a.repo.Create(ctx, payload)
+	if err != nil {
+		return model.Amenity{}, fmt.Errorf("failed to create amenity : %s", err)
+	}
+	return amenity, nil
+}
+
+// FindById implements AmenityUseCase.
+func (a *amenityUseCase) FindById(ctx context.Context, id string) (model.Amenity, error) {
+	amenity, err := a.repo.Get(ctx, id)
+	if err != nil {
+		return model.Amenity{}, fmt.Errorf("amenity with id %s not found", id)
+	}
+	return amenity, nil
+}
+
+// ViewAllAmenities implements AmenityUseCase.
+func (a *amenityUseCase) ViewAllAmenities(ctx context.Context) ([]model.Amenity, error) {
+	return a.repo.GetAll(ctx)
+}
+
+// UpdateById implements AmenityUseCase.
+func (a *amenityUseCase) UpdateById(ctx context.Context, id string, payload model.Amenity) (model.Amenity, error) {
+	amenity, err := a.repo.Get(ctx, id)
+	if err != nil {
+		return model.Amenity{}, fmt.Errorf("amenity with id %s not found", id)
+	}
+
+	if strings.TrimSpace(payload.Code) != "" {
+		amenity.Code = payload.Code
+	}
+	if strings.TrimSpace(payload.Name) != "" {
+		amenity.Name = payload.Name
+	}
+	if strings.TrimSpace(payload.Icon) != "" {
+		amenity.Icon = payload.Icon
+	}
+	if strings.TrimSpace(payload.Type) != "" {
+		amenity.Type = payload.Type
+	}
+	if err := validateAmenity(amenity); err != nil {
+		return model.Amenity{}, err
+	}
+
+	return a.repo.Update(ctx, id, amenity)
+}
+
+// DeleteById implements AmenityUseCase.
+func (a *amenityUseCase) DeleteById(ctx context.Context, id string) error {
+	if _, err := a.repo.Get(ctx, id); err != nil {
+		return fmt.Errorf("amenity with id %s not found", id)
+	}
+	return a.repo.Delete(ctx, id)
+}
+
+func NewAmenityUseCase(repo repository.AmenityRepository) AmenityUseCase {
+	return &amenityUseCase{repo: repo}
+}
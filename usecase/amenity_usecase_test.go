@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"final-project-booking-room/model"
+	repositorymock "final-project-booking-room/unit-test/mock-test/repository-mock"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type AmenityUsecaseTestSuite struct {
+	suite.Suite
+	arm *repositorymock.AmenityRepositoryMock
+	au  AmenityUseCase
+}
+
+func (suite *AmenityUsecaseTestSuite) SetupTest() {
+	suite.arm = new(repositorymock.AmenityRepositoryMock)
+	suite.au = NewAmenityUseCase(suite.arm)
+}
+
+func TestAmenityUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(AmenityUsecaseTestSuite))
+}
+
+var mockAmenity = model.Amenity{
+	Id:   "1",
+	Code: "whiteboard",
+	Name: "Whiteboard",
+	Icon: "board",
+	Type: "boolean",
+}
+
+func (suite *AmenityUsecaseTestSuite) TestRegisterNewAmenity_Success() {
+	suite.arm.On("Create", mock.Anything, mockAmenity).Return(mockAmenity, nil)
+	actual, err := suite.au.RegisterNewAmenity(context.Background(), mockAmenity)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), mockAmenity, actual)
+}
+
+func (suite *AmenityUsecaseTestSuite) TestRegisterNewAmenity_InvalidType() {
+	payload := mockAmenity
+	payload.Type = "text"
+	_, err := suite.au.RegisterNewAmenity(context.Background(), payload)
+	assert.EqualError(suite.T(), err, "invalid amenity type text, must be boolean or quantity")
+	suite.arm.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *AmenityUsecaseTestSuite) TestUpdateById_Success() {
+	expected := mockAmenity
+	expected.Name = "Papan Tulis"
+	suite.arm.On("Get", mock.Anything, mockAmenity.Id).Return(mockAmenity, nil)
+	suite.arm.On("Update", mock.Anything, mockAmenity.Id, expected).Return(expected, nil)
+
+	actual, err := suite.au.UpdateById(context.Background(), mockAmenity.Id, model.Amenity{Name: "Papan Tulis"})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expected, actual)
+}
+
+func (suite *AmenityUsecaseTestSuite) TestDeleteById_StillUsed() {
+	suite.arm.On("Get", mock.Anything, mockAmenity.Id).Return(mockAmenity, nil)
+	suite.arm.On("Delete", mock.Anything, mockAmenity.Id).Return(errors.New("amenity is still used by rooms"))
+
+	err := suite.au.DeleteById(context.Background(), mockAmenity.Id)
+	assert.EqualError(suite.T(), err, "amenity is still used by rooms")
+}
+
+func (suite *AmenityUsecaseTestSuite) TestDeleteById_NotFound() {
+	suite.arm.On("Get", mock.Anything, "99").Return(model.Amenity{}, errors.New("sql: no rows in result set"))
+
+	err := suite.au.DeleteById(context.Background(), "99")
+	assert.EqualError(suite.T(), err, "amenity with id 99 not found")
+	suite.arm.AssertNotCalled(suite.T(), "Delete", mock.Anything, mock.Anything)
+}
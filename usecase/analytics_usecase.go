@@ -0,0 +1,290 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"final-project-booking-room/model"
+	"final-project-booking-room/model/dto"
+	"final-project-booking-room/repository"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+type AnalyticsUseCase interface {
+	RoomUtilization(ctx context.Context, filter dto.UtilizationFilter) (dto.UtilizationReport, error)
+}
+
+const (
+	// maxUtilizationDays membatasi rentang laporan supaya query booking_details tetap ringan
+	maxUtilizationDays = 366
+	// jam tersedia sebuah ruangan dihitung dari jam buka sampai jam tutup lokal, senin sampai jumat
+	utilizationOpenHour  = 8
+	utilizationCloseHour = 18
+)
+
+type analyticsUseCase struct {
+	repo repository.BookingRepository
+}
+
+// utilizationBucket menampung angka mentah satu kelompok di satu periode sebelum dijadikan persentase
+type utilizationBucket struct {
+	row              dto.UtilizationRow
+	attendees        int // jumlah peserta booking accept yang mengisi attendees
+	attendeeBookings int
+	capacity         int // jumlah kapasitas ruangan dari booking yang sama
+	ended            int // booking accept yang sudah selesai
+	noShows          int
+	requested        int // semua booking dengan status apa pun
+	cancelled        int
+	hours            [24]int
+}
+
+// RoomUtilization implements AnalyticsUseCase.
+// Jam terpakai dan no-show hanya dihitung dari booking accept, cancellation rate dari semua booking yang diajukan.
+// Untuk groupBy division, jam tersedia adalah total jam tersedia semua ruangan yang masuk filter.
+func (a *analyticsUseCase) RoomUtilization(ctx context.Context, filter dto.UtilizationFilter) (dto.UtilizationReport, error) {
+	filter, days, err := validateUtilizationFilter(filter)
+	if err != nil {
+		return dto.UtilizationReport{}, err
+	}
+
+	// tanggal dibaca di zona masing-masing ruangan, jadi rentang absolutnya dilebarkan selisih zona terbesar (UTC-12 s/d UTC+14)
+	start := time.Date(filter.StartDate.Year(), filter.StartDate.Month(), filter.StartDate.Day(), 0, 0, 0, 0, time.UTC)
+	filter.From = start.Add(-14 * time.Hour)
+	filter.Until = start.AddDate(0, 0, days).Add(12 * time.Hour)
+
+	rooms, err := a.repo.GetUtilization(ctx, filter)
+	if err != nil {
+		return dto.UtilizationReport{}, fmt.Errorf("failed to get utilization: %v", err)
+	}
+
+	now := time.Now()
+	buckets := make(map[[2]string]*utilizationBucket)
+	bucketOf := func(period string, key string, name string) *utilizationBucket {
+		bucket, ok := buckets[[2]string{period, key}]
+		if !ok {
+			bucket = &utilizationBucket{row: dto.UtilizationRow{Period: period, GroupKey: key, GroupName: name}}
+			buckets[[2]string{period, key}] = bucket
+		}
+		return bucket
+	}
+	totalAvailable := make(map[string]float64)
+
+	for _, room := range rooms {
+		loc := model.RoomLocation{Timezone: room.Timezone}.Zone()
+		key, name := utilizationGroup(filter.GroupBy, room, "")
+
+		for d := 0; d < days; d++ {
+			day := time.Date(start.Year(), start.Month(), start.Day()+d, 0, 0, 0, 0, loc)
+			if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+				continue
+			}
+			period := utilizationPeriod(filter.Period, day)
+			totalAvailable[period] += utilizationCloseHour - utilizationOpenHour
+			if filter.GroupBy != "division" {
+				bucketOf(period, key, name).row.AvailableHours += utilizationCloseHour - utilizationOpenHour
+			}
+		}
+
+		lastDay := time.Date(start.Year(), start.Month(), start.Day()+days, 0, 0, 0, 0, loc)
+		for _, booking := range room.Bookings {
+			localStart := booking.Start.In(loc)
+			if localStart.Before(time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)) || !localStart.Before(lastDay) {
+				continue
+			}
+			key, name := utilizationGroup(filter.GroupBy, room, booking.Division)
+			bucket := bucketOf(utilizationPeriod(filter.Period, localStart), key, name)
+
+			bucket.requested++
+			if booking.Status == "cancel" {
+				bucket.cancelled++
+			}
+			if booking.Status != "accept" {
+				continue
+			}
+
+			bucket.row.Bookings++
+			bucket.row.BookedHours += businessHours(booking.Start, booking.End, loc)
+			if booking.Attendees > 0 {
+				bucket.attendees += booking.Attendees
+				bucket.attendeeBookings++
+				bucket.capacity += room.Capacity
+			}
+			if !booking.End.After(now) {
+				bucket.ended++
+				if !booking.CheckedIn {
+					bucket.noShows++
+				}
+			}
+			hour := time.Date(localStart.Year(), localStart.Month(), localStart.Day(), localStart.Hour(), 0, 0, 0, loc)
+			for ; hour.Before(booking.End); hour = hour.Add(time.Hour) {
+				bucket.hours[hour.In(loc).Hour()]++
+			}
+		}
+	}
+
+	report := dto.UtilizationReport{
+		StartDate: filter.StartDate.Format("2006-01-02"),
+		EndDate:   filter.EndDate.Format("2006-01-02"),
+		Period:    filter.Period,
+		GroupBy:   filter.GroupBy,
+		OpenHour:  utilizationOpenHour,
+		CloseHour: utilizationCloseHour,
+		Rows:      make([]dto.UtilizationRow, 0, len(buckets)),
+	}
+	for _, bucket := range buckets {
+		row := bucket.row
+		if filter.GroupBy == "division" {
+			row.AvailableHours = totalAvailable[row.Period]
+		}
+		row.BookedHours = round2(row.BookedHours)
+		row.Utilization = percentage(row.BookedHours, row.AvailableHours)
+		if bucket.attendeeBookings > 0 {
+			row.AverageAttendees = round2(float64(bucket.attendees) / float64(bucket.attendeeBookings))
+			row.AverageCapacity = round2(float64(bucket.capacity) / float64(bucket.attendeeBookings))
+		}
+		row.NoShowRate = percentage(float64(bucket.noShows), float64(bucket.ended))
+		row.CancellationRate = percentage(float64(bucket.cancelled), float64(bucket.requested))
+		row.PeakHours = peakHours(bucket.hours)
+		report.Rows = append(report.Rows, row)
+	}
+	sort.Slice(report.Rows, func(i, j int) bool {
+		if report.Rows[i].Period != report.Rows[j].Period {
+			return report.Rows[i].Period < report.Rows[j].Period
+		}
+		if report.Rows[i].GroupName != report.Rows[j].GroupName {
+			return report.Rows[i].GroupName < report.Rows[j].GroupName
+		}
+		return report.Rows[i].GroupKey < report.Rows[j].GroupKey
+	})
+
+	return report, nil
+}
+
+// validateUtilizationFilter mengecek filter dari query, mengisi nilai default dan mengembalikan jumlah hari di rentangnya
+func validateUtilizationFilter(filter dto.UtilizationFilter) (dto.UtilizationFilter, int, error) {
+	if filter.StartDate.IsZero() {
+		return dto.UtilizationFilter{}, 0, errors.New("startDate is required")
+	}
+	if filter.EndDate.IsZero() {
+		filter.EndDate = filter.StartDate
+	}
+	if filter.EndDate.Before(filter.StartDate) {
+		return dto.UtilizationFilter{}, 0, errors.New("endDate can't be before startDate")
+	}
+	days := int(filter.EndDate.Sub(filter.StartDate).Hours()/24) + 1
+	if days > maxUtilizationDays {
+		return dto.UtilizationFilter{}, 0, fmt.Errorf("utilization range can't be longer than %d days", maxUtilizationDays)
+	}
+
+	switch filter.Period {
+	case "":
+		filter.Period = "month"
+	case "day", "week", "month":
+	default:
+		return dto.UtilizationFilter{}, 0, fmt.Errorf(`period must be "day", "week" or "month", not %s`, filter.Period)
+	}
+
+	switch filter.GroupBy {
+	case "":
+		filter.GroupBy = "room"
+	case "room", "roomType", "building", "division":
+	default:
+		return dto.UtilizationFilter{}, 0, fmt.Errorf(`groupBy must be "room", "roomType", "building" or "division", not %s`, filter.GroupBy)
+	}
+
+	return filter, days, nil
+}
+
+// utilizationGroup mengembalikan kunci dan nama kelompok sebuah ruangan atau divisi pemesan
+func utilizationGroup(groupBy string, room dto.UtilizationRoom, division string) (string, string) {
+	switch groupBy {
+	case "roomType":
+		return room.RoomType, room.RoomType
+	case "building":
+		return room.BuildingId, room.BuildingName
+	case "division":
+		return division, division
+	default:
+		return room.RoomId, room.RoomName
+	}
+}
+
+// utilizationPeriod mengembalikan label periode dari tanggal lokal, minggu dimulai hari senin
+func utilizationPeriod(period string, day time.Time) string {
+	switch period {
+	case "day":
+		return day.Format("2006-01-02")
+	case "week":
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7)).Format("2006-01-02")
+	default:
+		return day.Format("2006-01")
+	}
+}
+
+// businessHours menghitung jam booking yang jatuh di jam buka hari kerja menurut zona loc
+func businessHours(start time.Time, end time.Time, loc *time.Location) float64 {
+	var total time.Duration
+	localStart := start.In(loc)
+	for day := time.Date(localStart.Year(), localStart.Month(), localStart.Day(), 0, 0, 0, 0, loc); day.Before(end); day = day.AddDate(0, 0, 1) {
+		if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+			continue
+		}
+		open := time.Date(day.Year(), day.Month(), day.Day(), utilizationOpenHour, 0, 0, 0, loc)
+		closing := time.Date(day.Year(), day.Month(), day.Day(), utilizationCloseHour, 0, 0, 0, loc)
+		from, until := maxTime(start, open), minTime(end, closing)
+		if from.Before(until) {
+			total += until.Sub(from)
+		}
+	}
+	return total.Hours()
+}
+
+// peakHours mengembalikan jam dengan jumlah booking terbanyak, kosong jika tidak ada booking
+func peakHours(hours [24]int) []int {
+	peak := 0
+	for _, count := range hours {
+		peak = max(peak, count)
+	}
+	result := []int{}
+	if peak == 0 {
+		return result
+	}
+	for hour, count := range hours {
+		if count == peak {
+			result = append(result, hour)
+		}
+	}
+	return result
+}
+
+func percentage(part float64, whole float64) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return round2(part / whole * 100)
+}
+
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+func maxTime(a time.Time, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func minTime(a time.Time, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func NewAnalyticsUseCase(repo repository.BookingRepository) AnalyticsUseCase {
+	return &analyticsUseCase{repo: repo}
+}
@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"final-project-booking-room/model/dto"
+	repositorymock "final-project-booking-room/unit-test/mock-test/repository-mock"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type AnalyticsUsecaseTestSuite struct {
+	suite.Suite
+	brm *repositorymock.BookingRepoMock
+	au  AnalyticsUseCase
+}
+
+func (suite *AnalyticsUsecaseTestSuite) SetupTest() {
+	suite.brm = new(repositorymock.BookingRepoMock)
+	suite.au = NewAnalyticsUseCase(suite.brm)
+}
+
+func TestAnalyticsUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(AnalyticsUsecaseTestSuite))
+}
+
+// mockUtilizationRooms berisi dua ruangan di Jakarta, booking di minggu 20-24 November 2023
+func mockUtilizationRooms() []dto.UtilizationRoom {
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+	at := func(day int, hour int) time.Time {
+		return time.Date(2023, 11, day, hour, 0, 0, 0, jakarta)
+	}
+	return []dto.UtilizationRoom{
+		{RoomId: "1", RoomName: "Melati", RoomType: "meeting", BuildingId: "b1", BuildingName: "Tower A", Timezone: "Asia/Jakarta", Capacity: 10, Bookings: []dto.UtilizationBooking{
+			// minggu malam WIB, di luar rentang tanggal lokal walaupun masih di rentang UTC yang diquery
+			{Division: "HR", Status: "accept", Attendees: 4, Start: at(19, 23), End: at(20, 1), CheckedIn: true},
+			{Division: "HR", Status: "accept", Attendees: 6, Start: at(20, 9), End: at(20, 11), CheckedIn: true},
+			{Division: "Finance", Status: "accept", Attendees: 8, Start: at(21, 10), End: at(21, 12)},
+			{Division: "Finance", Status: "cancel", Attendees: 5, Start: at(22, 9), End: at(22, 10)},
+			// jam setelah tutup tidak dihitung sebagai jam terpakai
+			{Division: "HR", Status: "accept", Start: at(23, 17), End: at(23, 19), CheckedIn: true},
+		}},
+		{RoomId: "2", RoomName: "Mawar", RoomType: "meeting", BuildingId: "b1", BuildingName: "Tower A", Timezone: "Asia/Jakarta", Capacity: 20},
+	}
+}
+
+func (suite *AnalyticsUsecaseTestSuite) TestRoomUtilization_PerRoomPerWeek() {
+	start, _ := time.Parse("2006-01-02", "2023-11-20")
+	suite.brm.On("GetUtilization", mock.Anything, mock.MatchedBy(func(f dto.UtilizationFilter) bool {
+		return f.GroupBy == "room" && f.From.Equal(start.Add(-14*time.Hour)) && f.Until.Equal(start.AddDate(0, 0, 5).Add(12*time.Hour))
+	})).Return(mockUtilizationRooms(), nil)
+
+	report, err := suite.au.RoomUtilization(context.Background(), dto.UtilizationFilter{StartDate: start, EndDate: start.AddDate(0, 0, 4), Period: "week"})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []dto.UtilizationRow{
+		{Period: "2023-11-20", GroupKey: "2", GroupName: "Mawar", AvailableHours: 50, PeakHours: []int{}},
+		{Period: "2023-11-20", GroupKey: "1", GroupName: "Melati", Bookings: 3, BookedHours: 5, AvailableHours: 50, Utilization: 10,
+			AverageAttendees: 7, AverageCapacity: 10, PeakHours: []int{10}, NoShowRate: 33.33, CancellationRate: 25},
+	}, report.Rows)
+}
+
+func (suite *AnalyticsUsecaseTestSuite) TestRoomUtilization_PerDivision() {
+	start, _ := time.Parse("2006-01-02", "2023-11-20")
+	suite.brm.On("GetUtilization", mock.Anything, mock.Anything).Return(mockUtilizationRooms(), nil)
+
+	report, err := suite.au.RoomUtilization(context.Background(), dto.UtilizationFilter{StartDate: start, EndDate: start.AddDate(0, 0, 6), GroupBy: "division"})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "month", report.Period)
+	assert.Len(suite.T(), report.Rows, 2)
+	// jam tersedia divisi adalah total jam tersedia kedua ruangan, sabtu dan minggu tidak dihitung
+	assert.Equal(suite.T(), "Finance", report.Rows[0].GroupName)
+	assert.Equal(suite.T(), 100.0, report.Rows[0].AvailableHours)
+	assert.Equal(suite.T(), 2.0, report.Rows[0].BookedHours)
+	assert.Equal(suite.T(), 50.0, report.Rows[0].CancellationRate)
+	assert.Equal(suite.T(), "HR", report.Rows[1].GroupName)
+	assert.Equal(suite.T(), 3.0, report.Rows[1].BookedHours)
+	assert.Equal(suite.T(), 3.0, report.Rows[1].Utilization)
+}
+
+func (suite *AnalyticsUsecaseTestSuite) TestRoomUtilization_InvalidGroupBy() {
+	start, _ := time.Parse("2006-01-02", "2023-11-20")
+
+	_, err := suite.au.RoomUtilization(context.Background(), dto.UtilizationFilter{StartDate: start, GroupBy: "floor"})
+
+	assert.EqualError(suite.T(), err, `groupBy must be "room", "roomType", "building" or "division", not floor`)
+	suite.brm.AssertNotCalled(suite.T(), "GetUtilization", mock.Anything, mock.Anything)
+}
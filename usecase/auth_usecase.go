@@ -1,14 +1,15 @@
 package usecase
 
 import (
+	"context"
 	"final-project-booking-room/model"
 	"final-project-booking-room/model/dto"
 	"final-project-booking-room/utils/common"
 )
 
 type AuthUseCase interface {
-	Register(payload model.User) (model.User, error)
-	Login(payload dto.AuthRequestDto) (dto.AuthResponseDto, error)
+	Register(ctx context.Context, payload model.User) (model.User, error)
+	Login(ctx context.Context, payload dto.AuthRequestDto) (dto.AuthResponseDto, error)
 }
 
 type authUseCase struct {
@@ -17,8 +18,8 @@ type authUseCase struct {
 }
 
 // Login implements AuthUseCase.
-func (a *authUseCase) Login(payload dto.AuthRequestDto) (dto.AuthResponseDto, error) {
-	user, err := a.uc.FindByEmailPassword(payload.Email, payload.Password)
+func (a *authUseCase) Login(ctx context.Context, payload dto.AuthRequestDto) (dto.AuthResponseDto, error) {
+	user, err := a.uc.FindByEmailPassword(ctx, payload.Email, payload.Password)
 	if err != nil {
 		return dto.AuthResponseDto{}, err
 	}
@@ -32,8 +33,8 @@ func (a *authUseCase) Login(payload dto.AuthRequestDto) (dto.AuthResponseDto, er
 }
 
 // Register implements AuthUseCase.
-func (a *authUseCase) Register(payload model.User) (model.User, error) {
-	return a.uc.RegisterNewUser(payload)
+func (a *authUseCase) Register(ctx context.Context, payload model.User) (model.User, error) {
+	return a.uc.RegisterNewUser(ctx, payload)
 
 }
 
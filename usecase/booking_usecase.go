@@ -1,13 +1,18 @@
 package usecase
 
 import (
+	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"final-project-booking-room/model"
 	"final-project-booking-room/model/dto"
 	"final-project-booking-room/repository"
 	"final-project-booking-room/utils/common"
 	"final-project-booking-room/utils/modelutil"
+	"log"
 	"os"
+	"time"
 
 	"fmt"
 
@@ -15,32 +20,57 @@ import (
 )
 
 type BookingUseCase interface {
-	RegisterNewBooking(payload dto.BookingRequestDto, userId string) (model.Booking, error)
-	FindById(id string, userId string, roleUser string) (model.Booking, error)
-	ViewAllBooking() ([]model.Booking, error)
-	ViewAllBookingByStatus(status string) ([]model.Booking, error)
-	UpdateStatusBookAndRoom(id string, approval string) (model.Booking, error)
-	DownloadReport() ([]model.Booking, error)
-	SendReport(requestJSON string) ([]model.Booking, error)
+	RegisterNewBooking(ctx context.Context, payload dto.BookingRequestDto, userId string) (model.Booking, error)
+	FindById(ctx context.Context, id string, userId string, roleUser string) (model.Booking, error)
+	ViewAllBooking(ctx context.Context) ([]model.Booking, error)
+	ViewAllBookingByStatus(ctx context.Context, status string) ([]model.Booking, error)
+	UpdateStatusBookAndRoom(ctx context.Context, id string, approval string) (model.Booking, error)
+	DownloadReport(ctx context.Context, filter dto.BookingFilter) ([]model.Booking, error)
+	SendReport(ctx context.Context, requestJSON string) ([]model.Booking, error)
+	ViewMyBookings(ctx context.Context, userId string, filter dto.BookingFilter) ([]model.Booking, dto.Paging, error)
+	SearchBooking(ctx context.Context, filter dto.BookingFilter) ([]model.Booking, dto.Paging, error)
+	ViewCalendar(ctx context.Context, filter dto.CalendarFilter, userId string, roleUser string) ([]dto.CalendarRoom, error)
+	CheckIn(ctx context.Context, id string, userId string) error
 }
 
 type EmailRecipient struct {
 	To string `json:"to"`
 }
 
+// maxCalendarDays membatasi rentang kalender supaya tetap ringan untuk widget intranet
+const maxCalendarDays = 31
+
+// reportTimeLayout mencantumkan offset UTC supaya jam di report tidak ambigu antar WIB, WITA dan WIT
+const reportTimeLayout = "2006-01-02 15:04 -07:00"
+
 type bookingUseCase struct {
 	repo         repository.BookingRepository
 	userUC       UserUseCase
 	roomUC       RoomUseCase
 	emailService common.EmailService
+	conference   common.ConferencingProvider
 }
 
-func (b *bookingUseCase) DownloadReport() ([]model.Booking, error) {
-	bookings, err := b.repo.GetAll()
+func (b *bookingUseCase) DownloadReport(ctx context.Context, filter dto.BookingFilter) ([]model.Booking, error) {
+	filter, err := validateBookingFilter(filter)
 	if err != nil {
 		return nil, err
 	}
 
+	// report berisi semua booking yang cocok dengan filter, diambil per halaman
+	var bookings []model.Booking
+	filter.Size = 100
+	for filter.Page = 1; ; filter.Page++ {
+		page, paging, err := b.repo.Search(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		bookings = append(bookings, page...)
+		if filter.Page >= paging.TotalPages {
+			break
+		}
+	}
+
 	file, err := os.Create("Report.xlsx")
 	if err != nil {
 		return nil, err
@@ -51,37 +81,42 @@ func (b *bookingUseCase) DownloadReport() ([]model.Booking, error) {
 	sheetName := "Sheet1"
 
 	// Set header row
-	header := []string{"ID", "Name", "Divisi", "Jabatan", "Email", "RoomType", "BookingDate", "BookingDateEnd", "Status", "Description"}
+	header := []string{"ID", "Name", "Divisi", "Jabatan", "Email", "Room", "RoomType", "Site", "Building", "Floor", "BookingDate", "BookingDateEnd", "Timezone", "Status", "Equipment", "Description"}
 	for colIndex, colName := range header {
 		cell := fmt.Sprintf("%c%d", 'A'+colIndex, 1)
 		xlsx.SetCellValue(sheetName, cell, colName)
 	}
 
-	// Write data rows
-	for rowIndex, row := range bookings {
-		bookingDetails, err := b.repo.GetBookingDetailsByBookingID(row.Id)
-		if err != nil {
-			return nil, err
-		}
-
-		for _, v := range bookingDetails {
+	// Write data rows, satu baris per booking detail
+	rowIndex := 2
+	for _, row := range bookings {
+		for _, v := range row.BookingDetails {
+			// jam ditulis dalam zona viewer, atau zona ruangan jika viewer tidak memilih
+			v = v.In(filter.Timezone)
 			data := []string{
 				row.Id,
 				row.Users.Name,
 				row.Users.Divisi,
 				row.Users.Jabatan,
 				row.Users.Email,
+				v.Rooms.Name,
 				v.Rooms.RoomType,
-				v.BookingDate.Format("2006-01-02"),
-				v.BookingDateEnd.Format("2006-01-02"),
+				v.Rooms.Location.SiteName,
+				v.Rooms.Location.BuildingName,
+				v.Rooms.Location.FloorName,
+				v.BookingDate.Format(reportTimeLayout),
+				v.BookingDateEnd.Format(reportTimeLayout),
+				v.Timezone,
 				v.Rooms.Status,
+				v.EquipmentSummary(),
 				v.Description,
 			}
 
 			for colIndex, cellValue := range data {
-				cell := fmt.Sprintf("%c%d", 'A'+colIndex, rowIndex+2)
+				cell := fmt.Sprintf("%c%d", 'A'+colIndex, rowIndex)
 				xlsx.SetCellValue(sheetName, cell, cellValue)
 			}
+			rowIndex++
 		}
 	}
 
@@ -94,7 +129,7 @@ func (b *bookingUseCase) DownloadReport() ([]model.Booking, error) {
 	return bookings, nil
 }
 
-func (b *bookingUseCase) SendReport(requestJSON string) ([]model.Booking, error) {
+func (b *bookingUseCase) SendReport(ctx context.Context, requestJSON string) ([]model.Booking, error) {
 	var emailRecipients EmailRecipient
 	err := json.Unmarshal([]byte(requestJSON), &emailRecipients)
 	if err != nil {
@@ -104,7 +139,7 @@ func (b *bookingUseCase) SendReport(requestJSON string) ([]model.Booking, error)
 	err = b.emailService.SendEmailFile(modelutil.BodySender{
 		To:          []string{emailRecipients.To},
 		Subject:     "Report",
-		Body:        "Booking Room Report",
+		Body:        "Booking Room Report\n\nBookingDate dan BookingDateEnd ditulis dalam zona waktu pada kolom Timezone (offset UTC ikut dicantumkan).",
 		CSVFilePath: "Report.xlsx",
 	})
 
@@ -116,12 +151,12 @@ func (b *bookingUseCase) SendReport(requestJSON string) ([]model.Booking, error)
 }
 
 // UpdateStatusBookAndRoom implements BookingUseCase.
-func (b *bookingUseCase) UpdateStatusBookAndRoom(id string, approval string) (model.Booking, error) {
+func (b *bookingUseCase) UpdateStatusBookAndRoom(ctx context.Context, id string, approval string) (model.Booking, error) {
 	if approval != "accept" && approval != "decline" {
 		return model.Booking{}, fmt.Errorf(`please give approval: "accept" or "decline", not %s`, approval)
 	}
 
-	status, err := b.repo.GetBookStatus(id)
+	status, err := b.repo.GetBookStatus(ctx, id)
 	if err != nil {
 		return model.Booking{}, err
 	}
@@ -130,7 +165,7 @@ func (b *bookingUseCase) UpdateStatusBookAndRoom(id string, approval string) (mo
 		return model.Booking{}, fmt.Errorf("booking status with id %s is already changed (not pending)", id)
 	}
 
-	statusRoom, err := b.roomUC.GetRoomStatusByBdId(id)
+	statusRoom, err := b.roomUC.GetRoomStatusByBdId(ctx, id)
 	if err != nil {
 		return model.Booking{}, fmt.Errorf(`sorry, id booking detail %s is not found`, id)
 	}
@@ -143,17 +178,264 @@ func (b *bookingUseCase) UpdateStatusBookAndRoom(id string, approval string) (mo
 		return model.Booking{}, fmt.Errorf(`please give approval: "accept" or "decline", not %s`, approval)
 	}
 
-	booking, err := b.repo.UpdateStatus(id, approval)
-	if err != nil {
+	// bentrok ruangan dan stok equipment dilaporkan apa adanya, hanya baris yang tidak ada dianggap not found
+	booking, err := b.repo.UpdateStatus(ctx, id, approval)
+	if errors.Is(err, sql.ErrNoRows) {
 		return model.Booking{}, fmt.Errorf("booking detail with id %s not found", id)
 	}
+	if err != nil {
+		return model.Booking{}, fmt.Errorf("failed to %s booking detail %s: %w", approval, id, err)
+	}
 
+	if approval == "accept" {
+		if err := b.confirmBooking(ctx, &booking, id); err != nil {
+			log.Printf("booking detail %s accepted, confirmation incomplete: %v", id, err)
+		}
+	}
 	return booking, nil
 }
 
+// confirmBooking membuat link video conference untuk booking detail yang baru accept lalu mengirim email konfirmasi
+// beserta invite kalender. Approval sudah tersimpan, jadi kegagalan provider atau email tidak membatalkannya,
+// semua kegagalan dikembalikan untuk dicatat.
+func (b *bookingUseCase) confirmBooking(ctx context.Context, booking *model.Booking, id string) error {
+	for i := range booking.BookingDetails {
+		detail := &booking.BookingDetails[i]
+		if detail.Id != id {
+			continue
+		}
+
+		var errs []error
+		request := conferenceRequest(detail.Description, roomName(detail.Rooms), detail.Rooms.Location, detail.BookingDate, detail.BookingDateEnd)
+		meeting, err := b.conference.CreateMeeting(ctx, request)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to create conference: %v", err))
+		} else if err := b.repo.SetConference(ctx, id, meeting.Id, meeting.JoinURL); err != nil {
+			// meeting yang tidak tersimpan dihapus supaya tidak tertinggal di provider
+			errs = append(errs, fmt.Errorf("failed to save conference %s: %v", meeting.Id, err))
+			if err := b.conference.DeleteMeeting(ctx, meeting.Id); err != nil {
+				errs = append(errs, fmt.Errorf("failed to delete conference %s: %v", meeting.Id, err))
+			}
+		} else {
+			detail.ConferenceURL = meeting.JoinURL
+		}
+
+		// attendee dan delegate ikut menerima konfirmasi dan invite, jika gagal dimuat email tetap dikirim ke pemesan
+		recipients := []string{booking.Users.Email}
+		if participants, err := b.repo.GetParticipantEmails(ctx, booking.Id); err != nil {
+			errs = append(errs, fmt.Errorf("failed to get participants: %v", err))
+		} else {
+			recipients = append(recipients, participants...)
+		}
+
+		email := bookingConfirmation(booking.Users, *detail)
+		email.To = recipients
+		if sequence, err := b.repo.NextInviteSequence(ctx, id); err != nil {
+			errs = append(errs, fmt.Errorf("failed to get invite sequence: %v", err))
+		} else {
+			email.Invite = bookingInvite(id, sequence, common.CalendarRequest, request, detail.ConferenceURL, recipients)
+		}
+		if err := b.emailService.SendEmail(email); err != nil {
+			errs = append(errs, fmt.Errorf("failed to send confirmation: %v", err))
+		}
+		return errors.Join(errs...)
+	}
+	return nil
+}
+
+// conferenceRequest menyusun data meeting dengan jadwal di zona lokasi ruangan
+func conferenceRequest(description string, room string, location model.RoomLocation, start time.Time, end time.Time) modelutil.ConferenceRequest {
+	loc := location.Zone()
+	topic := description
+	if topic == "" {
+		topic = "Meeting " + room
+	}
+	return modelutil.ConferenceRequest{Topic: topic, Location: room, Start: start.In(loc), End: end.In(loc), Timezone: loc.String()}
+}
+
+// bookingInvite menyusun VEVENT untuk booking detail. UID diturunkan dari id booking detail supaya sama di setiap perubahan,
+// sequence berasal dari kolom invitesequence.
+func bookingInvite(bookingDetailId string, sequence int, method string, request modelutil.ConferenceRequest, conferenceURL string, attendees []string) *modelutil.CalendarEvent {
+	invite := &modelutil.CalendarEvent{
+		UID:       "booking-" + bookingDetailId + "@final-project-booking-room",
+		Sequence:  sequence,
+		Method:    method,
+		Summary:   request.Topic,
+		Location:  request.Location,
+		URL:       conferenceURL,
+		Start:     request.Start,
+		End:       request.End,
+		Attendees: attendees,
+	}
+	if conferenceURL != "" {
+		invite.Description = "Join video conference: " + conferenceURL
+	}
+	return invite
+}
+
+func roomName(room model.Room) string {
+	if room.Name != "" {
+		return room.Name
+	}
+	return room.RoomType
+}
+
+// bookingConfirmation menyusun email konfirmasi booking yang sudah accept beserta link video conference
+func bookingConfirmation(user model.User, detail model.BookingDetail) modelutil.BodySender {
+	loc := detail.Rooms.Location.Zone()
+	body := fmt.Sprintf("Halo %s,\n\nBooking %s di %s pada %s - %s (%s) sudah disetujui.",
+		user.Name, detail.Description, roomName(detail.Rooms), detail.BookingDate.In(loc).Format(reportTimeLayout), detail.BookingDateEnd.In(loc).Format(reportTimeLayout), loc)
+	if detail.ConferenceURL != "" {
+		body += "\n\nPeserta remote bisa bergabung lewat " + detail.ConferenceURL
+	}
+
+	return modelutil.BodySender{
+		To:      []string{user.Email},
+		Subject: "Konfirmasi Booking Ruangan",
+		Body:    body,
+	}
+}
+
+// ViewMyBookings implements BookingUseCase.
+func (b *bookingUseCase) ViewMyBookings(ctx context.Context, userId string, filter dto.BookingFilter) ([]model.Booking, dto.Paging, error) {
+	filter, err := validateBookingFilter(filter)
+	if err != nil {
+		return nil, dto.Paging{}, err
+	}
+
+	bookings, paging, err := b.repo.GetAllByUser(ctx, userId, filter)
+	if err != nil {
+		return nil, dto.Paging{}, fmt.Errorf("failed to get bookings: %v", err)
+	}
+	return bookings, paging, nil
+}
+
+// SearchBooking implements BookingUseCase.
+func (b *bookingUseCase) SearchBooking(ctx context.Context, filter dto.BookingFilter) ([]model.Booking, dto.Paging, error) {
+	filter, err := validateBookingFilter(filter)
+	if err != nil {
+		return nil, dto.Paging{}, err
+	}
+
+	bookings, paging, err := b.repo.Search(ctx, filter)
+	if err != nil {
+		return nil, dto.Paging{}, fmt.Errorf("failed to search bookings: %v", err)
+	}
+	return bookings, paging, nil
+}
+
+// ViewCalendar implements BookingUseCase.
+// Booking dikelompokkan per ruangan lalu per hari. Admin dan GA melihat pemesan dan deskripsi semua booking,
+// user lain hanya melihat detail booking miliknya sendiri.
+func (b *bookingUseCase) ViewCalendar(ctx context.Context, filter dto.CalendarFilter, userId string, roleUser string) ([]dto.CalendarRoom, error) {
+	if filter.StartDate.IsZero() {
+		return nil, fmt.Errorf("startDate is required")
+	}
+	if filter.EndDate.IsZero() {
+		filter.EndDate = filter.StartDate
+	}
+	if filter.EndDate.Before(filter.StartDate) {
+		return nil, fmt.Errorf("endDate can't be before startDate")
+	}
+	days := int(filter.EndDate.Sub(filter.StartDate).Hours()/24) + 1
+	if days > maxCalendarDays {
+		return nil, fmt.Errorf("calendar range can't be longer than %d days", maxCalendarDays)
+	}
+
+	// tanggal dibaca di zona masing-masing ruangan, jadi rentang absolutnya dilebarkan selisih zona terbesar (UTC-12 s/d UTC+14)
+	start := time.Date(filter.StartDate.Year(), filter.StartDate.Month(), filter.StartDate.Day(), 0, 0, 0, 0, time.UTC)
+	filter.From = start.Add(-14 * time.Hour)
+	filter.Until = start.AddDate(0, 0, days).Add(12 * time.Hour)
+
+	rooms, err := b.repo.GetCalendar(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get calendar: %v", err)
+	}
+
+	seeAll := roleUser == "admin" || roleUser == "GA"
+	for i, room := range rooms {
+		loc := filter.Timezone
+		if loc == nil {
+			loc = model.RoomLocation{Timezone: room.Timezone}.Zone()
+		}
+		rooms[i].Timezone = loc.String()
+
+		rooms[i].Days = make([]dto.CalendarDay, days)
+		for d := range rooms[i].Days {
+			dayStart := time.Date(start.Year(), start.Month(), start.Day()+d, 0, 0, 0, 0, loc)
+			dayEnd := dayStart.AddDate(0, 0, 1)
+			day := dto.CalendarDay{Date: dayStart.Format("2006-01-02"), Bookings: []dto.CalendarBooking{}}
+
+			// booking yang melewati tengah malam muncul di setiap hari yang dilaluinya
+			for _, booking := range room.Bookings {
+				if !booking.Start.Before(dayEnd) || !dayStart.Before(booking.End) {
+					continue
+				}
+				booking.Mine = booking.Owner != nil && booking.Owner.Id == userId
+				if !seeAll && !booking.Mine {
+					booking.BookingId = ""
+					booking.BookingDetailId = ""
+					booking.Owner = nil
+					booking.Description = ""
+				}
+				booking.Start = booking.Start.In(loc)
+				booking.End = booking.End.In(loc)
+				booking.BlockedFrom = booking.BlockedFrom.In(loc)
+				booking.BlockedUntil = booking.BlockedUntil.In(loc)
+				day.Bookings = append(day.Bookings, booking)
+			}
+			rooms[i].Days[d] = day
+		}
+	}
+
+	return rooms, nil
+}
+
+// CheckIn implements BookingUseCase.
+// Booking accept yang selesai tanpa check-in dihitung sebagai no-show di analytics.
+func (b *bookingUseCase) CheckIn(ctx context.Context, id string, userId string) error {
+	err := b.repo.CheckIn(ctx, id, userId, time.Now().UTC())
+	if errors.Is(err, repository.ErrCheckInNotAllowed) {
+		return fmt.Errorf("booking detail with id %s can't be checked in, it must be your accepted booking that is running now and not checked in yet", id)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check in: %v", err)
+	}
+	return nil
+}
+
+// validateBookingFilter mengecek filter dari query dan mengisi nilai default paging
+func validateBookingFilter(filter dto.BookingFilter) (dto.BookingFilter, error) {
+	switch filter.SortBy {
+	case "", "bookingDate", "createdAt", "updatedAt":
+	default:
+		return dto.BookingFilter{}, fmt.Errorf(`sortBy must be "bookingDate", "createdAt" or "updatedAt", not %s`, filter.SortBy)
+	}
+
+	if filter.Order != "" && filter.Order != "asc" && filter.Order != "desc" {
+		return dto.BookingFilter{}, fmt.Errorf(`order must be "asc" or "desc", not %s`, filter.Order)
+	}
+
+	if !filter.StartDate.IsZero() && !filter.EndDate.IsZero() && filter.EndDate.Before(filter.StartDate) {
+		return dto.BookingFilter{}, fmt.Errorf("endDate can't be before startDate")
+	}
+
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.Size < 1 {
+		filter.Size = 10
+	}
+	if filter.Size > 100 {
+		filter.Size = 100
+	}
+
+	return filter, nil
+}
+
 // ViewAllBookingByStatus implements BookingUseCase.
-func (b *bookingUseCase) ViewAllBookingByStatus(status string) ([]model.Booking, error) {
-	bookings, err := b.repo.GetAllByStatus(status)
+func (b *bookingUseCase) ViewAllBookingByStatus(ctx context.Context, status string) ([]model.Booking, error) {
+	bookings, err := b.repo.GetAllByStatus(ctx, status)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get data error: %v", err)
 	}
@@ -161,8 +443,8 @@ func (b *bookingUseCase) ViewAllBookingByStatus(status string) ([]model.Booking,
 }
 
 // ViewAllBooking implements BookingUseCase.
-func (b *bookingUseCase) ViewAllBooking() ([]model.Booking, error) {
-	bookings, err := b.repo.GetAll()
+func (b *bookingUseCase) ViewAllBooking(ctx context.Context) ([]model.Booking, error) {
+	bookings, err := b.repo.GetAll(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all bookings: %v", err)
 	}
@@ -170,8 +452,8 @@ func (b *bookingUseCase) ViewAllBooking() ([]model.Booking, error) {
 }
 
 // FindById implements BookingUseCase.
-func (b *bookingUseCase) FindById(id string, userId string, roleUser string) (model.Booking, error) {
-	booking, err := b.repo.Get(id, userId, roleUser)
+func (b *bookingUseCase) FindById(ctx context.Context, id string, userId string, roleUser string) (model.Booking, error) {
+	booking, err := b.repo.Get(ctx, id, userId, roleUser)
 	if err != nil {
 		return model.Booking{}, fmt.Errorf("booking with id %s not found", id)
 	}
@@ -179,39 +461,107 @@ func (b *bookingUseCase) FindById(id string, userId string, roleUser string) (mo
 }
 
 // RegisterNewBooking implements BookingUseCase.
-func (b *bookingUseCase) RegisterNewBooking(payload dto.BookingRequestDto, userId string) (model.Booking, error) {
+func (b *bookingUseCase) RegisterNewBooking(ctx context.Context, payload dto.BookingRequestDto, userId string) (model.Booking, error) {
 
-	user, err := b.userUC.FindById(userId)
+	user, err := b.userUC.FindById(ctx, userId)
 	if err != nil {
 		return model.Booking{}, fmt.Errorf("user with ID %s not found", userId)
 	}
 
+	participants := make(map[string]bool)
+	for _, participant := range payload.Participants {
+		if participant.Role != model.ParticipantAttendee && participant.Role != model.ParticipantDelegate {
+			return model.Booking{}, fmt.Errorf(`participant role must be "attendee" or "delegate", not %s`, participant.Role)
+		}
+		if participant.UserId == userId {
+			return model.Booking{}, fmt.Errorf("you can't add yourself as a participant")
+		}
+		if participants[participant.UserId] {
+			return model.Booking{}, fmt.Errorf("user with ID %s is listed twice as a participant", participant.UserId)
+		}
+		participants[participant.UserId] = true
+		if _, err := b.userUC.FindById(ctx, participant.UserId); err != nil {
+			return model.Booking{}, fmt.Errorf("user with ID %s not found", participant.UserId)
+		}
+	}
+
 	var bookingDetails []model.BookingDetail
 	for _, v := range payload.BoookingDetails {
-		room, err := b.roomUC.FindById(v.Rooms.Id)
+		// jadwal dipakai apa adanya untuk insert dan semua cek bentrok di repository
+		if v.BookingDate.IsZero() || v.BookingDateEnd.IsZero() {
+			return model.Booking{}, fmt.Errorf("bookingDate and bookingDateEnd are required")
+		}
+		if !v.BookingDateEnd.After(v.BookingDate) {
+			return model.Booking{}, fmt.Errorf("bookingDateEnd must be after bookingDate")
+		}
+		if v.BookingDate.Before(time.Now()) {
+			return model.Booking{}, fmt.Errorf("bookingDate can't be in the past")
+		}
+
+		room, err := b.roomUC.FindById(ctx, v.Rooms.Id)
 		if err != nil {
 			return model.Booking{}, fmt.Errorf("room with id %s is not found", v.Rooms.Id)
 		}
+		if room.ArchivedAt != nil {
+			return model.Booking{}, fmt.Errorf("room with id %s is archived", v.Rooms.Id)
+		}
+		if !room.AllowsUser(user) {
+			return model.Booking{}, fmt.Errorf("room with id %s is restricted for your division or role", v.Rooms.Id)
+		}
+
+		// kapasitas dan buffer mengikuti layout yang dipilih, tanpa layout pakai MaxCapacity
+		var layout *model.RoomLayout
+		if v.Layout != nil && v.Layout.Id != "" {
+			selected, ok := room.Layout(v.Layout.Id)
+			if !ok {
+				return model.Booking{}, fmt.Errorf("layout with id %s is not available in room %s", v.Layout.Id, v.Rooms.Id)
+			}
+			layout = &selected
+		}
+		if v.Attendees < 0 {
+			return model.Booking{}, fmt.Errorf("attendees can't be negative")
+		}
+		if capacity := room.Capacity(layout); v.Attendees > capacity {
+			return model.Booking{}, fmt.Errorf("room with id %s only fits %d attendees in the selected layout", v.Rooms.Id, capacity)
+		}
+
+		// stok dicek di repository bersama booking lain, di sini hanya bentuk requestnya
+		reserved := make(map[string]bool)
+		for _, item := range v.Equipment {
+			if item.Quantity <= 0 {
+				return model.Booking{}, fmt.Errorf("equipment quantity must be greater than 0")
+			}
+			if reserved[item.EquipmentId] {
+				return model.Booking{}, fmt.Errorf("equipment with id %s is listed twice", item.EquipmentId)
+			}
+			reserved[item.EquipmentId] = true
+		}
 
-		status, _ := b.roomUC.GetRoomStatus(v.Rooms.Id)
+		status, _ := b.roomUC.GetRoomStatus(ctx, v.Rooms.Id)
 		if status != "available" {
 			return model.Booking{}, fmt.Errorf("room status with id %s is not available", v.Rooms.Id)
 		}
 
 		bookingDetails = append(bookingDetails, model.BookingDetail{
 
-			Rooms:       room,
-			Description: v.Description,
-			Status:      v.Status,
+			Rooms:          room,
+			Description:    v.Description,
+			Status:         v.Status,
+			Layout:         layout,
+			Attendees:      v.Attendees,
+			Equipment:      v.Equipment,
+			BookingDate:    v.BookingDate,
+			BookingDateEnd: v.BookingDateEnd,
 		})
 	}
 
 	newBookingPayload := model.Booking{
 		Users:          user,
 		BookingDetails: bookingDetails,
+		Participants:   payload.Participants,
 	}
 
-	booking, err := b.repo.Create(newBookingPayload, userId)
+	booking, err := b.repo.Create(ctx, newBookingPayload, userId)
 	if err != nil {
 		return model.Booking{}, err
 	}
@@ -224,11 +574,13 @@ func NewBookingUseCase(
 	userUC UserUseCase,
 	roomUC RoomUseCase,
 	emailService common.EmailService,
+	conference common.ConferencingProvider,
 ) BookingUseCase {
 	return &bookingUseCase{
 		repo:         repo,
 		userUC:       userUC,
 		roomUC:       roomUC,
 		emailService: emailService,
+		conference:   conference,
 	}
 }
@@ -1,16 +1,22 @@
 package usecase
 
 import (
+	"context"
+	"database/sql"
 	"errors"
 	"final-project-booking-room/model"
 	"final-project-booking-room/model/dto"
+	"final-project-booking-room/repository"
 	repositorymock "final-project-booking-room/unit-test/mock-test/repository-mock"
 	usecasemock "final-project-booking-room/unit-test/mock-test/usecase-mock"
+	"final-project-booking-room/utils/modelutil"
 	"fmt"
 
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -20,6 +26,7 @@ type BookingUseCaseTestSuite struct {
 	uum *usecasemock.UserUseCaseMock
 	rum *usecasemock.RoomUseCaseMock
 	ues *usecasemock.EmailServiceMock
+	cfm *usecasemock.ConferencingMock
 	bu  BookingUseCase
 }
 
@@ -28,7 +35,8 @@ func (suite *BookingUseCaseTestSuite) SetupTest() {
 	suite.uum = new(usecasemock.UserUseCaseMock)
 	suite.rum = new(usecasemock.RoomUseCaseMock)
 	suite.ues = new(usecasemock.EmailServiceMock)
-	suite.bu = NewBookingUseCase(suite.brm, suite.uum, suite.rum, suite.ues)
+	suite.cfm = new(usecasemock.ConferencingMock)
+	suite.bu = NewBookingUseCase(suite.brm, suite.uum, suite.rum, suite.ues, suite.cfm)
 }
 
 func TestBookingUseCaseTestSuite(t *testing.T) {
@@ -88,12 +96,19 @@ var mockBookingSlice = []model.Booking{{
 	},
 },
 }
+
+// jadwal booking yang valid untuk test RegisterNewBooking
+var bookingStart = time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+var bookingEnd = bookingStart.Add(2 * time.Hour)
+
 var mockPayload = dto.BookingRequestDto{
 	Id: "1",
 	BoookingDetails: []model.BookingDetail{
 		{
-			Id:        "1",
-			BookingId: "1",
+			Id:             "1",
+			BookingId:      "1",
+			BookingDate:    bookingStart,
+			BookingDateEnd: bookingEnd,
 			Rooms: model.Room{
 				Id:          "5",
 				RoomType:    "kolam",
@@ -122,22 +137,26 @@ var id = "1"
 var roleUser = "1"
 
 func (suite *BookingUseCaseTestSuite) TestDownloadReport() {
-	suite.brm.On("GetAll").Return(mockBookingSlice, nil)
+	filter := dto.BookingFilter{Location: dto.LocationFilter{SiteId: "site-1"}}
+	firstPage := func(f dto.BookingFilter) bool { return f.Page == 1 && f.Size == 100 && f.Location.SiteId == "site-1" }
+	secondPage := func(f dto.BookingFilter) bool { return f.Page == 2 && f.Size == 100 && f.Location.SiteId == "site-1" }
+	suite.brm.On("Search", mock.Anything, mock.MatchedBy(firstPage)).Return(mockBookingSlice, dto.Paging{Page: 1, Size: 100, TotalRows: 101, TotalPages: 2}, nil)
+	suite.brm.On("Search", mock.Anything, mock.MatchedBy(secondPage)).Return(mockBookingSlice, dto.Paging{Page: 2, Size: 100, TotalRows: 101, TotalPages: 2}, nil)
 
-	suite.brm.On("GetBookingDetailsByBookingID", "1").Return(mockBooking.BookingDetails, nil)
-
-	actualBookings, err := suite.bu.DownloadReport()
+	actualBookings, err := suite.bu.DownloadReport(context.Background(), filter)
 
 	assert.NoError(suite.T(), err)
+	suite.brm.AssertNotCalled(suite.T(), "GetBookingDetailsByBookingID", mock.Anything, "1")
+	suite.brm.AssertNumberOfCalls(suite.T(), "Search", 2)
 
-	assert.Equal(suite.T(), mockBookingSlice, actualBookings)
+	assert.Equal(suite.T(), append(mockBookingSlice, mockBookingSlice...), actualBookings)
 }
 
 func (suite *BookingUseCaseTestSuite) TestViewBookingAllByStatus_Failed() {
 	expectedError := errors.New("some error message")
-	suite.brm.On("GetAllByStatus", "someStatus").Return(mockBookingSlice, expectedError)
+	suite.brm.On("GetAllByStatus", mock.Anything, "someStatus").Return(mockBookingSlice, expectedError)
 
-	actualBookings, err := suite.bu.ViewAllBookingByStatus("someStatus")
+	actualBookings, err := suite.bu.ViewAllBookingByStatus(context.Background(), "someStatus")
 
 	assert.Error(suite.T(), err)
 
@@ -146,9 +165,9 @@ func (suite *BookingUseCaseTestSuite) TestViewBookingAllByStatus_Failed() {
 	assert.Nil(suite.T(), actualBookings)
 }
 func (suite *BookingUseCaseTestSuite) TestViewBookingAllByStatus_Success() {
-	suite.brm.On("GetAllByStatus", mockRoom.Status).Return(mockBookingSlice, nil)
+	suite.brm.On("GetAllByStatus", mock.Anything, mockRoom.Status).Return(mockBookingSlice, nil)
 
-	actualBookings, err := suite.bu.ViewAllBookingByStatus(mockRoom.Status)
+	actualBookings, err := suite.bu.ViewAllBookingByStatus(context.Background(), mockRoom.Status)
 
 	assert.NoError(suite.T(), err)
 
@@ -156,9 +175,9 @@ func (suite *BookingUseCaseTestSuite) TestViewBookingAllByStatus_Success() {
 }
 func (suite *BookingUseCaseTestSuite) TestViewBookingAll_Failed() {
 	expectedError := errors.New("some error message")
-	suite.brm.On("GetAll").Return(mockBookingSlice, expectedError)
+	suite.brm.On("GetAll", mock.Anything).Return(mockBookingSlice, expectedError)
 
-	actualBookings, err := suite.bu.ViewAllBooking()
+	actualBookings, err := suite.bu.ViewAllBooking(context.Background())
 
 	assert.Error(suite.T(), err)
 
@@ -168,9 +187,9 @@ func (suite *BookingUseCaseTestSuite) TestViewBookingAll_Failed() {
 }
 
 func (suite *BookingUseCaseTestSuite) TestViewBookingAll_Success() {
-	suite.brm.On("GetAll").Return(mockBookingSlice, nil)
+	suite.brm.On("GetAll", mock.Anything).Return(mockBookingSlice, nil)
 
-	actualBookings, err := suite.bu.ViewAllBooking()
+	actualBookings, err := suite.bu.ViewAllBooking(context.Background())
 
 	assert.NoError(suite.T(), err)
 
@@ -178,8 +197,8 @@ func (suite *BookingUseCaseTestSuite) TestViewBookingAll_Success() {
 }
 
 func (suite *BookingUseCaseTestSuite) TestFindById_Success() {
-	suite.brm.On("Get", id, userId, roleUser).Return(mockBooking, nil)
-	actualBooking, err := suite.bu.FindById(id, userId, roleUser)
+	suite.brm.On("Get", mock.Anything, id, userId, roleUser).Return(mockBooking, nil)
+	actualBooking, err := suite.bu.FindById(context.Background(), id, userId, roleUser)
 
 	assert.NoError(suite.T(), err)
 
@@ -188,9 +207,9 @@ func (suite *BookingUseCaseTestSuite) TestFindById_Success() {
 
 func (suite *BookingUseCaseTestSuite) TestFindById_Failed() {
 	expectedError := errors.New("some error message")
-	suite.brm.On("Get", id, userId, roleUser).Return(mockBooking, expectedError)
+	suite.brm.On("Get", mock.Anything, id, userId, roleUser).Return(mockBooking, expectedError)
 
-	actualBooking, err := suite.bu.FindById(id, userId, roleUser)
+	actualBooking, err := suite.bu.FindById(context.Background(), id, userId, roleUser)
 
 	assert.Error(suite.T(), err)
 
@@ -200,18 +219,20 @@ func (suite *BookingUseCaseTestSuite) TestFindById_Failed() {
 }
 
 func (suite *BookingUseCaseTestSuite) TestRegisterNewBooking_Succes() {
-	suite.uum.On("FindById", userId).Return(mockUser, nil)
+	suite.uum.On("FindById", mock.Anything, userId).Return(mockUser, nil)
 
 	var mockBookingDetails []model.BookingDetail
 	for _, v := range mockPayload.BoookingDetails {
-		suite.rum.On("FindById", v.Rooms.Id).Return(mockRoom1, nil)
+		suite.rum.On("FindById", mock.Anything, v.Rooms.Id).Return(mockRoom1, nil)
 
-		suite.rum.On("GetRoomStatus", v.Rooms.Id).Return(mockRoom1.Status, nil)
+		suite.rum.On("GetRoomStatus", mock.Anything, v.Rooms.Id).Return(mockRoom1.Status, nil)
 
 		mockBookingDetails = append(mockBookingDetails, model.BookingDetail{
-			Rooms:       mockRoom1,
-			Description: v.Description,
-			Status:      mockRoom.Status,
+			Rooms:          mockRoom1,
+			Description:    v.Description,
+			Status:         mockRoom.Status,
+			BookingDate:    v.BookingDate,
+			BookingDateEnd: v.BookingDateEnd,
 		})
 	}
 
@@ -220,10 +241,372 @@ func (suite *BookingUseCaseTestSuite) TestRegisterNewBooking_Succes() {
 		BookingDetails: mockBookingDetails,
 	}
 
-	suite.brm.On("Create", mockNewBookingPayload, userId).Return(mockBooking, nil)
-	actual, err := suite.bu.RegisterNewBooking(mockPayload, userId)
+	suite.brm.On("Create", mock.Anything, mockNewBookingPayload, userId).Return(mockBooking, nil)
+	actual, err := suite.bu.RegisterNewBooking(context.Background(), mockPayload, userId)
 	assert.Error(suite.T(), err)
 	assert.EqualError(suite.T(), err, "room status with id 5 is not available")
 	assert.NotNil(suite.T(), actual)
 	assert.Equal(suite.T(), mockBooking.Users.Id, userId)
 }
+
+func (suite *BookingUseCaseTestSuite) TestViewMyBookings_Success() {
+	expectedFilter := dto.BookingFilter{Status: "pending", Page: 1, Size: 10}
+	mockPaging := dto.Paging{Page: 1, Size: 10, TotalRows: 1, TotalPages: 1}
+	suite.brm.On("GetAllByUser", mock.Anything, userId, expectedFilter).Return(mockBookingSlice, mockPaging, nil)
+
+	actualBookings, actualPaging, err := suite.bu.ViewMyBookings(context.Background(), userId, dto.BookingFilter{Status: "pending"})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), mockBookingSlice, actualBookings)
+	assert.Equal(suite.T(), mockPaging, actualPaging)
+}
+
+func (suite *BookingUseCaseTestSuite) TestViewMyBookings_InvalidOrder() {
+	actualBookings, _, err := suite.bu.ViewMyBookings(context.Background(), userId, dto.BookingFilter{Order: "random"})
+
+	assert.EqualError(suite.T(), err, `order must be "asc" or "desc", not random`)
+	assert.Nil(suite.T(), actualBookings)
+	suite.brm.AssertNotCalled(suite.T(), "GetAllByUser", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *BookingUseCaseTestSuite) TestSearchBooking_Success() {
+	expectedFilter := dto.BookingFilter{Divisi: "HR", Keyword: "rapat", Page: 1, Size: 100}
+	mockPaging := dto.Paging{Page: 1, Size: 100, TotalRows: 1, TotalPages: 1}
+	suite.brm.On("Search", mock.Anything, expectedFilter).Return(mockBookingSlice, mockPaging, nil)
+
+	actualBookings, actualPaging, err := suite.bu.SearchBooking(context.Background(), dto.BookingFilter{Divisi: "HR", Keyword: "rapat", Size: 500})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), mockBookingSlice, actualBookings)
+	assert.Equal(suite.T(), mockPaging, actualPaging)
+}
+
+func (suite *BookingUseCaseTestSuite) TestSearchBooking_Failed() {
+	expectedError := errors.New("some error message")
+	suite.brm.On("Search", mock.Anything, dto.BookingFilter{Page: 1, Size: 10}).Return([]model.Booking{}, dto.Paging{}, expectedError)
+
+	actualBookings, _, err := suite.bu.SearchBooking(context.Background(), dto.BookingFilter{})
+
+	assert.EqualError(suite.T(), err, fmt.Sprintf("failed to search bookings: %v", expectedError))
+	assert.Nil(suite.T(), actualBookings)
+}
+
+func (suite *BookingUseCaseTestSuite) TestRegisterNewBooking_InvalidSchedule() {
+	tests := []struct {
+		name       string
+		start, end time.Time
+		want       string
+	}{
+		{name: "missing", want: "bookingDate and bookingDateEnd are required"},
+		{name: "end before start", start: bookingEnd, end: bookingStart, want: "bookingDateEnd must be after bookingDate"},
+		{name: "empty range", start: bookingStart, end: bookingStart, want: "bookingDateEnd must be after bookingDate"},
+		{name: "in the past", start: time.Now().Add(-2 * time.Hour), end: time.Now().Add(-time.Hour), want: "bookingDate can't be in the past"},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			payload := dto.BookingRequestDto{BoookingDetails: []model.BookingDetail{{Rooms: model.Room{Id: "5"}, BookingDate: tt.start, BookingDateEnd: tt.end}}}
+			suite.uum.On("FindById", mock.Anything, userId).Return(mockUser, nil)
+
+			_, err := suite.bu.RegisterNewBooking(context.Background(), payload, userId)
+
+			assert.EqualError(suite.T(), err, tt.want)
+			suite.rum.AssertNotCalled(suite.T(), "FindById", mock.Anything, mock.Anything)
+			suite.brm.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func (suite *BookingUseCaseTestSuite) TestRegisterNewBooking_LayoutCapacityExceeded() {
+	room := model.Room{Id: "5", MaxCapacity: 40, Layouts: []model.RoomLayout{{Id: "l1", RoomId: "5", Name: "classroom", Capacity: 20}}}
+	payload := dto.BookingRequestDto{BoookingDetails: []model.BookingDetail{
+		{Rooms: model.Room{Id: "5"}, BookingDate: bookingStart, BookingDateEnd: bookingEnd, Layout: &model.RoomLayout{Id: "l1"}, Attendees: 30},
+	}}
+	suite.uum.On("FindById", mock.Anything, userId).Return(mockUser, nil)
+	suite.rum.On("FindById", mock.Anything, "5").Return(room, nil)
+
+	_, err := suite.bu.RegisterNewBooking(context.Background(), payload, userId)
+
+	assert.EqualError(suite.T(), err, "room with id 5 only fits 20 attendees in the selected layout")
+	suite.brm.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *BookingUseCaseTestSuite) TestRegisterNewBooking_UnknownLayout() {
+	room := model.Room{Id: "5", MaxCapacity: 40}
+	payload := dto.BookingRequestDto{BoookingDetails: []model.BookingDetail{
+		{Rooms: model.Room{Id: "5"}, BookingDate: bookingStart, BookingDateEnd: bookingEnd, Layout: &model.RoomLayout{Id: "l9"}, Attendees: 10},
+	}}
+	suite.uum.On("FindById", mock.Anything, userId).Return(mockUser, nil)
+	suite.rum.On("FindById", mock.Anything, "5").Return(room, nil)
+
+	_, err := suite.bu.RegisterNewBooking(context.Background(), payload, userId)
+
+	assert.EqualError(suite.T(), err, "layout with id l9 is not available in room 5")
+}
+
+func (suite *BookingUseCaseTestSuite) TestRegisterNewBooking_RestrictedRoom() {
+	room := model.Room{Id: "5", MaxCapacity: 10, AccessRules: []model.RoomAccessRule{{Effect: "deny", Field: "divisi", Value: mockUser.Divisi}}}
+	payload := dto.BookingRequestDto{BoookingDetails: []model.BookingDetail{{Rooms: model.Room{Id: "5"}, BookingDate: bookingStart, BookingDateEnd: bookingEnd}}}
+	suite.uum.On("FindById", mock.Anything, userId).Return(mockUser, nil)
+	suite.rum.On("FindById", mock.Anything, "5").Return(room, nil)
+
+	_, err := suite.bu.RegisterNewBooking(context.Background(), payload, userId)
+
+	assert.EqualError(suite.T(), err, "room with id 5 is restricted for your division or role")
+	suite.brm.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *BookingUseCaseTestSuite) TestRegisterNewBooking_DuplicateEquipment() {
+	payload := dto.BookingRequestDto{BoookingDetails: []model.BookingDetail{
+		{Rooms: model.Room{Id: "5"}, BookingDate: bookingStart, BookingDateEnd: bookingEnd, Equipment: []model.EquipmentReservation{{EquipmentId: "e1", Quantity: 1}, {EquipmentId: "e1", Quantity: 2}}},
+	}}
+	suite.uum.On("FindById", mock.Anything, userId).Return(mockUser, nil)
+	suite.rum.On("FindById", mock.Anything, "5").Return(model.Room{Id: "5", MaxCapacity: 10}, nil)
+
+	_, err := suite.bu.RegisterNewBooking(context.Background(), payload, userId)
+
+	assert.EqualError(suite.T(), err, "equipment with id e1 is listed twice")
+	suite.brm.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *BookingUseCaseTestSuite) TestRegisterNewBooking_WithLayout() {
+	layout := model.RoomLayout{Id: "l1", RoomId: "5", Name: "theatre", Capacity: 40, SetupMinutes: 15}
+	room := model.Room{Id: "5", MaxCapacity: 20, Status: "available", Layouts: []model.RoomLayout{layout}}
+	payload := dto.BookingRequestDto{BoookingDetails: []model.BookingDetail{
+		{Rooms: model.Room{Id: "5"}, BookingDate: bookingStart, BookingDateEnd: bookingEnd, Layout: &model.RoomLayout{Id: "l1"}, Attendees: 35},
+	}}
+	suite.uum.On("FindById", mock.Anything, userId).Return(mockUser, nil)
+	suite.rum.On("FindById", mock.Anything, "5").Return(room, nil)
+	suite.rum.On("GetRoomStatus", mock.Anything, "5").Return("available", nil)
+	suite.brm.On("Create", mock.Anything, model.Booking{
+		Users:          mockUser,
+		BookingDetails: []model.BookingDetail{{Rooms: room, Layout: &layout, Attendees: 35, BookingDate: bookingStart, BookingDateEnd: bookingEnd}},
+	}, userId).Return(mockBooking, nil)
+
+	_, err := suite.bu.RegisterNewBooking(context.Background(), payload, userId)
+
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *BookingUseCaseTestSuite) TestViewCalendar_EmployeeSeesOnlyOwnDetails() {
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+	start, _ := time.Parse("2006-01-02", "2023-11-20")
+	filter := dto.CalendarFilter{StartDate: start, EndDate: start.AddDate(0, 0, 1)}
+	// booking orang lain 22:00-01:00 WIB melewati tengah malam, booking sendiri 09:00-10:00 WIB hari kedua
+	other := dto.CalendarBooking{BookingId: "b1", BookingDetailId: "bd1", Owner: &dto.CalendarOwner{Id: "other", Name: "Budi"}, Status: "accept", Description: "rapat direksi",
+		Start: time.Date(2023, 11, 20, 22, 0, 0, 0, jakarta), End: time.Date(2023, 11, 21, 1, 0, 0, 0, jakarta)}
+	mine := dto.CalendarBooking{BookingId: "b2", BookingDetailId: "bd2", Owner: &dto.CalendarOwner{Id: userId}, Status: "pending", Description: "sync tim",
+		Start: time.Date(2023, 11, 21, 9, 0, 0, 0, jakarta), End: time.Date(2023, 11, 21, 10, 0, 0, 0, jakarta)}
+	rooms := []dto.CalendarRoom{{RoomId: "5", RoomName: "Melati", Timezone: "Asia/Jakarta", Bookings: []dto.CalendarBooking{other, mine}}}
+	suite.brm.On("GetCalendar", mock.Anything, mock.MatchedBy(func(f dto.CalendarFilter) bool {
+		return f.From.Before(time.Date(2023, 11, 20, 0, 0, 0, 0, jakarta)) && f.Until.After(time.Date(2023, 11, 22, 0, 0, 0, 0, jakarta))
+	})).Return(rooms, nil)
+
+	actual, err := suite.bu.ViewCalendar(context.Background(), filter, userId, "employee")
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), actual[0].Days, 2)
+	assert.Equal(suite.T(), "2023-11-20", actual[0].Days[0].Date)
+	assert.Len(suite.T(), actual[0].Days[0].Bookings, 1)
+	assert.Len(suite.T(), actual[0].Days[1].Bookings, 2)
+	hidden := actual[0].Days[0].Bookings[0]
+	assert.Nil(suite.T(), hidden.Owner)
+	assert.Empty(suite.T(), hidden.Description)
+	assert.Empty(suite.T(), hidden.BookingId)
+	assert.False(suite.T(), hidden.Mine)
+	own := actual[0].Days[1].Bookings[1]
+	assert.True(suite.T(), own.Mine)
+	assert.Equal(suite.T(), "sync tim", own.Description)
+}
+
+func (suite *BookingUseCaseTestSuite) TestViewCalendar_GASeesEveryOwner() {
+	start, _ := time.Parse("2006-01-02", "2023-11-20")
+	booking := dto.CalendarBooking{BookingId: "b1", Owner: &dto.CalendarOwner{Id: "other", Name: "Budi"}, Status: "accept", Description: "rapat direksi",
+		Start: start.Add(2 * time.Hour), End: start.Add(3 * time.Hour)}
+	suite.brm.On("GetCalendar", mock.Anything, mock.Anything).Return([]dto.CalendarRoom{{RoomId: "5", Bookings: []dto.CalendarBooking{booking}}}, nil)
+
+	actual, err := suite.bu.ViewCalendar(context.Background(), dto.CalendarFilter{StartDate: start, Timezone: time.UTC}, userId, "GA")
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), actual[0].Days, 1)
+	assert.Equal(suite.T(), "UTC", actual[0].Timezone)
+	assert.Equal(suite.T(), "Budi", actual[0].Days[0].Bookings[0].Owner.Name)
+	assert.Equal(suite.T(), "rapat direksi", actual[0].Days[0].Bookings[0].Description)
+}
+
+func (suite *BookingUseCaseTestSuite) TestViewCalendar_RangeTooLong() {
+	start, _ := time.Parse("2006-01-02", "2023-11-01")
+
+	_, err := suite.bu.ViewCalendar(context.Background(), dto.CalendarFilter{StartDate: start, EndDate: start.AddDate(0, 2, 0)}, userId, "GA")
+
+	assert.EqualError(suite.T(), err, "calendar range can't be longer than 31 days")
+	suite.brm.AssertNotCalled(suite.T(), "GetCalendar", mock.Anything, mock.Anything)
+}
+
+func (suite *BookingUseCaseTestSuite) TestCheckIn_NotAllowed() {
+	suite.brm.On("CheckIn", mock.Anything, "bd1", userId, mock.Anything).Return(repository.ErrCheckInNotAllowed)
+
+	err := suite.bu.CheckIn(context.Background(), "bd1", userId)
+
+	assert.EqualError(suite.T(), err, "booking detail with id bd1 can't be checked in, it must be your accepted booking that is running now and not checked in yet")
+}
+
+func (suite *BookingUseCaseTestSuite) TestUpdateStatusBookAndRoom_AcceptCreatesConference() {
+	booking := model.Booking{Id: "1", Users: model.User{Name: "Saya", Email: "saya@mail.com"}, BookingDetails: []model.BookingDetail{
+		{Id: "bd1", Rooms: model.Room{Id: "5", Name: "Melati"}, Description: "sync tim", Status: "accept"},
+	}}
+	var sent modelutil.BodySender
+	suite.ues.SendEmailFunc = func(payload modelutil.BodySender) error {
+		sent = payload
+		return nil
+	}
+	suite.brm.On("GetBookStatus", mock.Anything, "bd1").Return("pending", nil)
+	suite.rum.On("GetRoomStatusByBdId", mock.Anything, "bd1").Return("available", nil)
+	suite.brm.On("UpdateStatus", mock.Anything, "bd1", "accept").Return(booking, nil)
+	suite.cfm.On("CreateMeeting", mock.Anything, mock.MatchedBy(func(req modelutil.ConferenceRequest) bool {
+		return req.Topic == "sync tim" && req.Location == "Melati"
+	})).Return(modelutil.ConferenceMeeting{Id: "m1", JoinURL: "https://meet.local/m1"}, nil)
+	suite.brm.On("SetConference", mock.Anything, "bd1", "m1", "https://meet.local/m1").Return(nil)
+	suite.brm.On("GetParticipantEmails", mock.Anything, "1").Return([]string{"budi@mail.com", "sari@mail.com"}, nil)
+	suite.brm.On("NextInviteSequence", mock.Anything, "bd1").Return(0, nil)
+
+	actual, err := suite.bu.UpdateStatusBookAndRoom(context.Background(), "bd1", "accept")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "https://meet.local/m1", actual.BookingDetails[0].ConferenceURL)
+	assert.Equal(suite.T(), []string{"saya@mail.com", "budi@mail.com", "sari@mail.com"}, sent.To)
+	assert.Equal(suite.T(), sent.To, sent.Invite.Attendees)
+	assert.Contains(suite.T(), sent.Body, "https://meet.local/m1")
+	assert.Equal(suite.T(), "booking-bd1@final-project-booking-room", sent.Invite.UID)
+	assert.Equal(suite.T(), "REQUEST", sent.Invite.Method)
+	assert.Equal(suite.T(), 0, sent.Invite.Sequence)
+	assert.Equal(suite.T(), "https://meet.local/m1", sent.Invite.URL)
+}
+
+func (suite *BookingUseCaseTestSuite) TestUpdateStatusBookAndRoom_ConferenceFailureKeepsApproval() {
+	booking := model.Booking{Id: "1", Users: model.User{Email: "saya@mail.com"}, BookingDetails: []model.BookingDetail{{Id: "bd1", Status: "accept"}}}
+	suite.brm.On("GetBookStatus", mock.Anything, "bd1").Return("pending", nil)
+	suite.rum.On("GetRoomStatusByBdId", mock.Anything, "bd1").Return("available", nil)
+	suite.brm.On("UpdateStatus", mock.Anything, "bd1", "accept").Return(booking, nil)
+	suite.cfm.On("CreateMeeting", mock.Anything, mock.Anything).Return(modelutil.ConferenceMeeting{}, errors.New("provider down"))
+	suite.brm.On("GetParticipantEmails", mock.Anything, "1").Return([]string{}, nil)
+	suite.brm.On("NextInviteSequence", mock.Anything, "bd1").Return(2, nil)
+
+	actual, err := suite.bu.UpdateStatusBookAndRoom(context.Background(), "bd1", "accept")
+
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), actual.BookingDetails[0].ConferenceURL)
+	suite.brm.AssertNotCalled(suite.T(), "SetConference", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *BookingUseCaseTestSuite) TestUpdateStatusBookAndRoom_SaveConferenceFailureDeletesMeeting() {
+	booking := model.Booking{Id: "1", Users: model.User{Email: "saya@mail.com"}, BookingDetails: []model.BookingDetail{{Id: "bd1", Status: "accept"}}}
+	suite.brm.On("GetBookStatus", mock.Anything, "bd1").Return("pending", nil)
+	suite.rum.On("GetRoomStatusByBdId", mock.Anything, "bd1").Return("available", nil)
+	suite.brm.On("UpdateStatus", mock.Anything, "bd1", "accept").Return(booking, nil)
+	suite.cfm.On("CreateMeeting", mock.Anything, mock.Anything).Return(modelutil.ConferenceMeeting{Id: "m1", JoinURL: "https://meet.local/m1"}, nil)
+	suite.brm.On("SetConference", mock.Anything, "bd1", "m1", "https://meet.local/m1").Return(errors.New("db down"))
+	suite.cfm.On("DeleteMeeting", mock.Anything, "m1").Return(nil)
+	suite.brm.On("GetParticipantEmails", mock.Anything, "1").Return([]string{}, nil)
+	suite.brm.On("NextInviteSequence", mock.Anything, "bd1").Return(0, nil)
+
+	actual, err := suite.bu.UpdateStatusBookAndRoom(context.Background(), "bd1", "accept")
+
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), actual.BookingDetails[0].ConferenceURL)
+	suite.cfm.AssertCalled(suite.T(), "DeleteMeeting", mock.Anything, "m1")
+}
+
+func (suite *BookingUseCaseTestSuite) TestUpdateStatusBookAndRoom_UpdateFailed() {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "not found", err: sql.ErrNoRows, want: "booking detail with id bd1 not found"},
+		{name: "equipment taken", err: fmt.Errorf("%w: Projector", repository.ErrEquipmentUnavailable), want: "failed to accept booking detail bd1: equipment is no longer available for this booking: Projector"},
+		{name: "room conflict", err: repository.ErrBookingConflict, want: "failed to accept booking detail bd1: room is already booked for the requested time"},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			suite.SetupTest()
+			suite.brm.On("GetBookStatus", mock.Anything, "bd1").Return("pending", nil)
+			suite.rum.On("GetRoomStatusByBdId", mock.Anything, "bd1").Return("available", nil)
+			suite.brm.On("UpdateStatus", mock.Anything, "bd1", "accept").Return(model.Booking{}, tt.err)
+
+			_, err := suite.bu.UpdateStatusBookAndRoom(context.Background(), "bd1", "accept")
+
+			assert.EqualError(suite.T(), err, tt.want)
+			if tt.err != sql.ErrNoRows {
+				assert.ErrorIs(suite.T(), err, tt.err)
+			}
+			suite.cfm.AssertNotCalled(suite.T(), "CreateMeeting", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func (suite *BookingUseCaseTestSuite) TestUpdateStatusBookAndRoom_DeclineSkipsConference() {
+	suite.brm.On("GetBookStatus", mock.Anything, "bd1").Return("pending", nil)
+	suite.rum.On("GetRoomStatusByBdId", mock.Anything, "bd1").Return("available", nil)
+	suite.brm.On("UpdateStatus", mock.Anything, "bd1", "decline").Return(mockBooking, nil)
+
+	_, err := suite.bu.UpdateStatusBookAndRoom(context.Background(), "bd1", "decline")
+
+	assert.NoError(suite.T(), err)
+	suite.cfm.AssertNotCalled(suite.T(), "CreateMeeting", mock.Anything, mock.Anything)
+}
+
+func (suite *BookingUseCaseTestSuite) TestRegisterNewBooking_WithParticipants() {
+	room := model.Room{Id: "5", MaxCapacity: 20, Status: "available"}
+	participants := []model.Participant{{UserId: "2", Role: model.ParticipantAttendee}, {UserId: "3", Role: model.ParticipantDelegate}}
+	payload := dto.BookingRequestDto{
+		BoookingDetails: []model.BookingDetail{{Rooms: model.Room{Id: "5"}, BookingDate: bookingStart, BookingDateEnd: bookingEnd}},
+		Participants:    participants,
+	}
+	suite.uum.On("FindById", mock.Anything, userId).Return(mockUser, nil)
+	suite.uum.On("FindById", mock.Anything, "2").Return(model.User{Id: "2"}, nil)
+	suite.uum.On("FindById", mock.Anything, "3").Return(model.User{Id: "3"}, nil)
+	suite.rum.On("FindById", mock.Anything, "5").Return(room, nil)
+	suite.rum.On("GetRoomStatus", mock.Anything, "5").Return("available", nil)
+	suite.brm.On("Create", mock.Anything, model.Booking{
+		Users:          mockUser,
+		BookingDetails: []model.BookingDetail{{Rooms: room, BookingDate: bookingStart, BookingDateEnd: bookingEnd}},
+		Participants:   participants,
+	}, userId).Return(mockBooking, nil)
+
+	_, err := suite.bu.RegisterNewBooking(context.Background(), payload, userId)
+
+	assert.NoError(suite.T(), err)
+	suite.brm.AssertExpectations(suite.T())
+}
+
+func (suite *BookingUseCaseTestSuite) TestRegisterNewBooking_InvalidParticipants() {
+	tests := []struct {
+		name         string
+		participants []model.Participant
+		want         string
+	}{
+		{name: "unknown role", participants: []model.Participant{{UserId: "2", Role: "owner"}}, want: `participant role must be "attendee" or "delegate", not owner`},
+		{name: "booker", participants: []model.Participant{{UserId: userId, Role: model.ParticipantDelegate}}, want: "you can't add yourself as a participant"},
+		{name: "listed twice", participants: []model.Participant{{UserId: "2", Role: model.ParticipantAttendee}, {UserId: "2", Role: model.ParticipantDelegate}}, want: "user with ID 2 is listed twice as a participant"},
+		{name: "unknown user", participants: []model.Participant{{UserId: "9", Role: model.ParticipantAttendee}}, want: "user with ID 9 not found"},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			payload := dto.BookingRequestDto{
+				BoookingDetails: []model.BookingDetail{{Rooms: model.Room{Id: "5"}, BookingDate: bookingStart, BookingDateEnd: bookingEnd}},
+				Participants:    tt.participants,
+			}
+			suite.uum.On("FindById", mock.Anything, userId).Return(mockUser, nil)
+			suite.uum.On("FindById", mock.Anything, "2").Return(model.User{Id: "2"}, nil)
+			suite.uum.On("FindById", mock.Anything, "9").Return(model.User{}, sql.ErrNoRows)
+
+			_, err := suite.bu.RegisterNewBooking(context.Background(), payload, userId)
+
+			assert.EqualError(suite.T(), err, tt.want)
+			suite.brm.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
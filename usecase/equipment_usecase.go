@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"final-project-booking-room/model"
+	"final-project-booking-room/repository"
+	"fmt"
+	"strings"
+)
+
+type EquipmentUseCase interface {
+	RegisterNewEquipment(ctx context.Context, payload model.Equipment) (model.Equipment, error)
+	FindById(ctx context.Context, id string) (model.Equipment, error)
+	ViewAllEquipment(ctx context.Context, siteId string) ([]model.Equipment, error)
+	UpdateById(ctx context.Context, id string, payload model.Equipment) (model.Equipment, error)
+	DeleteById(ctx context.Context, id string) error
+}
+
+type equipmentUseCase struct {
+	repo repository.EquipmentRepository
+}
+
+func validateEquipment(payload model.Equipment) error {
+	if strings.TrimSpace(payload.Code) == "" || strings.TrimSpace(payload.Name) == "" {
+		return errors.New("code and name are required")
+	}
+	if payload.Quantity < 0 {
+		return errors.New("quantity can't be negative")
+	}
+	return nil
+}
+
+// RegisterNewEquipment implements EquipmentUseCase.
+func (e *equipmentUseCase) RegisterNewEquipment(ctx context.Context, payload model.Equipment) (model.Equipment, error) {
+	if strings.TrimSpace(payload.SiteId) == "" {
+		return model.Equipment{}, errors.New("siteId is required")
+	}
+	if err := validateEquipment(payload); err != nil {
+		return model.Equipment{}, err
+	}
+
+	equipment, err := e.repo.Create(ctx, payload)
+	if err != nil {
+		return model.Equipment{}, fmt.Errorf("failed to create equipment : %s", err)
+	}
+	return equipment, nil
+}
+
+// FindById implements EquipmentUseCase.
+func (e *equipmentUseCase) FindById(ctx context.Context, id string) (model.Equipment, error) {
+	equipment, err := e.repo.Get(ctx, id)
+	if err != nil {
+		return model.Equipment{}, fmt.Errorf("equipment with id %s not found", id)
+	}
+	return equipment, nil
+}
+
+// ViewAllEquipment implements EquipmentUseCase.
+func (e *equipmentUseCase) ViewAllEquipment(ctx context.Context, siteId string) ([]model.Equipment, error) {
+	return e.repo.GetAll(ctx, siteId)
+}
+
+// UpdateById implements EquipmentUseCase. Site tidak bisa dipindah karena stok dihitung per site.
+func (e *equipmentUseCase) UpdateById(ctx context.Context, id string, payload model.Equipment) (model.Equipment, error) {
+	equipment, err := e.repo.Get(ctx, id)
+	if err != nil {
+		return model.Equipment{}, fmt.Errorf("equipment with id %s not found", id)
+	}
+
+	if strings.TrimSpace(payload.Code) != "" {
+		equipment.Code = payload.Code
+	}
+	if strings.TrimSpace(payload.Name) != "" {
+		equipment.Name = payload.Name
+	}
+	equipment.Quantity = payload.Quantity
+	if err := validateEquipment(equipment); err != nil {
+		return model.Equipment{}, err
+	}
+
+	return e.repo.Update(ctx, id, equipment)
+}
+
+// DeleteById implements EquipmentUseCase.
+func (e *equipmentUseCase) DeleteById(ctx context.Context, id string) error {
+	if _, err := e.repo.Get(ctx, id); err != nil {
+		return fmt.Errorf("equipment with id %s not found", id)
+	}
+	if err := e.repo.Delete(ctx, id); err != nil {
+		return errors.New("equipment has been reserved by bookings, set its quantity to 0 instead")
+	}
+	return nil
+}
+
+func NewEquipmentUseCase(repo repository.EquipmentRepository) EquipmentUseCase {
+	return &equipmentUseCase{repo: repo}
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"final-project-booking-room/model"
+	repositorymock "final-project-booking-room/unit-test/mock-test/repository-mock"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type EquipmentUsecaseTestSuite struct {
+	suite.Suite
+	erm *repositorymock.EquipmentRepositoryMock
+	eu  EquipmentUseCase
+}
+
+func (suite *EquipmentUsecaseTestSuite) SetupTest() {
+	suite.erm = new(repositorymock.EquipmentRepositoryMock)
+	suite.eu = NewEquipmentUseCase(suite.erm)
+}
+
+func TestEquipmentUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(EquipmentUsecaseTestSuite))
+}
+
+var mockEquipment = model.Equipment{
+	Id:       "1",
+	SiteId:   "s1",
+	Code:     "projector-portable",
+	Name:     "Projector Portable",
+	Quantity: 3,
+}
+
+func (suite *EquipmentUsecaseTestSuite) TestRegisterNewEquipment_Success() {
+	suite.erm.On("Create", mock.Anything, mockEquipment).Return(mockEquipment, nil)
+	actual, err := suite.eu.RegisterNewEquipment(context.Background(), mockEquipment)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), mockEquipment, actual)
+}
+
+func (suite *EquipmentUsecaseTestSuite) TestRegisterNewEquipment_NegativeQuantity() {
+	payload := mockEquipment
+	payload.Quantity = -1
+	_, err := suite.eu.RegisterNewEquipment(context.Background(), payload)
+	assert.EqualError(suite.T(), err, "quantity can't be negative")
+	suite.erm.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *EquipmentUsecaseTestSuite) TestUpdateById_Success() {
+	expected := mockEquipment
+	expected.Quantity = 0
+	suite.erm.On("Get", mock.Anything, mockEquipment.Id).Return(mockEquipment, nil)
+	suite.erm.On("Update", mock.Anything, mockEquipment.Id, expected).Return(expected, nil)
+
+	actual, err := suite.eu.UpdateById(context.Background(), mockEquipment.Id, model.Equipment{Quantity: 0})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expected, actual)
+}
+
+func (suite *EquipmentUsecaseTestSuite) TestDeleteById_Reserved() {
+	suite.erm.On("Get", mock.Anything, mockEquipment.Id).Return(mockEquipment, nil)
+	suite.erm.On("Delete", mock.Anything, mockEquipment.Id).Return(errors.New("violates foreign key constraint"))
+
+	err := suite.eu.DeleteById(context.Background(), mockEquipment.Id)
+	assert.EqualError(suite.T(), err, "equipment has been reserved by bookings, set its quantity to 0 instead")
+}
@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"final-project-booking-room/model"
+	"final-project-booking-room/repository"
+	"fmt"
+	"html"
+	"math"
+	"strings"
+	"time"
+)
+
+type FloorMapUseCase interface {
+	SetRoomShape(ctx context.Context, roomId string, shape model.RoomShape) (model.RoomShape, error)
+	DeleteRoomShape(ctx context.Context, roomId string) error
+	GetFloorMap(ctx context.Context, floorId string) (model.FloorMap, error)
+	GetFloorMapSVG(ctx context.Context, floorId string) ([]byte, error)
+}
+
+type floorMapUseCase struct {
+	repo       repository.FloorMapRepository
+	roomUC     RoomUseCase
+	locationUC LocationUseCase
+}
+
+// availabilityColors adalah warna isi ruangan di SVG untuk tiap status ketersediaan
+var availabilityColors = map[string]string{
+	"available": "#4caf50",
+	"pending":   "#ffb300",
+	"booked":    "#e53935",
+}
+
+func validateShape(shape model.RoomShape, floor model.Floor) error {
+	switch shape.Type {
+	case model.ShapePoint:
+		if len(shape.Points) != 1 {
+			return errors.New("point shape must have exactly 1 point")
+		}
+	case model.ShapePolygon:
+		if len(shape.Points) < 3 {
+			return errors.New("polygon shape must have at least 3 points")
+		}
+	default:
+		return fmt.Errorf(`shape type must be "point" or "polygon", not %s`, shape.Type)
+	}
+
+	for _, p := range shape.Points {
+		if math.IsNaN(p.X) || math.IsNaN(p.Y) || p.X < 0 || p.Y < 0 || p.X > float64(floor.MapWidth) || p.Y > float64(floor.MapHeight) {
+			return fmt.Errorf("point (%g, %g) is outside the floor map %dx%d", p.X, p.Y, floor.MapWidth, floor.MapHeight)
+		}
+	}
+	return nil
+}
+
+func (f *floorMapUseCase) SetRoomShape(ctx context.Context, roomId string, shape model.RoomShape) (model.RoomShape, error) {
+	room, err := f.roomUC.FindById(ctx, roomId)
+	if err != nil {
+		return model.RoomShape{}, err
+	}
+	if room.FloorId == "" {
+		return model.RoomShape{}, fmt.Errorf("room with id %s is not placed on a floor yet", roomId)
+	}
+
+	floor, err := f.locationUC.FindFloorById(ctx, room.FloorId)
+	if err != nil {
+		return model.RoomShape{}, err
+	}
+	if err := validateShape(shape, floor); err != nil {
+		return model.RoomShape{}, err
+	}
+
+	if err := f.repo.SetRoomShape(ctx, roomId, shape); err != nil {
+		return model.RoomShape{}, err
+	}
+	return shape, nil
+}
+
+func (f *floorMapUseCase) DeleteRoomShape(ctx context.Context, roomId string) error {
+	if _, err := f.roomUC.FindById(ctx, roomId); err != nil {
+		return err
+	}
+	return f.repo.DeleteRoomShape(ctx, roomId)
+}
+
+func (f *floorMapUseCase) GetFloorMap(ctx context.Context, floorId string) (model.FloorMap, error) {
+	floor, err := f.locationUC.FindFloorById(ctx, floorId)
+	if err != nil {
+		return model.FloorMap{}, err
+	}
+
+	now := time.Now().UTC()
+	rooms, err := f.repo.GetFloorRooms(ctx, floorId, now)
+	if err != nil {
+		return model.FloorMap{}, err
+	}
+
+	return model.FloorMap{Floor: floor, Rooms: rooms, GeneratedAt: now}, nil
+}
+
+func (f *floorMapUseCase) GetFloorMapSVG(ctx context.Context, floorId string) ([]byte, error) {
+	floorMap, err := f.GetFloorMap(ctx, floorId)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(renderFloorMapSVG(floorMap)), nil
+}
+
+// renderFloorMapSVG menggambar denah dengan koordinat floor apa adanya (viewBox), ruangan yang belum punya shape dilewati
+func renderFloorMapSVG(floorMap model.FloorMap) string {
+	width, height := floorMap.Floor.MapWidth, floorMap.Floor.MapHeight
+	radius := float64(min(width, height)) / 50
+	fontSize := float64(min(width, height)) / 40
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" width="%d" height="%d">`, width, height, width, height)
+	fmt.Fprintf(&sb, `<title>%s</title>`, html.EscapeString(floorMap.Floor.Name))
+	fmt.Fprintf(&sb, `<rect x="0" y="0" width="%d" height="%d" fill="#fafafa" stroke="#9e9e9e"/>`, width, height)
+
+	for _, room := range floorMap.Rooms {
+		if room.Shape.IsEmpty() {
+			continue
+		}
+
+		color, ok := availabilityColors[room.Availability]
+		if !ok {
+			color = "#9e9e9e"
+		}
+		fmt.Fprintf(&sb, `<g data-room-id="%s" data-availability="%s">`, html.EscapeString(room.RoomId), html.EscapeString(room.Availability))
+		fmt.Fprintf(&sb, `<title>%s (%s)</title>`, html.EscapeString(room.Name), html.EscapeString(room.Availability))
+
+		if room.Shape.Type == model.ShapePoint {
+			p := room.Shape.Points[0]
+			fmt.Fprintf(&sb, `<circle cx="%g" cy="%g" r="%g" fill="%s" stroke="#424242"/>`, p.X, p.Y, radius, color)
+		} else {
+			points := make([]string, len(room.Shape.Points))
+			for i, p := range room.Shape.Points {
+				points[i] = fmt.Sprintf("%g,%g", p.X, p.Y)
+			}
+			fmt.Fprintf(&sb, `<polygon points="%s" fill="%s" fill-opacity="0.6" stroke="#424242"/>`, strings.Join(points, " "), color)
+		}
+
+		// label titik diletakkan di bawah lingkaran supaya tidak menutupinya
+		center := room.Shape.Center()
+		if room.Shape.Type == model.ShapePoint {
+			center.Y += radius * 2
+		}
+		fmt.Fprintf(&sb, `<text x="%g" y="%g" font-size="%g" text-anchor="middle" dominant-baseline="middle">%s</text>`,
+			center.X, center.Y, fontSize, html.EscapeString(room.Name))
+		sb.WriteString(`</g>`)
+	}
+
+	sb.WriteString(`</svg>`)
+	return sb.String()
+}
+
+func NewFloorMapUseCase(repo repository.FloorMapRepository, roomUC RoomUseCase, locationUC LocationUseCase) FloorMapUseCase {
+	return &floorMapUseCase{repo: repo, roomUC: roomUC, locationUC: locationUC}
+}
@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"final-project-booking-room/model"
+	repositorymock "final-project-booking-room/unit-test/mock-test/repository-mock"
+	usecasemock "final-project-booking-room/unit-test/mock-test/usecase-mock"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type FloorMapUsecaseTestSuite struct {
+	suite.Suite
+	fmrm *repositorymock.FloorMapRepositoryMock
+	rum  *usecasemock.RoomUseCaseMock
+	lum  *usecasemock.LocationUseCaseMock
+	fmu  FloorMapUseCase
+}
+
+func (suite *FloorMapUsecaseTestSuite) SetupTest() {
+	suite.fmrm = new(repositorymock.FloorMapRepositoryMock)
+	suite.rum = new(usecasemock.RoomUseCaseMock)
+	suite.lum = new(usecasemock.LocationUseCaseMock)
+	suite.fmu = NewFloorMapUseCase(suite.fmrm, suite.rum, suite.lum)
+}
+
+func TestFloorMapUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(FloorMapUsecaseTestSuite))
+}
+
+var mockFloor = model.Floor{Id: "f1", BuildingId: "b1", Name: "Lantai 3", Level: 3, MapWidth: 800, MapHeight: 600}
+
+func (suite *FloorMapUsecaseTestSuite) TestSetRoomShape_Polygon() {
+	shape := model.RoomShape{Type: model.ShapePolygon, Points: []model.MapPoint{{X: 10, Y: 10}, {X: 200, Y: 10}, {X: 200, Y: 150}}}
+	suite.rum.On("FindById", mock.Anything, "1").Return(model.Room{Id: "1", FloorId: "f1"}, nil)
+	suite.lum.On("FindFloorById", mock.Anything, "f1").Return(mockFloor, nil)
+	suite.fmrm.On("SetRoomShape", mock.Anything, "1", shape).Return(nil)
+
+	actual, err := suite.fmu.SetRoomShape(context.Background(), "1", shape)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), shape, actual)
+}
+
+func (suite *FloorMapUsecaseTestSuite) TestSetRoomShape_OutsideFloor() {
+	shape := model.RoomShape{Type: model.ShapePoint, Points: []model.MapPoint{{X: 900, Y: 10}}}
+	suite.rum.On("FindById", mock.Anything, "1").Return(model.Room{Id: "1", FloorId: "f1"}, nil)
+	suite.lum.On("FindFloorById", mock.Anything, "f1").Return(mockFloor, nil)
+
+	_, err := suite.fmu.SetRoomShape(context.Background(), "1", shape)
+
+	assert.EqualError(suite.T(), err, "point (900, 10) is outside the floor map 800x600")
+	suite.fmrm.AssertNotCalled(suite.T(), "SetRoomShape", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *FloorMapUsecaseTestSuite) TestSetRoomShape_RoomWithoutFloor() {
+	suite.rum.On("FindById", mock.Anything, "1").Return(model.Room{Id: "1"}, nil)
+
+	_, err := suite.fmu.SetRoomShape(context.Background(), "1", model.RoomShape{Type: model.ShapePoint, Points: []model.MapPoint{{X: 1, Y: 1}}})
+
+	assert.EqualError(suite.T(), err, "room with id 1 is not placed on a floor yet")
+}
+
+func (suite *FloorMapUsecaseTestSuite) TestGetFloorMapSVG() {
+	suite.lum.On("FindFloorById", mock.Anything, "f1").Return(mockFloor, nil)
+	suite.fmrm.On("GetFloorRooms", mock.Anything, "f1", mock.Anything).Return([]model.FloorMapRoom{
+		{RoomId: "1", Name: "R&D", Availability: "booked",
+			Shape: model.RoomShape{Type: model.ShapePolygon, Points: []model.MapPoint{{X: 0, Y: 0}, {X: 100, Y: 0}, {X: 100, Y: 100}, {X: 0, Y: 100}}}},
+		{RoomId: "2", Name: "Huddle", Availability: "available",
+			Shape: model.RoomShape{Type: model.ShapePoint, Points: []model.MapPoint{{X: 300, Y: 200}}}},
+		{RoomId: "3", Name: "Belum ditempatkan", Availability: "available"},
+	}, nil)
+
+	svg, err := suite.fmu.GetFloorMapSVG(context.Background(), "f1")
+
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), string(svg), `viewBox="0 0 800 600"`)
+	assert.Contains(suite.T(), string(svg), `<polygon points="0,0 100,0 100,100 0,100" fill="#e53935"`)
+	assert.Contains(suite.T(), string(svg), `<circle cx="300" cy="200" r="12" fill="#4caf50"`)
+	assert.Contains(suite.T(), string(svg), `>R&amp;D</text>`)
+	assert.NotContains(suite.T(), string(svg), "Belum ditempatkan")
+}
@@ -0,0 +1,243 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"final-project-booking-room/model"
+	"final-project-booking-room/repository"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type LocationUseCase interface {
+	RegisterNewSite(ctx context.Context, payload model.Site) (model.Site, error)
+	FindSiteById(ctx context.Context, id string) (model.Site, error)
+	ViewAllSites(ctx context.Context) ([]model.Site, error)
+	UpdateSiteById(ctx context.Context, id string, payload model.Site) (model.Site, error)
+	DeleteSiteById(ctx context.Context, id string) error
+
+	RegisterNewBuilding(ctx context.Context, payload model.Building) (model.Building, error)
+	FindBuildingById(ctx context.Context, id string) (model.Building, error)
+	ViewAllBuildings(ctx context.Context, siteId string) ([]model.Building, error)
+	UpdateBuildingById(ctx context.Context, id string, payload model.Building) (model.Building, error)
+	DeleteBuildingById(ctx context.Context, id string) error
+
+	RegisterNewFloor(ctx context.Context, payload model.Floor) (model.Floor, error)
+	FindFloorById(ctx context.Context, id string) (model.Floor, error)
+	ViewAllFloors(ctx context.Context, buildingId string) ([]model.Floor, error)
+	UpdateFloorById(ctx context.Context, id string, payload model.Floor) (model.Floor, error)
+	DeleteFloorById(ctx context.Context, id string) error
+}
+
+type locationUseCase struct {
+	repo repository.LocationRepository
+}
+
+// validateTimezone memastikan timezone adalah nama IANA yang dikenal, misalnya Asia/Jakarta
+func validateTimezone(timezone string) error {
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return fmt.Errorf("invalid timezone %s", timezone)
+	}
+	return nil
+}
+
+func validateSite(payload model.Site) error {
+	if strings.TrimSpace(payload.Name) == "" {
+		return errors.New("name is required")
+	}
+	if strings.TrimSpace(payload.Timezone) == "" {
+		return errors.New("timezone is required")
+	}
+	return validateTimezone(payload.Timezone)
+}
+
+func (l *locationUseCase) RegisterNewSite(ctx context.Context, payload model.Site) (model.Site, error) {
+	if err := validateSite(payload); err != nil {
+		return model.Site{}, err
+	}
+	return l.repo.CreateSite(ctx, payload)
+}
+
+func (l *locationUseCase) FindSiteById(ctx context.Context, id string) (model.Site, error) {
+	site, err := l.repo.GetSite(ctx, id)
+	if err != nil {
+		return model.Site{}, fmt.Errorf("site with id %s not found", id)
+	}
+	return site, nil
+}
+
+func (l *locationUseCase) ViewAllSites(ctx context.Context) ([]model.Site, error) {
+	return l.repo.GetAllSites(ctx)
+}
+
+func (l *locationUseCase) UpdateSiteById(ctx context.Context, id string, payload model.Site) (model.Site, error) {
+	site, err := l.FindSiteById(ctx, id)
+	if err != nil {
+		return model.Site{}, err
+	}
+
+	if strings.TrimSpace(payload.Name) != "" {
+		site.Name = payload.Name
+	}
+	if strings.TrimSpace(payload.Address) != "" {
+		site.Address = payload.Address
+	}
+	if strings.TrimSpace(payload.Timezone) != "" {
+		site.Timezone = payload.Timezone
+	}
+	if err := validateSite(site); err != nil {
+		return model.Site{}, err
+	}
+
+	return l.repo.UpdateSite(ctx, id, site)
+}
+
+func (l *locationUseCase) DeleteSiteById(ctx context.Context, id string) error {
+	if _, err := l.FindSiteById(ctx, id); err != nil {
+		return err
+	}
+	return l.repo.DeleteSite(ctx, id)
+}
+
+func (l *locationUseCase) validateBuilding(ctx context.Context, payload model.Building) error {
+	if strings.TrimSpace(payload.Name) == "" {
+		return errors.New("name is required")
+	}
+	// timezone building boleh kosong, berarti mengikuti timezone site
+	if payload.Timezone != "" {
+		if err := validateTimezone(payload.Timezone); err != nil {
+			return err
+		}
+	}
+	_, err := l.FindSiteById(ctx, payload.SiteId)
+	return err
+}
+
+func (l *locationUseCase) RegisterNewBuilding(ctx context.Context, payload model.Building) (model.Building, error) {
+	if err := l.validateBuilding(ctx, payload); err != nil {
+		return model.Building{}, err
+	}
+	return l.repo.CreateBuilding(ctx, payload)
+}
+
+func (l *locationUseCase) FindBuildingById(ctx context.Context, id string) (model.Building, error) {
+	building, err := l.repo.GetBuilding(ctx, id)
+	if err != nil {
+		return model.Building{}, fmt.Errorf("building with id %s not found", id)
+	}
+	return building, nil
+}
+
+func (l *locationUseCase) ViewAllBuildings(ctx context.Context, siteId string) ([]model.Building, error) {
+	return l.repo.GetAllBuildings(ctx, siteId)
+}
+
+func (l *locationUseCase) UpdateBuildingById(ctx context.Context, id string, payload model.Building) (model.Building, error) {
+	building, err := l.FindBuildingById(ctx, id)
+	if err != nil {
+		return model.Building{}, err
+	}
+
+	if strings.TrimSpace(payload.SiteId) != "" {
+		building.SiteId = payload.SiteId
+	}
+	if strings.TrimSpace(payload.Name) != "" {
+		building.Name = payload.Name
+	}
+	if strings.TrimSpace(payload.Address) != "" {
+		building.Address = payload.Address
+	}
+	if strings.TrimSpace(payload.Timezone) != "" {
+		building.Timezone = payload.Timezone
+	}
+	if err := l.validateBuilding(ctx, building); err != nil {
+		return model.Building{}, err
+	}
+
+	return l.repo.UpdateBuilding(ctx, id, building)
+}
+
+func (l *locationUseCase) DeleteBuildingById(ctx context.Context, id string) error {
+	if _, err := l.FindBuildingById(ctx, id); err != nil {
+		return err
+	}
+	return l.repo.DeleteBuilding(ctx, id)
+}
+
+func (l *locationUseCase) validateFloor(ctx context.Context, payload model.Floor) error {
+	if strings.TrimSpace(payload.Name) == "" {
+		return errors.New("name is required")
+	}
+	if payload.MapWidth < 0 || payload.MapHeight < 0 {
+		return errors.New("mapWidth and mapHeight can't be negative")
+	}
+	_, err := l.FindBuildingById(ctx, payload.BuildingId)
+	return err
+}
+
+// defaultMapSize dipakai jika admin tidak menentukan ukuran bidang koordinat denah
+const defaultMapSize = 1000
+
+func (l *locationUseCase) RegisterNewFloor(ctx context.Context, payload model.Floor) (model.Floor, error) {
+	if payload.MapWidth == 0 {
+		payload.MapWidth = defaultMapSize
+	}
+	if payload.MapHeight == 0 {
+		payload.MapHeight = defaultMapSize
+	}
+	if err := l.validateFloor(ctx, payload); err != nil {
+		return model.Floor{}, err
+	}
+	return l.repo.CreateFloor(ctx, payload)
+}
+
+func (l *locationUseCase) FindFloorById(ctx context.Context, id string) (model.Floor, error) {
+	floor, err := l.repo.GetFloor(ctx, id)
+	if err != nil {
+		return model.Floor{}, fmt.Errorf("floor with id %s not found", id)
+	}
+	return floor, nil
+}
+
+func (l *locationUseCase) ViewAllFloors(ctx context.Context, buildingId string) ([]model.Floor, error) {
+	return l.repo.GetAllFloors(ctx, buildingId)
+}
+
+// UpdateFloorById mengganti level apa adanya karena level 0 (lantai dasar) adalah nilai yang valid
+func (l *locationUseCase) UpdateFloorById(ctx context.Context, id string, payload model.Floor) (model.Floor, error) {
+	floor, err := l.FindFloorById(ctx, id)
+	if err != nil {
+		return model.Floor{}, err
+	}
+
+	if strings.TrimSpace(payload.BuildingId) != "" {
+		floor.BuildingId = payload.BuildingId
+	}
+	if strings.TrimSpace(payload.Name) != "" {
+		floor.Name = payload.Name
+	}
+	floor.Level = payload.Level
+	if payload.MapWidth != 0 {
+		floor.MapWidth = payload.MapWidth
+	}
+	if payload.MapHeight != 0 {
+		floor.MapHeight = payload.MapHeight
+	}
+	if err := l.validateFloor(ctx, floor); err != nil {
+		return model.Floor{}, err
+	}
+
+	return l.repo.UpdateFloor(ctx, id, floor)
+}
+
+func (l *locationUseCase) DeleteFloorById(ctx context.Context, id string) error {
+	if _, err := l.FindFloorById(ctx, id); err != nil {
+		return err
+	}
+	return l.repo.DeleteFloor(ctx, id)
+}
+
+func NewLocationUseCase(repo repository.LocationRepository) LocationUseCase {
+	return &locationUseCase{repo: repo}
+}
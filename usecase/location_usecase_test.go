@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"final-project-booking-room/model"
+	repositorymock "final-project-booking-room/unit-test/mock-test/repository-mock"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type LocationUsecaseTestSuite struct {
+	suite.Suite
+	lrm *repositorymock.LocationRepositoryMock
+	lu  LocationUseCase
+}
+
+func (suite *LocationUsecaseTestSuite) SetupTest() {
+	suite.lrm = new(repositorymock.LocationRepositoryMock)
+	suite.lu = NewLocationUseCase(suite.lrm)
+}
+
+func TestLocationUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(LocationUsecaseTestSuite))
+}
+
+var mockSite = model.Site{Id: "s1", Name: "Jakarta HQ", Address: "Jl. Sudirman 1", Timezone: "Asia/Jakarta"}
+
+func (suite *LocationUsecaseTestSuite) TestRegisterNewSite_Success() {
+	suite.lrm.On("CreateSite", mock.Anything, mockSite).Return(mockSite, nil)
+	actual, err := suite.lu.RegisterNewSite(context.Background(), mockSite)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), mockSite, actual)
+}
+
+func (suite *LocationUsecaseTestSuite) TestRegisterNewSite_InvalidTimezone() {
+	payload := mockSite
+	payload.Timezone = "Jakarta"
+	_, err := suite.lu.RegisterNewSite(context.Background(), payload)
+	assert.EqualError(suite.T(), err, "invalid timezone Jakarta")
+	suite.lrm.AssertNotCalled(suite.T(), "CreateSite", mock.Anything, mock.Anything)
+}
+
+func (suite *LocationUsecaseTestSuite) TestRegisterNewBuilding_SiteNotFound() {
+	suite.lrm.On("GetSite", mock.Anything, "unknown").Return(model.Site{}, errors.New("sql: no rows in result set"))
+	_, err := suite.lu.RegisterNewBuilding(context.Background(), model.Building{SiteId: "unknown", Name: "Tower A"})
+	assert.EqualError(suite.T(), err, "site with id unknown not found")
+	suite.lrm.AssertNotCalled(suite.T(), "CreateBuilding", mock.Anything, mock.Anything)
+}
+
+func (suite *LocationUsecaseTestSuite) TestUpdateFloorById_GroundLevel() {
+	floor := model.Floor{Id: "f1", BuildingId: "b1", Name: "Lantai 2", Level: 2}
+	expected := floor
+	expected.Name = "Lobby"
+	expected.Level = 0
+	suite.lrm.On("GetFloor", mock.Anything, "f1").Return(floor, nil)
+	suite.lrm.On("GetBuilding", mock.Anything, "b1").Return(model.Building{Id: "b1", SiteId: "s1", Name: "Tower A"}, nil)
+	suite.lrm.On("UpdateFloor", mock.Anything, "f1", expected).Return(expected, nil)
+
+	actual, err := suite.lu.UpdateFloorById(context.Background(), "f1", model.Floor{Name: "Lobby", Level: 0})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expected, actual)
+}
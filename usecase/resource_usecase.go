@@ -0,0 +1,283 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"final-project-booking-room/model"
+	"final-project-booking-room/model/dto"
+	"final-project-booking-room/repository"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type ResourceUseCase interface {
+	ViewResourceTypes(ctx context.Context) ([]model.ResourceType, error)
+	RegisterNeighborhood(ctx context.Context, payload model.Neighborhood) (model.Neighborhood, error)
+	ViewNeighborhoods(ctx context.Context, floorId string) ([]model.Neighborhood, error)
+	RegisterNewResource(ctx context.Context, payload model.Resource) (model.Resource, error)
+	FindById(ctx context.Context, id string) (model.Resource, error)
+	ListResources(ctx context.Context, filter dto.ResourceFilter) ([]model.Resource, dto.Paging, error)
+	UpdateById(ctx context.Context, id string, payload model.Resource) (model.Resource, error)
+	ArchiveById(ctx context.Context, id string) (int, error)
+	BookResource(ctx context.Context, id string, userId string, payload dto.ResourceBookingRequest) (model.ResourceBooking, error)
+	ViewMyBookings(ctx context.Context, userId string) ([]model.ResourceBooking, error)
+	CancelBooking(ctx context.Context, id string, userId string) error
+}
+
+type resourceUseCase struct {
+	repo   repository.ResourceRepository
+	userUC UserUseCase
+}
+
+// ViewResourceTypes implements ResourceUseCase.
+func (r *resourceUseCase) ViewResourceTypes(ctx context.Context) ([]model.ResourceType, error) {
+	return r.repo.ListTypes(ctx)
+}
+
+// RegisterNeighborhood implements ResourceUseCase.
+func (r *resourceUseCase) RegisterNeighborhood(ctx context.Context, payload model.Neighborhood) (model.Neighborhood, error) {
+	payload.Name = strings.TrimSpace(payload.Name)
+	payload.Division = strings.TrimSpace(payload.Division)
+	if strings.TrimSpace(payload.FloorId) == "" || payload.Name == "" {
+		return model.Neighborhood{}, errors.New("floorId and name are required")
+	}
+
+	neighborhood, err := r.repo.CreateNeighborhood(ctx, payload)
+	if err != nil {
+		return model.Neighborhood{}, fmt.Errorf("failed to create neighborhood: %v", err)
+	}
+	return neighborhood, nil
+}
+
+// ViewNeighborhoods implements ResourceUseCase.
+func (r *resourceUseCase) ViewNeighborhoods(ctx context.Context, floorId string) ([]model.Neighborhood, error) {
+	return r.repo.ListNeighborhoods(ctx, floorId)
+}
+
+// validateResource mengecek kode, nama, atribut sesuai tipe resource dan neighborhood yang harus berada di floor resource.
+// Jika floorId kosong, floor diambil dari neighborhood.
+func (r *resourceUseCase) validateResource(ctx context.Context, payload model.Resource) (model.Resource, error) {
+	payload.Code = strings.TrimSpace(payload.Code)
+	payload.Name = strings.TrimSpace(payload.Name)
+	if payload.Code == "" || payload.Name == "" {
+		return model.Resource{}, errors.New("code and name are required")
+	}
+
+	resourceType, err := r.repo.GetType(ctx, payload.ResourceType)
+	if err != nil {
+		return model.Resource{}, fmt.Errorf("resource type %s not found", payload.ResourceType)
+	}
+	for name := range payload.Attributes {
+		if !resourceType.AllowsAttribute(name) {
+			return model.Resource{}, fmt.Errorf("attribute %s is not allowed for resource type %s", name, resourceType.Code)
+		}
+	}
+
+	if payload.NeighborhoodId != "" {
+		neighborhood, err := r.repo.GetNeighborhood(ctx, payload.NeighborhoodId)
+		if err != nil {
+			return model.Resource{}, fmt.Errorf("neighborhood with id %s not found", payload.NeighborhoodId)
+		}
+		if payload.FloorId == "" {
+			payload.FloorId = neighborhood.FloorId
+		}
+		if neighborhood.FloorId != payload.FloorId {
+			return model.Resource{}, fmt.Errorf("neighborhood with id %s is not on floor %s", payload.NeighborhoodId, payload.FloorId)
+		}
+	}
+	return payload, nil
+}
+
+// RegisterNewResource implements ResourceUseCase.
+func (r *resourceUseCase) RegisterNewResource(ctx context.Context, payload model.Resource) (model.Resource, error) {
+	payload, err := r.validateResource(ctx, payload)
+	if err != nil {
+		return model.Resource{}, err
+	}
+
+	resource, err := r.repo.Create(ctx, payload)
+	if err != nil {
+		return model.Resource{}, fmt.Errorf("failed to create resource: %v", err)
+	}
+	return resource, nil
+}
+
+// FindById implements ResourceUseCase.
+func (r *resourceUseCase) FindById(ctx context.Context, id string) (model.Resource, error) {
+	resource, err := r.repo.Get(ctx, id)
+	if err != nil {
+		return model.Resource{}, fmt.Errorf("resource with id %s not found", id)
+	}
+	return resource, nil
+}
+
+// ListResources implements ResourceUseCase.
+func (r *resourceUseCase) ListResources(ctx context.Context, filter dto.ResourceFilter) ([]model.Resource, dto.Paging, error) {
+	if filter.AvailableOn != "" {
+		if _, err := time.Parse("2006-01-02", filter.AvailableOn); err != nil {
+			return nil, dto.Paging{}, errors.New("availableOn must use format YYYY-MM-DD")
+		}
+	}
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.Size < 1 {
+		filter.Size = 10
+	}
+	if filter.Size > 100 {
+		filter.Size = 100
+	}
+
+	resources, paging, err := r.repo.List(ctx, filter)
+	if err != nil {
+		return nil, dto.Paging{}, fmt.Errorf("failed to get resources: %v", err)
+	}
+	return resources, paging, nil
+}
+
+// UpdateById implements ResourceUseCase, field kosong tidak diubah dan tipe resource tidak bisa diganti
+func (r *resourceUseCase) UpdateById(ctx context.Context, id string, payload model.Resource) (model.Resource, error) {
+	resource, err := r.repo.Get(ctx, id)
+	if err != nil {
+		return model.Resource{}, fmt.Errorf("resource with id %s not found", id)
+	}
+
+	if strings.TrimSpace(payload.Code) != "" {
+		resource.Code = payload.Code
+	}
+	if strings.TrimSpace(payload.Name) != "" {
+		resource.Name = payload.Name
+	}
+	if payload.FloorId != "" {
+		resource.FloorId = payload.FloorId
+		resource.NeighborhoodId = ""
+	}
+	if payload.NeighborhoodId != "" {
+		resource.NeighborhoodId = payload.NeighborhoodId
+	}
+	if payload.Attributes != nil {
+		resource.Attributes = payload.Attributes
+	}
+
+	resource, err = r.validateResource(ctx, resource)
+	if err != nil {
+		return model.Resource{}, err
+	}
+	return r.repo.Update(ctx, id, resource)
+}
+
+// ArchiveById implements ResourceUseCase, mengembalikan jumlah booking yang ikut dibatalkan
+func (r *resourceUseCase) ArchiveById(ctx context.Context, id string) (int, error) {
+	resource, err := r.repo.Get(ctx, id)
+	if err != nil {
+		return 0, fmt.Errorf("resource with id %s not found", id)
+	}
+	if resource.ArchivedAt != nil {
+		return 0, fmt.Errorf("resource with id %s is already archived", id)
+	}
+
+	cancelled, err := r.repo.Archive(ctx, id, time.Now().UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to archive resource: %v", err)
+	}
+	return cancelled, nil
+}
+
+// BookResource implements ResourceUseCase.
+// Tipe daily dibooking satu hari penuh di zona resource, tipe slot memakai start dan end.
+// Desk di neighborhood milik divisi tertentu hanya bisa dibooking user dari divisi itu.
+func (r *resourceUseCase) BookResource(ctx context.Context, id string, userId string, payload dto.ResourceBookingRequest) (model.ResourceBooking, error) {
+	resource, err := r.repo.Get(ctx, id)
+	if err != nil || resource.ArchivedAt != nil {
+		return model.ResourceBooking{}, fmt.Errorf("resource with id %s not found", id)
+	}
+	resourceType, err := r.repo.GetType(ctx, resource.ResourceType)
+	if err != nil {
+		return model.ResourceBooking{}, fmt.Errorf("resource type %s not found", resource.ResourceType)
+	}
+
+	user, err := r.userUC.FindById(ctx, userId)
+	if err != nil {
+		return model.ResourceBooking{}, fmt.Errorf("user with ID %s not found", userId)
+	}
+	if resource.Division != "" && !strings.EqualFold(strings.TrimSpace(user.Divisi), resource.Division) {
+		return model.ResourceBooking{}, fmt.Errorf("resource with id %s is reserved for division %s", id, resource.Division)
+	}
+
+	loc := resource.Location.Zone()
+	now := time.Now()
+	var start, end time.Time
+	switch resourceType.BookingMode {
+	case "daily":
+		start, err = time.ParseInLocation("2006-01-02", payload.Date, loc)
+		if err != nil {
+			return model.ResourceBooking{}, errors.New("date must use format YYYY-MM-DD")
+		}
+		end = start.AddDate(0, 0, 1)
+		if !end.After(now) {
+			return model.ResourceBooking{}, errors.New("date can't be in the past")
+		}
+	default:
+		start, end = payload.Start, payload.End
+		if start.IsZero() || end.IsZero() {
+			return model.ResourceBooking{}, errors.New("start and end are required")
+		}
+		if !end.After(start) {
+			return model.ResourceBooking{}, errors.New("end must be after start")
+		}
+		if start.Before(now) {
+			return model.ResourceBooking{}, errors.New("start can't be in the past")
+		}
+		if resourceType.MaxDurationMinutes > 0 && end.Sub(start) > time.Duration(resourceType.MaxDurationMinutes)*time.Minute {
+			return model.ResourceBooking{}, fmt.Errorf("%s can't be booked longer than %d minutes", resourceType.Name, resourceType.MaxDurationMinutes)
+		}
+	}
+
+	localStart := start.In(loc)
+	dayStart := time.Date(localStart.Year(), localStart.Month(), localStart.Day(), 0, 0, 0, 0, loc)
+	if resourceType.MaxAdvanceDays > 0 {
+		localNow := now.In(loc)
+		today := time.Date(localNow.Year(), localNow.Month(), localNow.Day(), 0, 0, 0, 0, loc)
+		if dayStart.After(today.AddDate(0, 0, resourceType.MaxAdvanceDays)) {
+			return model.ResourceBooking{}, fmt.Errorf("%s can only be booked %d days ahead", resourceType.Name, resourceType.MaxAdvanceDays)
+		}
+	}
+
+	booking, err := r.repo.Book(ctx, model.ResourceBooking{
+		ResourceId:   resource.Id,
+		ResourceType: resource.ResourceType,
+		ResourceName: resource.Name,
+		UserId:       userId,
+		Start:        start,
+		End:          end,
+		Description:  strings.TrimSpace(payload.Description),
+	}, dto.ResourceBookingLimit{DayStart: dayStart, DayEnd: dayStart.AddDate(0, 0, 1), MaxPerUser: resourceType.MaxPerUserPerDay})
+	if errors.Is(err, repository.ErrResourceBooked) {
+		return model.ResourceBooking{}, fmt.Errorf("resource with id %s is already booked at that time", id)
+	}
+	if errors.Is(err, repository.ErrResourceLimitReached) {
+		return model.ResourceBooking{}, fmt.Errorf("%s can only be booked %d time(s) per day", resourceType.Name, resourceType.MaxPerUserPerDay)
+	}
+	if err != nil {
+		return model.ResourceBooking{}, fmt.Errorf("failed to book resource: %v", err)
+	}
+	return booking, nil
+}
+
+// ViewMyBookings implements ResourceUseCase, booking resource milik user yang belum selesai
+func (r *resourceUseCase) ViewMyBookings(ctx context.Context, userId string) ([]model.ResourceBooking, error) {
+	return r.repo.ListBookings(ctx, userId, time.Now().UTC())
+}
+
+// CancelBooking implements ResourceUseCase.
+func (r *resourceUseCase) CancelBooking(ctx context.Context, id string, userId string) error {
+	if err := r.repo.CancelBooking(ctx, id, userId, time.Now().UTC()); err != nil {
+		return fmt.Errorf("resource booking with id %s not found or can't be cancelled", id)
+	}
+	return nil
+}
+
+func NewResourceUseCase(repo repository.ResourceRepository, userUC UserUseCase) ResourceUseCase {
+	return &resourceUseCase{repo: repo, userUC: userUC}
+}
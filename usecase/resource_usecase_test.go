@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"final-project-booking-room/model"
+	"final-project-booking-room/model/dto"
+	"final-project-booking-room/repository"
+	repositorymock "final-project-booking-room/unit-test/mock-test/repository-mock"
+	usecasemock "final-project-booking-room/unit-test/mock-test/usecase-mock"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ResourceUsecaseTestSuite struct {
+	suite.Suite
+	rrm *repositorymock.ResourceRepositoryMock
+	uum *usecasemock.UserUseCaseMock
+	ru  ResourceUseCase
+}
+
+func (suite *ResourceUsecaseTestSuite) SetupTest() {
+	suite.rrm = new(repositorymock.ResourceRepositoryMock)
+	suite.uum = new(usecasemock.UserUseCaseMock)
+	suite.ru = NewResourceUseCase(suite.rrm, suite.uum)
+}
+
+func TestResourceUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(ResourceUsecaseTestSuite))
+}
+
+var (
+	mockDeskType  = model.ResourceType{Code: "desk", Name: "Hot Desk", BookingMode: "daily", MaxAdvanceDays: 14, MaxPerUserPerDay: 1, Attributes: []string{"monitor", "standing"}}
+	mockBoothType = model.ResourceType{Code: "phone_booth", Name: "Phone Booth", BookingMode: "slot", MaxDurationMinutes: 60}
+	mockDesk      = model.Resource{Id: "d1", ResourceType: "desk", Code: "D-101", Name: "Desk 101", FloorId: "f1", NeighborhoodId: "n1", Division: "Finance",
+		Location: model.RoomLocation{FloorId: "f1", Timezone: "Asia/Jakarta"}}
+)
+
+func (suite *ResourceUsecaseTestSuite) TestBookResource_DailyDesk() {
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+	tomorrow := time.Now().In(jakarta).AddDate(0, 0, 1)
+	start := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 0, 0, 0, 0, jakarta)
+	suite.rrm.On("Get", mock.Anything, "d1").Return(mockDesk, nil)
+	suite.rrm.On("GetType", mock.Anything, "desk").Return(mockDeskType, nil)
+	suite.uum.On("FindById", mock.Anything, "u1").Return(model.User{Id: "u1", Divisi: "finance "}, nil)
+	payload := model.ResourceBooking{ResourceId: "d1", ResourceType: "desk", ResourceName: "Desk 101", UserId: "u1", Start: start, End: start.AddDate(0, 0, 1)}
+	suite.rrm.On("Book", mock.Anything, payload, dto.ResourceBookingLimit{DayStart: start, DayEnd: start.AddDate(0, 0, 1), MaxPerUser: 1}).
+		Return(model.ResourceBooking{Id: "bd1", Status: "accept"}, nil)
+
+	booking, err := suite.ru.BookResource(context.Background(), "d1", "u1", dto.ResourceBookingRequest{Date: start.Format("2006-01-02")})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "accept", booking.Status)
+}
+
+func (suite *ResourceUsecaseTestSuite) TestBookResource_OtherDivision() {
+	suite.rrm.On("Get", mock.Anything, "d1").Return(mockDesk, nil)
+	suite.rrm.On("GetType", mock.Anything, "desk").Return(mockDeskType, nil)
+	suite.uum.On("FindById", mock.Anything, "u2").Return(model.User{Id: "u2", Divisi: "HR"}, nil)
+
+	_, err := suite.ru.BookResource(context.Background(), "d1", "u2", dto.ResourceBookingRequest{Date: time.Now().Format("2006-01-02")})
+
+	assert.EqualError(suite.T(), err, "resource with id d1 is reserved for division Finance")
+	suite.rrm.AssertNotCalled(suite.T(), "Book", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ResourceUsecaseTestSuite) TestBookResource_TooFarAhead() {
+	suite.rrm.On("Get", mock.Anything, "d1").Return(mockDesk, nil)
+	suite.rrm.On("GetType", mock.Anything, "desk").Return(mockDeskType, nil)
+	suite.uum.On("FindById", mock.Anything, "u1").Return(model.User{Id: "u1", Divisi: "Finance"}, nil)
+
+	_, err := suite.ru.BookResource(context.Background(), "d1", "u1", dto.ResourceBookingRequest{Date: time.Now().AddDate(0, 0, 20).Format("2006-01-02")})
+
+	assert.EqualError(suite.T(), err, "Hot Desk can only be booked 14 days ahead")
+}
+
+func (suite *ResourceUsecaseTestSuite) TestBookResource_SlotTooLong() {
+	booth := model.Resource{Id: "p1", ResourceType: "phone_booth", Name: "Booth 1"}
+	start := time.Now().Add(time.Hour)
+	suite.rrm.On("Get", mock.Anything, "p1").Return(booth, nil)
+	suite.rrm.On("GetType", mock.Anything, "phone_booth").Return(mockBoothType, nil)
+	suite.uum.On("FindById", mock.Anything, "u1").Return(model.User{Id: "u1"}, nil)
+
+	_, err := suite.ru.BookResource(context.Background(), "p1", "u1", dto.ResourceBookingRequest{Start: start, End: start.Add(90 * time.Minute)})
+
+	assert.EqualError(suite.T(), err, "Phone Booth can't be booked longer than 60 minutes")
+}
+
+func (suite *ResourceUsecaseTestSuite) TestBookResource_AlreadyBooked() {
+	booth := model.Resource{Id: "p1", ResourceType: "phone_booth", Name: "Booth 1"}
+	start := time.Now().Add(time.Hour)
+	suite.rrm.On("Get", mock.Anything, "p1").Return(booth, nil)
+	suite.rrm.On("GetType", mock.Anything, "phone_booth").Return(mockBoothType, nil)
+	suite.uum.On("FindById", mock.Anything, "u1").Return(model.User{Id: "u1"}, nil)
+	suite.rrm.On("Book", mock.Anything, mock.Anything, mock.Anything).Return(model.ResourceBooking{}, repository.ErrResourceBooked)
+
+	_, err := suite.ru.BookResource(context.Background(), "p1", "u1", dto.ResourceBookingRequest{Start: start, End: start.Add(30 * time.Minute)})
+
+	assert.EqualError(suite.T(), err, "resource with id p1 is already booked at that time")
+}
+
+func (suite *ResourceUsecaseTestSuite) TestRegisterNewResource_AttributeNotAllowed() {
+	suite.rrm.On("GetType", mock.Anything, "desk").Return(mockDeskType, nil)
+
+	_, err := suite.ru.RegisterNewResource(context.Background(), model.Resource{ResourceType: "desk", Code: "D-102", Name: "Desk 102", Attributes: map[string]string{"ev_charger": "yes"}})
+
+	assert.EqualError(suite.T(), err, "attribute ev_charger is not allowed for resource type desk")
+	suite.rrm.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *ResourceUsecaseTestSuite) TestRegisterNewResource_FloorFromNeighborhood() {
+	suite.rrm.On("GetType", mock.Anything, "desk").Return(mockDeskType, nil)
+	suite.rrm.On("GetNeighborhood", mock.Anything, "n1").Return(model.Neighborhood{Id: "n1", FloorId: "f1"}, nil)
+	expected := model.Resource{ResourceType: "desk", Code: "D-102", Name: "Desk 102", FloorId: "f1", NeighborhoodId: "n1", Attributes: map[string]string{"monitor": "2"}}
+	suite.rrm.On("Create", mock.Anything, expected).Return(expected, nil)
+
+	resource, err := suite.ru.RegisterNewResource(context.Background(), model.Resource{ResourceType: "desk", Code: " D-102", Name: "Desk 102", NeighborhoodId: "n1", Attributes: map[string]string{"monitor": "2"}})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "f1", resource.FloorId)
+}
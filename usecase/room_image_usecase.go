@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"final-project-booking-room/model"
+	"final-project-booking-room/model/dto"
+	"final-project-booking-room/repository"
+	"final-project-booking-room/utils/common"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+type RoomImageUseCase interface {
+	UploadImage(ctx context.Context, roomId string, upload dto.ImageUpload) (model.RoomImage, error)
+	DeleteImage(ctx context.Context, roomId string, imageId string) error
+}
+
+type roomImageUseCase struct {
+	repo          repository.RoomImageRepository
+	roomUC        RoomUseCase
+	storage       common.FileStorage
+	maxUploadSize int64
+}
+
+// thumbnailSize adalah panjang sisi terpanjang thumbnail dalam piksel
+const thumbnailSize = 320
+
+// allowedImageTypes dicek dari isi file, bukan dari header Content-Type yang dikirim client
+var allowedImageTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+// newStorageName membuat nama file acak supaya nama file asli dari user tidak dipakai sebagai path
+func newStorageName() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func (r *roomImageUseCase) UploadImage(ctx context.Context, roomId string, upload dto.ImageUpload) (model.RoomImage, error) {
+	image := model.RoomImage{RoomId: roomId, Kind: upload.Kind}
+	if !image.IsValidKind() {
+		return model.RoomImage{}, fmt.Errorf(`kind must be "photo" or "floor_plan", not %s`, upload.Kind)
+	}
+	if upload.Size > r.maxUploadSize {
+		return model.RoomImage{}, fmt.Errorf("file is too large, maximum size is %d MB", r.maxUploadSize>>20)
+	}
+
+	if _, err := r.roomUC.FindById(ctx, roomId); err != nil {
+		return model.RoomImage{}, err
+	}
+
+	// baca maksimal satu byte di atas batas supaya ukuran yang tidak jujur di header tetap ketahuan
+	data, err := io.ReadAll(io.LimitReader(upload.File, r.maxUploadSize+1))
+	if err != nil {
+		return model.RoomImage{}, err
+	}
+	if int64(len(data)) > r.maxUploadSize {
+		return model.RoomImage{}, fmt.Errorf("file is too large, maximum size is %d MB", r.maxUploadSize>>20)
+	}
+	if len(data) == 0 {
+		return model.RoomImage{}, errors.New("file is empty")
+	}
+
+	image.ContentType = http.DetectContentType(data)
+	ext, ok := allowedImageTypes[image.ContentType]
+	if !ok {
+		return model.RoomImage{}, fmt.Errorf("file type %s is not allowed, use jpeg, png or gif", image.ContentType)
+	}
+	image.Size = int64(len(data))
+
+	thumbnail, thumbnailType, err := common.GenerateThumbnail(data, thumbnailSize)
+	if err != nil {
+		return model.RoomImage{}, fmt.Errorf("failed to read image: %v", err)
+	}
+
+	name, err := newStorageName()
+	if err != nil {
+		return model.RoomImage{}, err
+	}
+	image.StorageKey = fmt.Sprintf("rooms/%s/%s%s", roomId, name, ext)
+	image.ThumbnailKey = fmt.Sprintf("rooms/%s/%s_thumb%s", roomId, name, allowedImageTypes[thumbnailType])
+
+	image.Url, err = r.storage.Save(ctx, image.StorageKey, image.ContentType, data)
+	if err != nil {
+		return model.RoomImage{}, fmt.Errorf("failed to store image: %v", err)
+	}
+	image.ThumbnailUrl, err = r.storage.Save(ctx, image.ThumbnailKey, thumbnailType, thumbnail)
+	if err != nil {
+		r.removeFiles(ctx, image)
+		return model.RoomImage{}, fmt.Errorf("failed to store thumbnail: %v", err)
+	}
+
+	saved, err := r.repo.Create(ctx, image)
+	if err != nil {
+		r.removeFiles(ctx, image)
+		return model.RoomImage{}, err
+	}
+	return saved, nil
+}
+
+// removeFiles menghapus file gambar dan thumbnail-nya, error diabaikan karena file yatim tidak mengganggu data
+func (r *roomImageUseCase) removeFiles(ctx context.Context, image model.RoomImage) {
+	_ = r.storage.Delete(ctx, image.StorageKey)
+	_ = r.storage.Delete(ctx, image.ThumbnailKey)
+}
+
+func (r *roomImageUseCase) DeleteImage(ctx context.Context, roomId string, imageId string) error {
+	image, err := r.repo.Get(ctx, imageId)
+	if err != nil || image.RoomId != roomId {
+		return fmt.Errorf("image with id %s not found", imageId)
+	}
+
+	if err := r.repo.Delete(ctx, imageId); err != nil {
+		return err
+	}
+	r.removeFiles(ctx, image)
+	return nil
+}
+
+func NewRoomImageUseCase(repo repository.RoomImageRepository, roomUC RoomUseCase, storage common.FileStorage, maxUploadSize int64) RoomImageUseCase {
+	return &roomImageUseCase{repo: repo, roomUC: roomUC, storage: storage, maxUploadSize: maxUploadSize}
+}
@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"final-project-booking-room/model"
+	"final-project-booking-room/model/dto"
+	repositorymock "final-project-booking-room/unit-test/mock-test/repository-mock"
+	usecasemock "final-project-booking-room/unit-test/mock-test/usecase-mock"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type RoomImageUsecaseTestSuite struct {
+	suite.Suite
+	rirm *repositorymock.RoomImageRepositoryMock
+	rum  *usecasemock.RoomUseCaseMock
+	fsm  *usecasemock.FileStorageMock
+	riu  RoomImageUseCase
+}
+
+func (suite *RoomImageUsecaseTestSuite) SetupTest() {
+	suite.rirm = new(repositorymock.RoomImageRepositoryMock)
+	suite.rum = new(usecasemock.RoomUseCaseMock)
+	suite.fsm = new(usecasemock.FileStorageMock)
+	suite.riu = NewRoomImageUseCase(suite.rirm, suite.rum, suite.fsm, 1<<20)
+}
+
+func TestRoomImageUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(RoomImageUsecaseTestSuite))
+}
+
+// pngBytes membuat gambar png polos dengan ukuran tertentu
+func pngBytes(width, height int) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{R: 200, G: 100, B: 50, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	_ = png.Encode(&buf, img)
+	return buf.Bytes()
+}
+
+func (suite *RoomImageUsecaseTestSuite) TestUploadImage_Success() {
+	data := pngBytes(640, 480)
+	suite.rum.On("FindById", mock.Anything, "1").Return(model.Room{Id: "1"}, nil)
+	suite.fsm.On("Save", mock.Anything, mock.MatchedBy(func(key string) bool {
+		return strings.HasPrefix(key, "rooms/1/") && strings.HasSuffix(key, ".png") && !strings.Contains(key, "_thumb")
+	}), "image/png", data).Return("/uploads/rooms/1/a.png", nil)
+	suite.fsm.On("Save", mock.Anything, mock.MatchedBy(func(key string) bool {
+		return strings.HasSuffix(key, "_thumb.png")
+	}), "image/png", mock.MatchedBy(func(thumb []byte) bool {
+		// thumbnail 640x480 dikecilkan menjadi 320x240
+		img, err := png.Decode(bytes.NewReader(thumb))
+		return err == nil && img.Bounds().Dx() == 320 && img.Bounds().Dy() == 240
+	})).Return("/uploads/rooms/1/a_thumb.png", nil)
+	suite.rirm.On("Create", mock.Anything, mock.MatchedBy(func(image model.RoomImage) bool {
+		return image.RoomId == "1" && image.Kind == model.RoomImagePhoto && image.ContentType == "image/png" &&
+			image.Size == int64(len(data)) && image.Url == "/uploads/rooms/1/a.png" && image.ThumbnailUrl == "/uploads/rooms/1/a_thumb.png"
+	})).Return(model.RoomImage{Id: "i1", RoomId: "1", Url: "/uploads/rooms/1/a.png"}, nil)
+
+	actual, err := suite.riu.UploadImage(context.Background(), "1", dto.ImageUpload{
+		Kind: model.RoomImagePhoto, Filename: "ruang.png", Size: int64(len(data)), File: bytes.NewReader(data),
+	})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "i1", actual.Id)
+	suite.fsm.AssertExpectations(suite.T())
+}
+
+func (suite *RoomImageUsecaseTestSuite) TestUploadImage_InvalidType() {
+	data := []byte("%PDF-1.4 bukan gambar")
+	suite.rum.On("FindById", mock.Anything, "1").Return(model.Room{Id: "1"}, nil)
+
+	_, err := suite.riu.UploadImage(context.Background(), "1", dto.ImageUpload{
+		Kind: model.RoomImageFloorPlan, Filename: "denah.png", Size: int64(len(data)), File: bytes.NewReader(data),
+	})
+
+	assert.EqualError(suite.T(), err, "file type application/pdf is not allowed, use jpeg, png or gif")
+	suite.fsm.AssertNotCalled(suite.T(), "Save", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *RoomImageUsecaseTestSuite) TestUploadImage_TooLarge() {
+	data := make([]byte, 1<<20+10)
+	suite.rum.On("FindById", mock.Anything, "1").Return(model.Room{Id: "1"}, nil)
+
+	// ukuran di header sengaja tidak jujur, tetap ditolak dari isi file
+	_, err := suite.riu.UploadImage(context.Background(), "1", dto.ImageUpload{
+		Kind: model.RoomImagePhoto, Size: 10, File: bytes.NewReader(data),
+	})
+
+	assert.EqualError(suite.T(), err, "file is too large, maximum size is 1 MB")
+}
+
+func (suite *RoomImageUsecaseTestSuite) TestUploadImage_StoreFailedCleansUp() {
+	data := pngBytes(10, 10)
+	suite.rum.On("FindById", mock.Anything, "1").Return(model.Room{Id: "1"}, nil)
+	suite.fsm.On("Save", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("/uploads/x", nil)
+	suite.fsm.On("Delete", mock.Anything, mock.Anything).Return(nil)
+	suite.rirm.On("Create", mock.Anything, mock.Anything).Return(model.RoomImage{}, errors.New("insert failed"))
+
+	_, err := suite.riu.UploadImage(context.Background(), "1", dto.ImageUpload{
+		Kind: model.RoomImagePhoto, Size: int64(len(data)), File: bytes.NewReader(data),
+	})
+
+	assert.EqualError(suite.T(), err, "insert failed")
+	suite.fsm.AssertNumberOfCalls(suite.T(), "Delete", 2)
+}
+
+func (suite *RoomImageUsecaseTestSuite) TestDeleteImage_OtherRoom() {
+	suite.rirm.On("Get", mock.Anything, "i1").Return(model.RoomImage{Id: "i1", RoomId: "2"}, nil)
+
+	err := suite.riu.DeleteImage(context.Background(), "1", "i1")
+
+	assert.EqualError(suite.T(), err, "image with id i1 not found")
+	suite.rirm.AssertNotCalled(suite.T(), "Delete", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"final-project-booking-room/model"
+	"final-project-booking-room/model/dto"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/360EntSecGroup-Skylar/excelize"
+)
+
+// ErrRoomImportInvalid dikembalikan ImportRooms jika ada baris yang tidak valid, detailnya ada di RoomImportResult.Errors
+var ErrRoomImportInvalid = errors.New("room import has invalid rows, nothing was saved")
+
+// kolom file import, baris pertama file berisi header ini (urutan bebas, huruf besar kecil diabaikan).
+// Kolom amenities berisi kode amenity dipisah ";", amenity quantity ditulis kode:jumlah, misalnya "wifi;chairs:20".
+var (
+	roomImportColumns         = []string{"code", "name", "roomtype", "capacity", "floorid", "description", "amenities"}
+	roomImportRequiredColumns = []string{"code", "roomtype", "capacity"}
+)
+
+// ImportRooms implements RoomUseCase.
+// Semua baris divalidasi dulu, jika ada yang salah tidak ada yang disimpan. Dry run hanya melaporkan hasilnya tanpa menyimpan.
+func (r *roomUseCase) ImportRooms(ctx context.Context, filename string, file io.Reader, dryRun bool) (dto.RoomImportResult, error) {
+	records, err := readImportFile(filename, file)
+	if err != nil {
+		return dto.RoomImportResult{}, err
+	}
+	if len(records) < 2 {
+		return dto.RoomImportResult{}, errors.New("file has no rows to import")
+	}
+
+	header := make(map[string]int)
+	for i, name := range records[0] {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !slices.Contains(roomImportColumns, name) {
+			return dto.RoomImportResult{}, fmt.Errorf("unknown column %s", records[0][i])
+		}
+		header[name] = i
+	}
+	for _, name := range roomImportRequiredColumns {
+		if _, ok := header[name]; !ok {
+			return dto.RoomImportResult{}, fmt.Errorf("column %s is required", name)
+		}
+	}
+
+	amenities, err := r.amenityUC.ViewAllAmenities(ctx)
+	if err != nil {
+		return dto.RoomImportResult{}, fmt.Errorf("failed to get amenities: %v", err)
+	}
+	catalog := make(map[string]model.Amenity)
+	for _, amenity := range amenities {
+		catalog[amenity.Code] = amenity
+	}
+
+	var rows []dto.RoomImportRow
+	var rowErrors []dto.RoomImportError
+	codes := make(map[string]int)
+	for i, record := range records[1:] {
+		line := i + 2
+		cell := func(name string) string {
+			index, ok := header[name]
+			if !ok || index >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[index])
+		}
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+
+		room, err := parseImportRow(cell, catalog)
+		if err == nil {
+			if first, ok := codes[room.Code]; ok {
+				err = fmt.Errorf("code %s is already used on row %d", room.Code, first)
+			}
+		}
+		if err != nil {
+			rowErrors = append(rowErrors, dto.RoomImportError{Row: line, Code: cell("code"), Message: err.Error()})
+			continue
+		}
+		codes[room.Code] = line
+		rows = append(rows, dto.RoomImportRow{Row: line, Room: room})
+	}
+
+	if len(rowErrors) > 0 {
+		result := dto.RoomImportResult{DryRun: dryRun, TotalRows: len(rows) + len(rowErrors), Errors: rowErrors}
+		if dryRun {
+			return result, nil
+		}
+		return result, ErrRoomImportInvalid
+	}
+
+	result, err := r.repo.Import(ctx, rows, dryRun)
+	if err != nil {
+		return dto.RoomImportResult{}, fmt.Errorf("failed to import rooms: %v", err)
+	}
+	return result, nil
+}
+
+// readImportFile membaca isi file csv atau sheet pertama file xlsx sebagai baris-baris string
+func readImportFile(filename string, file io.Reader) ([][]string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		reader := csv.NewReader(file)
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		records, err := reader.ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("invalid csv file: %v", err)
+		}
+		return records, nil
+	case ".xlsx":
+		xlsx, err := excelize.OpenReader(file)
+		if err != nil {
+			return nil, fmt.Errorf("invalid xlsx file: %v", err)
+		}
+		return xlsx.GetRows(xlsx.GetSheetName(1)), nil
+	default:
+		return nil, errors.New("file must be a .csv or .xlsx file")
+	}
+}
+
+// parseImportRow mengubah satu baris file menjadi ruangan, ruangan baru selalu berstatus available
+func parseImportRow(cell func(name string) string, catalog map[string]model.Amenity) (model.Room, error) {
+	room := model.Room{
+		Code:     cell("code"),
+		Name:     cell("name"),
+		RoomType: cell("roomtype"),
+		FloorId:  cell("floorid"),
+		Status:   "available",
+	}
+	room.Facility.RoomDescription = cell("description")
+
+	if room.Code == "" {
+		return model.Room{}, errors.New("code is required")
+	}
+	if room.RoomType == "" {
+		return model.Room{}, errors.New("roomType is required")
+	}
+	if room.Name == "" {
+		room.Name = room.RoomType
+	}
+
+	capacity, err := strconv.Atoi(cell("capacity"))
+	if err != nil || capacity < 1 {
+		return model.Room{}, errors.New("capacity must be a positive number")
+	}
+	room.MaxCapacity = capacity
+
+	room.Amenities = []model.RoomAmenity{}
+	seen := make(map[string]bool)
+	for _, item := range strings.Split(cell("amenities"), ";") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		code, quantityText, hasQuantity := strings.Cut(item, ":")
+		code = strings.TrimSpace(code)
+		amenity, ok := catalog[code]
+		if !ok {
+			return model.Room{}, fmt.Errorf("amenity %s not found", code)
+		}
+		if seen[code] {
+			return model.Room{}, fmt.Errorf("amenity %s is listed twice", code)
+		}
+		seen[code] = true
+
+		quantity := 1
+		if hasQuantity {
+			quantity, err = strconv.Atoi(strings.TrimSpace(quantityText))
+			if err != nil || quantity < 1 {
+				return model.Room{}, fmt.Errorf("quantity of amenity %s must be a positive number", code)
+			}
+		} else if amenity.Type == "quantity" {
+			return model.Room{}, fmt.Errorf("amenity %s needs a quantity, e.g. %s:10", code, code)
+		}
+		room.Amenities = append(room.Amenities, model.RoomAmenity{Id: amenity.Id, Code: amenity.Code, Quantity: quantity})
+	}
+
+	return room, nil
+}
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"final-project-booking-room/model"
+	"final-project-booking-room/repository"
+	"fmt"
+	"strings"
+)
+
+type RoomLayoutUseCase interface {
+	AddLayout(ctx context.Context, roomId string, payload model.RoomLayout) (model.RoomLayout, error)
+	UpdateLayout(ctx context.Context, roomId string, layoutId string, payload model.RoomLayout) (model.RoomLayout, error)
+	DeleteLayout(ctx context.Context, roomId string, layoutId string) error
+}
+
+type roomLayoutUseCase struct {
+	repo   repository.RoomLayoutRepository
+	roomUC RoomUseCase
+}
+
+func validateLayout(layout model.RoomLayout) error {
+	if strings.TrimSpace(layout.Name) == "" {
+		return errors.New("layout name is required")
+	}
+	if layout.Capacity <= 0 {
+		return errors.New("layout capacity must be greater than 0")
+	}
+	if layout.SetupMinutes < 0 || layout.TeardownMinutes < 0 {
+		return errors.New("setupMinutes and teardownMinutes can't be negative")
+	}
+	return nil
+}
+
+func (r *roomLayoutUseCase) AddLayout(ctx context.Context, roomId string, payload model.RoomLayout) (model.RoomLayout, error) {
+	if err := validateLayout(payload); err != nil {
+		return model.RoomLayout{}, err
+	}
+	if _, err := r.roomUC.FindById(ctx, roomId); err != nil {
+		return model.RoomLayout{}, err
+	}
+
+	payload.RoomId = roomId
+	layout, err := r.repo.Create(ctx, payload)
+	if err != nil {
+		return model.RoomLayout{}, fmt.Errorf("failed to save layout %s: %v", payload.Name, err)
+	}
+	return layout, nil
+}
+
+func (r *roomLayoutUseCase) UpdateLayout(ctx context.Context, roomId string, layoutId string, payload model.RoomLayout) (model.RoomLayout, error) {
+	if err := validateLayout(payload); err != nil {
+		return model.RoomLayout{}, err
+	}
+	layout, err := r.repo.Get(ctx, layoutId)
+	if err != nil || layout.RoomId != roomId {
+		return model.RoomLayout{}, fmt.Errorf("layout with id %s not found", layoutId)
+	}
+
+	layout.Name = payload.Name
+	layout.Capacity = payload.Capacity
+	layout.SetupMinutes = payload.SetupMinutes
+	layout.TeardownMinutes = payload.TeardownMinutes
+	return r.repo.Update(ctx, layout)
+}
+
+func (r *roomLayoutUseCase) DeleteLayout(ctx context.Context, roomId string, layoutId string) error {
+	layout, err := r.repo.Get(ctx, layoutId)
+	if err != nil || layout.RoomId != roomId {
+		return fmt.Errorf("layout with id %s not found", layoutId)
+	}
+	return r.repo.Delete(ctx, layoutId)
+}
+
+func NewRoomLayoutUseCase(repo repository.RoomLayoutRepository, roomUC RoomUseCase) RoomLayoutUseCase {
+	return &roomLayoutUseCase{repo: repo, roomUC: roomUC}
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"final-project-booking-room/model"
+	repositorymock "final-project-booking-room/unit-test/mock-test/repository-mock"
+	usecasemock "final-project-booking-room/unit-test/mock-test/usecase-mock"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type RoomLayoutUsecaseTestSuite struct {
+	suite.Suite
+	rlrm *repositorymock.RoomLayoutRepositoryMock
+	rum  *usecasemock.RoomUseCaseMock
+	rlu  RoomLayoutUseCase
+}
+
+func (suite *RoomLayoutUsecaseTestSuite) SetupTest() {
+	suite.rlrm = new(repositorymock.RoomLayoutRepositoryMock)
+	suite.rum = new(usecasemock.RoomUseCaseMock)
+	suite.rlu = NewRoomLayoutUseCase(suite.rlrm, suite.rum)
+}
+
+func TestRoomLayoutUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(RoomLayoutUsecaseTestSuite))
+}
+
+var mockLayout = model.RoomLayout{Id: "l1", RoomId: "1", Name: "classroom", Capacity: 20, SetupMinutes: 30, TeardownMinutes: 15}
+
+func (suite *RoomLayoutUsecaseTestSuite) TestAddLayout_Success() {
+	payload := model.RoomLayout{Name: "classroom", Capacity: 20, SetupMinutes: 30, TeardownMinutes: 15}
+	suite.rum.On("FindById", mock.Anything, "1").Return(model.Room{Id: "1"}, nil)
+	suite.rlrm.On("Create", mock.Anything, model.RoomLayout{RoomId: "1", Name: "classroom", Capacity: 20, SetupMinutes: 30, TeardownMinutes: 15}).Return(mockLayout, nil)
+
+	layout, err := suite.rlu.AddLayout(context.Background(), "1", payload)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), mockLayout, layout)
+}
+
+func (suite *RoomLayoutUsecaseTestSuite) TestAddLayout_InvalidCapacity() {
+	_, err := suite.rlu.AddLayout(context.Background(), "1", model.RoomLayout{Name: "theatre"})
+
+	assert.EqualError(suite.T(), err, "layout capacity must be greater than 0")
+	suite.rlrm.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *RoomLayoutUsecaseTestSuite) TestUpdateLayout_OtherRoom() {
+	suite.rlrm.On("Get", mock.Anything, "l1").Return(mockLayout, nil)
+
+	_, err := suite.rlu.UpdateLayout(context.Background(), "2", "l1", mockLayout)
+
+	assert.EqualError(suite.T(), err, "layout with id l1 not found")
+	suite.rlrm.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything)
+}
+
+func (suite *RoomLayoutUsecaseTestSuite) TestDeleteLayout_Success() {
+	suite.rlrm.On("Get", mock.Anything, "l1").Return(mockLayout, nil)
+	suite.rlrm.On("Delete", mock.Anything, "l1").Return(nil)
+
+	err := suite.rlu.DeleteLayout(context.Background(), "1", "l1")
+
+	assert.NoError(suite.T(), err)
+}
+
+func TestRoomLayoutBuffer(t *testing.T) {
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	from, until := mockLayout.Buffer(start, end)
+	assert.Equal(t, start.Add(-30*time.Minute), from)
+	assert.Equal(t, end.Add(15*time.Minute), until)
+
+	var noLayout *model.RoomLayout
+	from, until = noLayout.Buffer(start, end)
+	assert.Equal(t, start, from)
+	assert.Equal(t, end, until)
+}
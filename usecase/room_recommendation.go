@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"final-project-booking-room/model/dto"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	maxRecommendations = 10
+	maxAlternatives    = 5
+	// waktu alternatif dicari dengan menggeser waktu yang diminta per 30 menit, paling jauh 3 jam sebelum atau sesudahnya
+	alternativeStep  = 30 * time.Minute
+	alternativeRange = 3 * time.Hour
+	// bobot skor rekomendasi, totalnya 100
+	capacityFitWeight   = 50.0
+	amenityMatchWeight  = 30.0
+	usualBuildingWeight = 20.0
+)
+
+// RecommendRooms implements RoomUseCase.
+// Ruangan yang punya semua amenity yang diminta selalu di atas ruangan yang amenity-nya kurang, lalu diurutkan dari skor:
+// kapasitas yang paling pas, amenity yang cocok dan gedung yang paling sering dibooking user.
+// Jika tidak ada ruangan yang kosong, dicarikan waktu lain di sekitar waktu yang diminta.
+func (r *roomUseCase) RecommendRooms(ctx context.Context, filter dto.RecommendationFilter, userId string, role string) (dto.RecommendationResult, error) {
+	if filter.Attendees < 1 {
+		return dto.RecommendationResult{}, errors.New("attendees must be greater than 0")
+	}
+	if filter.Start.IsZero() || filter.End.IsZero() {
+		return dto.RecommendationResult{}, errors.New("start and end are required")
+	}
+	if !filter.End.After(filter.Start) {
+		return dto.RecommendationResult{}, errors.New("end must be after start")
+	}
+	now := time.Now()
+	if filter.Start.Before(now) {
+		return dto.RecommendationResult{}, errors.New("start can't be in the past")
+	}
+
+	var amenities []string
+	for _, code := range filter.Amenities {
+		code = strings.ToLower(strings.TrimSpace(code))
+		if code != "" && !slices.Contains(amenities, code) {
+			amenities = append(amenities, code)
+		}
+	}
+	filter.Amenities = amenities
+
+	if role == "employee" {
+		user, err := r.userUC.FindById(ctx, userId)
+		if err != nil {
+			return dto.RecommendationResult{}, fmt.Errorf("user with ID %s not found", userId)
+		}
+		filter.Viewer = &user
+	}
+
+	usualBuilding, err := r.repo.UsualBuilding(ctx, userId)
+	if err != nil {
+		return dto.RecommendationResult{}, fmt.Errorf("failed to get booking history: %v", err)
+	}
+
+	// interval terpakai diambil sekaligus untuk semua waktu alternatif
+	filter.From = filter.Start.Add(-alternativeRange)
+	filter.Until = filter.End.Add(alternativeRange)
+	candidates, err := r.repo.Recommend(ctx, filter)
+	if err != nil {
+		return dto.RecommendationResult{}, fmt.Errorf("failed to get rooms: %v", err)
+	}
+
+	result := dto.RecommendationResult{
+		Start:        filter.Start,
+		End:          filter.End,
+		Rooms:        rankRooms(candidates, filter, usualBuilding, filter.Start, filter.End),
+		Alternatives: []dto.AlternativeSlot{},
+	}
+	if len(result.Rooms) > 0 {
+		return result, nil
+	}
+
+	// waktu terdekat dulu, untuk jarak yang sama waktu yang lebih awal didahulukan
+	for step := alternativeStep; step <= alternativeRange && len(result.Alternatives) < maxAlternatives; step += alternativeStep {
+		for _, shift := range []time.Duration{-step, step} {
+			start, end := filter.Start.Add(shift), filter.End.Add(shift)
+			if start.Before(now) {
+				continue
+			}
+			if rooms := rankRooms(candidates, filter, usualBuilding, start, end); len(rooms) > 0 {
+				result.Alternatives = append(result.Alternatives, dto.AlternativeSlot{Start: start, End: end, Room: rooms[0]})
+			}
+			if len(result.Alternatives) == maxAlternatives {
+				break
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// rankRooms mengembalikan ruangan yang kosong di start-end, urut dari yang paling cocok
+func rankRooms(candidates []dto.RoomCandidate, filter dto.RecommendationFilter, usualBuilding string, start time.Time, end time.Time) []dto.RoomRecommendation {
+	rooms := []dto.RoomRecommendation{}
+	for _, candidate := range candidates {
+		if roomBusy(candidate.Busy, start, end) {
+			continue
+		}
+		rooms = append(rooms, scoreRoom(candidate, filter, usualBuilding))
+	}
+
+	sort.SliceStable(rooms, func(i, j int) bool {
+		if (len(rooms[i].MissingAmenities) == 0) != (len(rooms[j].MissingAmenities) == 0) {
+			return len(rooms[i].MissingAmenities) == 0
+		}
+		if rooms[i].Score != rooms[j].Score {
+			return rooms[i].Score > rooms[j].Score
+		}
+		if rooms[i].Capacity != rooms[j].Capacity {
+			return rooms[i].Capacity < rooms[j].Capacity
+		}
+		return rooms[i].RoomName < rooms[j].RoomName
+	})
+
+	if len(rooms) > maxRecommendations {
+		rooms = rooms[:maxRecommendations]
+	}
+	return rooms
+}
+
+func scoreRoom(candidate dto.RoomCandidate, filter dto.RecommendationFilter, usualBuilding string) dto.RoomRecommendation {
+	room := dto.RoomRecommendation{
+		RoomId:           candidate.RoomId,
+		RoomName:         candidate.RoomName,
+		RoomType:         candidate.RoomType,
+		Capacity:         candidate.Capacity,
+		BuildingId:       candidate.BuildingId,
+		BuildingName:     candidate.BuildingName,
+		UsualBuilding:    usualBuilding != "" && candidate.BuildingId == usualBuilding,
+		MissingAmenities: []string{},
+	}
+
+	// repository hanya mengembalikan ruangan yang muat, jadi kapasitas tidak pernah lebih kecil dari peserta
+	capacityFit := float64(filter.Attendees) / float64(candidate.Capacity)
+	amenityMatch := 1.0
+	if len(filter.Amenities) > 0 {
+		for _, code := range filter.Amenities {
+			if !slices.Contains(candidate.Amenities, code) {
+				room.MissingAmenities = append(room.MissingAmenities, code)
+			}
+		}
+		amenityMatch = float64(len(filter.Amenities)-len(room.MissingAmenities)) / float64(len(filter.Amenities))
+	}
+
+	room.CapacityFit = round2(capacityFit * 100)
+	room.AmenityMatch = round2(amenityMatch * 100)
+	room.Score = capacityFitWeight*capacityFit + amenityMatchWeight*amenityMatch
+	if room.UsualBuilding {
+		room.Score += usualBuildingWeight
+	}
+	room.Score = round2(room.Score)
+	return room
+}
+
+// roomBusy bernilai true jika salah satu interval terpakai beririsan dengan start-end
+func roomBusy(busy []dto.BusyInterval, start time.Time, end time.Time) bool {
+	for _, interval := range busy {
+		if interval.From.Before(end) && start.Before(interval.Until) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,55 +1,114 @@
 package usecase
 
 import (
+	"context"
+	"errors"
 	"final-project-booking-room/model"
+	"final-project-booking-room/model/dto"
 	"final-project-booking-room/repository"
+	"final-project-booking-room/utils/common"
+	"final-project-booking-room/utils/modelutil"
 	"fmt"
+	"io"
+	"log"
 	"strings"
+	"time"
 )
 
 type RoomUseCase interface {
-	RegisterNewRoom(payload model.Room) (model.Room, error)
-	FindById(id string) (model.Room, error)
-	FindByRoomType(roomType string) (model.Room, error)
-	ViewAllRooms() ([]model.Room, error)
-	DeleteById(id string) (model.Room, error)
-	UpdateById(id string, payload model.Room) (model.Room, error)
-	GetRoomStatus(id string) (string, error)
-	GetRoomStatusByBdId(id string) (string, error)
-	ChangeRoomStatus(id string) error
-	GetAllRoomByStatus(status string) ([]model.Room, error)
+	RegisterNewRoom(ctx context.Context, payload model.Room) (model.Room, error)
+	FindById(ctx context.Context, id string) (model.Room, error)
+	ListRooms(ctx context.Context, filter dto.RoomFilter, userId string, role string) ([]model.Room, dto.Paging, error)
+	ArchiveById(ctx context.Context, id string, option dto.ArchiveOption) (dto.ArchiveResult, error)
+	RestoreById(ctx context.Context, id string) (model.Room, error)
+	SetRoomParts(ctx context.Context, id string, partIds []string) (model.Room, error)
+	SetRoomAccess(ctx context.Context, id string, rules []model.RoomAccessRule) (model.Room, error)
+	ImportRooms(ctx context.Context, filename string, file io.Reader, dryRun bool) (dto.RoomImportResult, error)
+	RecommendRooms(ctx context.Context, filter dto.RecommendationFilter, userId string, role string) (dto.RecommendationResult, error)
+	UpdateById(ctx context.Context, id string, payload model.Room) (model.Room, error)
+	GetRoomStatus(ctx context.Context, id string) (string, error)
+	GetRoomStatusByBdId(ctx context.Context, id string) (string, error)
+	ChangeRoomStatus(ctx context.Context, id string) error
 }
 
+// ErrRoomHasFutureBookings dipakai controller untuk membedakan arsip yang ditolak karena masih ada booking
+var ErrRoomHasFutureBookings = repository.ErrRoomHasFutureBookings
+
+// ErrAmenityNotFound dipakai controller untuk membedakan payload ruangan yang salah dari kegagalan server
+var ErrAmenityNotFound = repository.ErrAmenityNotFound
+
 type roomUseCase struct {
-	repo repository.RoomRepository
+	repo         repository.RoomRepository
+	userUC       UserUseCase
+	amenityUC    AmenityUseCase
+	emailService common.EmailService
+	conference   common.ConferencingProvider
 }
 
-func (r *roomUseCase) GetAllRoomByStatus(status string) ([]model.Room, error) {
-	room, err := r.repo.GetAllRoomByStatus(status)
-
+// ListRooms implements RoomUseCase.
+// Employee hanya melihat ruangan yang boleh dibooking sesuai rule akses, admin dan GA melihat semua ruangan.
+func (r *roomUseCase) ListRooms(ctx context.Context, filter dto.RoomFilter, userId string, role string) ([]model.Room, dto.Paging, error) {
+	filter, err := validateRoomFilter(filter)
 	if err != nil {
-		return []model.Room{}, fmt.Errorf("room with status %s not found", status)
+		return nil, dto.Paging{}, err
 	}
-	if status != "available" {
-		return []model.Room{}, fmt.Errorf("room with status %s not found", status)
+
+	if role == "employee" {
+		user, err := r.userUC.FindById(ctx, userId)
+		if err != nil {
+			return nil, dto.Paging{}, fmt.Errorf("user with ID %s not found", userId)
+		}
+		filter.Viewer = &user
 	}
 
-	return room, err
+	rooms, paging, err := r.repo.List(ctx, filter)
+	if err != nil {
+		return nil, dto.Paging{}, fmt.Errorf("failed to get rooms: %v", err)
+	}
+	return rooms, paging, nil
 }
 
-// ViewAllRooms implements RoomUseCase.
-func (r *roomUseCase) ViewAllRooms() ([]model.Room, error) {
-	room, err := r.repo.GetAllRoom()
-	if err != nil {
-		return nil, err
+// validateRoomFilter mengecek filter dari query dan mengisi nilai default paging
+func validateRoomFilter(filter dto.RoomFilter) (dto.RoomFilter, error) {
+	switch filter.SortBy {
+	case "", "name", "capacity", "createdAt":
+	default:
+		return dto.RoomFilter{}, fmt.Errorf(`sortBy must be "name", "capacity" or "createdAt", not %s`, filter.SortBy)
+	}
+
+	if filter.Order != "" && filter.Order != "asc" && filter.Order != "desc" {
+		return dto.RoomFilter{}, fmt.Errorf(`order must be "asc" or "desc", not %s`, filter.Order)
 	}
 
-	return room, err
+	switch filter.Status {
+	case "", "available", "booked", "maintenance":
+	default:
+		return dto.RoomFilter{}, fmt.Errorf(`status must be "available", "booked" or "maintenance", not %s`, filter.Status)
+	}
+
+	if filter.MinCapacity < 0 || filter.MaxCapacity < 0 {
+		return dto.RoomFilter{}, errors.New("capacity can't be negative")
+	}
+	if filter.MaxCapacity > 0 && filter.MaxCapacity < filter.MinCapacity {
+		return dto.RoomFilter{}, errors.New("maxCapacity can't be less than minCapacity")
+	}
+
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.Size < 1 {
+		filter.Size = 10
+	}
+	if filter.Size > 100 {
+		filter.Size = 100
+	}
+
+	return filter, nil
 }
 
 // ChangeRoomStatus implements RoomUseCase.
-func (r *roomUseCase) ChangeRoomStatus(id string) error {
-	err := r.repo.ChangeStatus(id)
+func (r *roomUseCase) ChangeRoomStatus(ctx context.Context, id string) error {
+	err := r.repo.ChangeStatus(ctx, id)
 	if err != nil {
 		return fmt.Errorf("room with id %s not found", id)
 	}
@@ -57,8 +116,8 @@ func (r *roomUseCase) ChangeRoomStatus(id string) error {
 }
 
 // GetRoomStatusByBdId implements RoomUseCase.
-func (r *roomUseCase) GetRoomStatusByBdId(id string) (string, error) {
-	getStatus, err := r.repo.GetStatusByBd(id)
+func (r *roomUseCase) GetRoomStatusByBdId(ctx context.Context, id string) (string, error) {
+	getStatus, err := r.repo.GetStatusByBd(ctx, id)
 	if err != nil {
 		return "Can't get room status from booking details ID", fmt.Errorf("room with booking details id %s not found", id)
 	}
@@ -66,32 +125,32 @@ func (r *roomUseCase) GetRoomStatusByBdId(id string) (string, error) {
 }
 
 // GetRoomStatus implements RoomUseCase.
-func (r *roomUseCase) GetRoomStatus(id string) (string, error) {
-	getStatus, err := r.repo.GetStatus(id)
+func (r *roomUseCase) GetRoomStatus(ctx context.Context, id string) (string, error) {
+	getStatus, err := r.repo.GetStatus(ctx, id)
 	if err != nil {
 		return "Can't get room status", fmt.Errorf("room with id %s not found", id)
 	}
 	return getStatus, nil
 }
 
-// FindByRoomType implements RoomUseCase.
-func (r *roomUseCase) FindByRoomType(roomType string) (model.Room, error) {
-	findRoom, err := r.repo.GetByRoomType(roomType)
-	if err != nil {
-		return model.Room{}, fmt.Errorf("room with roomType %s not found", roomType)
-	}
-	return findRoom, err
-}
-
 // UpdateById implements RoomUseCase.
-func (r *roomUseCase) UpdateById(id string, payload model.Room) (model.Room, error) {
+func (r *roomUseCase) UpdateById(ctx context.Context, id string, payload model.Room) (model.Room, error) {
 
-	updatedRoom, err := r.repo.Get(id)
+	updatedRoom, err := r.repo.Get(ctx, id)
 	if err != nil {
 		return model.Room{}, fmt.Errorf("room with id %s not found", id)
 	}
 
 	if updatedRoom.Id == id {
+		if strings.TrimSpace(payload.Name) != "" {
+			updatedRoom.Name = payload.Name
+		}
+		if strings.TrimSpace(payload.Code) != "" {
+			updatedRoom.Code = payload.Code
+		}
+		if strings.TrimSpace(payload.FloorId) != "" {
+			updatedRoom.FloorId = payload.FloorId
+		}
 		if strings.TrimSpace(payload.RoomType) != "" {
 			updatedRoom.RoomType = payload.RoomType
 		}
@@ -104,65 +163,155 @@ func (r *roomUseCase) UpdateById(id string, payload model.Room) (model.Room, err
 		if strings.TrimSpace(payload.Facility.RoomDescription) != "" {
 			updatedRoom.Facility.RoomDescription = payload.Facility.RoomDescription
 		}
-		if strings.TrimSpace(payload.Facility.Fwifi) != "" {
-			updatedRoom.Facility.Fwifi = payload.Facility.Fwifi
+		// kolom facility lama read-only, perubahan fasilitas lewat daftar amenities
+		if payload.Amenities != nil {
+			updatedRoom.Amenities = payload.Amenities
 		}
-		if strings.TrimSpace(payload.Facility.FsoundSystem) != "" {
-			updatedRoom.Facility.FsoundSystem = payload.Facility.FsoundSystem
-		}
-		if strings.TrimSpace(payload.Facility.Fprojector) != "" {
-			updatedRoom.Facility.Fprojector = payload.Facility.Fprojector
-		}
-		if strings.TrimSpace(payload.Facility.FscreenProjector) != "" {
-			updatedRoom.Facility.FscreenProjector = payload.Facility.FscreenProjector
-		}
-		if strings.TrimSpace(payload.Facility.Fchairs) != "" {
-			updatedRoom.Facility.Fchairs = payload.Facility.Fchairs
-		}
-		if strings.TrimSpace(payload.Facility.Ftables) != "" {
-			updatedRoom.Facility.Ftables = payload.Facility.Ftables
+	}
+
+	update, err := r.repo.Update(ctx, id, updatedRoom)
+	if err != nil {
+		return model.Room{}, err
+	}
+
+	return update, err
+}
+
+// ArchiveById implements RoomUseCase.
+func (r *roomUseCase) ArchiveById(ctx context.Context, id string, option dto.ArchiveOption) (dto.ArchiveResult, error) {
+	var target model.Room
+	switch option.Cascade {
+	case "", "cancel":
+	case "reassign":
+		if option.ReassignTo == "" || option.ReassignTo == id {
+			return dto.ArchiveResult{}, errors.New("reassignTo must be another room id")
 		}
-		if strings.TrimSpace(payload.Facility.FsoundProof) != "" {
-			updatedRoom.Facility.FsoundProof = payload.Facility.FsoundProof
+		var err error
+		target, err = r.repo.Get(ctx, option.ReassignTo)
+		if err != nil || target.ArchivedAt != nil {
+			return dto.ArchiveResult{}, fmt.Errorf("room with id %s not found or archived", option.ReassignTo)
 		}
-		if strings.TrimSpace(payload.Facility.FsmonkingArea) != "" {
-			updatedRoom.Facility.FsmonkingArea = payload.Facility.FsmonkingArea
+	default:
+		return dto.ArchiveResult{}, fmt.Errorf("invalid cascade %s, must be cancel or reassign", option.Cascade)
+	}
+
+	room, err := r.repo.Get(ctx, id)
+	if err != nil {
+		return dto.ArchiveResult{}, fmt.Errorf("room with id %s not found", id)
+	}
+
+	now := time.Now().UTC()
+	if option.Cascade == "reassign" {
+		if err := r.checkReassignAccess(ctx, id, target, now); err != nil {
+			return dto.ArchiveResult{}, err
 		}
-		if strings.TrimSpace(payload.Facility.Ftelevison) != "" {
-			updatedRoom.Facility.Ftelevison = payload.Facility.Ftelevison
+	}
+
+	affected, err := r.repo.Archive(ctx, id, option, now)
+	if errors.Is(err, ErrRoomHasFutureBookings) {
+		return dto.ArchiveResult{Room: room, AffectedBookings: affected},
+			fmt.Errorf("%w: %d booking(s) still scheduled, use cascade=cancel or cascade=reassign", err, len(affected))
+	}
+	if err != nil {
+		return dto.ArchiveResult{}, err
+	}
+
+	// arsip sudah tersimpan, kegagalan kirim email hanya ditandai di Notified
+	for i := range affected {
+		if err := r.moveConference(ctx, target, option.Cascade, affected[i]); err != nil {
+			log.Printf("room %s archived, conference of booking detail %s not updated: %v", id, affected[i].BookingDetailId, err)
 		}
-		if strings.TrimSpace(payload.Facility.FAc) != "" {
-			updatedRoom.Facility.FAc = payload.Facility.FAc
+		affected[i].Notified = r.emailService.SendEmail(archiveNotice(room, target, option.Cascade, affected[i])) == nil
+	}
+
+	room.ArchivedAt = &now
+	return dto.ArchiveResult{Room: room, Cascade: option.Cascade, AffectedBookings: affected}, nil
+}
+
+// checkReassignAccess memastikan setiap pemesan booking yang akan dipindahkan boleh memakai ruangan tujuan
+func (r *roomUseCase) checkReassignAccess(ctx context.Context, id string, target model.Room, at time.Time) error {
+	if len(target.AccessRules) == 0 {
+		return nil
+	}
+	bookings, err := r.repo.FutureBookings(ctx, id, at)
+	if err != nil {
+		return err
+	}
+
+	checked := make(map[string]bool)
+	for _, booking := range bookings {
+		if checked[booking.UserId] {
+			continue
 		}
-		if strings.TrimSpace(payload.Facility.Fbathroom) != "" {
-			updatedRoom.Facility.Fbathroom = payload.Facility.Fbathroom
+		checked[booking.UserId] = true
+
+		user, err := r.userUC.FindById(ctx, booking.UserId)
+		if err != nil {
+			return fmt.Errorf("user with ID %s not found", booking.UserId)
 		}
-		if strings.TrimSpace(payload.Facility.FcoffeMaker) != "" {
-			updatedRoom.Facility.FcoffeMaker = payload.Facility.FcoffeMaker
+		if !target.AllowsUser(user) {
+			return fmt.Errorf("room with id %s is restricted for %s, who has booking detail %s", target.Id, user.Name, booking.BookingDetailId)
 		}
 	}
+	return nil
+}
 
-	update, err := r.repo.Update(id, updatedRoom)
-	if err != nil {
-		return model.Room{}, err
+// moveConference mengikuti perubahan booking ke meeting video conference: ruangan diganti saat reassign, meeting dihapus saat cancel.
+// Link untuk join tetap sama saat reassign, kegagalan provider dikembalikan untuk dicatat dan tidak membatalkan arsip.
+func (r *roomUseCase) moveConference(ctx context.Context, target model.Room, cascade string, booking dto.AffectedBooking) error {
+	if booking.ConferenceId == "" {
+		return nil
 	}
-
-	return update, err
+	switch cascade {
+	case "reassign":
+		return r.conference.UpdateMeeting(ctx, booking.ConferenceId, conferenceRequest(booking.Description, roomName(target), target.Location, booking.BookingDate, booking.BookingDateEnd))
+	case "cancel":
+		return r.conference.DeleteMeeting(ctx, booking.ConferenceId)
+	}
+	return nil
 }
 
-// DeleteById implements RoomUseCase.
-func (r *roomUseCase) DeleteById(id string) (model.Room, error) {
-	_, err := r.repo.Delete(id)
-	if err != nil {
-		return model.Room{}, fmt.Errorf("room with id %s not found", id)
+// archiveNotice menyusun email untuk pemesan dan peserta yang bookingnya dibatalkan atau dipindahkan beserta invite kalender
+func archiveNotice(room model.Room, target model.Room, cascade string, booking dto.AffectedBooking) modelutil.BodySender {
+	loc := room.Location.Zone()
+	schedule := fmt.Sprintf("%s - %s (%s)", booking.BookingDate.In(loc).Format(reportTimeLayout), booking.BookingDateEnd.In(loc).Format(reportTimeLayout), loc)
+
+	body := fmt.Sprintf("Halo %s,\n\nRuangan %s tidak lagi tersedia sehingga booking anda pada %s dibatalkan.", booking.UserName, room.Name, schedule)
+	if cascade == "reassign" {
+		body = fmt.Sprintf("Halo %s,\n\nRuangan %s tidak lagi tersedia. Booking anda pada %s dipindahkan ke ruangan %s.", booking.UserName, room.Name, schedule, target.Name)
+	}
+
+	recipients := append([]string{booking.UserEmail}, booking.Participants...)
+	email := modelutil.BodySender{
+		To:      recipients,
+		Subject: "Perubahan Booking Room",
+		Body:    body,
 	}
 
-	return model.Room{}, err
+	// hanya booking accept yang sudah punya event di kalender pemesan
+	if booking.Status == "accept" {
+		if cascade == "reassign" {
+			request := conferenceRequest(booking.Description, roomName(target), target.Location, booking.BookingDate, booking.BookingDateEnd)
+			email.Invite = bookingInvite(booking.BookingDetailId, booking.InviteSequence, common.CalendarRequest, request, booking.ConferenceURL, recipients)
+		} else {
+			request := conferenceRequest(booking.Description, roomName(room), room.Location, booking.BookingDate, booking.BookingDateEnd)
+			email.Invite = bookingInvite(booking.BookingDetailId, booking.InviteSequence, common.CalendarCancel, request, "", recipients)
+		}
+	}
+	return email
+}
+
+// RestoreById implements RoomUseCase.
+func (r *roomUseCase) RestoreById(ctx context.Context, id string) (model.Room, error) {
+	if err := r.repo.Restore(ctx, id); err != nil {
+		return model.Room{}, fmt.Errorf("room with id %s not found or not archived", id)
+	}
+	return r.repo.Get(ctx, id)
 }
 
 // FindById implements RoomUseCase.
-func (r *roomUseCase) FindById(id string) (model.Room, error) {
-	findRoom, err := r.repo.Get(id)
+func (r *roomUseCase) FindById(ctx context.Context, id string) (model.Room, error) {
+	findRoom, err := r.repo.Get(ctx, id)
 	if err != nil {
 		return model.Room{}, fmt.Errorf("room with ID %s not found", id)
 	}
@@ -171,14 +320,87 @@ func (r *roomUseCase) FindById(id string) (model.Room, error) {
 }
 
 // RegisterNewRoom implements RoomUseCase.
-func (r *roomUseCase) RegisterNewRoom(payload model.Room) (model.Room, error) {
-	newRoom, err := r.repo.Create(payload)
+func (r *roomUseCase) RegisterNewRoom(ctx context.Context, payload model.Room) (model.Room, error) {
+	// client lama hanya mengirim roomType yang selama ini dipakai sebagai nama ruangan
+	if strings.TrimSpace(payload.Name) == "" {
+		payload.Name = payload.RoomType
+	}
+	newRoom, err := r.repo.Create(ctx, payload)
 	if err != nil {
-		panic(err)
+		return model.Room{}, err
+	}
+	return newRoom, nil
+}
+
+// SetRoomParts implements RoomUseCase.
+// Penggabungan hanya satu tingkat: ruangan gabungan tidak bisa menjadi bagian dari ruangan gabungan lain.
+func (r *roomUseCase) SetRoomParts(ctx context.Context, id string, partIds []string) (model.Room, error) {
+	room, err := r.repo.Get(ctx, id)
+	if err != nil {
+		return model.Room{}, fmt.Errorf("room with id %s not found", id)
+	}
+	if len(partIds) > 0 && len(room.CombinedIn) > 0 {
+		return model.Room{}, fmt.Errorf("room with id %s is already a part of a combined room", id)
+	}
+	if len(partIds) == 1 {
+		return model.Room{}, errors.New("combined room needs at least 2 parts")
+	}
+
+	seen := make(map[string]bool)
+	for _, partId := range partIds {
+		if partId == id {
+			return model.Room{}, errors.New("room can't be a part of itself")
+		}
+		if seen[partId] {
+			return model.Room{}, fmt.Errorf("room with id %s is listed twice", partId)
+		}
+		seen[partId] = true
+
+		part, err := r.repo.Get(ctx, partId)
+		if err != nil {
+			return model.Room{}, fmt.Errorf("room with id %s not found", partId)
+		}
+		if part.ArchivedAt != nil {
+			return model.Room{}, fmt.Errorf("room with id %s is archived", partId)
+		}
+		if len(part.PartIds) > 0 {
+			return model.Room{}, fmt.Errorf("room with id %s is itself a combined room", partId)
+		}
+	}
+
+	if err := r.repo.SetParts(ctx, id, partIds); err != nil {
+		return model.Room{}, err
+	}
+	return r.repo.Get(ctx, id)
+}
+
+// SetRoomAccess implements RoomUseCase.
+func (r *roomUseCase) SetRoomAccess(ctx context.Context, id string, rules []model.RoomAccessRule) (model.Room, error) {
+	if _, err := r.repo.Get(ctx, id); err != nil {
+		return model.Room{}, fmt.Errorf("room with id %s not found", id)
+	}
+
+	seen := make(map[model.RoomAccessRule]bool)
+	for i, rule := range rules {
+		rule.Effect = strings.ToLower(strings.TrimSpace(rule.Effect))
+		rule.Field = strings.ToLower(strings.TrimSpace(rule.Field))
+		rule.Value = strings.TrimSpace(rule.Value)
+		if !rule.IsValid() {
+			return model.Room{}, errors.New("access rule effect must be allow or deny, field must be divisi, jabatan or role, and value can't be empty")
+		}
+		if seen[rule] {
+			return model.Room{}, fmt.Errorf("access rule %s %s %s is listed twice", rule.Effect, rule.Field, rule.Value)
+		}
+		seen[rule] = true
+		rules[i] = rule
+	}
+
+	if err := r.repo.SetAccessRules(ctx, id, rules); err != nil {
+		return model.Room{}, err
 	}
-	return newRoom, err
+	return r.repo.Get(ctx, id)
 }
 
-func NewRoomUseCase(repo repository.RoomRepository) RoomUseCase {
-	return &roomUseCase{repo: repo}
+func NewRoomUseCase(repo repository.RoomRepository, userUC UserUseCase, amenityUC AmenityUseCase, emailService common.EmailService, conference common.ConferencingProvider) RoomUseCase {
+	return &roomUseCase{repo: repo, userUC: userUC, amenityUC: amenityUC, emailService: emailService, conference: conference}
 }
@@ -1,13 +1,19 @@
 package usecase
 
 import (
+	"context"
 	"final-project-booking-room/model"
+	"final-project-booking-room/model/dto"
 	repositorymock "final-project-booking-room/unit-test/mock-test/repository-mock"
+	usecasemock "final-project-booking-room/unit-test/mock-test/usecase-mock"
+	"final-project-booking-room/utils/modelutil"
 	"fmt"
+	"strings"
 
 	"testing"
 	"time"
 
+	"github.com/360EntSecGroup-Skylar/excelize"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -16,12 +22,18 @@ import (
 type RoomUsecaseTestSuite struct {
 	suite.Suite
 	rrm *repositorymock.RoomRepositoryMock
+	uum *usecasemock.UserUseCaseMock
+	aum *usecasemock.AmenityUseCaseMock
+	cfm *usecasemock.ConferencingMock
 	ru  RoomUseCase
 }
 
 func (suite *RoomUsecaseTestSuite) SetupTest() {
 	suite.rrm = new(repositorymock.RoomRepositoryMock)
-	suite.ru = NewRoomUseCase(suite.rrm)
+	suite.uum = new(usecasemock.UserUseCaseMock)
+	suite.aum = new(usecasemock.AmenityUseCaseMock)
+	suite.cfm = new(usecasemock.ConferencingMock)
+	suite.ru = NewRoomUseCase(suite.rrm, suite.uum, suite.aum, &usecasemock.EmailServiceMock{}, suite.cfm)
 }
 
 func TestRoomUsecaseTestSuite(t *testing.T) {
@@ -32,6 +44,7 @@ var arrayMockRoom []model.Room
 
 var mockRoom = model.Room{
 	Id:          "1",
+	Name:        "test",
 	RoomType:    "test",
 	MaxCapacity: 10,
 	Facility: model.RoomFacility{
@@ -58,88 +71,390 @@ var mockRoom = model.Room{
 }
 
 func (suite *RoomUsecaseTestSuite) TestRegisterNewRoom_Success() {
-	suite.rrm.On("Create", mockRoom).Return(mockRoom, nil)
-	_, err := suite.ru.RegisterNewRoom(mockRoom)
+	suite.rrm.On("Create", mock.Anything, mockRoom).Return(mockRoom, nil)
+	_, err := suite.ru.RegisterNewRoom(context.Background(), mockRoom)
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
 }
 
+func (suite *RoomUsecaseTestSuite) TestRegisterNewRoom_AmenityNotFound() {
+	suite.rrm.On("Create", mock.Anything, mockRoom).Return(model.Room{}, fmt.Errorf("%w: unknown", ErrAmenityNotFound))
+	_, err := suite.ru.RegisterNewRoom(context.Background(), mockRoom)
+	assert.ErrorIs(suite.T(), err, ErrAmenityNotFound)
+}
+
 func (suite *RoomUsecaseTestSuite) TestFindById_Success() {
-	suite.rrm.On("Get", mockRoom.Id).Return(mockRoom, nil)
-	_, err := suite.ru.FindById(mockRoom.Id)
+	suite.rrm.On("Get", mock.Anything, mockRoom.Id).Return(mockRoom, nil)
+	_, err := suite.ru.FindById(context.Background(), mockRoom.Id)
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
 }
 
-func (suite *RoomUsecaseTestSuite) TestFindByRoomType_Success() {
-	suite.rrm.On("GetByRoomType", mockRoom.RoomType).Return(mockRoom, nil)
-	_, err := suite.ru.FindByRoomType(mockRoom.RoomType)
-	assert.Nil(suite.T(), err)
+func (suite *RoomUsecaseTestSuite) TestArchiveById_Success() {
+	var sent []modelutil.BodySender
+	suite.ru = NewRoomUseCase(suite.rrm, suite.uum, suite.aum, &usecasemock.EmailServiceMock{SendEmailFunc: func(payload modelutil.BodySender) error {
+		sent = append(sent, payload)
+		return nil
+	}}, suite.cfm)
+	option := dto.ArchiveOption{Cascade: "cancel"}
+	affected := []dto.AffectedBooking{{BookingDetailId: "bd1", UserName: "budi", UserEmail: "budi@mail.com", Participants: []string{"sari@mail.com"}}}
+	suite.rrm.On("Get", mock.Anything, mockRoom.Id).Return(mockRoom, nil)
+	suite.rrm.On("Archive", mock.Anything, mockRoom.Id, option, mock.Anything).Return(affected, nil)
+
+	result, err := suite.ru.ArchiveById(context.Background(), mockRoom.Id, option)
+
 	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), result.Room.ArchivedAt)
+	assert.True(suite.T(), result.AffectedBookings[0].Notified)
+	assert.Equal(suite.T(), []string{"budi@mail.com", "sari@mail.com"}, sent[0].To)
 }
 
-func (suite *RoomUsecaseTestSuite) TestViewAllRooms_Success() {
-	suite.rrm.On("GetAllRoom").Return(arrayMockRoom, nil)
-	_, err := suite.ru.ViewAllRooms()
-	assert.Nil(suite.T(), err)
+func (suite *RoomUsecaseTestSuite) TestArchiveById_CancelSendsCalendarCancel() {
+	var sent []modelutil.BodySender
+	suite.ru = NewRoomUseCase(suite.rrm, suite.uum, suite.aum, &usecasemock.EmailServiceMock{SendEmailFunc: func(payload modelutil.BodySender) error {
+		sent = append(sent, payload)
+		return nil
+	}}, suite.cfm)
+	option := dto.ArchiveOption{Cascade: "cancel"}
+	affected := []dto.AffectedBooking{{BookingDetailId: "bd1", UserEmail: "budi@mail.com", Participants: []string{"sari@mail.com"}, Status: "accept", InviteSequence: 1}, {BookingDetailId: "bd2", Status: "pending"}}
+	suite.rrm.On("Get", mock.Anything, mockRoom.Id).Return(mockRoom, nil)
+	suite.rrm.On("Archive", mock.Anything, mockRoom.Id, option, mock.Anything).Return(affected, nil)
+
+	_, err := suite.ru.ArchiveById(context.Background(), mockRoom.Id, option)
+
 	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "CANCEL", sent[0].Invite.Method)
+	assert.Equal(suite.T(), "booking-bd1@final-project-booking-room", sent[0].Invite.UID)
+	assert.Equal(suite.T(), 1, sent[0].Invite.Sequence)
+	assert.Equal(suite.T(), []string{"budi@mail.com", "sari@mail.com"}, sent[0].Invite.Attendees)
+	assert.Nil(suite.T(), sent[1].Invite)
 }
 
-func (suite *RoomUsecaseTestSuite) TestDeleteById_Success() {
-	suite.rrm.On("Delete", mockRoom.Id).Return(mockRoom, nil)
-	_, err := suite.ru.DeleteById(mockRoom.Id)
-	assert.Nil(suite.T(), err)
+func (suite *RoomUsecaseTestSuite) TestArchiveById_CancelDeletesConference() {
+	option := dto.ArchiveOption{Cascade: "cancel"}
+	affected := []dto.AffectedBooking{{BookingDetailId: "bd1", ConferenceId: "m1"}, {BookingDetailId: "bd2"}}
+	suite.rrm.On("Get", mock.Anything, mockRoom.Id).Return(mockRoom, nil)
+	suite.rrm.On("Archive", mock.Anything, mockRoom.Id, option, mock.Anything).Return(affected, nil)
+	suite.cfm.On("DeleteMeeting", mock.Anything, "m1").Return(nil)
+
+	_, err := suite.ru.ArchiveById(context.Background(), mockRoom.Id, option)
+
+	assert.NoError(suite.T(), err)
+	suite.cfm.AssertNumberOfCalls(suite.T(), "DeleteMeeting", 1)
+}
+
+func (suite *RoomUsecaseTestSuite) TestArchiveById_ReassignMovesConference() {
+	target := model.Room{Id: "2", Name: "Melati"}
+	option := dto.ArchiveOption{Cascade: "reassign", ReassignTo: target.Id}
+	affected := []dto.AffectedBooking{{BookingDetailId: "bd1", ConferenceId: "m1", Description: "sync tim"}}
+	suite.rrm.On("Get", mock.Anything, target.Id).Return(target, nil)
+	suite.rrm.On("Get", mock.Anything, mockRoom.Id).Return(mockRoom, nil)
+	suite.rrm.On("Archive", mock.Anything, mockRoom.Id, option, mock.Anything).Return(affected, nil)
+	suite.cfm.On("UpdateMeeting", mock.Anything, "m1", mock.MatchedBy(func(req modelutil.ConferenceRequest) bool {
+		return req.Location == "Melati" && req.Topic == "sync tim"
+	})).Return(nil)
+
+	_, err := suite.ru.ArchiveById(context.Background(), mockRoom.Id, option)
+
+	assert.NoError(suite.T(), err)
+	suite.cfm.AssertExpectations(suite.T())
+	suite.cfm.AssertNotCalled(suite.T(), "DeleteMeeting", mock.Anything, mock.Anything)
+}
+
+func (suite *RoomUsecaseTestSuite) TestArchiveById_ReassignRestrictedTarget() {
+	target := model.Room{Id: "2", Name: "Melati", AccessRules: []model.RoomAccessRule{{Effect: "allow", Field: "divisi", Value: "Finance"}}}
+	option := dto.ArchiveOption{Cascade: "reassign", ReassignTo: target.Id}
+	bookings := []dto.AffectedBooking{
+		{BookingDetailId: "bd1", UserId: "u1"},
+		{BookingDetailId: "bd2", UserId: "u1"},
+		{BookingDetailId: "bd3", UserId: "u2"},
+	}
+	suite.rrm.On("Get", mock.Anything, target.Id).Return(target, nil)
+	suite.rrm.On("Get", mock.Anything, mockRoom.Id).Return(mockRoom, nil)
+	suite.rrm.On("FutureBookings", mock.Anything, mockRoom.Id, mock.Anything).Return(bookings, nil)
+	suite.uum.On("FindById", mock.Anything, "u1").Return(model.User{Id: "u1", Name: "Sari", Divisi: "Finance"}, nil).Once()
+	suite.uum.On("FindById", mock.Anything, "u2").Return(model.User{Id: "u2", Name: "Budi", Divisi: "HR"}, nil)
+
+	_, err := suite.ru.ArchiveById(context.Background(), mockRoom.Id, option)
+
+	assert.EqualError(suite.T(), err, "room with id 2 is restricted for Budi, who has booking detail bd3")
+	suite.uum.AssertExpectations(suite.T())
+	suite.rrm.AssertNotCalled(suite.T(), "Archive", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *RoomUsecaseTestSuite) TestArchiveById_HasFutureBookings() {
+	affected := []dto.AffectedBooking{{BookingDetailId: "bd1"}}
+	suite.rrm.On("Get", mock.Anything, mockRoom.Id).Return(mockRoom, nil)
+	suite.rrm.On("Archive", mock.Anything, mockRoom.Id, dto.ArchiveOption{}, mock.Anything).Return(affected, ErrRoomHasFutureBookings)
+
+	result, err := suite.ru.ArchiveById(context.Background(), mockRoom.Id, dto.ArchiveOption{})
+
+	assert.ErrorIs(suite.T(), err, ErrRoomHasFutureBookings)
+	assert.Len(suite.T(), result.AffectedBookings, 1)
+}
+
+func (suite *RoomUsecaseTestSuite) TestArchiveById_InvalidReassign() {
+	_, err := suite.ru.ArchiveById(context.Background(), mockRoom.Id, dto.ArchiveOption{Cascade: "reassign", ReassignTo: mockRoom.Id})
+
+	assert.Error(suite.T(), err)
+	suite.rrm.AssertNotCalled(suite.T(), "Archive", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *RoomUsecaseTestSuite) TestRestoreById_Success() {
+	suite.rrm.On("Restore", mock.Anything, mockRoom.Id).Return(nil)
+	suite.rrm.On("Get", mock.Anything, mockRoom.Id).Return(mockRoom, nil)
+
+	room, err := suite.ru.RestoreById(context.Background(), mockRoom.Id)
+
 	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), mockRoom, room)
 }
 
 //update
 
 func (suite *RoomUsecaseTestSuite) TestGetRoomStatus_Success() {
-	suite.rrm.On("GetStatus", mockRoom.Id).Return(mockRoom.Status, nil)
-	_, err := suite.ru.GetRoomStatus(mockRoom.Id)
+	suite.rrm.On("GetStatus", mock.Anything, mockRoom.Id).Return(mockRoom.Status, nil)
+	_, err := suite.ru.GetRoomStatus(context.Background(), mockRoom.Id)
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
 }
 
 func (suite *RoomUsecaseTestSuite) TestGetRoomStatusByBdId_Success() {
-	suite.rrm.On("GetStatusByBd", mockRoom.Id).Return(mockRoom.Id, nil)
-	_, err := suite.ru.GetRoomStatusByBdId(mockRoom.Id)
+	suite.rrm.On("GetStatusByBd", mock.Anything, mockRoom.Id).Return(mockRoom.Id, nil)
+	_, err := suite.ru.GetRoomStatusByBdId(context.Background(), mockRoom.Id)
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
 }
 
 func (suite *RoomUsecaseTestSuite) TestChangeRoomStatus_Success() {
-	suite.rrm.On("ChangeStatus", mockRoom.Id).Return(mockRoom.Id, nil)
-	err := suite.ru.ChangeRoomStatus(mockRoom.Id)
+	suite.rrm.On("ChangeStatus", mock.Anything, mockRoom.Id).Return(mockRoom.Id, nil)
+	err := suite.ru.ChangeRoomStatus(context.Background(), mockRoom.Id)
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
 }
 
-func (suite *RoomUsecaseTestSuite) TestGetAllRoomByStatus() {
-	suite.rrm.On("GetAllRoomByStatus", mockRoom.Status).Return(arrayMockRoom, nil)
-	_, err := suite.ru.GetAllRoomByStatus(mockRoom.Status)
-	assert.Nil(suite.T(), err)
+func (suite *RoomUsecaseTestSuite) TestListRooms_DefaultPaging() {
+	expected := dto.RoomFilter{Status: "available", Page: 1, Size: 10}
+	paging := dto.Paging{Page: 1, Size: 10, TotalRows: 1, TotalPages: 1}
+	suite.rrm.On("List", mock.Anything, expected).Return([]model.Room{mockRoom}, paging, nil)
+
+	actual, actualPaging, err := suite.ru.ListRooms(context.Background(), dto.RoomFilter{Status: "available"}, "1", "admin")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []model.Room{mockRoom}, actual)
+	assert.Equal(suite.T(), paging, actualPaging)
+	suite.uum.AssertNotCalled(suite.T(), "FindById", mock.Anything, mock.Anything)
+}
+
+func (suite *RoomUsecaseTestSuite) TestListRooms_EmployeeOnlySeesAllowedRooms() {
+	expected := dto.RoomFilter{Viewer: &mockUser, Page: 2, Size: 100}
+	suite.uum.On("FindById", mock.Anything, mockUser.Id).Return(mockUser, nil)
+	suite.rrm.On("List", mock.Anything, expected).Return([]model.Room{}, dto.Paging{Page: 2, Size: 100}, nil)
+
+	_, _, err := suite.ru.ListRooms(context.Background(), dto.RoomFilter{Page: 2, Size: 500}, mockUser.Id, "employee")
+	assert.NoError(suite.T(), err)
+	suite.rrm.AssertCalled(suite.T(), "List", mock.Anything, expected)
+}
+
+func (suite *RoomUsecaseTestSuite) TestListRooms_InvalidFilter() {
+	_, _, err := suite.ru.ListRooms(context.Background(), dto.RoomFilter{SortBy: "price"}, "1", "admin")
+	assert.EqualError(suite.T(), err, `sortBy must be "name", "capacity" or "createdAt", not price`)
+
+	_, _, err = suite.ru.ListRooms(context.Background(), dto.RoomFilter{MinCapacity: 20, MaxCapacity: 10}, "1", "admin")
+	assert.EqualError(suite.T(), err, "maxCapacity can't be less than minCapacity")
+	suite.rrm.AssertNotCalled(suite.T(), "List", mock.Anything, mock.Anything)
+}
+
+func (suite *RoomUsecaseTestSuite) TestSetRoomAccess_Success() {
+	rules := []model.RoomAccessRule{{Effect: " Allow", Field: "Divisi", Value: "HR "}}
+	expected := []model.RoomAccessRule{{Effect: "allow", Field: "divisi", Value: "HR"}}
+	suite.rrm.On("Get", mock.Anything, mockRoom.Id).Return(mockRoom, nil)
+	suite.rrm.On("SetAccessRules", mock.Anything, mockRoom.Id, expected).Return(nil)
+
+	_, err := suite.ru.SetRoomAccess(context.Background(), mockRoom.Id, rules)
 	assert.NoError(suite.T(), err)
+	suite.rrm.AssertCalled(suite.T(), "SetAccessRules", mock.Anything, mockRoom.Id, expected)
+}
+
+func (suite *RoomUsecaseTestSuite) TestSetRoomAccess_InvalidField() {
+	suite.rrm.On("Get", mock.Anything, mockRoom.Id).Return(mockRoom, nil)
+
+	_, err := suite.ru.SetRoomAccess(context.Background(), mockRoom.Id, []model.RoomAccessRule{{Effect: "allow", Field: "email", Value: "a@mail.com"}})
+	assert.Error(suite.T(), err)
+	suite.rrm.AssertNotCalled(suite.T(), "SetAccessRules", mock.Anything, mock.Anything, mock.Anything)
 }
 
-func (suite *RoomUsecaseTestSuite) TestDeleteById() {
+func (suite *RoomUsecaseTestSuite) TestArchiveById_NotFound() {
 	expectedError := fmt.Errorf("room with id %s not found", mockRoom.Id)
 
-	suite.rrm.On("Delete", mockRoom.Id).Return(model.Room{}, expectedError)
+	suite.rrm.On("Get", mock.Anything, mockRoom.Id).Return(model.Room{}, expectedError)
 
-	deletedRoom, err := suite.ru.DeleteById(mockRoom.Id)
+	result, err := suite.ru.ArchiveById(context.Background(), mockRoom.Id, dto.ArchiveOption{})
 
 	suite.rrm.AssertExpectations(suite.T())
 
 	assert.EqualError(suite.T(), err, expectedError.Error())
-	assert.Equal(suite.T(), model.Room{}, deletedRoom)
+	assert.Equal(suite.T(), dto.ArchiveResult{}, result)
+}
+
+func (suite *RoomUsecaseTestSuite) TestSetRoomParts_Success() {
+	combined := model.Room{Id: "ab", Name: "Hall A+B"}
+	suite.rrm.On("Get", mock.Anything, "ab").Return(combined, nil).Once()
+	suite.rrm.On("Get", mock.Anything, "a").Return(model.Room{Id: "a"}, nil)
+	suite.rrm.On("Get", mock.Anything, "b").Return(model.Room{Id: "b"}, nil)
+	suite.rrm.On("SetParts", mock.Anything, "ab", []string{"a", "b"}).Return(nil)
+	combined.PartIds = []string{"a", "b"}
+	suite.rrm.On("Get", mock.Anything, "ab").Return(combined, nil).Once()
+
+	room, err := suite.ru.SetRoomParts(context.Background(), "ab", []string{"a", "b"})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"a", "b"}, room.PartIds)
+}
+
+func (suite *RoomUsecaseTestSuite) TestSetRoomParts_NestedCombination() {
+	suite.rrm.On("Get", mock.Anything, "abc").Return(model.Room{Id: "abc"}, nil)
+	suite.rrm.On("Get", mock.Anything, "ab").Return(model.Room{Id: "ab", PartIds: []string{"a", "b"}}, nil)
+	suite.rrm.On("Get", mock.Anything, "c").Return(model.Room{Id: "c"}, nil)
+
+	_, err := suite.ru.SetRoomParts(context.Background(), "abc", []string{"ab", "c"})
+
+	assert.EqualError(suite.T(), err, "room with id ab is itself a combined room")
+	suite.rrm.AssertNotCalled(suite.T(), "SetParts", mock.Anything, mock.Anything, mock.Anything)
+}
+
+var mockImportAmenities = []model.Amenity{
+	{Id: "a1", Code: "wifi", Type: "boolean"},
+	{Id: "a2", Code: "chairs", Type: "quantity"},
+}
+
+func (suite *RoomUsecaseTestSuite) TestImportRooms_CsvDryRun() {
+	file := strings.NewReader("Code,Name,RoomType,Capacity,Amenities\nR-01,Melati,meeting,8,wifi;chairs:8\n,,,,\nR-02,,training,30,\n")
+	rows := []dto.RoomImportRow{
+		{Row: 2, Room: model.Room{Code: "R-01", Name: "Melati", RoomType: "meeting", MaxCapacity: 8, Status: "available",
+			Amenities: []model.RoomAmenity{{Id: "a1", Code: "wifi", Quantity: 1}, {Id: "a2", Code: "chairs", Quantity: 8}}}},
+		{Row: 4, Room: model.Room{Code: "R-02", Name: "training", RoomType: "training", MaxCapacity: 30, Status: "available", Amenities: []model.RoomAmenity{}}},
+	}
+	suite.aum.On("ViewAllAmenities", mock.Anything).Return(mockImportAmenities, nil)
+	suite.rrm.On("Import", mock.Anything, rows, true).Return(dto.RoomImportResult{DryRun: true, TotalRows: 2, Created: 1, Updated: 1}, nil)
+
+	result, err := suite.ru.ImportRooms(context.Background(), "rooms.csv", file, true)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, result.Created)
+	suite.rrm.AssertExpectations(suite.T())
+}
+
+func (suite *RoomUsecaseTestSuite) TestImportRooms_InvalidRows() {
+	file := strings.NewReader("code,roomType,capacity,amenities\nR-01,meeting,8,projector\nR-02,meeting,0,\nR-03,meeting,10,chairs\nR-04,meeting,10,wifi\nR-04,meeting,12,\n")
+	suite.aum.On("ViewAllAmenities", mock.Anything).Return(mockImportAmenities, nil)
+
+	result, err := suite.ru.ImportRooms(context.Background(), "rooms.csv", file, false)
+
+	assert.ErrorIs(suite.T(), err, ErrRoomImportInvalid)
+	assert.Equal(suite.T(), 5, result.TotalRows)
+	assert.Equal(suite.T(), []dto.RoomImportError{
+		{Row: 2, Code: "R-01", Message: "amenity projector not found"},
+		{Row: 3, Code: "R-02", Message: "capacity must be a positive number"},
+		{Row: 4, Code: "R-03", Message: "amenity chairs needs a quantity, e.g. chairs:10"},
+		{Row: 6, Code: "R-04", Message: "code R-04 is already used on row 5"},
+	}, result.Errors)
+	suite.rrm.AssertNotCalled(suite.T(), "Import", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *RoomUsecaseTestSuite) TestImportRooms_Xlsx() {
+	xlsx := excelize.NewFile()
+	xlsx.SetCellValue("Sheet1", "A1", "code")
+	xlsx.SetCellValue("Sheet1", "B1", "roomType")
+	xlsx.SetCellValue("Sheet1", "C1", "capacity")
+	xlsx.SetCellValue("Sheet1", "A2", "R-01")
+	xlsx.SetCellValue("Sheet1", "B2", "meeting")
+	xlsx.SetCellValue("Sheet1", "C2", 6)
+	file, err := xlsx.WriteToBuffer()
+	assert.NoError(suite.T(), err)
+
+	rows := []dto.RoomImportRow{{Row: 2, Room: model.Room{Code: "R-01", Name: "meeting", RoomType: "meeting", MaxCapacity: 6, Status: "available", Amenities: []model.RoomAmenity{}}}}
+	suite.aum.On("ViewAllAmenities", mock.Anything).Return(mockImportAmenities, nil)
+	suite.rrm.On("Import", mock.Anything, rows, false).Return(dto.RoomImportResult{TotalRows: 1, Created: 1}, nil)
+
+	result, err := suite.ru.ImportRooms(context.Background(), "Rooms.XLSX", file, false)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, result.Created)
+}
+
+func (suite *RoomUsecaseTestSuite) TestImportRooms_MissingColumn() {
+	_, err := suite.ru.ImportRooms(context.Background(), "rooms.csv", strings.NewReader("code,name\nR-01,Melati\n"), false)
+
+	assert.EqualError(suite.T(), err, "column roomtype is required")
+}
+
+// mockRoomCandidates berisi ruangan yang muat untuk 3 orang, dari yang paling kecil
+func mockRoomCandidates(start time.Time) []dto.RoomCandidate {
+	return []dto.RoomCandidate{
+		{RoomId: "r4", RoomName: "Anggrek", Capacity: 4, BuildingId: "b2", Amenities: []string{"wifi"}},
+		{RoomId: "r6", RoomName: "Mawar", Capacity: 6, BuildingId: "b1", Amenities: []string{"projector", "wifi"}},
+		{RoomId: "r8", RoomName: "Melati", Capacity: 8, BuildingId: "b2", Amenities: []string{"projector", "wifi"},
+			Busy: []dto.BusyInterval{{From: start.Add(-30 * time.Minute), Until: start.Add(30 * time.Minute)}}},
+		{RoomId: "r40", RoomName: "Aula", Capacity: 40, BuildingId: "b1", Amenities: []string{"projector", "wifi"}},
+	}
+}
+
+func (suite *RoomUsecaseTestSuite) TestRecommendRooms_Ranking() {
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	end := start.Add(time.Hour)
+	user := model.User{Id: "u1", Role: "employee", Divisi: "HR"}
+	suite.uum.On("FindById", mock.Anything, "u1").Return(user, nil)
+	suite.rrm.On("UsualBuilding", mock.Anything, "u1").Return("b1", nil)
+	suite.rrm.On("Recommend", mock.Anything, dto.RecommendationFilter{Attendees: 3, Amenities: []string{"wifi", "projector"}, Start: start, End: end,
+		Viewer: &user, From: start.Add(-3 * time.Hour), Until: end.Add(3 * time.Hour)}).Return(mockRoomCandidates(start), nil)
+
+	result, err := suite.ru.RecommendRooms(context.Background(), dto.RecommendationFilter{Attendees: 3, Amenities: []string{" WiFi", "projector", "wifi"}, Start: start, End: end}, "u1", "employee")
+
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), result.Alternatives)
+	// Melati terpakai, Anggrek paling pas tapi tidak punya projector jadi di urutan terakhir
+	assert.Equal(suite.T(), []dto.RoomRecommendation{
+		{RoomId: "r6", RoomName: "Mawar", Capacity: 6, BuildingId: "b1", Score: 75, CapacityFit: 50, AmenityMatch: 100, UsualBuilding: true, MissingAmenities: []string{}},
+		{RoomId: "r40", RoomName: "Aula", Capacity: 40, BuildingId: "b1", Score: 53.75, CapacityFit: 7.5, AmenityMatch: 100, UsualBuilding: true, MissingAmenities: []string{}},
+		{RoomId: "r4", RoomName: "Anggrek", Capacity: 4, BuildingId: "b2", Score: 52.5, CapacityFit: 75, AmenityMatch: 50, MissingAmenities: []string{"projector"}},
+	}, result.Rooms)
+}
+
+func (suite *RoomUsecaseTestSuite) TestRecommendRooms_AlternativeTimes() {
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	end := start.Add(time.Hour)
+	// satu-satunya ruangan terpakai dari 1 jam sebelum sampai 1 jam setelah waktu yang diminta
+	candidates := []dto.RoomCandidate{{RoomId: "r6", RoomName: "Mawar", Capacity: 6, BuildingId: "b1",
+		Busy: []dto.BusyInterval{{From: start.Add(-time.Hour), Until: end.Add(time.Hour)}}}}
+	suite.rrm.On("UsualBuilding", mock.Anything, "ga1").Return("", nil)
+	suite.rrm.On("Recommend", mock.Anything, mock.Anything).Return(candidates, nil)
+
+	result, err := suite.ru.RecommendRooms(context.Background(), dto.RecommendationFilter{Attendees: 6, Start: start, End: end}, "ga1", "GA")
+
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), result.Rooms)
+	assert.Len(suite.T(), result.Alternatives, 5)
+	assert.Equal(suite.T(), start.Add(-2*time.Hour), result.Alternatives[0].Start)
+	assert.Equal(suite.T(), start.Add(2*time.Hour), result.Alternatives[1].Start)
+	assert.Equal(suite.T(), start.Add(-150*time.Minute), result.Alternatives[2].Start)
+	assert.Equal(suite.T(), "r6", result.Alternatives[0].Room.RoomId)
+	suite.uum.AssertNotCalled(suite.T(), "FindById", mock.Anything, mock.Anything)
+}
+
+func (suite *RoomUsecaseTestSuite) TestRecommendRooms_StartInPast() {
+	start := time.Now().Add(-time.Hour)
+
+	_, err := suite.ru.RecommendRooms(context.Background(), dto.RecommendationFilter{Attendees: 3, Start: start, End: start.Add(time.Hour)}, "u1", "employee")
+
+	assert.EqualError(suite.T(), err, "start can't be in the past")
+	suite.rrm.AssertNotCalled(suite.T(), "Recommend", mock.Anything, mock.Anything)
 }
 
 func (suite *RoomUsecaseTestSuite) TestFindById() {
 	expectedError := fmt.Errorf("room with ID %s not found", mockRoom.Id)
-	suite.rrm.On("Get", mockRoom.Id).Return(model.Room{}, expectedError)
+	suite.rrm.On("Get", mock.Anything, mockRoom.Id).Return(model.Room{}, expectedError)
 
-	foundRoom, err := suite.ru.FindById(mockRoom.Id)
+	foundRoom, err := suite.ru.FindById(context.Background(), mockRoom.Id)
 
 	suite.rrm.AssertExpectations(suite.T())
 
@@ -178,11 +493,11 @@ func (suite *RoomUsecaseTestSuite) TestUpdateById_Success() {
 
 	expectedError := fmt.Errorf("room with id %s not found", updatePayload.Id)
 
-	suite.rrm.On("Get", mockRoom.Id).Return(mockRoom, nil)
+	suite.rrm.On("Get", mock.Anything, mockRoom.Id).Return(mockRoom, nil)
 
-	suite.rrm.On("Update", roomId, mock.AnythingOfType("model.Room")).Return(model.Room{}, expectedError)
+	suite.rrm.On("Update", mock.Anything, roomId, mock.AnythingOfType("model.Room")).Return(model.Room{}, expectedError)
 
-	updatedRoom, err := suite.ru.UpdateById(roomId, updatePayload)
+	updatedRoom, err := suite.ru.UpdateById(context.Background(), roomId, updatePayload)
 
 	suite.rrm.AssertExpectations(suite.T())
 
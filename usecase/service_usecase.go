@@ -0,0 +1,193 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"final-project-booking-room/model"
+	"final-project-booking-room/model/dto"
+	"final-project-booking-room/repository"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// layanan boleh diantar paling awal satu jam sebelum booking dimulai, misalnya untuk IT setup
+const serviceEarlyDelivery = time.Hour
+
+type ServiceUseCase interface {
+	RequestService(ctx context.Context, bookingDetailId string, userId string, payload dto.ServiceOrderRequest) (model.ServiceOrder, error)
+	ViewBookingOrders(ctx context.Context, bookingDetailId string, userId string, roleUser string) ([]model.ServiceOrder, error)
+	ListOrders(ctx context.Context, filter dto.ServiceOrderFilter) ([]model.ServiceOrder, dto.Paging, error)
+	RespondOrder(ctx context.Context, id string, handledBy string, payload dto.ServiceApprovalRequest) (model.ServiceOrder, error)
+	UpdateFulfillment(ctx context.Context, id string, handledBy string, payload dto.ServiceFulfillmentRequest) (model.ServiceOrder, error)
+	CancelOrder(ctx context.Context, id string, userId string) error
+	ViewDailyList(ctx context.Context, filter dto.ServiceDailyFilter) (dto.ServiceDailyList, error)
+}
+
+type serviceUseCase struct {
+	repo repository.ServiceRepository
+}
+
+// RequestService implements ServiceUseCase.
+// Hanya pemesan yang bisa menambah order ke booking ruangan yang masih pending atau accept dan belum selesai.
+func (s *serviceUseCase) RequestService(ctx context.Context, bookingDetailId string, userId string, payload dto.ServiceOrderRequest) (model.ServiceOrder, error) {
+	order := model.ServiceOrder{
+		BookingDetailId: bookingDetailId,
+		RequestedBy:     userId,
+		ServiceType:     strings.ToLower(strings.TrimSpace(payload.ServiceType)),
+		Quantity:        payload.Quantity,
+		DeliverAt:       payload.DeliverAt,
+		Notes:           strings.TrimSpace(payload.Notes),
+	}
+	if !order.IsValidType() {
+		return model.ServiceOrder{}, fmt.Errorf("invalid serviceType %s, must be coffee_break, snack, lunch, dinner, it_setup or other", payload.ServiceType)
+	}
+	if order.ServiceType == "other" && order.Notes == "" {
+		return model.ServiceOrder{}, errors.New("notes is required for serviceType other")
+	}
+	if order.Quantity == 0 {
+		order.Quantity = 1
+	}
+	if order.Quantity < 0 {
+		return model.ServiceOrder{}, errors.New("quantity must be greater than 0")
+	}
+
+	target, err := s.repo.GetTarget(ctx, bookingDetailId)
+	if err != nil || target.UserId != userId {
+		return model.ServiceOrder{}, fmt.Errorf("booking detail with id %s not found", bookingDetailId)
+	}
+	if (target.Status != "pending" && target.Status != "accept") || !target.BookingDateEnd.After(time.Now()) {
+		return model.ServiceOrder{}, fmt.Errorf("booking detail with id %s is no longer active", bookingDetailId)
+	}
+
+	if order.DeliverAt.IsZero() {
+		order.DeliverAt = target.BookingDate
+	}
+	if order.DeliverAt.Before(target.BookingDate.Add(-serviceEarlyDelivery)) || !order.DeliverAt.Before(target.BookingDateEnd) {
+		return model.ServiceOrder{}, errors.New("deliverAt must be between one hour before the booking starts and its end")
+	}
+	if order.DeliverAt.Before(time.Now()) {
+		return model.ServiceOrder{}, errors.New("deliverAt can't be in the past")
+	}
+
+	order, err = s.repo.Create(ctx, order)
+	if err != nil {
+		return model.ServiceOrder{}, fmt.Errorf("failed to create service order: %v", err)
+	}
+	return order, nil
+}
+
+// ViewBookingOrders implements ServiceUseCase, employee hanya bisa melihat order di bookingnya sendiri
+func (s *serviceUseCase) ViewBookingOrders(ctx context.Context, bookingDetailId string, userId string, roleUser string) ([]model.ServiceOrder, error) {
+	target, err := s.repo.GetTarget(ctx, bookingDetailId)
+	if err != nil || (target.UserId != userId && roleUser == "employee") {
+		return nil, fmt.Errorf("booking detail with id %s not found", bookingDetailId)
+	}
+	return s.repo.ListByBookingDetail(ctx, bookingDetailId)
+}
+
+// ListOrders implements ServiceUseCase.
+func (s *serviceUseCase) ListOrders(ctx context.Context, filter dto.ServiceOrderFilter) ([]model.ServiceOrder, dto.Paging, error) {
+	switch filter.ApprovalStatus {
+	case "", "pending", "approved", "rejected", "cancelled":
+	default:
+		return nil, dto.Paging{}, fmt.Errorf(`approvalStatus must be "pending", "approved", "rejected" or "cancelled", not %s`, filter.ApprovalStatus)
+	}
+	switch filter.FulfillmentStatus {
+	case "", "pending", "in_progress", "fulfilled":
+	default:
+		return nil, dto.Paging{}, fmt.Errorf(`fulfillmentStatus must be "pending", "in_progress" or "fulfilled", not %s`, filter.FulfillmentStatus)
+	}
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.Size < 1 {
+		filter.Size = 10
+	}
+	if filter.Size > 100 {
+		filter.Size = 100
+	}
+
+	orders, paging, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, dto.Paging{}, fmt.Errorf("failed to get service orders: %v", err)
+	}
+	return orders, paging, nil
+}
+
+// RespondOrder implements ServiceUseCase.
+func (s *serviceUseCase) RespondOrder(ctx context.Context, id string, handledBy string, payload dto.ServiceApprovalRequest) (model.ServiceOrder, error) {
+	var status string
+	switch payload.Approval {
+	case "approve":
+		status = "approved"
+	case "reject":
+		status = "rejected"
+	default:
+		return model.ServiceOrder{}, fmt.Errorf(`please give approval: "approve" or "reject", not %s`, payload.Approval)
+	}
+
+	err := s.repo.Respond(ctx, id, status, handledBy, strings.TrimSpace(payload.Note), time.Now().UTC())
+	if errors.Is(err, repository.ErrServiceOrderStatus) {
+		return model.ServiceOrder{}, fmt.Errorf("service order with id %s not found or not pending", id)
+	}
+	if err != nil {
+		return model.ServiceOrder{}, fmt.Errorf("failed to respond service order: %v", err)
+	}
+	return s.repo.Get(ctx, id)
+}
+
+// UpdateFulfillment implements ServiceUseCase, fulfillment hanya bisa maju: pending, in_progress lalu fulfilled
+func (s *serviceUseCase) UpdateFulfillment(ctx context.Context, id string, handledBy string, payload dto.ServiceFulfillmentRequest) (model.ServiceOrder, error) {
+	var from []string
+	switch payload.Status {
+	case "in_progress":
+		from = []string{"pending"}
+	case "fulfilled":
+		from = []string{"pending", "in_progress"}
+	default:
+		return model.ServiceOrder{}, fmt.Errorf(`status must be "in_progress" or "fulfilled", not %s`, payload.Status)
+	}
+
+	err := s.repo.UpdateFulfillment(ctx, id, payload.Status, from, handledBy, strings.TrimSpace(payload.Note), time.Now().UTC())
+	if errors.Is(err, repository.ErrServiceOrderStatus) {
+		return model.ServiceOrder{}, fmt.Errorf("service order with id %s can't be set to %s, it must be approved and %s", id, payload.Status, strings.Join(from, " or "))
+	}
+	if err != nil {
+		return model.ServiceOrder{}, fmt.Errorf("failed to update service order: %v", err)
+	}
+	return s.repo.Get(ctx, id)
+}
+
+// CancelOrder implements ServiceUseCase.
+func (s *serviceUseCase) CancelOrder(ctx context.Context, id string, userId string) error {
+	if err := s.repo.Cancel(ctx, id, userId, time.Now().UTC()); err != nil {
+		return fmt.Errorf("service order with id %s not found or can't be cancelled", id)
+	}
+	return nil
+}
+
+// ViewDailyList implements ServiceUseCase, tanggal kosong berarti hari ini
+func (s *serviceUseCase) ViewDailyList(ctx context.Context, filter dto.ServiceDailyFilter) (dto.ServiceDailyList, error) {
+	if filter.Date == "" {
+		filter.Date = time.Now().Format("2006-01-02")
+	}
+	if _, err := time.Parse("2006-01-02", filter.Date); err != nil {
+		return dto.ServiceDailyList{}, errors.New("date must use format YYYY-MM-DD")
+	}
+
+	orders, err := s.repo.ListDaily(ctx, filter)
+	if err != nil {
+		return dto.ServiceDailyList{}, fmt.Errorf("failed to get service orders: %v", err)
+	}
+
+	list := dto.ServiceDailyList{Date: filter.Date, Totals: map[string]int{}, Orders: orders}
+	for _, order := range orders {
+		list.Totals[order.ServiceType] += order.Quantity
+	}
+	return list, nil
+}
+
+func NewServiceUseCase(repo repository.ServiceRepository) ServiceUseCase {
+	return &serviceUseCase{repo: repo}
+}
@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"final-project-booking-room/model"
+	"final-project-booking-room/model/dto"
+	"final-project-booking-room/repository"
+	repositorymock "final-project-booking-room/unit-test/mock-test/repository-mock"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ServiceUsecaseTestSuite struct {
+	suite.Suite
+	srm *repositorymock.ServiceRepositoryMock
+	su  ServiceUseCase
+}
+
+func (suite *ServiceUsecaseTestSuite) SetupTest() {
+	suite.srm = new(repositorymock.ServiceRepositoryMock)
+	suite.su = NewServiceUseCase(suite.srm)
+}
+
+func TestServiceUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(ServiceUsecaseTestSuite))
+}
+
+func (suite *ServiceUsecaseTestSuite) serviceTarget() dto.ServiceTarget {
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	return dto.ServiceTarget{UserId: "u1", Status: "pending", BookingDate: start, BookingDateEnd: start.Add(2 * time.Hour)}
+}
+
+func (suite *ServiceUsecaseTestSuite) TestRequestService_DefaultsToBookingStart() {
+	target := suite.serviceTarget()
+	suite.srm.On("GetTarget", mock.Anything, "bd1").Return(target, nil)
+	expected := model.ServiceOrder{BookingDetailId: "bd1", RequestedBy: "u1", ServiceType: "lunch", Quantity: 12, DeliverAt: target.BookingDate, Notes: "2 vegetarian"}
+	suite.srm.On("Create", mock.Anything, expected).Return(expected, nil)
+
+	order, err := suite.su.RequestService(context.Background(), "bd1", "u1", dto.ServiceOrderRequest{ServiceType: "Lunch", Quantity: 12, Notes: " 2 vegetarian "})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expected, order)
+}
+
+func (suite *ServiceUsecaseTestSuite) TestRequestService_InvalidType() {
+	_, err := suite.su.RequestService(context.Background(), "bd1", "u1", dto.ServiceOrderRequest{ServiceType: "massage"})
+
+	assert.EqualError(suite.T(), err, "invalid serviceType massage, must be coffee_break, snack, lunch, dinner, it_setup or other")
+	suite.srm.AssertNotCalled(suite.T(), "GetTarget", mock.Anything, mock.Anything)
+}
+
+func (suite *ServiceUsecaseTestSuite) TestRequestService_BookingCancelled() {
+	target := suite.serviceTarget()
+	target.Status = "cancel"
+	suite.srm.On("GetTarget", mock.Anything, "bd1").Return(target, nil)
+
+	_, err := suite.su.RequestService(context.Background(), "bd1", "u1", dto.ServiceOrderRequest{ServiceType: "coffee_break", Quantity: 5})
+
+	assert.EqualError(suite.T(), err, "booking detail with id bd1 is no longer active")
+	suite.srm.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *ServiceUsecaseTestSuite) TestRequestService_DeliverAfterBooking() {
+	target := suite.serviceTarget()
+	suite.srm.On("GetTarget", mock.Anything, "bd1").Return(target, nil)
+
+	_, err := suite.su.RequestService(context.Background(), "bd1", "u1", dto.ServiceOrderRequest{ServiceType: "it_setup", DeliverAt: target.BookingDateEnd.Add(time.Minute)})
+
+	assert.EqualError(suite.T(), err, "deliverAt must be between one hour before the booking starts and its end")
+}
+
+func (suite *ServiceUsecaseTestSuite) TestRespondOrder_NotPending() {
+	suite.srm.On("Respond", mock.Anything, "so1", "approved", "s1", "", mock.Anything).Return(repository.ErrServiceOrderStatus)
+
+	_, err := suite.su.RespondOrder(context.Background(), "so1", "s1", dto.ServiceApprovalRequest{Approval: "approve"})
+
+	assert.EqualError(suite.T(), err, "service order with id so1 not found or not pending")
+}
+
+func (suite *ServiceUsecaseTestSuite) TestUpdateFulfillment_InProgressFromPending() {
+	suite.srm.On("UpdateFulfillment", mock.Anything, "so1", "in_progress", []string{"pending"}, "s1", "", mock.Anything).Return(nil)
+	suite.srm.On("Get", mock.Anything, "so1").Return(model.ServiceOrder{Id: "so1", FulfillmentStatus: "in_progress"}, nil)
+
+	order, err := suite.su.UpdateFulfillment(context.Background(), "so1", "s1", dto.ServiceFulfillmentRequest{Status: "in_progress"})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "in_progress", order.FulfillmentStatus)
+}
+
+func (suite *ServiceUsecaseTestSuite) TestViewDailyList_Totals() {
+	orders := []model.ServiceOrder{{Id: "so1", ServiceType: "lunch", Quantity: 10}, {Id: "so2", ServiceType: "lunch", Quantity: 4}, {Id: "so3", ServiceType: "coffee_break", Quantity: 8}}
+	suite.srm.On("ListDaily", mock.Anything, dto.ServiceDailyFilter{Date: "2026-10-20"}).Return(orders, nil)
+
+	list, err := suite.su.ViewDailyList(context.Background(), dto.ServiceDailyFilter{Date: "2026-10-20"})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), map[string]int{"lunch": 14, "coffee_break": 8}, list.Totals)
+	assert.Len(suite.T(), list.Orders, 3)
+}
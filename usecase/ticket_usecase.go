@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"final-project-booking-room/model"
+	"final-project-booking-room/model/dto"
+	"final-project-booking-room/repository"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type TicketUseCase interface {
+	SubmitFeedback(ctx context.Context, bookingDetailId string, userId string, payload dto.FeedbackRequest) (model.RoomFeedback, error)
+	ListTickets(ctx context.Context, filter dto.TicketFilter) ([]model.MaintenanceTicket, dto.Paging, error)
+	AssignTicket(ctx context.Context, id string, assigneeId string) (model.MaintenanceTicket, error)
+	ResolveTicket(ctx context.Context, id string, resolution string) (model.MaintenanceTicket, error)
+}
+
+type ticketUseCase struct {
+	repo   repository.TicketRepository
+	userUC UserUseCase
+}
+
+// SubmitFeedback implements TicketUseCase.
+// Pemesan, attendee dan delegate booking bisa memberi feedback, masing-masing sekali untuk setiap booking detail accept yang sudah selesai.
+func (t *ticketUseCase) SubmitFeedback(ctx context.Context, bookingDetailId string, userId string, payload dto.FeedbackRequest) (model.RoomFeedback, error) {
+	if payload.Rating < 1 || payload.Rating > 5 {
+		return model.RoomFeedback{}, errors.New("rating must be between 1 and 5")
+	}
+
+	tickets := make([]model.MaintenanceTicket, 0, len(payload.Issues))
+	for _, issue := range payload.Issues {
+		ticket := model.MaintenanceTicket{
+			Category:    strings.ToLower(strings.TrimSpace(issue.Category)),
+			Severity:    strings.ToLower(strings.TrimSpace(issue.Severity)),
+			Description: strings.TrimSpace(issue.Description),
+		}
+		if ticket.Category == "" {
+			return model.RoomFeedback{}, errors.New("issue category is required")
+		}
+		if !ticket.IsValidSeverity() {
+			return model.RoomFeedback{}, fmt.Errorf("invalid issue severity %s, must be low, medium, high or critical", issue.Severity)
+		}
+		tickets = append(tickets, ticket)
+	}
+
+	target, err := t.repo.GetFeedbackTarget(ctx, bookingDetailId, userId)
+	if err != nil || (target.UserId != userId && !target.Participant) {
+		return model.RoomFeedback{}, fmt.Errorf("booking detail with id %s not found", bookingDetailId)
+	}
+	if target.Status != "accept" || target.BookingDateEnd.After(time.Now()) {
+		return model.RoomFeedback{}, fmt.Errorf("booking detail with id %s is not completed yet", bookingDetailId)
+	}
+	if target.HasFeedback {
+		return model.RoomFeedback{}, fmt.Errorf("you already gave feedback for booking detail with id %s", bookingDetailId)
+	}
+
+	feedback, err := t.repo.CreateFeedback(ctx, model.RoomFeedback{
+		BookingDetailId: bookingDetailId,
+		RoomId:          target.RoomId,
+		UserId:          userId,
+		Rating:          payload.Rating,
+		Comment:         strings.TrimSpace(payload.Comment),
+		Tickets:         tickets,
+	})
+	if err != nil {
+		return model.RoomFeedback{}, fmt.Errorf("failed to save feedback: %v", err)
+	}
+	return feedback, nil
+}
+
+// ListTickets implements TicketUseCase.
+func (t *ticketUseCase) ListTickets(ctx context.Context, filter dto.TicketFilter) ([]model.MaintenanceTicket, dto.Paging, error) {
+	switch filter.Status {
+	case "", "open", "assigned", "resolved":
+	default:
+		return nil, dto.Paging{}, fmt.Errorf(`status must be "open", "assigned" or "resolved", not %s`, filter.Status)
+	}
+	if filter.Severity != "" && !(model.MaintenanceTicket{Severity: filter.Severity}).IsValidSeverity() {
+		return nil, dto.Paging{}, fmt.Errorf("invalid severity %s, must be low, medium, high or critical", filter.Severity)
+	}
+
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.Size < 1 {
+		filter.Size = 10
+	}
+	if filter.Size > 100 {
+		filter.Size = 100
+	}
+
+	tickets, paging, err := t.repo.List(ctx, filter)
+	if err != nil {
+		return nil, dto.Paging{}, fmt.Errorf("failed to get tickets: %v", err)
+	}
+	return tickets, paging, nil
+}
+
+// AssignTicket implements TicketUseCase.
+// Tiket hanya bisa diberikan ke staf GA, tiket yang sudah resolved tidak bisa diassign ulang.
+func (t *ticketUseCase) AssignTicket(ctx context.Context, id string, assigneeId string) (model.MaintenanceTicket, error) {
+	assignee, err := t.userUC.FindById(ctx, assigneeId)
+	if err != nil {
+		return model.MaintenanceTicket{}, fmt.Errorf("user with ID %s not found", assigneeId)
+	}
+	if assignee.Role != "GA" {
+		return model.MaintenanceTicket{}, fmt.Errorf("user with ID %s is not a GA staff", assigneeId)
+	}
+
+	if err := t.repo.Assign(ctx, id, assigneeId); err != nil {
+		return model.MaintenanceTicket{}, fmt.Errorf("ticket with id %s not found or already resolved", id)
+	}
+	return t.repo.Get(ctx, id)
+}
+
+// ResolveTicket implements TicketUseCase.
+// Setelah tiket critical resolved, ruangan kembali bisa dibooking jika tidak ada tiket critical lain.
+func (t *ticketUseCase) ResolveTicket(ctx context.Context, id string, resolution string) (model.MaintenanceTicket, error) {
+	resolution = strings.TrimSpace(resolution)
+	if resolution == "" {
+		return model.MaintenanceTicket{}, errors.New("resolution is required")
+	}
+
+	if err := t.repo.Resolve(ctx, id, resolution, time.Now().UTC()); err != nil {
+		return model.MaintenanceTicket{}, fmt.Errorf("ticket with id %s not found or already resolved", id)
+	}
+	return t.repo.Get(ctx, id)
+}
+
+func NewTicketUseCase(repo repository.TicketRepository, userUC UserUseCase) TicketUseCase {
+	return &ticketUseCase{repo: repo, userUC: userUC}
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"final-project-booking-room/model"
+	"final-project-booking-room/model/dto"
+	repositorymock "final-project-booking-room/unit-test/mock-test/repository-mock"
+	usecasemock "final-project-booking-room/unit-test/mock-test/usecase-mock"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type TicketUsecaseTestSuite struct {
+	suite.Suite
+	trm *repositorymock.TicketRepositoryMock
+	uum *usecasemock.UserUseCaseMock
+	tu  TicketUseCase
+}
+
+func (suite *TicketUsecaseTestSuite) SetupTest() {
+	suite.trm = new(repositorymock.TicketRepositoryMock)
+	suite.uum = new(usecasemock.UserUseCaseMock)
+	suite.tu = NewTicketUseCase(suite.trm, suite.uum)
+}
+
+func TestTicketUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(TicketUsecaseTestSuite))
+}
+
+func (suite *TicketUsecaseTestSuite) TestSubmitFeedback_OpensTicket() {
+	target := dto.FeedbackTarget{RoomId: "r1", UserId: "u1", Status: "accept", BookingDateEnd: time.Now().Add(-time.Hour)}
+	suite.trm.On("GetFeedbackTarget", mock.Anything, "bd1", "u1").Return(target, nil)
+	expected := model.RoomFeedback{BookingDetailId: "bd1", RoomId: "r1", UserId: "u1", Rating: 2, Comment: "ruangan kotor",
+		Tickets: []model.MaintenanceTicket{{Category: "cleanliness", Severity: "medium", Description: "sampah belum dibuang"}}}
+	suite.trm.On("CreateFeedback", mock.Anything, expected).Return(expected, nil)
+
+	feedback, err := suite.tu.SubmitFeedback(context.Background(), "bd1", "u1", dto.FeedbackRequest{Rating: 2, Comment: " ruangan kotor ",
+		Issues: []dto.IssueRequest{{Category: "Cleanliness", Severity: "MEDIUM", Description: "sampah belum dibuang"}}})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expected, feedback)
+}
+
+func (suite *TicketUsecaseTestSuite) TestSubmitFeedback_BookingNotCompleted() {
+	target := dto.FeedbackTarget{RoomId: "r1", UserId: "u1", Status: "accept", BookingDateEnd: time.Now().Add(time.Hour)}
+	suite.trm.On("GetFeedbackTarget", mock.Anything, "bd1", "u1").Return(target, nil)
+
+	_, err := suite.tu.SubmitFeedback(context.Background(), "bd1", "u1", dto.FeedbackRequest{Rating: 5})
+
+	assert.EqualError(suite.T(), err, "booking detail with id bd1 is not completed yet")
+	suite.trm.AssertNotCalled(suite.T(), "CreateFeedback", mock.Anything, mock.Anything)
+}
+
+func (suite *TicketUsecaseTestSuite) TestSubmitFeedback_OtherUsersBooking() {
+	target := dto.FeedbackTarget{RoomId: "r1", UserId: "someone-else", Status: "accept", BookingDateEnd: time.Now().Add(-time.Hour)}
+	suite.trm.On("GetFeedbackTarget", mock.Anything, "bd1", "u1").Return(target, nil)
+
+	_, err := suite.tu.SubmitFeedback(context.Background(), "bd1", "u1", dto.FeedbackRequest{Rating: 4})
+
+	assert.EqualError(suite.T(), err, "booking detail with id bd1 not found")
+}
+
+func (suite *TicketUsecaseTestSuite) TestSubmitFeedback_Attendee() {
+	target := dto.FeedbackTarget{RoomId: "r1", UserId: "someone-else", Participant: true, Status: "accept", BookingDateEnd: time.Now().Add(-time.Hour)}
+	suite.trm.On("GetFeedbackTarget", mock.Anything, "bd1", "u1").Return(target, nil)
+	expected := model.RoomFeedback{BookingDetailId: "bd1", RoomId: "r1", UserId: "u1", Rating: 4, Tickets: []model.MaintenanceTicket{}}
+	suite.trm.On("CreateFeedback", mock.Anything, expected).Return(expected, nil)
+
+	feedback, err := suite.tu.SubmitFeedback(context.Background(), "bd1", "u1", dto.FeedbackRequest{Rating: 4})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "u1", feedback.UserId)
+}
+
+func (suite *TicketUsecaseTestSuite) TestSubmitFeedback_AlreadyGiven() {
+	target := dto.FeedbackTarget{RoomId: "r1", UserId: "u1", Status: "accept", BookingDateEnd: time.Now().Add(-time.Hour), HasFeedback: true}
+	suite.trm.On("GetFeedbackTarget", mock.Anything, "bd1", "u1").Return(target, nil)
+
+	_, err := suite.tu.SubmitFeedback(context.Background(), "bd1", "u1", dto.FeedbackRequest{Rating: 4})
+
+	assert.EqualError(suite.T(), err, "you already gave feedback for booking detail with id bd1")
+	suite.trm.AssertNotCalled(suite.T(), "CreateFeedback", mock.Anything, mock.Anything)
+}
+
+func (suite *TicketUsecaseTestSuite) TestSubmitFeedback_InvalidSeverity() {
+	_, err := suite.tu.SubmitFeedback(context.Background(), "bd1", "u1", dto.FeedbackRequest{Rating: 3, Issues: []dto.IssueRequest{{Category: "ac", Severity: "urgent"}}})
+
+	assert.EqualError(suite.T(), err, "invalid issue severity urgent, must be low, medium, high or critical")
+	suite.trm.AssertNotCalled(suite.T(), "GetFeedbackTarget", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TicketUsecaseTestSuite) TestAssignTicket_NotGA() {
+	suite.uum.On("FindById", mock.Anything, "u2").Return(model.User{Id: "u2", Role: "employee"}, nil)
+
+	_, err := suite.tu.AssignTicket(context.Background(), "t1", "u2")
+
+	assert.EqualError(suite.T(), err, "user with ID u2 is not a GA staff")
+	suite.trm.AssertNotCalled(suite.T(), "Assign", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TicketUsecaseTestSuite) TestResolveTicket_Success() {
+	resolved := model.MaintenanceTicket{Id: "t1", Status: "resolved", Resolution: "lampu proyektor diganti"}
+	suite.trm.On("Resolve", mock.Anything, "t1", "lampu proyektor diganti", mock.Anything).Return(nil)
+	suite.trm.On("Get", mock.Anything, "t1").Return(resolved, nil)
+
+	ticket, err := suite.tu.ResolveTicket(context.Background(), "t1", "lampu proyektor diganti")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), resolved, ticket)
+}
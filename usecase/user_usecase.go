@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"final-project-booking-room/model"
 	"final-project-booking-room/repository"
@@ -11,12 +12,12 @@ import (
 )
 
 type UserUseCase interface {
-	FindById(id string) (model.User, error)
-	RegisterNewUser(payload model.User) (model.User, error)
-	DeleteUser(id string) (model.User, error)
-	ViewAllUser() ([]model.User, error)
-	UpdateUserById(userId string, payload model.User) (model.User, error)
-	FindByEmailPassword(email string, password string) (model.User, error)
+	FindById(ctx context.Context, id string) (model.User, error)
+	RegisterNewUser(ctx context.Context, payload model.User) (model.User, error)
+	DeleteUser(ctx context.Context, id string) (model.User, error)
+	ViewAllUser(ctx context.Context) ([]model.User, error)
+	UpdateUserById(ctx context.Context, userId string, payload model.User) (model.User, error)
+	FindByEmailPassword(ctx context.Context, email string, password string) (model.User, error)
 }
 
 type userUseCase struct {
@@ -24,8 +25,8 @@ type userUseCase struct {
 	emailService common.EmailService
 }
 
-func (u *userUseCase) FindByEmailPassword(email string, password string) (model.User, error) {
-	user, err := u.repo.GetByEmail(email)
+func (u *userUseCase) FindByEmailPassword(ctx context.Context, email string, password string) (model.User, error) {
+	user, err := u.repo.GetByEmail(ctx, email)
 	if err != nil {
 		return model.User{}, fmt.Errorf("user with email %s not found", email)
 	}
@@ -40,7 +41,7 @@ func (u *userUseCase) FindByEmailPassword(email string, password string) (model.
 }
 
 // UpdateUserById implements UserUseCase.
-func (u *userUseCase) UpdateUserById(userId string, payload model.User) (model.User, error) {
+func (u *userUseCase) UpdateUserById(ctx context.Context, userId string, payload model.User) (model.User, error) {
 	newPassword, err := common.GeneratePasswordHash(payload.Password)
 	if err != nil {
 		return model.User{}, err
@@ -48,7 +49,7 @@ func (u *userUseCase) UpdateUserById(userId string, payload model.User) (model.U
 
 	payload.Password = newPassword
 
-	user, err := u.repo.UpdateUserById(userId, payload)
+	user, err := u.repo.UpdateUserById(ctx, userId, payload)
 	if err != nil {
 		return model.User{}, fmt.Errorf("failed to get all user : %s", err)
 	}
@@ -56,33 +57,33 @@ func (u *userUseCase) UpdateUserById(userId string, payload model.User) (model.U
 }
 
 // ViewAllUser implements UserUseCase.
-func (u *userUseCase) ViewAllUser() ([]model.User, error) {
-	user, err := u.repo.GetAllUser()
+func (u *userUseCase) ViewAllUser(ctx context.Context) ([]model.User, error) {
+	user, err := u.repo.GetAllUser(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all user : %s", err)
 	}
 	return user, nil
 }
 
-func (u *userUseCase) FindById(id string) (model.User, error) {
-	user, err := u.repo.GetById(id)
+func (u *userUseCase) FindById(ctx context.Context, id string) (model.User, error) {
+	user, err := u.repo.GetById(ctx, id)
 	if err != nil {
 		return model.User{}, fmt.Errorf("user with ID %s not found", id)
 	}
 	return user, nil
 }
 
-func (u *userUseCase) DeleteUser(id string) (model.User, error) {
-	_, err := u.repo.DeleteUserById(id)
+func (u *userUseCase) DeleteUser(ctx context.Context, id string) (model.User, error) {
+	_, err := u.repo.DeleteUserById(ctx, id)
 	if err != nil {
 		return model.User{}, fmt.Errorf("user with ID %s not found", id)
 	}
 	return model.User{}, nil
 }
 
-func (u *userUseCase) RegisterNewUser(payload model.User) (model.User, error) {
+func (u *userUseCase) RegisterNewUser(ctx context.Context, payload model.User) (model.User, error) {
 	if !payload.IsValidRole() {
-		return model.User{}, errors.New("invalid role, role must be admin or employee")
+		return model.User{}, errors.New("invalid role, role must be admin, employee, GA or services")
 	}
 
 	if payload.IsEmpty() {
@@ -109,7 +110,7 @@ func (u *userUseCase) RegisterNewUser(payload model.User) (model.User, error) {
 	}
 
 	payload.Password = newPassword
-	return u.repo.Create(payload)
+	return u.repo.Create(ctx, payload)
 
 }
 
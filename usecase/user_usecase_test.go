@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"final-project-booking-room/model"
 	repositorymock "final-project-booking-room/unit-test/mock-test/repository-mock"
 	usecasemock "final-project-booking-room/unit-test/mock-test/usecase-mock"
@@ -9,6 +10,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -46,11 +48,11 @@ var mockUser = model.User{
 func (suite *UserUseCaseTestSuite) TestGetAllUser_Success() {
 	mockError := fmt.Errorf("an error occurred")
 
-	suite.urm.On("GetAllUser").Return(sampleMockUser, mockError)
+	suite.urm.On("GetAllUser", mock.Anything).Return(sampleMockUser, mockError)
 
-	result, err := suite.uc.ViewAllUser()
+	result, err := suite.uc.ViewAllUser(context.Background())
 
-	suite.urm.AssertCalled(suite.T(), "GetAllUser")
+	suite.urm.AssertCalled(suite.T(), "GetAllUser", mock.Anything)
 
 	assert.Error(suite.T(), err)
 	assert.EqualError(suite.T(), err, "failed to get all user : an error occurred")
@@ -59,16 +61,16 @@ func (suite *UserUseCaseTestSuite) TestGetAllUser_Success() {
 }
 
 func (s *UserUseCaseTestSuite) TestGetById_Success() {
-	s.urm.On("GetById", "1").Return(mockUser, nil)
+	s.urm.On("GetById", mock.Anything, "1").Return(mockUser, nil)
 
-	resultUser, err := s.uc.FindById("1")
+	resultUser, err := s.uc.FindById(context.Background(), "1")
 
 	assert.NoError(s.T(), err, "Unexpected error")
 	assert.Equal(s.T(), mockUser, resultUser, "User not as expected")
 
-	s.urm.On("GetById", "nonexistent").Return(model.User{}, fmt.Errorf("user not found"))
+	s.urm.On("GetById", mock.Anything, "nonexistent").Return(model.User{}, fmt.Errorf("user not found"))
 
-	_, err = s.uc.FindById("nonexistent")
+	_, err = s.uc.FindById(context.Background(), "nonexistent")
 
 	assert.Error(s.T(), err, "Expected error for user not found")
 	assert.EqualError(s.T(), err, "user with ID nonexistent not found", "Error message not as expected")
@@ -78,16 +80,16 @@ func (s *UserUseCaseTestSuite) TestGetById_Success() {
 
 // func (s *UserUseCaseTestSuite) TestDeleteById_Success() {
 
-// 	s.urm.On("DeleteUserById", "1").Return(mockUser, nil)
+// 	s.urm.On("DeleteUserById", mock.Anything, "1").Return(mockUser, nil)
 
-// 	resultUser, err := s.uc.DeleteUser("1")
+// 	resultUser, err := s.uc.DeleteUser(context.Background(), "1")
 
 // 	assert.NoError(s.T(), err, "Unexpected error")
 // 	assert.Equal(s.T(), mockUser, resultUser, "User not as expected")
 
-// 	s.urm.On("DeleteUserById", "nonexistent").Return(model.User{}, fmt.Errorf("user not found"))
+// 	s.urm.On("DeleteUserById", mock.Anything, "nonexistent").Return(model.User{}, fmt.Errorf("user not found"))
 
-// 	_, err = s.uc.DeleteUser("nonexistent")
+// 	_, err = s.uc.DeleteUser(context.Background(), "nonexistent")
 
 // 	assert.Error(s.T(), err, "Expected error for user not found")
 // 	assert.EqualError(s.T(), err, "user with ID nonexistent not found", "Error message not as expected")
@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"final-project-booking-room/model"
+	"final-project-booking-room/model/dto"
+	"final-project-booking-room/repository"
+	"final-project-booking-room/utils/common"
+	"final-project-booking-room/utils/modelutil"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+// visitor boleh datang paling awal satu jam sebelum booking dimulai
+const visitorEarlyArrival = time.Hour
+
+type VisitorUseCase interface {
+	RegisterVisitors(ctx context.Context, bookingDetailId string, userId string, roleUser string, payload dto.VisitorRequest) ([]model.Visitor, error)
+	ViewBookingVisitors(ctx context.Context, bookingDetailId string, userId string, roleUser string) ([]model.Visitor, error)
+	ViewDay(ctx context.Context, filter dto.VisitorFilter) ([]model.Visitor, error)
+	CheckIn(ctx context.Context, id string) (model.Visitor, error)
+	CheckOut(ctx context.Context, id string) (model.Visitor, error)
+	PurgeExpired(ctx context.Context, retention time.Duration) (int, error)
+}
+
+type visitorUseCase struct {
+	repo         repository.VisitorRepository
+	emailService common.EmailService
+}
+
+// RegisterVisitors implements VisitorUseCase.
+// Pemesan (atau admin) mendaftarkan tamu ke booking ruangan yang masih pending atau accept dan belum selesai.
+// Setiap tamu dikirimi email instruksi kunjungan, kegagalan kirim email hanya ditandai di Notified.
+func (v *visitorUseCase) RegisterVisitors(ctx context.Context, bookingDetailId string, userId string, roleUser string, payload dto.VisitorRequest) ([]model.Visitor, error) {
+	if len(payload.Visitors) == 0 {
+		return nil, errors.New("visitors can't be empty")
+	}
+
+	target, err := v.repo.GetTarget(ctx, bookingDetailId)
+	if err != nil || (target.UserId != userId && roleUser != "admin") {
+		return nil, fmt.Errorf("booking detail with id %s not found", bookingDetailId)
+	}
+	if (target.Status != "pending" && target.Status != "accept") || !target.BookingDateEnd.After(time.Now()) {
+		return nil, fmt.Errorf("booking detail with id %s is no longer active", bookingDetailId)
+	}
+
+	visitors := make([]model.Visitor, 0, len(payload.Visitors))
+	for _, input := range payload.Visitors {
+		visitor := model.Visitor{
+			Name:            strings.TrimSpace(input.Name),
+			Company:         strings.TrimSpace(input.Company),
+			Email:           strings.TrimSpace(input.Email),
+			ExpectedArrival: input.ExpectedArrival,
+		}
+		if visitor.Name == "" {
+			return nil, errors.New("visitor name is required")
+		}
+		if _, err := mail.ParseAddress(visitor.Email); err != nil {
+			return nil, fmt.Errorf("invalid email %s for visitor %s", input.Email, visitor.Name)
+		}
+		if visitor.ExpectedArrival.IsZero() {
+			visitor.ExpectedArrival = target.BookingDate
+		}
+		if visitor.ExpectedArrival.Before(target.BookingDate.Add(-visitorEarlyArrival)) || !visitor.ExpectedArrival.Before(target.BookingDateEnd) {
+			return nil, fmt.Errorf("expectedArrival of visitor %s must be between one hour before the booking starts and its end", visitor.Name)
+		}
+		visitors = append(visitors, visitor)
+	}
+
+	visitors, err = v.repo.CreateMany(ctx, bookingDetailId, userId, visitors)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register visitors: %v", err)
+	}
+
+	for i := range visitors {
+		visitors[i].Notified = v.emailService.SendEmail(visitInstruction(target, visitors[i])) == nil
+	}
+	return visitors, nil
+}
+
+// visitInstruction menyusun email instruksi kunjungan untuk tamu
+func visitInstruction(target dto.VisitorTarget, visitor model.Visitor) modelutil.BodySender {
+	loc := target.Location.Zone()
+	var place []string
+	for _, name := range []string{target.RoomName, target.Location.FloorName, target.Location.BuildingName, target.Location.SiteName} {
+		if name != "" {
+			place = append(place, name)
+		}
+	}
+
+	body := fmt.Sprintf("Halo %s,\n\nAnda terdaftar sebagai tamu %s untuk pertemuan di %s pada %s - %s (%s).\n\n"+
+		"Silakan datang ke resepsionis sekitar %s, tunjukkan email ini beserta kartu identitas untuk check-in, lalu lakukan check-out di resepsionis saat meninggalkan gedung.",
+		visitor.Name, target.HostName, strings.Join(place, ", "), target.BookingDate.In(loc).Format(reportTimeLayout), target.BookingDateEnd.In(loc).Format(reportTimeLayout), loc,
+		visitor.ExpectedArrival.In(loc).Format(reportTimeLayout))
+
+	return modelutil.BodySender{
+		To:      []string{visitor.Email},
+		Subject: "Instruksi Kunjungan",
+		Body:    body,
+	}
+}
+
+// ViewBookingVisitors implements VisitorUseCase, employee hanya bisa melihat tamu di bookingnya sendiri
+func (v *visitorUseCase) ViewBookingVisitors(ctx context.Context, bookingDetailId string, userId string, roleUser string) ([]model.Visitor, error) {
+	target, err := v.repo.GetTarget(ctx, bookingDetailId)
+	if err != nil || (target.UserId != userId && roleUser == "employee") {
+		return nil, fmt.Errorf("booking detail with id %s not found", bookingDetailId)
+	}
+	return v.repo.ListByBookingDetail(ctx, bookingDetailId)
+}
+
+// ViewDay implements VisitorUseCase, tanggal kosong berarti hari ini
+func (v *visitorUseCase) ViewDay(ctx context.Context, filter dto.VisitorFilter) ([]model.Visitor, error) {
+	if filter.Date == "" {
+		filter.Date = time.Now().Format("2006-01-02")
+	}
+	if _, err := time.Parse("2006-01-02", filter.Date); err != nil {
+		return nil, errors.New("date must use format YYYY-MM-DD")
+	}
+
+	visitors, err := v.repo.ListByDay(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get visitors: %v", err)
+	}
+	return visitors, nil
+}
+
+// CheckIn implements VisitorUseCase.
+func (v *visitorUseCase) CheckIn(ctx context.Context, id string) (model.Visitor, error) {
+	err := v.repo.CheckIn(ctx, id, time.Now().UTC())
+	if errors.Is(err, repository.ErrVisitorStatus) {
+		return model.Visitor{}, fmt.Errorf("visitor with id %s can't be checked in, the visitor must be expected and the booking accepted", id)
+	}
+	if err != nil {
+		return model.Visitor{}, fmt.Errorf("failed to check in visitor: %v", err)
+	}
+	return v.repo.Get(ctx, id)
+}
+
+// CheckOut implements VisitorUseCase.
+func (v *visitorUseCase) CheckOut(ctx context.Context, id string) (model.Visitor, error) {
+	err := v.repo.CheckOut(ctx, id, time.Now().UTC())
+	if errors.Is(err, repository.ErrVisitorStatus) {
+		return model.Visitor{}, fmt.Errorf("visitor with id %s can't be checked out, the visitor must be checked in", id)
+	}
+	if err != nil {
+		return model.Visitor{}, fmt.Errorf("failed to check out visitor: %v", err)
+	}
+	return v.repo.Get(ctx, id)
+}
+
+// PurgeExpired implements VisitorUseCase, menghapus visitor yang kunjungannya sudah lebih lama dari retention
+func (v *visitorUseCase) PurgeExpired(ctx context.Context, retention time.Duration) (int, error) {
+	if retention <= 0 {
+		return 0, errors.New("retention must be greater than 0")
+	}
+	return v.repo.Purge(ctx, time.Now().UTC().Add(-retention))
+}
+
+func NewVisitorUseCase(repo repository.VisitorRepository, emailService common.EmailService) VisitorUseCase {
+	return &visitorUseCase{repo: repo, emailService: emailService}
+}
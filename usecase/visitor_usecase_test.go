@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"final-project-booking-room/model"
+	"final-project-booking-room/model/dto"
+	"final-project-booking-room/repository"
+	repositorymock "final-project-booking-room/unit-test/mock-test/repository-mock"
+	usecasemock "final-project-booking-room/unit-test/mock-test/usecase-mock"
+	"final-project-booking-room/utils/modelutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type VisitorUsecaseTestSuite struct {
+	suite.Suite
+	vrm  *repositorymock.VisitorRepositoryMock
+	sent []modelutil.BodySender
+	vu   VisitorUseCase
+}
+
+func (suite *VisitorUsecaseTestSuite) SetupTest() {
+	suite.vrm = new(repositorymock.VisitorRepositoryMock)
+	suite.sent = nil
+	suite.vu = NewVisitorUseCase(suite.vrm, &usecasemock.EmailServiceMock{SendEmailFunc: func(payload modelutil.BodySender) error {
+		suite.sent = append(suite.sent, payload)
+		return nil
+	}})
+}
+
+func TestVisitorUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(VisitorUsecaseTestSuite))
+}
+
+func (suite *VisitorUsecaseTestSuite) visitorTarget() dto.VisitorTarget {
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	return dto.VisitorTarget{UserId: "u1", HostName: "Budi", Status: "accept", BookingDate: start, BookingDateEnd: start.Add(2 * time.Hour),
+		RoomId: "r1", RoomName: "Ruang Rapat 1", Location: model.RoomLocation{SiteName: "Jakarta HQ", BuildingName: "Tower A", FloorName: "Lantai 3", Timezone: "Asia/Jakarta"}}
+}
+
+func (suite *VisitorUsecaseTestSuite) TestRegisterVisitors_SendsInstruction() {
+	target := suite.visitorTarget()
+	suite.vrm.On("GetTarget", mock.Anything, "bd1").Return(target, nil)
+	expected := []model.Visitor{{Name: "Siti", Company: "PT Maju", Email: "siti@maju.co.id", ExpectedArrival: target.BookingDate}}
+	suite.vrm.On("CreateMany", mock.Anything, "bd1", "u1", expected).Return(expected, nil)
+
+	visitors, err := suite.vu.RegisterVisitors(context.Background(), "bd1", "u1", "employee", dto.VisitorRequest{
+		Visitors: []dto.VisitorInput{{Name: " Siti ", Company: "PT Maju", Email: "siti@maju.co.id"}}})
+
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), visitors[0].Notified)
+	assert.Len(suite.T(), suite.sent, 1)
+	assert.Equal(suite.T(), []string{"siti@maju.co.id"}, suite.sent[0].To)
+	assert.Contains(suite.T(), suite.sent[0].Body, "Ruang Rapat 1, Lantai 3, Tower A, Jakarta HQ")
+}
+
+func (suite *VisitorUsecaseTestSuite) TestRegisterVisitors_OtherUsersBooking() {
+	suite.vrm.On("GetTarget", mock.Anything, "bd1").Return(suite.visitorTarget(), nil)
+
+	_, err := suite.vu.RegisterVisitors(context.Background(), "bd1", "u2", "employee", dto.VisitorRequest{
+		Visitors: []dto.VisitorInput{{Name: "Siti", Email: "siti@maju.co.id"}}})
+
+	assert.EqualError(suite.T(), err, "booking detail with id bd1 not found")
+	suite.vrm.AssertNotCalled(suite.T(), "CreateMany", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *VisitorUsecaseTestSuite) TestRegisterVisitors_ArrivalAfterBooking() {
+	target := suite.visitorTarget()
+	suite.vrm.On("GetTarget", mock.Anything, "bd1").Return(target, nil)
+
+	_, err := suite.vu.RegisterVisitors(context.Background(), "bd1", "u1", "employee", dto.VisitorRequest{
+		Visitors: []dto.VisitorInput{{Name: "Siti", Email: "siti@maju.co.id", ExpectedArrival: target.BookingDateEnd}}})
+
+	assert.EqualError(suite.T(), err, "expectedArrival of visitor Siti must be between one hour before the booking starts and its end")
+	assert.Empty(suite.T(), suite.sent)
+}
+
+func (suite *VisitorUsecaseTestSuite) TestRegisterVisitors_InvalidEmail() {
+	suite.vrm.On("GetTarget", mock.Anything, "bd1").Return(suite.visitorTarget(), nil)
+
+	_, err := suite.vu.RegisterVisitors(context.Background(), "bd1", "u1", "employee", dto.VisitorRequest{
+		Visitors: []dto.VisitorInput{{Name: "Siti", Email: "siti"}}})
+
+	assert.EqualError(suite.T(), err, "invalid email siti for visitor Siti")
+}
+
+func (suite *VisitorUsecaseTestSuite) TestCheckIn_NotExpected() {
+	suite.vrm.On("CheckIn", mock.Anything, "v1", mock.Anything).Return(repository.ErrVisitorStatus)
+
+	_, err := suite.vu.CheckIn(context.Background(), "v1")
+
+	assert.EqualError(suite.T(), err, "visitor with id v1 can't be checked in, the visitor must be expected and the booking accepted")
+}
+
+func (suite *VisitorUsecaseTestSuite) TestViewDay_InvalidDate() {
+	_, err := suite.vu.ViewDay(context.Background(), dto.VisitorFilter{Date: "19-10-2026"})
+
+	assert.EqualError(suite.T(), err, "date must use format YYYY-MM-DD")
+	suite.vrm.AssertNotCalled(suite.T(), "ListByDay", mock.Anything, mock.Anything)
+}
+
+func (suite *VisitorUsecaseTestSuite) TestPurgeExpired_UsesRetention() {
+	suite.vrm.On("Purge", mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+		return time.Since(before) > 29*24*time.Hour && time.Since(before) < 31*24*time.Hour
+	})).Return(3, nil)
+
+	purged, err := suite.vu.PurgeExpired(context.Background(), 30*24*time.Hour)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 3, purged)
+}
+
+func (suite *VisitorUsecaseTestSuite) TestPurgeExpired_RepoError() {
+	suite.vrm.On("Purge", mock.Anything, mock.Anything).Return(0, errors.New("db down"))
+
+	_, err := suite.vu.PurgeExpired(context.Background(), time.Hour)
+
+	assert.Error(suite.T(), err)
+}
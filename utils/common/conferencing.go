@@ -0,0 +1,63 @@
+package common
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"final-project-booking-room/config"
+	"final-project-booking-room/utils/modelutil"
+	"fmt"
+	"strings"
+)
+
+// ConferencingProvider membuat link video conference untuk booking. Provider lokal dipakai sekarang,
+// provider Zoom, Google Meet atau Teams cukup memenuhi interface yang sama.
+type ConferencingProvider interface {
+	CreateMeeting(ctx context.Context, payload modelutil.ConferenceRequest) (modelutil.ConferenceMeeting, error)
+	// UpdateMeeting mengubah jadwal dan ruangan meeting, link untuk join tetap sama
+	UpdateMeeting(ctx context.Context, meetingId string, payload modelutil.ConferenceRequest) error
+	DeleteMeeting(ctx context.Context, meetingId string) error
+}
+
+// localConferencing adalah stub yang membuat link di bawah BaseURL tanpa memanggil layanan luar
+type localConferencing struct {
+	cfg config.ConferenceConfig
+}
+
+func (l *localConferencing) CreateMeeting(ctx context.Context, payload modelutil.ConferenceRequest) (modelutil.ConferenceMeeting, error) {
+	if err := ctx.Err(); err != nil {
+		return modelutil.ConferenceMeeting{}, err
+	}
+
+	code := make([]byte, 6)
+	if _, err := rand.Read(code); err != nil {
+		return modelutil.ConferenceMeeting{}, err
+	}
+	id := hex.EncodeToString(code)
+	return modelutil.ConferenceMeeting{Id: id, JoinURL: strings.TrimSuffix(l.cfg.BaseURL, "/") + "/" + id}, nil
+}
+
+func (l *localConferencing) UpdateMeeting(ctx context.Context, meetingId string, payload modelutil.ConferenceRequest) error {
+	if meetingId == "" {
+		return errors.New("meeting id is required")
+	}
+	return ctx.Err()
+}
+
+func (l *localConferencing) DeleteMeeting(ctx context.Context, meetingId string) error {
+	if meetingId == "" {
+		return errors.New("meeting id is required")
+	}
+	return ctx.Err()
+}
+
+// NewConferencingProvider memilih provider sesuai cfg.Provider
+func NewConferencingProvider(cfg config.ConferenceConfig) (ConferencingProvider, error) {
+	switch cfg.Provider {
+	case "local":
+		return &localConferencing{cfg: cfg}, nil
+	default:
+		return nil, fmt.Errorf("unknown conferencing provider %s", cfg.Provider)
+	}
+}
@@ -1,12 +1,19 @@
 package common
 
 import (
+	"bytes"
 	"encoding/base64"
 	"final-project-booking-room/config"
 	"final-project-booking-room/utils/modelutil"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"fmt"
-	"io"
 	"net/smtp"
 	"os"
 )
@@ -20,14 +27,15 @@ type emailService struct {
 	cfg *config.Config
 }
 
+// SendEmail mengirim body sebagai text/plain, lampiran dan invite (jika ada) dikirim sebagai multipart/mixed
 func (e *emailService) SendEmail(payload modelutil.BodySender) error {
-	message := "From: " + e.cfg.EmailFrom + "\n" +
-		"To: " + payload.To[0] + "\n" +
-		"Subject: " + payload.Subject + "\n\n" +
-		payload.Body
+	message, err := e.buildMessage(payload, time.Now())
+	if err != nil {
+		return err
+	}
 
 	auth := smtp.PlainAuth("", e.cfg.EmailFrom, e.cfg.EmailConfig.Password, e.cfg.Server)
-	err := smtp.SendMail(e.cfg.Server+":"+e.cfg.EmailConfig.Port, auth, e.cfg.EmailFrom, payload.To, []byte(message))
+	err = smtp.SendMail(e.cfg.Server+":"+e.cfg.EmailConfig.Port, auth, e.cfg.EmailFrom, payload.To, message)
 	if err != nil {
 		return err
 	}
@@ -36,43 +44,112 @@ func (e *emailService) SendEmail(payload modelutil.BodySender) error {
 
 }
 
+// SendEmailFile mengirim email dengan file CSVFilePath sebagai lampiran
 func (e *emailService) SendEmailFile(payload modelutil.BodySender) error {
-	file, err := os.Open(payload.CSVFilePath)
+	contents, err := os.ReadFile(payload.CSVFilePath)
 	if err != nil {
-		return fmt.Errorf("error opening csv file: %v", err)
+		return fmt.Errorf("error reading CSV file: %v", err)
 	}
-	defer file.Close()
 
-	contents, err := io.ReadAll(file)
-	if err != nil {
-		return fmt.Errorf("error reading CSV file: %v", err)
+	payload.Attachments = append(append([]modelutil.Attachment{}, payload.Attachments...), modelutil.Attachment{
+		Filename:    filepath.Base(payload.CSVFilePath),
+		ContentType: attachmentType(payload.CSVFilePath),
+		Data:        contents,
+	})
+	if err := e.SendEmail(payload); err != nil {
+		return fmt.Errorf("error sending email: %v", err)
 	}
 
-	encodedContents := base64.StdEncoding.EncodeToString(contents)
+	return nil
 
-	message := "From: " + e.cfg.EmailFrom + "\n" +
-		"To: " + payload.To[0] + "\n" +
-		"Subject: " + payload.Subject + "\n" +
-		"Content-Type: multipart/mixed; boundary=boundarystring\n\n" +
-		"--boundarystring\n" +
-		"Content-Type: text/plain; charset=UTF-8\n\n" +
-		payload.Body + "\n\n" +
-		"--boundarystring\n" +
-		"Content-Type: application/csv\n" +
-		"Content-Transfer-Encoding: base64\n" +
-		"Content-Disposition: attachment; filename=\"" + payload.CSVFilePath + "\"\n\n"
+}
 
-	message += encodedContents + "\n\n" +
-		"--boundarystring--"
+// attachmentTypes dipakai sebelum tabel mime sistem yang isinya berbeda antar OS
+var attachmentTypes = map[string]string{
+	".csv":  "text/csv",
+	".xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
 
-	auth := smtp.PlainAuth("", e.cfg.EmailFrom, e.cfg.EmailConfig.Password, e.cfg.Server)
-	err = smtp.SendMail(e.cfg.Server+":"+e.cfg.EmailConfig.Port, auth, e.cfg.EmailFrom, payload.To, []byte(message))
+// attachmentType menentukan Content-Type lampiran dari ekstensi file
+func attachmentType(path string) string {
+	ext := strings.ToLower(filepath.Ext(path))
+	if contentType, ok := attachmentTypes[ext]; ok {
+		return contentType
+	}
+	if contentType := mime.TypeByExtension(ext); contentType != "" {
+		return contentType
+	}
+	return "application/octet-stream"
+}
+
+// buildMessage menyusun pesan MIME dengan baris CRLF, body text/plain quoted-printable dan lampiran base64
+func (e *emailService) buildMessage(payload modelutil.BodySender, now time.Time) ([]byte, error) {
+	attachments := append([]modelutil.Attachment{}, payload.Attachments...)
+	if payload.Invite != nil {
+		attachments = append(attachments, modelutil.Attachment{
+			Filename:    "invite.ics",
+			ContentType: mime.FormatMediaType("text/calendar", map[string]string{"charset": "UTF-8", "method": payload.Invite.Method}),
+			Data:        NewICalendar(*payload.Invite, e.cfg.EmailFrom, now),
+		})
+	}
+
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	header := "From: " + e.cfg.EmailFrom + "\r\n" +
+		"To: " + strings.Join(payload.To, ", ") + "\r\n" +
+		"Subject: " + mime.QEncoding.Encode("UTF-8", payload.Subject) + "\r\n" +
+		"Date: " + now.Format(time.RFC1123Z) + "\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: multipart/mixed; boundary=" + writer.Boundary() + "\r\n\r\n"
+	buf.WriteString(header)
+
+	part, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/plain; charset=UTF-8"},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
 	if err != nil {
-		return fmt.Errorf("error sending email: %v", err)
+		return nil, err
+	}
+	body := quotedprintable.NewWriter(part)
+	if _, err := body.Write([]byte(payload.Body)); err != nil {
+		return nil, err
+	}
+	if err := body.Close(); err != nil {
+		return nil, err
 	}
 
-	return nil
+	for _, attachment := range attachments {
+		part, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {attachment.ContentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := part.Write(wrapBase64(attachment.Data)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
 
+// wrapBase64 meng-encode data dengan baris maksimal 76 karakter sesuai RFC 2045
+func wrapBase64(data []byte) []byte {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	var b bytes.Buffer
+	for len(encoded) > 76 {
+		b.WriteString(encoded[:76])
+		b.WriteString("\r\n")
+		encoded = encoded[76:]
+	}
+	b.WriteString(encoded)
+	b.WriteString("\r\n")
+	return b.Bytes()
 }
 
 func NewEmailService(cfg *config.Config) EmailService {
@@ -0,0 +1,104 @@
+package common
+
+import (
+	"bytes"
+	"encoding/base64"
+	"final-project-booking-room/config"
+	"final-project-booking-room/utils/modelutil"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildMessage_MultipartReadBack(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.EmailFrom = "noreply@test.id"
+	service := &emailService{cfg: cfg}
+	now := time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC)
+	xlsx := bytes.Repeat([]byte{0x50, 0x4b, 0x03, 0x04, 0xff}, 40) // lebih dari satu baris base64
+	payload := modelutil.BodySender{
+		To:      []string{"budi@mail.com", "sari@mail.com"},
+		Subject: "Konfirmasi Booking Ruangan – Melati",
+		Body:    "Halo Budi,\n\nBooking sync tim = disetujui.",
+		Attachments: []modelutil.Attachment{
+			{Filename: "Report.xlsx", ContentType: attachmentType("Report.xlsx"), Data: xlsx},
+			{Filename: "peserta.csv", ContentType: attachmentType("peserta.csv"), Data: []byte("nama,email\nBudi,budi@mail.com\n")},
+		},
+		Invite: &modelutil.CalendarEvent{UID: "booking-bd1@test", Method: CalendarCancel, Summary: "sync tim", Start: now, End: now.Add(time.Hour)},
+	}
+
+	raw, err := service.buildMessage(payload, now)
+	require.NoError(t, err)
+
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	require.NoError(t, err)
+	assert.Equal(t, "noreply@test.id", msg.Header.Get("From"))
+	assert.Equal(t, "budi@mail.com, sari@mail.com", msg.Header.Get("To"))
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, payload.Subject, subject)
+	assert.Equal(t, "1.0", msg.Header.Get("MIME-Version"))
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/mixed", mediaType)
+
+	type part struct {
+		contentType, filename string
+		data                  []byte
+	}
+	var parts []part
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		p, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		data, err := io.ReadAll(p)
+		require.NoError(t, err)
+		// quoted-printable sudah didecode multipart.Reader, base64 didecode di sini
+		if p.Header.Get("Content-Transfer-Encoding") == "base64" {
+			for _, line := range strings.Split(strings.TrimSpace(string(data)), "\r\n") {
+				assert.LessOrEqual(t, len(line), 76)
+			}
+			data, err = base64.StdEncoding.DecodeString(strings.ReplaceAll(string(data), "\r\n", ""))
+			require.NoError(t, err)
+		}
+		parts = append(parts, part{contentType: p.Header.Get("Content-Type"), filename: p.FileName(), data: data})
+	}
+
+	require.Len(t, parts, 4)
+	assert.Equal(t, "text/plain; charset=UTF-8", parts[0].contentType)
+	assert.Equal(t, strings.ReplaceAll(payload.Body, "\n", "\r\n"), string(parts[0].data))
+	assert.Equal(t, part{"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "Report.xlsx", xlsx}, parts[1])
+	assert.Equal(t, part{"text/csv", "peserta.csv", payload.Attachments[1].Data}, parts[2])
+	assert.Equal(t, "invite.ics", parts[3].filename)
+	assert.Equal(t, "text/calendar; charset=UTF-8; method=CANCEL", parts[3].contentType)
+	assert.Equal(t, NewICalendar(*payload.Invite, "noreply@test.id", now), parts[3].data)
+}
+
+func TestAttachmentType(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "Report.xlsx", want: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"},
+		{path: "reports/REPORT.XLSX", want: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"},
+		{path: "report.csv", want: "text/csv"},
+		{path: "report", want: "application/octet-stream"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, attachmentType(tt.path))
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"final-project-booking-room/config"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// FileStorage menyimpan file upload. Implementasi lokal dipakai sekarang,
+// implementasi S3-compatible cukup memenuhi interface yang sama.
+type FileStorage interface {
+	// Save menyimpan data dengan key tertentu dan mengembalikan URL publiknya
+	Save(ctx context.Context, key string, contentType string, data []byte) (string, error)
+	Delete(ctx context.Context, key string) error
+}
+
+type localStorage struct {
+	cfg config.StorageConfig
+}
+
+// filePath mengubah key menjadi path di dalam folder storage dan menolak key yang keluar dari folder itu
+func (l *localStorage) filePath(key string) (string, error) {
+	clean := path.Clean("/" + key)
+	if clean == "/" || strings.Contains(key, "..") {
+		return "", errors.New("invalid storage key")
+	}
+	return filepath.Join(l.cfg.Dir, filepath.FromSlash(clean)), nil
+}
+
+func (l *localStorage) Save(ctx context.Context, key string, contentType string, data []byte) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	filePath, err := l.filePath(key)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(l.cfg.PublicURL, "/") + path.Clean("/"+key), nil
+}
+
+func (l *localStorage) Delete(ctx context.Context, key string) error {
+	filePath, err := l.filePath(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func NewLocalStorage(cfg config.StorageConfig) FileStorage {
+	return &localStorage{cfg: cfg}
+}
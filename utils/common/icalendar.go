@@ -0,0 +1,84 @@
+package common
+
+import (
+	"final-project-booking-room/utils/modelutil"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// method iTIP yang dipakai invite booking
+const (
+	CalendarRequest = "REQUEST"
+	CalendarCancel  = "CANCEL"
+)
+
+const icalTimeLayout = "20060102T150405Z"
+
+var icalEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// NewICalendar menyusun VCALENDAR berisi satu VEVENT sesuai RFC 5545.
+// organizer adalah email pengirim, waktu event ditulis dalam UTC.
+func NewICalendar(event modelutil.CalendarEvent, organizer string, now time.Time) []byte {
+	status := "CONFIRMED"
+	if event.Method == CalendarCancel {
+		status = "CANCELLED"
+	}
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//final-project-booking-room//Booking Room//ID",
+		"CALSCALE:GREGORIAN",
+		"METHOD:" + event.Method,
+		"BEGIN:VEVENT",
+		"UID:" + event.UID,
+		"SEQUENCE:" + strconv.Itoa(event.Sequence),
+		"DTSTAMP:" + now.UTC().Format(icalTimeLayout),
+		"DTSTART:" + event.Start.UTC().Format(icalTimeLayout),
+		"DTEND:" + event.End.UTC().Format(icalTimeLayout),
+		"SUMMARY:" + icalEscaper.Replace(event.Summary),
+		"STATUS:" + status,
+		"ORGANIZER:mailto:" + organizer,
+	}
+	if event.Description != "" {
+		lines = append(lines, "DESCRIPTION:"+icalEscaper.Replace(event.Description))
+	}
+	if event.Location != "" {
+		lines = append(lines, "LOCATION:"+icalEscaper.Replace(event.Location))
+	}
+	if event.URL != "" {
+		lines = append(lines, "URL:"+event.URL)
+	}
+	for _, attendee := range event.Attendees {
+		lines = append(lines, "ATTENDEE;ROLE=REQ-PARTICIPANT;RSVP=FALSE:mailto:"+attendee)
+	}
+	lines = append(lines, "END:VEVENT", "END:VCALENDAR")
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(foldICalLine(line))
+		b.WriteString("\r\n")
+	}
+	return []byte(b.String())
+}
+
+// foldICalLine memecah baris lebih dari 75 octet tanpa memotong karakter UTF-8,
+// baris lanjutan diawali satu spasi
+func foldICalLine(line string) string {
+	var b strings.Builder
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74 // spasi di awal baris lanjutan ikut dihitung
+	}
+	b.WriteString(line)
+	return b.String()
+}
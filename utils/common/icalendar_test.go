@@ -0,0 +1,127 @@
+package common
+
+import (
+	"final-project-booking-room/utils/modelutil"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// unfoldICal menggabungkan kembali baris yang dilipat lalu memecahnya per content line
+func unfoldICal(data []byte) []string {
+	return strings.Split(strings.TrimSuffix(strings.ReplaceAll(string(data), "\r\n ", ""), "\r\n"), "\r\n")
+}
+
+func TestFoldICalLine(t *testing.T) {
+	tests := []struct {
+		name  string
+		line  string
+		lines int // 0 berarti jumlah baris tidak dicek
+	}{
+		{name: "short line", line: "SUMMARY:Rapat", lines: 1},
+		{name: "exactly 75 octets", line: strings.Repeat("a", 75), lines: 1},
+		{name: "76 octets", line: strings.Repeat("a", 76), lines: 2},
+		{name: "multi-byte character on the boundary", line: strings.Repeat("a", 74) + "éé", lines: 2},
+		{name: "long multi-byte text", line: "SUMMARY:" + strings.Repeat("Rapat ü日本 ", 30)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			folded := foldICalLine(tt.line)
+			physical := strings.Split(folded, "\r\n")
+
+			if tt.lines > 0 {
+				assert.Len(t, physical, tt.lines)
+			}
+			for i, line := range physical {
+				assert.LessOrEqual(t, len(line), 75, "line %d is longer than 75 octets", i)
+				assert.True(t, utf8.ValidString(line), "line %d splits a UTF-8 character", i)
+				if i > 0 {
+					assert.True(t, strings.HasPrefix(line, " "), "continuation line %d must start with a space", i)
+				}
+			}
+			assert.Equal(t, tt.line, strings.ReplaceAll(folded, "\r\n ", ""))
+		})
+	}
+}
+
+func TestNewICalendar_EscapesText(t *testing.T) {
+	tests := []struct {
+		name    string
+		summary string
+		want    string
+	}{
+		{name: "comma", summary: "rapat, evaluasi", want: `SUMMARY:rapat\, evaluasi`},
+		{name: "semicolon", summary: "rapat; evaluasi", want: `SUMMARY:rapat\; evaluasi`},
+		{name: "backslash", summary: `folder\laporan`, want: `SUMMARY:folder\\laporan`},
+		{name: "newline", summary: "rapat\nevaluasi", want: `SUMMARY:rapat\nevaluasi`},
+		{name: "crlf", summary: "rapat\r\nevaluasi", want: `SUMMARY:rapat\nevaluasi`},
+		{name: "escaped backslash before comma", summary: `a\,b`, want: `SUMMARY:a\\\,b`},
+	}
+
+	start := time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := modelutil.CalendarEvent{UID: "booking-1@test", Method: CalendarRequest, Summary: tt.summary, Start: start, End: start.Add(time.Hour)}
+
+			lines := unfoldICal(NewICalendar(event, "noreply@test.id", start))
+
+			assert.Contains(t, lines, tt.want)
+		})
+	}
+}
+
+func TestNewICalendar_Method(t *testing.T) {
+	loc := time.FixedZone("WIB", 7*60*60)
+	start := time.Date(2026, 10, 20, 9, 0, 0, 0, loc)
+	now := time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		method   string
+		sequence int
+		status   string
+	}{
+		{name: "request", method: CalendarRequest, sequence: 0, status: "STATUS:CONFIRMED"},
+		{name: "updated request", method: CalendarRequest, sequence: 1, status: "STATUS:CONFIRMED"},
+		{name: "cancel", method: CalendarCancel, sequence: 2, status: "STATUS:CANCELLED"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := modelutil.CalendarEvent{
+				UID:       "booking-bd1@final-project-booking-room",
+				Sequence:  tt.sequence,
+				Method:    tt.method,
+				Summary:   "sync tim",
+				Location:  "Melati",
+				URL:       "https://meet.local/m1",
+				Start:     start,
+				End:       start.Add(time.Hour),
+				Attendees: []string{"budi@mail.com"},
+			}
+
+			data := NewICalendar(event, "noreply@test.id", now)
+			lines := unfoldICal(data)
+
+			assert.True(t, strings.HasSuffix(string(data), "END:VCALENDAR\r\n"))
+			assert.NotContains(t, strings.ReplaceAll(string(data), "\r\n", ""), "\n")
+			assert.Equal(t, "BEGIN:VCALENDAR", lines[0])
+			assert.Contains(t, lines, "METHOD:"+tt.method)
+			assert.Contains(t, lines, "UID:booking-bd1@final-project-booking-room")
+			assert.Contains(t, lines, "SEQUENCE:"+strconv.Itoa(tt.sequence))
+			assert.Contains(t, lines, tt.status)
+			assert.Contains(t, lines, "DTSTAMP:20261019T083000Z")
+			assert.Contains(t, lines, "DTSTART:20261020T020000Z")
+			assert.Contains(t, lines, "DTEND:20261020T030000Z")
+			assert.Contains(t, lines, "ORGANIZER:mailto:noreply@test.id")
+			assert.Contains(t, lines, "ATTENDEE;ROLE=REQ-PARTICIPANT;RSVP=FALSE:mailto:budi@mail.com")
+			assert.Contains(t, lines, "LOCATION:Melati")
+			assert.Contains(t, lines, "URL:https://meet.local/m1")
+		})
+	}
+}
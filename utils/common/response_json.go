@@ -46,3 +46,14 @@ func SendPagedResponse(ctx *gin.Context, description string, data []any, paging
 		Paging: paging,
 	})
 }
+
+// SendErrorDataResponse sama seperti SendErrorResponse tapi ikut mengirim detail kesalahan di data
+func SendErrorDataResponse(ctx *gin.Context, code int, description string, data any) {
+	ctx.JSON(code, modelutil.SingleResponse{
+		Status: modelutil.Status{
+			Code:        code,
+			Description: description,
+		},
+		Data: data,
+	})
+}
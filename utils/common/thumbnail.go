@@ -0,0 +1,89 @@
+package common
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // decoder gif untuk image.Decode
+	"image/jpeg"
+	"image/png"
+)
+
+// maxImagePixels membatasi lebar x tinggi gambar. File kecil bisa mengaku berukuran sangat besar
+// di header-nya, jadi ukuran dicek sebelum seluruh piksel di-decode ke memori.
+const maxImagePixels = 6000 * 4000
+
+var ErrImageTooLarge = errors.New("image dimensions are too large")
+
+// GenerateThumbnail mengecilkan gambar jpeg/png/gif sehingga sisi terpanjangnya maxSide piksel.
+// Gambar yang sudah kecil tidak diperbesar. PNG dan GIF di-encode sebagai PNG supaya
+// transparansinya tetap, selain itu JPEG.
+func GenerateThumbnail(data []byte, maxSide int) ([]byte, string, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", err
+	}
+	if int64(config.Width)*int64(config.Height) > maxImagePixels {
+		return nil, "", fmt.Errorf("%w: %dx%d, maximum is %d pixels", ErrImageTooLarge, config.Width, config.Height, maxImagePixels)
+	}
+
+	src, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", err
+	}
+
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width > maxSide || height > maxSide {
+		if width >= height {
+			height = max(1, height*maxSide/width)
+			width = maxSide
+		} else {
+			width = max(1, width*maxSide/height)
+			height = maxSide
+		}
+	}
+	thumb := scaleDown(src, width, height)
+
+	var buf bytes.Buffer
+	if format == "png" || format == "gif" {
+		if err := png.Encode(&buf, thumb); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), "image/png", nil
+	}
+	if err := jpeg.Encode(&buf, thumb, &jpeg.Options{Quality: 80}); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), "image/jpeg", nil
+}
+
+// scaleDown memperkecil gambar dengan merata-ratakan piksel sumber di tiap kotak tujuan (box filter)
+func scaleDown(src image.Image, width, height int) *image.NRGBA {
+	bounds := src.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*bounds.Dy()/height)
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := max(x0+1, bounds.Min.X+(x+1)*bounds.Dx()/width)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					c := color.NRGBAModel.Convert(src.At(sx, sy)).(color.NRGBA)
+					r += uint64(c.R)
+					g += uint64(c.G)
+					b += uint64(c.B)
+					a += uint64(c.A)
+					n++
+				}
+			}
+			dst.SetNRGBA(x, y, color.NRGBA{R: uint8(r / n), G: uint8(g / n), B: uint8(b / n), A: uint8(a / n)})
+		}
+	}
+	return dst
+}
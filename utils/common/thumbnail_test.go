@@ -0,0 +1,109 @@
+package common
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodeTestImage(t *testing.T, format string, width, height int) []byte {
+	src := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			src.SetNRGBA(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	var err error
+	switch format {
+	case "png":
+		err = png.Encode(&buf, src)
+	case "gif":
+		err = gif.Encode(&buf, src, nil)
+	default:
+		err = jpeg.Encode(&buf, src, nil)
+	}
+	require.NoError(t, err)
+	return buf.Bytes()
+}
+
+// pngHeader membuat PNG yang hanya berisi signature dan chunk IHDR dengan ukuran yang diklaim,
+// cukup untuk image.DecodeConfig tapi tidak punya data piksel
+func pngHeader(width, height uint32) []byte {
+	ihdr := make([]byte, 17)
+	copy(ihdr, "IHDR")
+	binary.BigEndian.PutUint32(ihdr[4:], width)
+	binary.BigEndian.PutUint32(ihdr[8:], height)
+	ihdr[12] = 8 // bit depth
+	ihdr[13] = 6 // RGBA
+
+	var buf bytes.Buffer
+	buf.WriteString("\x89PNG\r\n\x1a\n")
+	binary.Write(&buf, binary.BigEndian, uint32(len(ihdr)-4))
+	buf.Write(ihdr)
+	binary.Write(&buf, binary.BigEndian, crc32.ChecksumIEEE(ihdr))
+	return buf.Bytes()
+}
+
+func TestGenerateThumbnail(t *testing.T) {
+	tests := []struct {
+		name          string
+		data          []byte
+		contentType   string
+		width, height int
+	}{
+		{name: "landscape png", data: encodeTestImage(t, "png", 640, 320), contentType: "image/png", width: 320, height: 160},
+		{name: "portrait jpeg", data: encodeTestImage(t, "jpeg", 300, 900), contentType: "image/jpeg", width: 106, height: 320},
+		{name: "gif is encoded as png", data: encodeTestImage(t, "gif", 400, 400), contentType: "image/png", width: 320, height: 320},
+		{name: "small image is not enlarged", data: encodeTestImage(t, "jpeg", 100, 50), contentType: "image/jpeg", width: 100, height: 50},
+		{name: "thin image keeps at least one pixel", data: encodeTestImage(t, "png", 1000, 1), contentType: "image/png", width: 320, height: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			thumbnail, contentType, err := GenerateThumbnail(tt.data, 320)
+			require.NoError(t, err)
+			assert.Equal(t, tt.contentType, contentType)
+
+			config, _, err := image.DecodeConfig(bytes.NewReader(thumbnail))
+			require.NoError(t, err)
+			assert.Equal(t, tt.width, config.Width)
+			assert.Equal(t, tt.height, config.Height)
+		})
+	}
+}
+
+func TestGenerateThumbnail_Rejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		tooLarge bool
+	}{
+		{name: "dimensions over the pixel limit", data: pngHeader(50000, 50000), tooLarge: true},
+		{name: "one dimension over the pixel limit", data: pngHeader(maxImagePixels+1, 1), tooLarge: true},
+		{name: "not an image", data: []byte("bukan gambar")},
+		{name: "header only", data: pngHeader(10, 10)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			thumbnail, contentType, err := GenerateThumbnail(tt.data, 320)
+
+			assert.Error(t, err)
+			assert.Equal(t, tt.tooLarge, errors.Is(err, ErrImageTooLarge))
+			assert.Nil(t, thumbnail)
+			assert.Empty(t, contentType)
+		})
+	}
+}
@@ -5,4 +5,13 @@ type BodySender struct {
 	Subject     string   `json:"subject"`
 	Body        string   `json:"body"`
 	CSVFilePath string
+	Attachments []Attachment
+	Invite      *CalendarEvent // dikirim sebagai lampiran invite.ics
+}
+
+// Attachment adalah file yang dilampirkan ke email
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
 }
@@ -0,0 +1,17 @@
+package modelutil
+
+import "time"
+
+// CalendarEvent adalah satu VEVENT untuk invite iCalendar (RFC 5545)
+type CalendarEvent struct {
+	UID         string // sama untuk booking detail yang sama supaya kalender penerima mengganti event lama
+	Sequence    int    // harus naik setiap event diubah atau dibatalkan
+	Method      string // REQUEST atau CANCEL
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	Start       time.Time
+	End         time.Time
+	Attendees   []string
+}
@@ -0,0 +1,18 @@
+package modelutil
+
+import "time"
+
+// ConferenceRequest adalah data meeting yang dikirim ke provider video conference
+type ConferenceRequest struct {
+	Topic    string
+	Location string // nama ruangan fisik untuk meeting hybrid
+	Start    time.Time
+	End      time.Time
+	Timezone string
+}
+
+// ConferenceMeeting adalah meeting yang dibuat provider, Id dipakai untuk update dan hapus
+type ConferenceMeeting struct {
+	Id      string
+	JoinURL string
+}